@@ -18,6 +18,8 @@ import (
 	"databasus-backend/internal/features/backups/backups/backuping"
 	backups_download "databasus-backend/internal/features/backups/backups/download"
 	backups_config "databasus-backend/internal/features/backups/config"
+	backups_groups "databasus-backend/internal/features/backups/groups"
+	backups_settings "databasus-backend/internal/features/backups/settings"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/disk"
 	"databasus-backend/internal/features/encryption/secrets"
@@ -231,6 +233,8 @@ func setUpRoutes(r *gin.Engine) {
 	healthcheck_config.GetHealthcheckConfigController().RegisterRoutes(protected)
 	healthcheck_attempt.GetHealthcheckAttemptController().RegisterRoutes(protected)
 	backups_config.GetBackupConfigController().RegisterRoutes(protected)
+	backups_groups.GetBackupGroupController().RegisterRoutes(protected)
+	backups_settings.GetBackupSystemSettingsController().RegisterRoutes(protected)
 	audit_logs.GetAuditLogController().RegisterRoutes(protected)
 	users_controllers.GetManagementController().RegisterRoutes(protected)
 	users_controllers.GetSettingsController().RegisterRoutes(protected)
@@ -279,6 +283,30 @@ func runBackgroundTasks(log *slog.Logger) {
 			backuping.GetBackupCleaner().Run(ctx)
 		})
 
+		go runWithPanicLogging(log, "backup deletion job background service", func() {
+			backuping.GetDeletionJobWorker().Run(ctx)
+		})
+
+		go runWithPanicLogging(log, "scheduled cleanup background service", func() {
+			backuping.GetScheduledCleanupJob().Run(ctx)
+		})
+
+		go runWithPanicLogging(log, "backup encryption verification background service", func() {
+			backups.GetBackupEncryptionVerificationJob().Run(ctx)
+		})
+
+		go runWithPanicLogging(log, "backup integrity check background service", func() {
+			backups.GetBackupIntegrityCheckJob().Run(ctx)
+		})
+
+		go runWithPanicLogging(log, "storage quota warning background service", func() {
+			backups.GetStorageQuotaWarningJob().Run(ctx)
+		})
+
+		go runWithPanicLogging(log, "missed backup check background service", func() {
+			backups.GetMissedBackupCheckJob().Run(ctx)
+		})
+
 		go runWithPanicLogging(log, "restore background service", func() {
 			restoring.GetRestoresScheduler().Run(ctx)
 		})
@@ -48,6 +48,10 @@ type EnvVariables struct {
 	IsProcessingNode         bool `env:"IS_PROCESSING_NODE"`
 	NodeNetworkThroughputMBs int  `env:"NODE_NETWORK_THROUGHPUT_MBPS"`
 
+	// IsCleanerReportOnly makes the retention cleaner only log backups it would
+	// delete, so new retention settings can be observed before they take effect
+	IsCleanerReportOnly bool `env:"IS_CLEANER_REPORT_ONLY"`
+
 	DataFolder    string
 	TempFolder    string
 	SecretKeyPath string
@@ -107,6 +111,8 @@ type EnvVariables struct {
 	// Cloudflare Turnstile
 	CloudflareTurnstileSecretKey string `env:"CLOUDFLARE_TURNSTILE_SECRET_KEY"`
 	CloudflareTurnstileSiteKey   string `env:"CLOUDFLARE_TURNSTILE_SITE_KEY"`
+	// comma separated, "*.example.com" allows any subdomain. Empty allows any hostname
+	CloudflareTurnstileAllowedHostnames []string `env:"CLOUDFLARE_TURNSTILE_ALLOWED_HOSTNAMES" env-separator:","`
 
 	// testing Telegram
 	TestTelegramBotToken string `env:"TEST_TELEGRAM_BOT_TOKEN"`
@@ -0,0 +1,40 @@
+package backuping
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// BackupMutexRegistry holds a mutex per database, so the cleaner does not delete
+// files of a database while its backup is being uploaded. Locks are in-process
+// only and protect backuper and cleaner running on the same node
+type BackupMutexRegistry struct {
+	mu      sync.Mutex
+	mutexes map[uuid.UUID]*sync.Mutex
+}
+
+func (r *BackupMutexRegistry) Lock(databaseID uuid.UUID) {
+	r.getMutex(databaseID).Lock()
+}
+
+func (r *BackupMutexRegistry) TryLock(databaseID uuid.UUID) bool {
+	return r.getMutex(databaseID).TryLock()
+}
+
+func (r *BackupMutexRegistry) Unlock(databaseID uuid.UUID) {
+	r.getMutex(databaseID).Unlock()
+}
+
+func (r *BackupMutexRegistry) getMutex(databaseID uuid.UUID) *sync.Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mutex, isExists := r.mutexes[databaseID]
+	if !isExists {
+		mutex = &sync.Mutex{}
+		r.mutexes[databaseID] = mutex
+	}
+
+	return mutex
+}
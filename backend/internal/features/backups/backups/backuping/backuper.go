@@ -16,13 +16,16 @@ import (
 	"github.com/google/uuid"
 
 	"databasus-backend/internal/config"
+	usecases_common "databasus-backend/internal/features/backups/backups/common"
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
 	tasks_cancellation "databasus-backend/internal/features/tasks/cancellation"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
 	util_encryption "databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/size"
 )
 
 const (
@@ -42,6 +45,7 @@ type BackuperNode struct {
 	backupNodesRegistry *BackupNodesRegistry
 	logger              *slog.Logger
 	createBackupUseCase backups_core.CreateBackupUsecase
+	backupMutexRegistry *BackupMutexRegistry
 	nodeID              uuid.UUID
 
 	lastHeartbeat time.Time
@@ -168,12 +172,12 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 	backupProgressListener := func(
 		completedMBs float64,
 	) {
-		backup.BackupSizeMb = completedMBs
+		backup.SetSizeBytes(size.MBToBytes(completedMBs))
 		backup.BackupDurationMs = time.Since(start).Milliseconds()
 
 		// Check size limit (0 = unlimited)
 		if backupConfig.MaxBackupSizeMB > 0 &&
-			completedMBs > float64(backupConfig.MaxBackupSizeMB) {
+			backup.BackupSizeBytes > backupConfig.MaxBackupSizeMB*size.BytesInMB {
 			errMsg := fmt.Sprintf(
 				"backup size (%.2f MB) exceeded maximum allowed size (%d MB)",
 				completedMBs,
@@ -196,14 +200,19 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 		}
 	}
 
-	backupMetadata, err := n.createBackupUseCase.Execute(
-		ctx,
-		backup,
-		backupConfig,
-		database,
-		storage,
-		backupProgressListener,
-	)
+	backupMetadata, err := func() (*usecases_common.BackupMetadata, error) {
+		n.backupMutexRegistry.Lock(databaseID)
+		defer n.backupMutexRegistry.Unlock(databaseID)
+
+		return n.createBackupUseCase.Execute(
+			ctx,
+			backup,
+			backupConfig,
+			database,
+			storage,
+			backupProgressListener,
+		)
+	}()
 	if err != nil {
 		// Check if backup was already marked as failed by progress listener (e.g., size limit exceeded)
 		// If so, skip error handling to avoid overwriting the status
@@ -217,6 +226,11 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 				*currentBackup.FailMessage,
 			)
 
+			n.removeFailedBackupFile(backupConfig, currentBackup)
+			if err := n.backupRepository.Save(currentBackup); err != nil {
+				n.logger.Error("Failed to save failed backup", "error", err)
+			}
+
 			// Still call notification for size limit failures
 			n.SendBackupNotification(
 				backupConfig,
@@ -256,7 +270,7 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 
 			backup.Status = backups_core.BackupStatusCanceled
 			backup.BackupDurationMs = time.Since(start).Milliseconds()
-			backup.BackupSizeMb = 0
+			backup.SetSizeBytes(0)
 
 			if err := n.backupRepository.Save(backup); err != nil {
 				n.logger.Error("Failed to save cancelled backup", "error", err)
@@ -282,7 +296,8 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 		backup.FailMessage = &errMsg
 		backup.Status = backups_core.BackupStatusFailed
 		backup.BackupDurationMs = time.Since(start).Milliseconds()
-		backup.BackupSizeMb = 0
+		backup.SetSizeBytes(0)
+		n.removeFailedBackupFile(backupConfig, backup)
 
 		if updateErr := n.databaseService.SetBackupError(databaseID, errMsg); updateErr != nil {
 			n.logger.Error(
@@ -342,12 +357,14 @@ func (n *BackuperNode) MakeBackup(backupID uuid.UUID, isCallNotifier bool) {
 			metadataReader := bytes.NewReader(metadataJSON)
 			metadataFileName := backup.FileName + ".metadata"
 
-			if err := storage.SaveFile(
+			if err := storage.SaveFileWithStorageClass(
 				context.Background(),
 				n.fieldEncryptor,
 				n.logger,
 				metadataFileName,
 				metadataReader,
+				"",
+				string(backupConfig.StorageACL),
 			); err != nil {
 				n.logger.Error("Failed to save backup metadata file to storage",
 					"backupId", backup.ID,
@@ -393,6 +410,10 @@ func (n *BackuperNode) SendBackupNotification(
 	notificationType backups_config.BackupNotificationType,
 	errorMessage *string,
 ) {
+	if !slices.Contains(backupConfig.SendNotificationsOn, notificationType) {
+		return
+	}
+
 	database, err := n.databaseService.GetDatabaseByID(backupConfig.DatabaseID)
 	if err != nil {
 		return
@@ -403,60 +424,66 @@ func (n *BackuperNode) SendBackupNotification(
 		return
 	}
 
-	for _, notifier := range database.Notifiers {
-		if !slices.Contains(
-			backupConfig.SendNotificationsOn,
-			notificationType,
-		) {
-			continue
-		}
+	title := ""
+	switch notificationType {
+	case backups_config.NotificationBackupFailed:
+		title = fmt.Sprintf(
+			"❌ Backup failed for database \"%s\" (workspace \"%s\")",
+			database.Name,
+			workspace.Name,
+		)
+	case backups_config.NotificationBackupSuccess:
+		title = fmt.Sprintf(
+			"✅ Backup completed for database \"%s\" (workspace \"%s\")",
+			database.Name,
+			workspace.Name,
+		)
+	}
 
-		title := ""
-		switch notificationType {
-		case backups_config.NotificationBackupFailed:
-			title = fmt.Sprintf(
-				"❌ Backup failed for database \"%s\" (workspace \"%s\")",
-				database.Name,
-				workspace.Name,
-			)
-		case backups_config.NotificationBackupSuccess:
-			title = fmt.Sprintf(
-				"✅ Backup completed for database \"%s\" (workspace \"%s\")",
-				database.Name,
-				workspace.Name,
-			)
+	message := ""
+	if errorMessage != nil {
+		message = *errorMessage
+	} else {
+		// Format size conditionally
+		var sizeStr string
+		if backup.BackupSizeMb < 1024 {
+			sizeStr = fmt.Sprintf("%.2f MB", backup.BackupSizeMb)
+		} else {
+			sizeGB := backup.BackupSizeMb / 1024
+			sizeStr = fmt.Sprintf("%.2f GB", sizeGB)
 		}
 
-		message := ""
-		if errorMessage != nil {
-			message = *errorMessage
-		} else {
-			// Format size conditionally
-			var sizeStr string
-			if backup.BackupSizeMb < 1024 {
-				sizeStr = fmt.Sprintf("%.2f MB", backup.BackupSizeMb)
-			} else {
-				sizeGB := backup.BackupSizeMb / 1024
-				sizeStr = fmt.Sprintf("%.2f GB", sizeGB)
-			}
+		// Format duration as "0m 0s 0ms"
+		totalMs := backup.BackupDurationMs
+		minutes := totalMs / (1000 * 60)
+		seconds := (totalMs % (1000 * 60)) / 1000
+		durationStr := fmt.Sprintf("%dm %ds", minutes, seconds)
 
-			// Format duration as "0m 0s 0ms"
-			totalMs := backup.BackupDurationMs
-			minutes := totalMs / (1000 * 60)
-			seconds := (totalMs % (1000 * 60)) / 1000
-			durationStr := fmt.Sprintf("%dm %ds", minutes, seconds)
+		message = fmt.Sprintf(
+			"Backup completed successfully in %s.\nCompressed backup size: %s",
+			durationStr,
+			sizeStr,
+		)
+	}
 
-			message = fmt.Sprintf(
-				"Backup completed successfully in %s.\nCompressed backup size: %s",
-				durationStr,
-				sizeStr,
-			)
-		}
+	recipients, err := n.backupConfigService.GetNotificationRecipients(backupConfig, database)
+	if err != nil {
+		n.logger.Error(
+			"Failed to get some notification recipients",
+			"databaseId",
+			database.ID,
+			"error",
+			err,
+		)
+	}
 
-		n.notificationSender.SendNotification(
-			&notifier,
-			title,
-			message,
+	if err := n.deliverNotification(recipients, title, message); err != nil {
+		n.logger.Error(
+			"Failed to deliver backup notification",
+			"databaseId",
+			database.ID,
+			"error",
+			err,
 		)
 	}
 }
@@ -467,3 +494,67 @@ func (n *BackuperNode) sendHeartbeat(backupNode *BackupNode) {
 		n.logger.Error("Failed to send heartbeat", "error", err)
 	}
 }
+
+// removeFailedBackupFile deletes the partial upload of a failed backup when the
+// config asks for it. The row is kept so the fail message stays visible, the
+// caller is responsible for saving it
+func (n *BackuperNode) removeFailedBackupFile(
+	backupConfig *backups_config.BackupConfig,
+	backup *backups_core.Backup,
+) {
+	if !backupConfig.ShouldDeleteFailedBackupFiles || backup.FileName == "" {
+		return
+	}
+
+	storage, err := n.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		n.logger.Error(
+			"Failed to get storage to delete failed backup file",
+			"backupId", backup.ID,
+			"error", err,
+		)
+		return
+	}
+
+	if err := storage.DeleteFile(n.fieldEncryptor, backup.FileName); err != nil {
+		// the file name is kept, so the file is still removed together with the row
+		n.logger.Error(
+			"Failed to delete failed backup file",
+			"backupId", backup.ID,
+			"error", err,
+		)
+		return
+	}
+
+	// the metadata file is missing when the backup failed before it was written,
+	// so a failure to delete it does not keep the file name
+	metadataFileName := backup.FileName + ".metadata"
+	if err := storage.DeleteFile(n.fieldEncryptor, metadataFileName); err != nil {
+		n.logger.Warn(
+			"Failed to delete failed backup metadata file",
+			"backupId", backup.ID,
+			"error", err,
+		)
+	}
+
+	backup.FileName = ""
+	backup.IsFailedFileDeleted = true
+}
+
+// deliverNotification sends to every recipient, so one failing channel does
+// not keep the notification from the others
+func (n *BackuperNode) deliverNotification(
+	recipients []notifiers.Notifier,
+	title string,
+	message string,
+) error {
+	var deliveryErrors []error
+
+	for _, notifier := range recipients {
+		if err := n.notificationSender.DeliverNotification(&notifier, title, message); err != nil {
+			deliveryErrors = append(deliveryErrors, fmt.Errorf("notifier %s: %w", notifier.ID, err))
+		}
+	}
+
+	return errors.Join(deliveryErrors...)
+}
@@ -1,6 +1,8 @@
 package backuping
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -14,7 +16,10 @@ import (
 	users_testing "databasus-backend/internal/features/users/testing"
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
 	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -60,7 +65,7 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Set up expectations
-		mockNotificationSender.On("SendNotification",
+		mockNotificationSender.On("DeliverNotification",
 			mock.Anything,
 			mock.MatchedBy(func(title string) bool {
 				return strings.Contains(title, "❌ Backup failed")
@@ -68,7 +73,7 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 			mock.MatchedBy(func(message string) bool {
 				return strings.Contains(message, "backup failed")
 			}),
-		).Once()
+		).Return(nil).Once()
 
 		backuperNode.MakeBackup(backup.ID, true)
 
@@ -93,7 +98,7 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Set up expectations
-		mockNotificationSender.On("SendNotification",
+		mockNotificationSender.On("DeliverNotification",
 			mock.Anything,
 			mock.MatchedBy(func(title string) bool {
 				return strings.Contains(title, "✅ Backup completed")
@@ -101,7 +106,7 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 			mock.MatchedBy(func(message string) bool {
 				return strings.Contains(message, "Backup completed successfully")
 			}),
-		).Once()
+		).Return(nil).Once()
 
 		backuperNode.MakeBackup(backup.ID, true)
 
@@ -130,7 +135,7 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 		var capturedTitle string
 		var capturedMessage string
 
-		mockNotificationSender.On("SendNotification",
+		mockNotificationSender.On("DeliverNotification",
 			mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
@@ -138,7 +143,7 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 			capturedNotifier = args.Get(0).(*notifiers.Notifier)
 			capturedTitle = args.Get(1).(string)
 			capturedMessage = args.Get(2).(string)
-		}).Once()
+		}).Return(nil).Once()
 
 		backuperNode.MakeBackup(backup.ID, true)
 
@@ -154,6 +159,76 @@ func Test_BackupExecuted_NotificationSent(t *testing.T) {
 	})
 }
 
+func Test_BackupFailed_WithSeveralNotifiersAndOneDeliveryError_AllNotifiersReceiveNotification(
+	t *testing.T,
+) {
+	cache_utils.ClearAllCache()
+	user := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := CreateTestRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", user, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	attachedNotifier := notifiers.CreateTestNotifier(workspace.ID)
+	failingNotifier := notifiers.CreateTestNotifier(workspace.ID)
+	secondNotifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, attachedNotifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond) // Wait for cascading deletes
+		notifiers.RemoveTestNotifier(attachedNotifier)
+		notifiers.RemoveTestNotifier(failingNotifier)
+		notifiers.RemoveTestNotifier(secondNotifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupConfig := backups_config.EnableBackupsForTestDatabase(database.ID, storage)
+	backupConfig.NotifierIDs = []uuid.UUID{failingNotifier.ID, secondNotifier.ID}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	mockNotificationSender := &MockNotificationSender{}
+	mockNotificationSender.On("DeliverNotification",
+		mock.MatchedBy(func(notifier *notifiers.Notifier) bool {
+			return notifier.ID == failingNotifier.ID
+		}),
+		mock.Anything,
+		mock.Anything,
+	).Return(errors.New("webhook is unreachable")).Once()
+	mockNotificationSender.On("DeliverNotification",
+		mock.MatchedBy(func(notifier *notifiers.Notifier) bool {
+			return notifier.ID == secondNotifier.ID
+		}),
+		mock.MatchedBy(func(title string) bool {
+			return strings.Contains(title, "❌ Backup failed")
+		}),
+		mock.Anything,
+	).Return(nil).Once()
+
+	backuperNode := CreateTestBackuperNode()
+	backuperNode.notificationSender = mockNotificationSender
+	backuperNode.createBackupUseCase = &CreateFailedBackupUsecase{}
+
+	backup := &backups_core.Backup{
+		DatabaseID: database.ID,
+		StorageID:  storage.ID,
+		Status:     backups_core.BackupStatusInProgress,
+		CreatedAt:  time.Now().UTC(),
+	}
+	err = backupRepository.Save(backup)
+	assert.NoError(t, err)
+
+	backuperNode.MakeBackup(backup.ID, true)
+
+	mockNotificationSender.AssertExpectations(t)
+	mockNotificationSender.AssertNumberOfCalls(t, "DeliverNotification", 2)
+}
+
 func Test_BackupSizeLimits(t *testing.T) {
 	cache_utils.ClearAllCache()
 	user := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
@@ -271,3 +346,116 @@ func Test_BackupSizeLimits(t *testing.T) {
 		assert.Nil(t, updatedBackup.FailMessage)
 	})
 }
+
+func Test_MakeBackup_WhenBackupFailsAndFailedFilesDeletionEnabled_FileDeletedRowKept(
+	t *testing.T,
+) {
+	cache_utils.ClearAllCache()
+	user := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := CreateTestRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", user, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupConfig := backups_config.EnableBackupsForTestDatabase(database.ID, storage)
+	backupConfig.ShouldDeleteFailedBackupFiles = true
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	backuperNode := CreateTestBackuperNode()
+	backuperNode.createBackupUseCase = &CreatePartiallyUploadedBackupUsecase{}
+
+	fileName := uuid.New().String()
+	backup := &backups_core.Backup{
+		FileName:   fileName,
+		DatabaseID: database.ID,
+		StorageID:  storage.ID,
+		Status:     backups_core.BackupStatusInProgress,
+		CreatedAt:  time.Now().UTC(),
+	}
+	err = backupRepository.Save(backup)
+	assert.NoError(t, err)
+
+	metadataFileName := fileName + ".metadata"
+	err = storage.SaveFile(
+		context.Background(),
+		encryption.GetFieldEncryptor(),
+		logger.GetLogger(),
+		metadataFileName,
+		strings.NewReader("{}"),
+	)
+	assert.NoError(t, err)
+
+	backuperNode.MakeBackup(backup.ID, false)
+
+	failedBackup, err := backupRepository.FindByID(backup.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, backups_core.BackupStatusFailed, failedBackup.Status)
+	assert.NotNil(t, failedBackup.FailMessage)
+	assert.Empty(t, failedBackup.FileName)
+	assert.True(t, failedBackup.IsFailedFileDeleted)
+
+	_, err = storage.GetFile(encryption.GetFieldEncryptor(), fileName)
+	assert.Error(t, err)
+
+	_, err = storage.GetFile(encryption.GetFieldEncryptor(), metadataFileName)
+	assert.Error(t, err)
+}
+
+func Test_MakeBackup_WhenUseCasePanics_DatabaseMutexReleased(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	backups_config.EnableBackupsForTestDatabase(database.ID, storage)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backuperNode := CreateTestBackuperNode()
+	backuperNode.createBackupUseCase = &CreatePanickingBackupUsecase{}
+
+	backup := &backups_core.Backup{
+		DatabaseID: database.ID,
+		StorageID:  storage.ID,
+		Status:     backups_core.BackupStatusInProgress,
+		CreatedAt:  time.Now().UTC(),
+	}
+	assert.NoError(t, backupRepository.Save(backup))
+
+	assert.Panics(t, func() {
+		backuperNode.MakeBackup(backup.ID, false)
+	})
+
+	isLocked := backupMutexRegistry.TryLock(database.ID)
+	assert.True(t, isLocked)
+	if isLocked {
+		backupMutexRegistry.Unlock(database.ID)
+	}
+}
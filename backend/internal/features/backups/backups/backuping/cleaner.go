@@ -1,9 +1,14 @@
 package backuping
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"runtime/debug"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -12,14 +17,32 @@ import (
 
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/storages"
+	cache_utils "databasus-backend/internal/util/cache"
 	util_encryption "databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/period"
+	"databasus-backend/internal/util/size"
 )
 
 const (
 	cleanerTickerInterval   = 1 * time.Minute
-	recentBackupGracePeriod = 60 * time.Minute
+	recentBackupGracePeriod = backups_core.RecentBackupGracePeriod
+	lastBackupAlertInterval = 24 * time.Hour
+	secondsInHour           = int64(time.Hour / time.Second)
+)
+
+// ErrNoDeletionScheduled is returned when no existing backup will be deleted
+// under the current retention policy
+var ErrNoDeletionScheduled = errors.New("no backup deletion is scheduled")
+
+var (
+	ErrCleanupDatabaseReadOnly = errors.New(
+		"backups of read-only database are held, nothing is deleted",
+	)
+	ErrCleanupStoragesUnavailable = errors.New(
+		"backup storages are unavailable, nothing is deleted until they are back",
+	)
 )
 
 type BackupCleaner struct {
@@ -29,140 +52,592 @@ type BackupCleaner struct {
 	fieldEncryptor        util_encryption.FieldEncryptor
 	logger                *slog.Logger
 	backupRemoveListeners []backups_core.BackupRemoveListener
-
-	runOnce sync.Once
-	hasRun  atomic.Bool
+	backupMutexRegistry   *BackupMutexRegistry
+	usageRecorder         backups_core.UsageRecorder
+	tracer                backups_core.Tracer
+
+	databaseService      *databases.DatabaseService
+	notificationSender   backups_core.NotificationSender
+	lastBackupAlertCache *cache_utils.CacheUtil[time.Time]
+
+	tickerInterval time.Duration
+
+	// isReportOnly replaces every pass of Run() with a plan which is only
+	// logged and counted, nothing is deleted or moved
+	isReportOnly bool
+
+	errorsCount        atomic.Int64
+	misconfiguredCount atomic.Int64
+	wouldDeleteCount   atomic.Int64
+	// deletedByReason maps DeletionReason to *atomic.Int64
+	deletedByReason sync.Map
+
+	// oneShotRetentionOverrides maps database ID to the override applied to
+	// its config by the next retention pass only
+	oneShotRetentionOverrides sync.Map
+
+	hasRun    atomic.Bool
+	isRunning atomic.Bool
+	// lastTickAt is in unix nanoseconds, 0 until the first tick completes
+	lastTickAt          atomic.Int64
+	lastTickDurationMs  atomic.Int64
+	lastTickErrorsCount atomic.Int64
 }
 
+// tickRetentionOverridesKey holds the configs produced by one-shot overrides
+// consumed in the current tick, a nil config means the database is skipped
+type tickRetentionOverridesKey struct{}
+
 func (c *BackupCleaner) Run(ctx context.Context) {
-	wasAlreadyRun := c.hasRun.Load()
+	// The check and the mark must be a single step: with a separate Load two
+	// concurrent callers both passed it, and the second one silently blocked
+	// in sync.Once until the first one returned
+	if !c.hasRun.CompareAndSwap(false, true) {
+		panic(fmt.Sprintf("%T.Run() called multiple times", c))
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
 
-	c.runOnce.Do(func() {
-		c.hasRun.Store(true)
+	c.isRunning.Store(true)
+	defer c.isRunning.Store(false)
 
-		if ctx.Err() != nil {
+	ticker := time.NewTicker(c.tickerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
 			return
+		case <-ticker.C:
+			c.runTick(ctx)
 		}
+	}
+}
 
-		ticker := time.NewTicker(cleanerTickerInterval)
-		defer ticker.Stop()
+// DeleteBackup removes the backup unconditionally. The recent backup grace
+// period is applied only by the automatic clean passes: an explicit deletion
+// requested by a user must not be blocked because the backup is fresh
+func (c *BackupCleaner) DeleteBackup(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	reason backups_core.DeletionReason,
+) error {
+	ctx, span := c.tracer.Start(ctx, deleteBackupSpanName)
+	defer span.End()
 
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				if err := c.cleanByRetentionPolicy(); err != nil {
-					c.logger.Error("Failed to clean backups by retention policy", "error", err)
-				}
+	span.SetAttribute(spanAttributeDatabaseID, backup.DatabaseID.String())
+	span.SetAttribute(spanAttributeBackupID, backup.ID.String())
+	span.SetAttribute(spanAttributeDeletionReason, string(reason))
 
-				if err := c.cleanExceededBackups(); err != nil {
-					c.logger.Error("Failed to clean exceeded backups", "error", err)
-				}
-			}
-		}
-	})
+	if err := c.deleteBackup(ctx, backup, reason); err != nil {
+		span.RecordError(err)
+		return err
+	}
 
-	if wasAlreadyRun {
-		panic(fmt.Sprintf("%T.Run() called multiple times", c))
+	span.SetAttribute(spanAttributeFreedMB, backup.BackupSizeMb)
+
+	if tally, ok := ctx.Value(cleanupTallyKey{}).(*cleanupTally); ok {
+		tally.deletedCount++
+		tally.freedMB += backup.BackupSizeMb
 	}
+
+	return nil
 }
 
-func (c *BackupCleaner) DeleteBackup(backup *backups_core.Backup) error {
-	for _, listener := range c.backupRemoveListeners {
-		if err := listener.OnBeforeBackupRemove(backup); err != nil {
-			return err
+// DeleteBackupFiles fires backup remove listeners and deletes the files of the
+// backup, leaving its row to the caller
+func (c *BackupCleaner) DeleteBackupFiles(ctx context.Context, backup *backups_core.Backup) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return c.deleteBackupFiles(backup)
+}
+
+// DeletePurgedDatabaseRecords deletes the rows of the purged backups together
+// with the backup config of the database in one transaction
+func (c *BackupCleaner) DeletePurgedDatabaseRecords(
+	databaseID uuid.UUID,
+	purgedBackups []*backups_core.Backup,
+) error {
+	if err := c.backupRepository.DeleteByDatabaseIDWithConfig(databaseID); err != nil {
+		return err
+	}
+
+	counter, _ := c.deletedByReason.LoadOrStore(
+		backups_core.DeletionReasonDatabasePurge,
+		&atomic.Int64{},
+	)
+	counter.(*atomic.Int64).Add(int64(len(purgedBackups)))
+
+	c.logger.Info(
+		"Database backups purged",
+		"databaseId", databaseID,
+		"backupsCount", len(purgedBackups),
+	)
+
+	return nil
+}
+
+func (c *BackupCleaner) AddBackupRemoveListener(listener backups_core.BackupRemoveListener) {
+	c.backupRemoveListeners = append(c.backupRemoveListeners, listener)
+}
+
+func (c *BackupCleaner) SetUsageRecorder(recorder backups_core.UsageRecorder) {
+	c.usageRecorder = recorder
+}
+
+func (c *BackupCleaner) SetTracer(tracer backups_core.Tracer) {
+	c.tracer = tracer
+}
+
+func (c *BackupCleaner) GetStats() CleanerStats {
+	return CleanerStats{
+		Errors:               c.errorsCount.Load(),
+		MisconfiguredConfigs: c.misconfiguredCount.Load(),
+		WouldDelete:          c.wouldDeleteCount.Load(),
+		DeletedByReason:      c.getDeletedByReason(),
+	}
+}
+
+// CleanerHealth tells whether Run is active and how its last tick went, so a
+// health check can detect a dead or stuck cleaner
+func (c *BackupCleaner) CleanerHealth() CleanerHealthStatus {
+	health := CleanerHealthStatus{
+		IsRunning:           c.isRunning.Load(),
+		LastTickDurationMs:  c.lastTickDurationMs.Load(),
+		LastTickErrorsCount: c.lastTickErrorsCount.Load(),
+	}
+
+	if lastTickAt := c.lastTickAt.Load(); lastTickAt != 0 {
+		lastTickTime := time.Unix(0, lastTickAt).UTC()
+		health.LastTickAt = &lastTickTime
+	}
+
+	return health
+}
+
+// SetOneShotRetentionOverride makes every deleting pass of the next tick use the
+// config returned by override instead of the stored one for the database, later
+// ticks use the stored config again. A nil config skips the database in that
+// tick, so nothing is deleted. Setting a new override replaces the pending one
+func (c *BackupCleaner) SetOneShotRetentionOverride(
+	databaseID uuid.UUID,
+	override func(*backups_config.BackupConfig) *backups_config.BackupConfig,
+) {
+	c.oneShotRetentionOverrides.Store(databaseID, override)
+}
+
+// TimeUntilNextDeletion returns how long until the soonest-to-expire backup of the
+// database is deleted by the retention policy, together with that backup
+func (c *BackupCleaner) TimeUntilNextDeletion(
+	ctx context.Context,
+	databaseID uuid.UUID,
+) (time.Duration, *backups_core.Backup, error) {
+	backupConfig, err := c.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		databaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return getTimeUntilNextDeletion(backupConfig, completedBackups, time.Now().UTC())
+}
+
+// ForceRetentionCleanup runs the retention and the exceeded size passes for a single
+// database right away instead of waiting for the next tick. With isDryRun it only
+// reports backups that would be deleted. Databases skipped by the ticks are skipped
+// here too, the reason is reported in the result errors
+func (c *BackupCleaner) ForceRetentionCleanup(
+	ctx context.Context,
+	databaseID uuid.UUID,
+	isDryRun bool,
+) (*RetentionCleanupResult, error) {
+	backupConfig, err := c.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkCleanupAllowed(backupConfig); err != nil {
+		return &RetentionCleanupResult{
+			BackupIDs: []uuid.UUID{},
+			Errors:    []string{err.Error()},
+		}, nil
+	}
+
+	if isDryRun {
+		return c.planRetentionCleanup(ctx, backupConfig, time.Now().UTC())
+	}
+
+	backupsBefore, err := c.backupRepository.FindByDatabaseID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RetentionCleanupResult{
+		BackupIDs: []uuid.UUID{},
+		Errors:    []string{},
+	}
+
+	if err := c.cleanByPolicy(ctx, backupConfig); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	if backupConfig.MaxBackupsTotalSizeMB > 0 {
+		if err := c.cleanExceededBackupsForDatabase(
+			ctx,
+			databaseID,
+			backupConfig.MaxBackupsTotalSizeMB,
+		); err != nil {
+			result.Errors = append(result.Errors, err.Error())
 		}
 	}
 
-	storage, err := c.storageService.GetStorageByID(backup.StorageID)
+	c.recordUsage(databaseID)
+
+	backupsAfter, err := c.backupRepository.FindByDatabaseID(databaseID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	remainingIDs := make(map[uuid.UUID]bool, len(backupsAfter))
+	for _, backup := range backupsAfter {
+		remainingIDs[backup.ID] = true
+	}
+
+	for _, backup := range backupsBefore {
+		if !remainingIDs[backup.ID] {
+			result.BackupIDs = append(result.BackupIDs, backup.ID)
+			result.DeletedCount++
+			result.FreedMB += backup.BackupSizeMb
+		}
 	}
 
-	err = storage.DeleteFile(c.fieldEncryptor, backup.FileName)
+	return result, nil
+}
+
+// GFSSlotFillStatus reports how many periods of each active GFS slot are covered
+// by completed backups. Partly filled slots mean the backup interval is too slow
+// to fill the policy, or the database is younger than the slot
+func (c *BackupCleaner) GFSSlotFillStatus(
+	ctx context.Context,
+	databaseID uuid.UUID,
+) (map[string]SlotFill, error) {
+	backupConfig, err := c.backupConfigService.GetBackupConfigByDbId(databaseID)
 	if err != nil {
-		// we do not return error here, because sometimes clean up performed
-		// before unavailable storage removal or change - therefore we should
-		// proceed even in case of error. It's possible that some S3 or
-		// storage is not available yet, it should not block us
-		c.logger.Error("Failed to delete backup file", "error", err)
+		return nil, err
 	}
 
-	metadataFileName := backup.FileName + ".metadata"
-	if err := storage.DeleteFile(c.fieldEncryptor, metadataFileName); err != nil {
-		c.logger.Error("Failed to delete backup metadata file", "error", err)
+	if backupConfig.RetentionPolicyType != backups_config.RetentionPolicyTypeGFS {
+		return nil, errors.New("retention policy of the database is not GFS")
+	}
+
+	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		databaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return c.backupRepository.DeleteByID(backup.ID)
+	return calculateGFSSlotFills(completedBackups, GFSSlots{
+		Hours:  backupConfig.RetentionGfsHours,
+		Days:   backupConfig.RetentionGfsDays,
+		Weeks:  backupConfig.RetentionGfsWeeks,
+		Months: backupConfig.RetentionGfsMonths,
+		Years:  backupConfig.RetentionGfsYears,
+	}), nil
 }
 
-func (c *BackupCleaner) AddBackupRemoveListener(listener backups_core.BackupRemoveListener) {
-	c.backupRemoveListeners = append(c.backupRemoveListeners, listener)
+func (c *BackupCleaner) runTick(ctx context.Context) {
+	startedAt := time.Now().UTC()
+	errorsCountBefore := c.errorsCount.Load()
+	var failedPassesCount int64
+
+	if c.isReportOnly {
+		if err := c.reportRetentionCleanup(ctx); err != nil {
+			c.logger.Error("Failed to report retention cleanup", "error", err)
+			failedPassesCount++
+		}
+	} else {
+		ctx = context.WithValue(
+			ctx,
+			tickRetentionOverridesKey{},
+			make(map[uuid.UUID]*backups_config.BackupConfig),
+		)
+
+		if err := c.cleanByRetentionPolicy(ctx); err != nil {
+			c.logger.Error("Failed to clean backups by retention policy", "error", err)
+			failedPassesCount++
+		}
+
+		if err := c.cleanExceededBackups(ctx); err != nil {
+			c.logger.Error("Failed to clean exceeded backups", "error", err)
+			failedPassesCount++
+		}
+
+		if err := c.cleanBackupsWithoutFileName(ctx); err != nil {
+			c.logger.Error("Failed to clean backups without file name", "error", err)
+			failedPassesCount++
+		}
+	}
+
+	finishedAt := time.Now().UTC()
+
+	c.lastTickErrorsCount.Store(failedPassesCount + c.errorsCount.Load() - errorsCountBefore)
+	c.lastTickDurationMs.Store(finishedAt.Sub(startedAt).Milliseconds())
+	c.lastTickAt.Store(finishedAt.UnixNano())
 }
 
-func (c *BackupCleaner) cleanByRetentionPolicy() error {
+func (c *BackupCleaner) cleanByRetentionPolicy(ctx context.Context) error {
 	enabledBackupConfigs, err := c.backupConfigService.GetBackupConfigsWithEnabledBackups()
 	if err != nil {
 		return err
 	}
 
+	// when a pass over many databases outlasts the tick interval, critical
+	// databases must not wait behind all the normal ones
+	slices.SortStableFunc(enabledBackupConfigs, func(a, b *backups_config.BackupConfig) int {
+		return cmp.Compare(b.CleanerPriority, a.CleanerPriority)
+	})
+
 	for _, backupConfig := range enabledBackupConfigs {
-		var cleanErr error
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-		switch backupConfig.RetentionPolicyType {
-		case backups_config.RetentionPolicyTypeCount:
-			cleanErr = c.cleanByCount(backupConfig)
-		case backups_config.RetentionPolicyTypeGFS:
-			cleanErr = c.cleanByGFS(backupConfig)
-		default:
-			cleanErr = c.cleanByTimePeriod(backupConfig)
+		if c.checkCleanupAllowed(backupConfig) != nil {
+			continue
 		}
 
-		if cleanErr != nil {
+		backupConfig = c.consumeOneShotRetentionOverride(ctx, backupConfig)
+		if backupConfig == nil {
+			continue
+		}
+
+		c.cleanByDatabaseID(ctx, backupConfig)
+	}
+
+	return nil
+}
+
+// reportRetentionCleanup plans the same deletions as the retention and the
+// exceeded size passes, but only logs them
+func (c *BackupCleaner) reportRetentionCleanup(ctx context.Context) error {
+	enabledBackupConfigs, err := c.backupConfigService.GetBackupConfigsWithEnabledBackups()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	for _, backupConfig := range enabledBackupConfigs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if c.checkCleanupAllowed(backupConfig) != nil {
+			continue
+		}
+
+		result, err := c.planRetentionCleanup(ctx, backupConfig, now)
+		if err != nil {
+			c.errorsCount.Add(1)
 			c.logger.Error(
-				"Failed to clean backups by retention policy",
+				"Failed to plan retention cleanup",
 				"databaseId", backupConfig.DatabaseID,
-				"policy", backupConfig.RetentionPolicyType,
-				"error", cleanErr,
+				"error", err,
 			)
+			continue
+		}
+
+		if result.DeletedCount == 0 {
+			continue
 		}
+
+		c.wouldDeleteCount.Add(int64(result.DeletedCount))
+		c.logger.Info(
+			"Report-only mode, backups would be deleted",
+			"databaseId", backupConfig.DatabaseID,
+			"count", result.DeletedCount,
+			"freedMb", result.FreedMB,
+			"backupIds", result.BackupIDs,
+		)
 	}
 
 	return nil
 }
 
-func (c *BackupCleaner) cleanExceededBackups() error {
+// cleanByDatabaseID recovers from panics so a single broken database does not
+// stop the Run() loop and cleanup of all other databases
+func (c *BackupCleaner) cleanByDatabaseID(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+) {
+	var cleanErr error
+
+	ctx, span := c.tracer.Start(ctx, cleanDatabaseSpanName)
+	tally := &cleanupTally{}
+	ctx = context.WithValue(ctx, cleanupTallyKey{}, tally)
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			c.errorsCount.Add(1)
+			c.logger.Error(
+				"Panic while cleaning backups by retention policy",
+				"databaseId", backupConfig.DatabaseID,
+				"panic", fmt.Sprint(recovered),
+				"stack", string(debug.Stack()),
+			)
+
+			cleanErr = fmt.Errorf("panic while cleaning backups: %v", recovered)
+		}
+
+		c.recordCleanupResult(backupConfig, cleanErr)
+
+		span.SetAttribute(spanAttributeDatabaseID, backupConfig.DatabaseID.String())
+		span.SetAttribute(spanAttributePolicy, string(backupConfig.RetentionPolicyType))
+		span.SetAttribute(spanAttributeDeletedCount, tally.deletedCount)
+		span.SetAttribute(spanAttributeFreedMB, tally.freedMB)
+		if cleanErr != nil {
+			span.RecordError(cleanErr)
+		}
+		span.End()
+	}()
+
+	cleanErr = c.cleanByPolicy(ctx, backupConfig)
+	if cleanErr != nil {
+		c.errorsCount.Add(1)
+		c.logger.Error(
+			"Failed to clean backups by retention policy",
+			"databaseId", backupConfig.DatabaseID,
+			"policy", backupConfig.RetentionPolicyType,
+			"error", cleanErr,
+		)
+	}
+}
+
+func (c *BackupCleaner) cleanByPolicy(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+) error {
+	switch backupConfig.RetentionPolicyType {
+	case backups_config.RetentionPolicyTypeCount:
+		return c.cleanByCount(ctx, backupConfig)
+	case backups_config.RetentionPolicyTypeGFS:
+		return c.cleanByGFS(ctx, backupConfig)
+	case backups_config.RetentionPolicyTypeHotCold:
+		return c.cleanByHotColdRetention(ctx, backupConfig, time.Now().UTC())
+	case backups_config.RetentionPolicyTypeThinning:
+		return c.cleanByThinning(ctx, backupConfig)
+	case backups_config.RetentionPolicyTypeSchedule:
+		return c.cleanBySchedule(ctx, backupConfig)
+	case backups_config.RetentionPolicyTypeUnion:
+		return c.cleanByUnion(ctx, backupConfig)
+	default:
+		return c.cleanByTimePeriod(ctx, backupConfig)
+	}
+}
+
+func (c *BackupCleaner) cleanExceededBackups(ctx context.Context) error {
 	enabledBackupConfigs, err := c.backupConfigService.GetBackupConfigsWithEnabledBackups()
 	if err != nil {
 		return err
 	}
 
 	for _, backupConfig := range enabledBackupConfigs {
-		if backupConfig.MaxBackupsTotalSizeMB <= 0 {
-			continue
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		if err := c.cleanExceededBackupsForDatabase(
-			backupConfig.DatabaseID,
-			backupConfig.MaxBackupsTotalSizeMB,
-		); err != nil {
+		var effectiveConfig *backups_config.BackupConfig
+		if c.checkCleanupAllowed(backupConfig) == nil {
+			effectiveConfig = c.consumeOneShotRetentionOverride(ctx, backupConfig)
+		}
+
+		if effectiveConfig != nil && effectiveConfig.MaxBackupsTotalSizeMB > 0 {
+			if err := c.cleanExceededBackupsForDatabase(
+				ctx,
+				effectiveConfig.DatabaseID,
+				effectiveConfig.MaxBackupsTotalSizeMB,
+			); err != nil {
+				c.logger.Error(
+					"Failed to clean exceeded backups for database",
+					"databaseId",
+					backupConfig.DatabaseID,
+					"error",
+					err,
+				)
+			}
+		}
+
+		// size cleanup is the last step of the cleanup, so usage is recorded here.
+		// It is recorded even when nothing was deleted, because size may change
+		// for external reasons
+		c.recordUsage(backupConfig.DatabaseID)
+	}
+
+	return nil
+}
+
+// cleanBackupsWithoutFileName removes finished backups that have no file name,
+// e.g. when the node crashed before the upload recorded it
+func (c *BackupCleaner) cleanBackupsWithoutFileName(ctx context.Context) error {
+	backups, err := c.backupRepository.FindWithoutFileNameExcludingInProgress(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range backups {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := c.DeleteBackup(ctx, backup, backups_core.DeletionReasonOrphaned); err != nil {
 			c.logger.Error(
-				"Failed to clean exceeded backups for database",
-				"databaseId",
-				backupConfig.DatabaseID,
+				"Failed to delete backup without file name",
+				"backupId",
+				backup.ID,
 				"error",
 				err,
 			)
 			continue
 		}
+
+		c.logger.Info(
+			"Deleted backup without file name",
+			"backupId", backup.ID,
+			"databaseId", backup.DatabaseID,
+		)
 	}
 
 	return nil
 }
 
-func (c *BackupCleaner) cleanByTimePeriod(backupConfig *backups_config.BackupConfig) error {
+func (c *BackupCleaner) cleanByTimePeriod(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+) error {
+	// kept forever on purpose: any guessed period could delete backups the user
+	// wanted to keep, so the row is only reported
 	if backupConfig.RetentionTimePeriod == "" {
+		c.misconfiguredCount.Add(1)
+		c.logger.Warn(
+			"Time period retention has no period, backups are kept forever",
+			"databaseId", backupConfig.DatabaseID,
+			"policy", backupConfig.RetentionPolicyType,
+		)
+
 		return nil
 	}
 
@@ -170,13 +645,9 @@ func (c *BackupCleaner) cleanByTimePeriod(backupConfig *backups_config.BackupCon
 		return nil
 	}
 
-	storeDuration := backupConfig.RetentionTimePeriod.ToDuration()
-	dateBeforeBackupsShouldBeDeleted := time.Now().UTC().Add(-storeDuration)
+	now := time.Now().UTC()
 
-	oldBackups, err := c.backupRepository.FindBackupsBeforeDate(
-		backupConfig.DatabaseID,
-		dateBeforeBackupsShouldBeDeleted,
-	)
+	oldBackups, err := c.findBackupsForTimePeriod(ctx, backupConfig, now)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to find old backups for database %s: %w",
@@ -185,12 +656,27 @@ func (c *BackupCleaner) cleanByTimePeriod(backupConfig *backups_config.BackupCon
 		)
 	}
 
+	protectedSet, err := c.findRecentDaysProtectedSet(ctx, backupConfig, now)
+	if err != nil {
+		return err
+	}
+
 	for _, backup := range oldBackups {
-		if isRecentBackup(backup) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if isRecentBackup(backup) || protectedSet[backup.ID] {
 			continue
 		}
 
-		if err := c.DeleteBackup(backup); err != nil {
+		if backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			continue
+		}
+
+		c.notifyBeforeLastBackupDeletion(backup, backups_core.DeletionReasonTimePeriod)
+
+		if err := c.DeleteBackup(ctx, backup, backups_core.DeletionReasonTimePeriod); err != nil {
 			c.logger.Error("Failed to delete old backup", "backupId", backup.ID, "error", err)
 			continue
 		}
@@ -205,37 +691,48 @@ func (c *BackupCleaner) cleanByTimePeriod(backupConfig *backups_config.BackupCon
 	return nil
 }
 
-func (c *BackupCleaner) cleanByCount(backupConfig *backups_config.BackupConfig) error {
+func (c *BackupCleaner) cleanByCount(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+) error {
 	if backupConfig.RetentionCount <= 0 {
 		return nil
 	}
 
-	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
-		backupConfig.DatabaseID,
-		backups_core.BackupStatusCompleted,
-	)
+	now := time.Now().UTC()
+
+	toDelete, err := c.backupRepository.FindDeletionCandidates(ctx, backupConfig, now)
 	if err != nil {
 		return fmt.Errorf(
-			"failed to find completed backups for database %s: %w",
+			"failed to find backups beyond retention count for database %s: %w",
 			backupConfig.DatabaseID,
 			err,
 		)
 	}
 
-	// completedBackups are ordered newest first; delete everything beyond position RetentionCount
-	if len(completedBackups) <= backupConfig.RetentionCount {
-		return nil
+	protectedSet, err := c.findRecentDaysProtectedSet(ctx, backupConfig, now)
+	if err != nil {
+		return err
 	}
 
-	toDelete := completedBackups[backupConfig.RetentionCount:]
 	for _, backup := range toDelete {
-		if isRecentBackup(backup) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if protectedSet[backup.ID] {
 			continue
 		}
 
-		if err := c.DeleteBackup(backup); err != nil {
-			c.logger.Error(
-				"Failed to delete backup by count policy",
+		if backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			continue
+		}
+
+		c.notifyBeforeLastBackupDeletion(backup, backups_core.DeletionReasonCount)
+
+		if err := c.DeleteBackup(ctx, backup, backups_core.DeletionReasonCount); err != nil {
+			c.logger.Error(
+				"Failed to delete backup by count policy",
 				"backupId",
 				backup.ID,
 				"error",
@@ -255,7 +752,10 @@ func (c *BackupCleaner) cleanByCount(backupConfig *backups_config.BackupConfig)
 	return nil
 }
 
-func (c *BackupCleaner) cleanByGFS(backupConfig *backups_config.BackupConfig) error {
+func (c *BackupCleaner) cleanByGFS(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+) error {
 	if backupConfig.RetentionGfsHours <= 0 && backupConfig.RetentionGfsDays <= 0 &&
 		backupConfig.RetentionGfsWeeks <= 0 && backupConfig.RetentionGfsMonths <= 0 &&
 		backupConfig.RetentionGfsYears <= 0 {
@@ -263,6 +763,7 @@ func (c *BackupCleaner) cleanByGFS(backupConfig *backups_config.BackupConfig) er
 	}
 
 	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
 		backupConfig.DatabaseID,
 		backups_core.BackupStatusCompleted,
 	)
@@ -282,9 +783,32 @@ func (c *BackupCleaner) cleanByGFS(backupConfig *backups_config.BackupConfig) er
 		backupConfig.RetentionGfsMonths,
 		backupConfig.RetentionGfsYears,
 	)
+	maps.Copy(keepSet, buildRecentDaysKeepSet(
+		completedBackups,
+		backupConfig.GuaranteeOnePerRecentDay,
+		time.Now().UTC(),
+	))
+
+	// a broken bucketing must never remove newer data while keeping older one
+	if newerBackup := findBackupNewerThanNewestKept(completedBackups, keepSet); newerBackup != nil {
+		return fmt.Errorf(
+			"GFS cleanup aborted for database %s: backup %s is newer than the newest kept backup",
+			backupConfig.DatabaseID,
+			newerBackup.ID,
+		)
+	}
+
+	consolidatedIDs, err := c.consolidateGFSBackups(ctx, backupConfig, completedBackups, keepSet)
+	if err != nil {
+		return err
+	}
 
 	for _, backup := range completedBackups {
-		if keepSet[backup.ID] {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if keepSet[backup.ID] || consolidatedIDs[backup.ID] {
 			continue
 		}
 
@@ -292,7 +816,13 @@ func (c *BackupCleaner) cleanByGFS(backupConfig *backups_config.BackupConfig) er
 			continue
 		}
 
-		if err := c.DeleteBackup(backup); err != nil {
+		if backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			continue
+		}
+
+		c.notifyBeforeLastBackupDeletion(backup, backups_core.DeletionReasonGFS)
+
+		if err := c.DeleteBackup(ctx, backup, backups_core.DeletionReasonGFS); err != nil {
 			c.logger.Error(
 				"Failed to delete backup by GFS policy",
 				"backupId",
@@ -313,149 +843,1714 @@ func (c *BackupCleaner) cleanByGFS(backupConfig *backups_config.BackupConfig) er
 	return nil
 }
 
-func (c *BackupCleaner) cleanExceededBackupsForDatabase(
-	databaseID uuid.UUID,
-	limitperDbMB int64,
+// consolidateGFSBackups removes the tail of backups older than the
+// consolidation window which fill no GFS slot. The files are removed one by one,
+// the records with a single statement instead of one per backup
+func (c *BackupCleaner) consolidateGFSBackups(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+	completedBackups []*backups_core.Backup,
+	keepSet map[uuid.UUID]bool,
+) (map[uuid.UUID]bool, error) {
+	if backupConfig.ConsolidateAfterDays <= 0 {
+		return nil, nil
+	}
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -backupConfig.ConsolidateAfterDays)
+
+	var removedBackups []*backups_core.Backup
+	for _, backup := range completedBackups {
+		// stop removing files, but still drop the records of the removed ones below
+		if ctx.Err() != nil {
+			break
+		}
+
+		if keepSet[backup.ID] || !backup.CreatedAt.Before(cutoff) || isRecentBackup(backup) {
+			continue
+		}
+
+		if backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			continue
+		}
+
+		if err := c.deleteBackupFiles(backup); err != nil {
+			c.logger.Error(
+				"Failed to delete files of consolidated backup",
+				"backupId", backup.ID,
+				"error", err,
+			)
+			continue
+		}
+
+		removedBackups = append(removedBackups, backup)
+	}
+
+	if err := c.deleteBackupRecords(
+		ctx,
+		backupConfig.DatabaseID,
+		removedBackups,
+		backups_core.DeletionReasonConsolidation,
+	); err != nil {
+		return nil, err
+	}
+
+	consolidatedIDs := make(map[uuid.UUID]bool, len(removedBackups))
+	for _, backup := range removedBackups {
+		consolidatedIDs[backup.ID] = true
+	}
+
+	return consolidatedIDs, ctx.Err()
+}
+
+func (c *BackupCleaner) cleanByUnion(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
 ) error {
-	for {
-		backupsTotalSizeMB, err := c.backupRepository.GetTotalSizeByDatabase(databaseID)
-		if err != nil {
+	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		backupConfig.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to find completed backups for database %s: %w",
+			backupConfig.DatabaseID,
+			err,
+		)
+	}
+
+	now := time.Now().UTC()
+
+	keepSet := buildMostConservativeKeepSet(completedBackups, backupConfig, now)
+	maps.Copy(keepSet, buildRecentDaysKeepSet(
+		completedBackups,
+		backupConfig.GuaranteeOnePerRecentDay,
+		now,
+	))
+
+	for _, backup := range completedBackups {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		if backupsTotalSizeMB <= float64(limitperDbMB) {
-			break
+		if keepSet[backup.ID] || isRecentBackup(backup) {
+			continue
 		}
 
-		oldestBackups, err := c.backupRepository.FindOldestByDatabaseExcludingInProgress(
-			databaseID,
-			1,
+		if backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			continue
+		}
+
+		c.notifyBeforeLastBackupDeletion(backup, backups_core.DeletionReasonUnion)
+
+		if err := c.DeleteBackup(ctx, backup, backups_core.DeletionReasonUnion); err != nil {
+			c.logger.Error(
+				"Failed to delete backup by union policy",
+				"backupId", backup.ID,
+				"error", err,
+			)
+			continue
+		}
+
+		c.logger.Info(
+			"Deleted backup by union policy",
+			"backupId", backup.ID,
+			"databaseId", backupConfig.DatabaseID,
 		)
-		if err != nil {
+	}
+
+	return nil
+}
+
+func (c *BackupCleaner) cleanByThinning(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+) error {
+	if backupConfig.ThinningKeepEvery < 2 || backupConfig.ThinningAfter == "" ||
+		backupConfig.BackupInterval == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	// thinning relies on spacing between backups rather than their positions,
+	// otherwise every pass would thin already thinned backups again
+	runTimes := backupConfig.BackupInterval.NextNRunTimes(now, 2)
+	if len(runTimes) < 2 {
+		return nil
+	}
+
+	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		backupConfig.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to find completed backups for database %s: %w",
+			backupConfig.DatabaseID,
+			err,
+		)
+	}
+
+	keepSet := buildThinningKeepSet(
+		completedBackups,
+		backupConfig.ThinningKeepEvery,
+		runTimes[1].Sub(runTimes[0]),
+		backupConfig.ThinningAfter.ToDuration(),
+		now,
+	)
+	maps.Copy(keepSet, buildRecentDaysKeepSet(
+		completedBackups,
+		backupConfig.GuaranteeOnePerRecentDay,
+		now,
+	))
+
+	for _, backup := range completedBackups {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		if len(oldestBackups) == 0 {
-			c.logger.Warn(
-				"No backups to delete but still over limit",
-				"databaseId",
-				databaseID,
-				"totalSizeMB",
-				backupsTotalSizeMB,
-				"limitMB",
-				limitperDbMB,
-			)
-			break
+		if keepSet[backup.ID] {
+			continue
 		}
 
-		backup := oldestBackups[0]
 		if isRecentBackup(backup) {
-			c.logger.Warn(
-				"Oldest backup is too recent to delete, stopping size cleanup",
-				"databaseId",
-				databaseID,
-				"backupId",
-				backup.ID,
-				"totalSizeMB",
-				backupsTotalSizeMB,
-				"limitMB",
-				limitperDbMB,
-			)
-			break
+			continue
+		}
+
+		if backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			continue
 		}
 
-		if err := c.DeleteBackup(backup); err != nil {
+		c.notifyBeforeLastBackupDeletion(backup, backups_core.DeletionReasonThinning)
+
+		if err := c.DeleteBackup(ctx, backup, backups_core.DeletionReasonThinning); err != nil {
 			c.logger.Error(
-				"Failed to delete exceeded backup",
+				"Failed to delete backup by thinning policy",
 				"backupId",
 				backup.ID,
-				"databaseId",
-				databaseID,
 				"error",
 				err,
 			)
-			return err
+			continue
 		}
 
 		c.logger.Info(
-			"Deleted exceeded backup",
-			"backupId",
-			backup.ID,
-			"databaseId",
-			databaseID,
-			"backupSizeMB",
-			backup.BackupSizeMb,
-			"totalSizeMB",
-			backupsTotalSizeMB,
-			"limitMB",
-			limitperDbMB,
+			"Deleted backup by thinning policy",
+			"backupId", backup.ID,
+			"databaseId", backupConfig.DatabaseID,
+			"keepEvery", backupConfig.ThinningKeepEvery,
 		)
 	}
 
 	return nil
 }
 
-func isRecentBackup(backup *backups_core.Backup) bool {
-	return time.Since(backup.CreatedAt) < recentBackupGracePeriod
-}
-
-// buildGFSKeepSet determines which backups to retain under the GFS rotation scheme.
-// Backups must be sorted newest-first. A backup can fill multiple slots simultaneously
-// (e.g. the newest backup of a year also fills the monthly, weekly, daily, and hourly slot).
-func buildGFSKeepSet(
-	backups []*backups_core.Backup,
-	hours, days, weeks, months, years int,
-) map[uuid.UUID]bool {
-	keep := make(map[uuid.UUID]bool)
-
-	hoursSeen := make(map[string]bool)
-	daysSeen := make(map[string]bool)
-	weeksSeen := make(map[string]bool)
-	monthsSeen := make(map[string]bool)
-	yearsSeen := make(map[string]bool)
-
-	hoursKept, daysKept, weeksKept, monthsKept, yearsKept := 0, 0, 0, 0, 0
+func (c *BackupCleaner) cleanBySchedule(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+) error {
+	scheduleTime, err := backupConfig.GetRetentionScheduleTime()
+	if err != nil || backupConfig.RetentionScheduleDays <= 0 {
+		return nil
+	}
 
-	for _, backup := range backups {
-		t := backup.CreatedAt
+	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		backupConfig.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to find completed backups for database %s: %w",
+			backupConfig.DatabaseID,
+			err,
+		)
+	}
 
-		hourKey := t.Format("2006-01-02-15")
-		dayKey := t.Format("2006-01-02")
-		weekYear, week := t.ISOWeek()
-		weekKey := fmt.Sprintf("%d-%02d", weekYear, week)
-		monthKey := t.Format("2006-01")
-		yearKey := t.Format("2006")
+	keepSet := buildScheduleKeepSet(
+		completedBackups,
+		scheduleTime,
+		backupConfig.RetentionScheduleDays,
+	)
+	maps.Copy(keepSet, buildRecentDaysKeepSet(
+		completedBackups,
+		backupConfig.GuaranteeOnePerRecentDay,
+		time.Now().UTC(),
+	))
 
-		if hours > 0 && hoursKept < hours && !hoursSeen[hourKey] {
-			keep[backup.ID] = true
-			hoursSeen[hourKey] = true
-			hoursKept++
+	for _, backup := range completedBackups {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		if days > 0 && daysKept < days && !daysSeen[dayKey] {
-			keep[backup.ID] = true
-			daysSeen[dayKey] = true
-			daysKept++
+		if keepSet[backup.ID] {
+			continue
 		}
 
-		if weeks > 0 && weeksKept < weeks && !weeksSeen[weekKey] {
-			keep[backup.ID] = true
-			weeksSeen[weekKey] = true
-			weeksKept++
+		if isRecentBackup(backup) {
+			continue
 		}
 
-		if months > 0 && monthsKept < months && !monthsSeen[monthKey] {
-			keep[backup.ID] = true
-			monthsSeen[monthKey] = true
-			monthsKept++
+		if backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			continue
 		}
 
-		if years > 0 && yearsKept < years && !yearsSeen[yearKey] {
-			keep[backup.ID] = true
-			yearsSeen[yearKey] = true
-			yearsKept++
+		c.notifyBeforeLastBackupDeletion(backup, backups_core.DeletionReasonSchedule)
+
+		if err := c.DeleteBackup(ctx, backup, backups_core.DeletionReasonSchedule); err != nil {
+			c.logger.Error(
+				"Failed to delete backup by schedule policy",
+				"backupId",
+				backup.ID,
+				"error",
+				err,
+			)
+			continue
 		}
+
+		c.logger.Info(
+			"Deleted backup by schedule policy",
+			"backupId", backup.ID,
+			"databaseId", backupConfig.DatabaseID,
+		)
 	}
 
-	return keep
+	return nil
+}
+
+// cleanByHotColdRetention moves backups older than HotRetention to the cold storage
+// and deletes backups older than ColdRetention. `now` is passed explicitly to keep
+// the two-stage lifecycle deterministic
+func (c *BackupCleaner) cleanByHotColdRetention(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+	now time.Time,
+) error {
+	if !isHotColdRetentionConfigured(backupConfig) {
+		return nil
+	}
+
+	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		backupConfig.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to find completed backups for database %s: %w",
+			backupConfig.DatabaseID,
+			err,
+		)
+	}
+
+	hotDeadline := now.Add(-backupConfig.HotRetention.ToDuration())
+
+	var coldDeadline *time.Time
+	if backupConfig.ColdRetention != period.PeriodForever {
+		deadline := now.Add(-backupConfig.ColdRetention.ToDuration())
+		coldDeadline = &deadline
+	}
+
+	protectedSet := buildRecentDaysKeepSet(
+		completedBackups,
+		backupConfig.GuaranteeOnePerRecentDay,
+		now,
+	)
+
+	var coldStorage *storages.Storage
+
+	for _, backup := range completedBackups {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if isRecentBackup(backup) {
+			continue
+		}
+
+		if coldDeadline != nil && backup.CreatedAt.Before(*coldDeadline) {
+			if protectedSet[backup.ID] ||
+				backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+				continue
+			}
+
+			c.notifyBeforeLastBackupDeletion(backup, backups_core.DeletionReasonColdRetention)
+
+			if err := c.DeleteBackup(
+				ctx,
+				backup,
+				backups_core.DeletionReasonColdRetention,
+			); err != nil {
+				c.logger.Error(
+					"Failed to delete backup by cold retention",
+					"backupId",
+					backup.ID,
+					"error",
+					err,
+				)
+				continue
+			}
+
+			c.logger.Info(
+				"Deleted backup by cold retention",
+				"backupId", backup.ID,
+				"databaseId", backupConfig.DatabaseID,
+			)
+			continue
+		}
+
+		if !backup.CreatedAt.Before(hotDeadline) || backup.StorageID == *backupConfig.ColdStorageID {
+			continue
+		}
+
+		if coldStorage == nil {
+			coldStorage, err = c.storageService.GetStorageByID(*backupConfig.ColdStorageID)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to get cold storage %s: %w",
+					*backupConfig.ColdStorageID,
+					err,
+				)
+			}
+		}
+
+		if err := c.moveBackupToStorage(ctx, backup, coldStorage); err != nil {
+			c.logger.Error(
+				"Failed to move backup to cold storage",
+				"backupId",
+				backup.ID,
+				"coldStorageId",
+				coldStorage.ID,
+				"error",
+				err,
+			)
+			continue
+		}
+
+		c.logger.Info(
+			"Moved backup to cold storage",
+			"backupId", backup.ID,
+			"databaseId", backupConfig.DatabaseID,
+			"coldStorageId", coldStorage.ID,
+		)
+	}
+
+	return nil
+}
+
+func (c *BackupCleaner) moveBackupToStorage(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	targetStorage *storages.Storage,
+) error {
+	sourceStorage, err := c.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		return err
+	}
+
+	if err := c.copyFileBetweenStorages(
+		ctx,
+		sourceStorage,
+		targetStorage,
+		backup.FileName,
+	); err != nil {
+		return err
+	}
+
+	metadataFileName := backup.FileName + ".metadata"
+	if err := c.copyFileBetweenStorages(
+		ctx,
+		sourceStorage,
+		targetStorage,
+		metadataFileName,
+	); err != nil {
+		// metadata file is optional for old backups, so it should not block the move
+		c.logger.Warn("Failed to copy backup metadata file", "backupId", backup.ID, "error", err)
+	}
+
+	backup.StorageID = targetStorage.ID
+	if err := c.backupRepository.Save(backup); err != nil {
+		return err
+	}
+
+	if err := sourceStorage.DeleteFile(c.fieldEncryptor, backup.FileName); err != nil {
+		c.logger.Error("Failed to delete moved backup file from source storage", "error", err)
+	}
+
+	if err := sourceStorage.DeleteFile(c.fieldEncryptor, metadataFileName); err != nil {
+		c.logger.Error("Failed to delete moved backup metadata from source storage", "error", err)
+	}
+
+	return nil
+}
+
+func (c *BackupCleaner) copyFileBetweenStorages(
+	ctx context.Context,
+	sourceStorage *storages.Storage,
+	targetStorage *storages.Storage,
+	fileName string,
+) error {
+	reader, err := sourceStorage.GetFile(c.fieldEncryptor, fileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	return targetStorage.SaveFile(
+		ctx,
+		c.fieldEncryptor,
+		c.logger,
+		fileName,
+		reader,
+	)
+}
+
+func (c *BackupCleaner) cleanExceededBackupsForDatabase(
+	ctx context.Context,
+	databaseID uuid.UUID,
+	limitperDbMB int64,
+) error {
+	if !c.backupMutexRegistry.TryLock(databaseID) {
+		c.logger.Info(
+			"Backup is in progress, skipping exceeded backups cleanup",
+			"databaseId",
+			databaseID,
+		)
+		return nil
+	}
+	defer c.backupMutexRegistry.Unlock(databaseID)
+
+	// backups within the grace period are never deleted by this pass. When they
+	// alone exceed the limit, the loop would remove every older backup and still
+	// end up over the limit
+	lockedSizeBytes, err := c.backupRepository.GetTotalSizeBytesCreatedAfterByDatabase(
+		ctx,
+		databaseID,
+		time.Now().UTC().Add(-recentBackupGracePeriod),
+	)
+	if err != nil {
+		return err
+	}
+
+	if lockedSizeBytes > limitperDbMB*size.BytesInMB {
+		c.logger.Warn(
+			"Limit cannot be met due to locked backups, skipping size cleanup",
+			"databaseId",
+			databaseID,
+			"lockedSizeMB",
+			size.BytesToMB(lockedSizeBytes),
+			"limitMB",
+			limitperDbMB,
+		)
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		backupsTotalSizeBytes, err := c.backupRepository.GetTotalSizeBytesByDatabase(
+			ctx,
+			databaseID,
+		)
+		if err != nil {
+			return err
+		}
+
+		if backupsTotalSizeBytes <= limitperDbMB*size.BytesInMB {
+			break
+		}
+
+		backupsTotalSizeMB := size.BytesToMB(backupsTotalSizeBytes)
+
+		oldestBackups, err := c.backupRepository.FindOldestByDatabaseExcludingInProgress(
+			ctx,
+			databaseID,
+			1,
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(oldestBackups) == 0 {
+			c.logger.Warn(
+				"No backups to delete but still over limit",
+				"databaseId",
+				databaseID,
+				"totalSizeMB",
+				backupsTotalSizeMB,
+				"limitMB",
+				limitperDbMB,
+			)
+			break
+		}
+
+		backup := oldestBackups[0]
+		if isRecentBackup(backup) {
+			c.logger.Warn(
+				"Oldest backup is too recent to delete, stopping size cleanup",
+				"databaseId",
+				databaseID,
+				"backupId",
+				backup.ID,
+				"totalSizeMB",
+				backupsTotalSizeMB,
+				"limitMB",
+				limitperDbMB,
+			)
+			break
+		}
+
+		c.notifyBeforeLastBackupDeletion(backup, backups_core.DeletionReasonSizeLimit)
+
+		if err := c.DeleteBackup(ctx, backup, backups_core.DeletionReasonSizeLimit); err != nil {
+			c.logger.Error(
+				"Failed to delete exceeded backup",
+				"backupId",
+				backup.ID,
+				"databaseId",
+				databaseID,
+				"error",
+				err,
+			)
+			return err
+		}
+
+		c.logger.Info(
+			"Deleted exceeded backup",
+			"backupId",
+			backup.ID,
+			"databaseId",
+			databaseID,
+			"backupSizeMB",
+			backup.BackupSizeMb,
+			"totalSizeMB",
+			backupsTotalSizeMB,
+			"limitMB",
+			limitperDbMB,
+		)
+	}
+
+	return nil
+}
+
+// findBackupsForTimePeriod returns backups older than the retention period. With
+// ShouldUseStorageObjectAge the age reported by the storage is used instead,
+// so all completed backups have to be checked
+func (c *BackupCleaner) findBackupsForTimePeriod(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+	now time.Time,
+) ([]*backups_core.Backup, error) {
+	if !backupConfig.ShouldUseStorageObjectAge {
+		return c.backupRepository.FindDeletionCandidates(ctx, backupConfig, now)
+	}
+
+	date := now.Add(-backupConfig.RetentionTimePeriod.ToDuration())
+
+	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		backupConfig.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	storagesByID := make(map[uuid.UUID]*storages.Storage)
+
+	var oldBackups []*backups_core.Backup
+	for _, backup := range completedBackups {
+		storage, ok := storagesByID[backup.StorageID]
+		if !ok {
+			storage, err = c.storageService.GetStorageByID(backup.StorageID)
+			if err != nil {
+				return nil, err
+			}
+
+			storagesByID[backup.StorageID] = storage
+		}
+
+		if getBackupAgeReferenceTime(backup, storage, c.fieldEncryptor, c.logger).Before(date) {
+			oldBackups = append(oldBackups, backup)
+		}
+	}
+
+	return oldBackups, nil
+}
+
+// findDeletionCandidates applies GFS and thinning keep sets on top of the SQL
+// filtering of FindDeletionCandidates
+func (c *BackupCleaner) findDeletionCandidates(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+	now time.Time,
+) ([]*backups_core.Backup, error) {
+	candidates, err := c.backupRepository.FindDeletionCandidates(ctx, backupConfig, now)
+	if err != nil {
+		return nil, err
+	}
+
+	protectedSet, err := c.findRecentDaysProtectedSet(ctx, backupConfig, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var keepSet map[uuid.UUID]bool
+
+	switch backupConfig.RetentionPolicyType {
+	case backups_config.RetentionPolicyTypeGFS:
+		keepSet = buildGFSKeepSet(
+			candidates,
+			backupConfig.RetentionGfsHours,
+			backupConfig.RetentionGfsDays,
+			backupConfig.RetentionGfsWeeks,
+			backupConfig.RetentionGfsMonths,
+			backupConfig.RetentionGfsYears,
+		)
+	case backups_config.RetentionPolicyTypeThinning:
+		if backupConfig.BackupInterval == nil || backupConfig.ThinningAfter == "" {
+			return []*backups_core.Backup{}, nil
+		}
+
+		runTimes := backupConfig.BackupInterval.NextNRunTimes(now, 2)
+		if len(runTimes) < 2 {
+			return []*backups_core.Backup{}, nil
+		}
+
+		keepSet = buildThinningKeepSet(
+			candidates,
+			backupConfig.ThinningKeepEvery,
+			runTimes[1].Sub(runTimes[0]),
+			backupConfig.ThinningAfter.ToDuration(),
+			now,
+		)
+	case backups_config.RetentionPolicyTypeSchedule:
+		scheduleTime, err := backupConfig.GetRetentionScheduleTime()
+		if err != nil {
+			return []*backups_core.Backup{}, nil
+		}
+
+		keepSet = buildScheduleKeepSet(
+			candidates,
+			scheduleTime,
+			backupConfig.RetentionScheduleDays,
+		)
+	case backups_config.RetentionPolicyTypeHotCold:
+		if !isHotColdRetentionConfigured(backupConfig) {
+			return []*backups_core.Backup{}, nil
+		}
+
+		keepSet = map[uuid.UUID]bool{}
+	case backups_config.RetentionPolicyTypeUnion:
+		keepSet = buildMostConservativeKeepSet(candidates, backupConfig, now)
+	default:
+		deletable := []*backups_core.Backup{}
+		for _, backup := range candidates {
+			if !protectedSet[backup.ID] &&
+				!backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+				deletable = append(deletable, backup)
+			}
+		}
+
+		return deletable, nil
+	}
+
+	maps.Copy(keepSet, protectedSet)
+
+	graceCutoff := now.Add(-recentBackupGracePeriod)
+
+	deletable := []*backups_core.Backup{}
+	for _, backup := range candidates {
+		if keepSet[backup.ID] || backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			continue
+		}
+
+		if backup.CreatedAt.Before(graceCutoff) {
+			deletable = append(deletable, backup)
+		}
+	}
+
+	return deletable, nil
+}
+
+func (c *BackupCleaner) planRetentionCleanup(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+	now time.Time,
+) (*RetentionCleanupResult, error) {
+	// the same candidates as the size pass, failed backups count towards the
+	// limit and are deleted by it too. A negative limit loads all of them
+	sizePassBackups, err := c.backupRepository.FindOldestByDatabaseExcludingInProgress(
+		ctx,
+		backupConfig.DatabaseID,
+		-1,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RetentionCleanupResult{
+		BackupIDs: []uuid.UUID{},
+		Errors:    []string{},
+	}
+
+	plannedBackups, err := c.findDeletionCandidates(ctx, backupConfig, now)
+	if err != nil {
+		return nil, err
+	}
+
+	isPlannedByID := make(map[uuid.UUID]bool)
+	for _, backup := range plannedBackups {
+		isPlannedByID[backup.ID] = true
+		result.BackupIDs = append(result.BackupIDs, backup.ID)
+		result.DeletedCount++
+		result.FreedMB += backup.BackupSizeMb
+	}
+
+	remainingSizeMB := 0.0
+	for _, backup := range sizePassBackups {
+		if !isPlannedByID[backup.ID] {
+			remainingSizeMB += backup.BackupSizeMb
+		}
+	}
+
+	if backupConfig.MaxBackupsTotalSizeMB > 0 {
+		limitMB := float64(backupConfig.MaxBackupsTotalSizeMB)
+
+		for _, backup := range sizePassBackups {
+			if remainingSizeMB <= limitMB {
+				break
+			}
+
+			if isPlannedByID[backup.ID] {
+				continue
+			}
+
+			if isRecentBackup(backup) {
+				break
+			}
+
+			result.BackupIDs = append(result.BackupIDs, backup.ID)
+			result.DeletedCount++
+			result.FreedMB += backup.BackupSizeMb
+			remainingSizeMB -= backup.BackupSizeMb
+		}
+	}
+
+	return result, nil
+}
+
+// findRecentDaysProtectedSet loads completed backups only when the guarantee is
+// enabled, policies which do not load them otherwise should not pay for it
+func (c *BackupCleaner) findRecentDaysProtectedSet(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+	now time.Time,
+) (map[uuid.UUID]bool, error) {
+	if backupConfig.GuaranteeOnePerRecentDay <= 0 {
+		return map[uuid.UUID]bool{}, nil
+	}
+
+	completedBackups, err := c.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		backupConfig.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to find completed backups for database %s: %w",
+			backupConfig.DatabaseID,
+			err,
+		)
+	}
+
+	return buildRecentDaysKeepSet(completedBackups, backupConfig.GuaranteeOnePerRecentDay, now), nil
+}
+
+// checkCleanupAllowed holds the guards of every path which deletes backups or
+// plans their deletion, so the on-demand cleanup never deletes what the ticks keep
+func (c *BackupCleaner) checkCleanupAllowed(backupConfig *backups_config.BackupConfig) error {
+	// backups of read-only databases are held indefinitely
+	if backupConfig.IsReadOnlyMode {
+		return ErrCleanupDatabaseReadOnly
+	}
+
+	// rows removed while files cannot be reached would orphan the files
+	// once the storage is back
+	if !c.isBackupStoragesAvailable(backupConfig) {
+		return ErrCleanupStoragesUnavailable
+	}
+
+	return nil
+}
+
+func (c *BackupCleaner) isBackupStoragesAvailable(
+	backupConfig *backups_config.BackupConfig,
+) bool {
+	for _, storageID := range []*uuid.UUID{backupConfig.StorageID, backupConfig.ColdStorageID} {
+		if storageID == nil {
+			continue
+		}
+
+		isAvailable, err := c.storageService.IsAvailable(*storageID)
+		if err != nil {
+			c.logger.Error(
+				"Failed to check storage availability, skipping retention cleanup",
+				"databaseId", backupConfig.DatabaseID,
+				"storageId", *storageID,
+				"error", err,
+			)
+			return false
+		}
+
+		if !isAvailable {
+			c.logger.Warn(
+				"Storage is unavailable, skipping retention cleanup",
+				"databaseId", backupConfig.DatabaseID,
+				"storageId", *storageID,
+			)
+			return false
+		}
+	}
+
+	return true
+}
+
+// notifyBeforeLastBackupDeletion warns the user when retention is about to remove
+// the only completed backup of the database. The alert is sent at most once per
+// lastBackupAlertInterval, as a failed deletion is retried on every cleaner tick
+func (c *BackupCleaner) notifyBeforeLastBackupDeletion(
+	backup *backups_core.Backup,
+	reason backups_core.DeletionReason,
+) {
+	if backup.Status != backups_core.BackupStatusCompleted {
+		return
+	}
+
+	isLastBackup, err := c.isLastCompletedBackup(backup)
+	if err != nil {
+		c.logger.Error(
+			"Failed to check whether backup is the last one",
+			"backupId", backup.ID,
+			"error", err,
+		)
+		return
+	}
+
+	if !isLastBackup {
+		return
+	}
+
+	alertKey := backup.DatabaseID.String()
+	if c.lastBackupAlertCache.Get(alertKey) != nil {
+		return
+	}
+
+	backupConfig, err := c.backupConfigService.GetBackupConfigByDbId(backup.DatabaseID)
+	if err != nil {
+		c.logger.Error("Failed to get backup config for last backup alert", "error", err)
+		return
+	}
+
+	if !slices.Contains(
+		backupConfig.SendNotificationsOn,
+		backups_config.NotificationLastBackupDeletion,
+	) {
+		return
+	}
+
+	database, err := c.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		c.logger.Error("Failed to get database for last backup alert", "error", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	c.lastBackupAlertCache.SetWithExpiration(alertKey, &now, lastBackupAlertInterval)
+
+	title := fmt.Sprintf(
+		"🚨 Last backup of database \"%s\" is being deleted by retention",
+		database.Name,
+	)
+	message := fmt.Sprintf(
+		"Backup created at %s is the only completed backup of the database. "+
+			"After its deletion there is nothing to restore from. Deletion reason: %s",
+		backup.CreatedAt.Format(time.RFC3339),
+		reason,
+	)
+
+	recipients, err := c.backupConfigService.GetNotificationRecipients(backupConfig, database)
+	if err != nil {
+		c.logger.Error(
+			"Failed to get some recipients of last backup alert",
+			"databaseId", database.ID,
+			"error", err,
+		)
+	}
+
+	for _, notifier := range recipients {
+		c.notificationSender.SendNotification(&notifier, title, message)
+	}
+}
+
+func (c *BackupCleaner) deleteBackup(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	reason backups_core.DeletionReason,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := c.deleteBackupFiles(backup); err != nil {
+		return err
+	}
+
+	// the record is removed regardless of the context: once the file is gone,
+	// an aborted removal would leave a record pointing to nothing
+	return c.deleteBackupRecord(backup, reason)
+}
+
+func (c *BackupCleaner) deleteBackupFiles(backup *backups_core.Backup) error {
+	for _, listener := range c.backupRemoveListeners {
+		if err := listener.OnBeforeBackupRemove(backup); err != nil {
+			return err
+		}
+	}
+
+	// backups without file name never reached the storage, deleting an empty
+	// file name would point storage API to the root of the bucket or folder
+	if backup.FileName == "" {
+		c.logger.Warn(
+			"Backup has no file name, removing only its record",
+			"backupId",
+			backup.ID,
+		)
+		return nil
+	}
+
+	storage := backup.Storage
+	if storage == nil {
+		loadedStorage, err := c.storageService.GetStorageByID(backup.StorageID)
+		if err != nil {
+			return err
+		}
+
+		storage = loadedStorage
+	}
+
+	err := storage.DeleteFile(c.fieldEncryptor, backup.FileName)
+	if err != nil {
+		// we do not return error here, because sometimes clean up performed
+		// before unavailable storage removal or change - therefore we should
+		// proceed even in case of error. It's possible that some S3 or
+		// storage is not available yet, it should not block us
+		c.logger.Error("Failed to delete backup file", "error", err)
+	}
+
+	metadataFileName := backup.FileName + ".metadata"
+	if err := storage.DeleteFile(c.fieldEncryptor, metadataFileName); err != nil {
+		c.logger.Error("Failed to delete backup metadata file", "error", err)
+	}
+
+	return nil
+}
+
+func (c *BackupCleaner) deleteBackupRecord(
+	backup *backups_core.Backup,
+	reason backups_core.DeletionReason,
+) error {
+	if err := c.backupRepository.DeleteByID(backup.ID); err != nil {
+		return err
+	}
+
+	counter, _ := c.deletedByReason.LoadOrStore(reason, &atomic.Int64{})
+	counter.(*atomic.Int64).Add(1)
+
+	c.logger.Info(
+		"Backup deleted",
+		"backupId", backup.ID,
+		"databaseId", backup.DatabaseID,
+		"reason", reason,
+	)
+
+	return nil
+}
+
+func (c *BackupCleaner) deleteBackupRecords(
+	ctx context.Context,
+	databaseID uuid.UUID,
+	backups []*backups_core.Backup,
+	reason backups_core.DeletionReason,
+) error {
+	if len(backups) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(backups))
+	var freedMB float64
+	for i, backup := range backups {
+		ids[i] = backup.ID
+		freedMB += backup.BackupSizeMb
+	}
+
+	deletedCount, err := c.backupRepository.DeleteByIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	counter, _ := c.deletedByReason.LoadOrStore(reason, &atomic.Int64{})
+	counter.(*atomic.Int64).Add(deletedCount)
+
+	if tally, ok := ctx.Value(cleanupTallyKey{}).(*cleanupTally); ok {
+		tally.deletedCount += int(deletedCount)
+		tally.freedMB += freedMB
+	}
+
+	c.logger.Info(
+		"Backups deleted",
+		"databaseId", databaseID,
+		"count", deletedCount,
+		"reason", reason,
+	)
+
+	return nil
+}
+
+// consumeOneShotRetentionOverride remembers the consumed override in the tick
+// context, so the later passes of the same tick get the same config
+func (c *BackupCleaner) consumeOneShotRetentionOverride(
+	ctx context.Context,
+	backupConfig *backups_config.BackupConfig,
+) *backups_config.BackupConfig {
+	tickOverrides, hasTickOverrides := ctx.Value(
+		tickRetentionOverridesKey{},
+	).(map[uuid.UUID]*backups_config.BackupConfig)
+	if hasTickOverrides {
+		if overriddenConfig, isConsumed := tickOverrides[backupConfig.DatabaseID]; isConsumed {
+			return overriddenConfig
+		}
+	}
+
+	storedOverride, isFound := c.oneShotRetentionOverrides.LoadAndDelete(backupConfig.DatabaseID)
+	if !isFound {
+		return backupConfig
+	}
+
+	override := storedOverride.(func(*backups_config.BackupConfig) *backups_config.BackupConfig)
+	overriddenConfig := override(backupConfig)
+	if hasTickOverrides {
+		tickOverrides[backupConfig.DatabaseID] = overriddenConfig
+	}
+
+	if overriddenConfig == nil {
+		c.logger.Info(
+			"One-shot retention override skips the database in this pass",
+			"databaseId", backupConfig.DatabaseID,
+		)
+		return nil
+	}
+
+	c.logger.Info(
+		"Applying one-shot retention override",
+		"databaseId", backupConfig.DatabaseID,
+		"policy", overriddenConfig.RetentionPolicyType,
+	)
+
+	return overriddenConfig
+}
+
+func (c *BackupCleaner) getDeletedByReason() map[backups_core.DeletionReason]int64 {
+	deletedByReason := make(map[backups_core.DeletionReason]int64)
+
+	c.deletedByReason.Range(func(reason, counter any) bool {
+		deletedByReason[reason.(backups_core.DeletionReason)] = counter.(*atomic.Int64).Load()
+		return true
+	})
+
+	return deletedByReason
+}
+
+func (c *BackupCleaner) isLastCompletedBackup(backup *backups_core.Backup) (bool, error) {
+	newestBackup, err := c.backupRepository.FindNewestByDatabaseID(
+		backup.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil || newestBackup == nil || newestBackup.ID != backup.ID {
+		return false, err
+	}
+
+	oldestBackup, err := c.backupRepository.FindOldestByDatabaseID(
+		backup.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil || oldestBackup == nil {
+		return false, err
+	}
+
+	return oldestBackup.ID == backup.ID, nil
+}
+
+func (c *BackupCleaner) recordUsage(databaseID uuid.UUID) {
+	totalSizeMB, err := c.backupRepository.GetTotalSizeByDatabase(databaseID)
+	if err != nil {
+		c.logger.Error(
+			"Failed to get total backups size for usage recording",
+			"databaseId",
+			databaseID,
+			"error",
+			err,
+		)
+		return
+	}
+
+	c.usageRecorder(databaseID, totalSizeMB)
+}
+
+// recordCleanupResult skips the write when nothing changed, so healthy
+// databases do not cause an update on every cleaner tick
+func (c *BackupCleaner) recordCleanupResult(
+	backupConfig *backups_config.BackupConfig,
+	cleanErr error,
+) {
+	if cleanErr == nil && backupConfig.LastCleanupError == "" {
+		return
+	}
+
+	if err := c.backupConfigService.SetLastCleanupError(
+		backupConfig.DatabaseID,
+		cleanErr,
+	); err != nil {
+		c.logger.Error(
+			"Failed to record cleanup result",
+			"databaseId", backupConfig.DatabaseID,
+			"error", err,
+		)
+	}
+}
+
+// BuildMostConservativeKeepSet returns backups which any of the count, time
+// period or GFS policies of the config would keep. Backups must be sorted
+// newest-first
+func BuildMostConservativeKeepSet(
+	backups []*backups_core.Backup,
+	config *backups_config.BackupConfig,
+) map[uuid.UUID]bool {
+	return buildMostConservativeKeepSet(backups, config, time.Now().UTC())
+}
+
+// isHotColdRetentionConfigured reports whether the hot/cold pass runs at all.
+// Until both retentions and the cold storage are set, nothing is moved or deleted
+func isHotColdRetentionConfigured(backupConfig *backups_config.BackupConfig) bool {
+	return backupConfig.HotRetention != "" && backupConfig.ColdRetention != "" &&
+		backupConfig.ColdStorageID != nil
+}
+
+func isRecentBackup(backup *backups_core.Backup) bool {
+	return time.Since(backup.CreatedAt) < recentBackupGracePeriod
+}
+
+type fileModTimeGetter interface {
+	GetFileModTime(
+		encryptor util_encryption.FieldEncryptor,
+		fileName string,
+	) (time.Time, bool, error)
+}
+
+// getBackupAgeReferenceTime falls back to CreatedAt when the storage cannot
+// report the modification time of the backup file
+func getBackupAgeReferenceTime(
+	backup *backups_core.Backup,
+	storage fileModTimeGetter,
+	encryptor util_encryption.FieldEncryptor,
+	logger *slog.Logger,
+) time.Time {
+	if backup.FileName == "" {
+		return backup.CreatedAt
+	}
+
+	modTime, isSupported, err := storage.GetFileModTime(encryptor, backup.FileName)
+	if err != nil {
+		logger.Warn(
+			"Failed to get backup file modification time, using backup creation time",
+			"backupId", backup.ID,
+			"error", err,
+		)
+		return backup.CreatedAt
+	}
+
+	if !isSupported {
+		return backup.CreatedAt
+	}
+
+	return modTime
+}
+
+// findBackupNewerThanNewestKept returns the newest backup excluded from keepSet
+// that was created after every kept backup, or nil when there is none. When
+// nothing is kept, the newest backup is returned
+func findBackupNewerThanNewestKept(
+	backups []*backups_core.Backup,
+	keepSet map[uuid.UUID]bool,
+) *backups_core.Backup {
+	var newestKept *backups_core.Backup
+	var newestDeleted *backups_core.Backup
+
+	for _, backup := range backups {
+		if keepSet[backup.ID] {
+			if newestKept == nil || backup.CreatedAt.After(newestKept.CreatedAt) {
+				newestKept = backup
+			}
+
+			continue
+		}
+
+		if newestDeleted == nil || backup.CreatedAt.After(newestDeleted.CreatedAt) {
+			newestDeleted = backup
+		}
+	}
+
+	if newestDeleted == nil {
+		return nil
+	}
+
+	if newestKept == nil || newestDeleted.CreatedAt.After(newestKept.CreatedAt) {
+		return newestDeleted
+	}
+
+	return nil
+}
+
+// buildGFSKeepSet determines which backups to retain under the GFS rotation scheme.
+// Backups must be sorted newest-first. A backup can fill multiple slots simultaneously
+// (e.g. the newest backup of a year also fills the monthly, weekly, daily, and hourly slot).
+func buildGFSKeepSet(
+	backups []*backups_core.Backup,
+	hours, days, weeks, months, years int,
+) map[uuid.UUID]bool {
+	keep := make(map[uuid.UUID]bool)
+
+	hoursSeen := make(map[int64]bool)
+	daysSeen := make(map[string]bool)
+	weeksSeen := make(map[string]bool)
+	monthsSeen := make(map[string]bool)
+	yearsSeen := make(map[string]bool)
+
+	hoursKept, daysKept, weeksKept, monthsKept, yearsKept := 0, 0, 0, 0, 0
+
+	for _, backup := range backups {
+		hourKey, dayKey, weekKey, monthKey, yearKey := getGFSBucketKeys(backup.CreatedAt)
+
+		if hours > 0 && hoursKept < hours && !hoursSeen[hourKey] {
+			keep[backup.ID] = true
+			hoursSeen[hourKey] = true
+			hoursKept++
+		}
+
+		if days > 0 && daysKept < days && !daysSeen[dayKey] {
+			keep[backup.ID] = true
+			daysSeen[dayKey] = true
+			daysKept++
+		}
+
+		if weeks > 0 && weeksKept < weeks && !weeksSeen[weekKey] {
+			keep[backup.ID] = true
+			weeksSeen[weekKey] = true
+			weeksKept++
+		}
+
+		if months > 0 && monthsKept < months && !monthsSeen[monthKey] {
+			keep[backup.ID] = true
+			monthsSeen[monthKey] = true
+			monthsKept++
+		}
+
+		if years > 0 && yearsKept < years && !yearsSeen[yearKey] {
+			keep[backup.ID] = true
+			yearsSeen[yearKey] = true
+			yearsKept++
+		}
+	}
+
+	return keep
+}
+
+func buildMostConservativeKeepSet(
+	backups []*backups_core.Backup,
+	config *backups_config.BackupConfig,
+	now time.Time,
+) map[uuid.UUID]bool {
+	keep := buildCountAndGFSKeepSet(backups, config)
+
+	if config.RetentionTimePeriod == period.PeriodForever {
+		for _, backup := range backups {
+			keep[backup.ID] = true
+		}
+
+		return keep
+	}
+
+	if config.RetentionTimePeriod != "" {
+		cutoff := now.Add(-config.RetentionTimePeriod.ToDuration())
+
+		for _, backup := range backups {
+			if !backup.CreatedAt.Before(cutoff) {
+				keep[backup.ID] = true
+			}
+		}
+	}
+
+	return keep
+}
+
+func buildCountAndGFSKeepSet(
+	backups []*backups_core.Backup,
+	config *backups_config.BackupConfig,
+) map[uuid.UUID]bool {
+	keep := buildGFSKeepSet(
+		backups,
+		config.RetentionGfsHours,
+		config.RetentionGfsDays,
+		config.RetentionGfsWeeks,
+		config.RetentionGfsMonths,
+		config.RetentionGfsYears,
+	)
+
+	for _, backup := range backups[:min(max(config.RetentionCount, 0), len(backups))] {
+		keep[backup.ID] = true
+	}
+
+	return keep
+}
+
+func getGFSBucketKeys(t time.Time) (hourKey int64, dayKey, weekKey, monthKey, yearKey string) {
+	// a formatted local hour repeats on a DST fall back, the absolute hour
+	// index is unique for each real hour in any location
+	hourKey = t.Unix() / secondsInHour
+	dayKey = t.Format("2006-01-02")
+	weekYear, week := t.ISOWeek()
+	weekKey = fmt.Sprintf("%d-%02d", weekYear, week)
+	monthKey = t.Format("2006-01")
+	yearKey = t.Format("2006")
+
+	return hourKey, dayKey, weekKey, monthKey, yearKey
+}
+
+// buildThinningKeepSet keeps all backups younger than thinAfter. Among older ones it
+// keeps the newest and then every backup at least keepEvery intervals apart from the
+// previously kept one. Half an interval is tolerated for scheduling jitter. Backups
+// must be sorted newest-first
+func buildThinningKeepSet(
+	backups []*backups_core.Backup,
+	keepEvery int,
+	backupInterval time.Duration,
+	thinAfter time.Duration,
+	now time.Time,
+) map[uuid.UUID]bool {
+	keep := make(map[uuid.UUID]bool)
+
+	minGap := time.Duration(keepEvery)*backupInterval - backupInterval/2
+
+	var lastKeptAt *time.Time
+
+	for _, backup := range backups {
+		if now.Sub(backup.CreatedAt) < thinAfter {
+			keep[backup.ID] = true
+			continue
+		}
+
+		if lastKeptAt == nil || lastKeptAt.Sub(backup.CreatedAt) >= minGap {
+			keep[backup.ID] = true
+			createdAt := backup.CreatedAt
+			lastKeptAt = &createdAt
+		}
+	}
+
+	return keep
+}
+
+// buildScheduleKeepSet buckets backups by day like the daily GFS slot, but keeps
+// the backup closest to scheduleTime in each of the newest `days` days instead of
+// the newest one. The newest backup is kept as well, otherwise the latest restore
+// point of the current day would be deleted in favour of an older one. Backups
+// must be sorted newest-first
+func buildScheduleKeepSet(
+	backups []*backups_core.Backup,
+	scheduleTime time.Duration,
+	days int,
+) map[uuid.UUID]bool {
+	keep := make(map[uuid.UUID]bool)
+	if len(backups) == 0 {
+		return keep
+	}
+
+	keep[backups[0].ID] = true
+
+	closestByDay := make(map[string]*backups_core.Backup)
+	dayKeys := []string{}
+
+	for _, backup := range backups {
+		dayKey := backup.CreatedAt.UTC().Format("2006-01-02")
+
+		closest, isDaySeen := closestByDay[dayKey]
+		if !isDaySeen {
+			if len(dayKeys) == days {
+				break
+			}
+
+			dayKeys = append(dayKeys, dayKey)
+			closestByDay[dayKey] = backup
+			continue
+		}
+
+		if getScheduleDistance(backup, scheduleTime) < getScheduleDistance(closest, scheduleTime) {
+			closestByDay[dayKey] = backup
+		}
+	}
+
+	for _, backup := range closestByDay {
+		keep[backup.ID] = true
+	}
+
+	return keep
+}
+
+// buildRecentDaysKeepSet keeps the newest backup of each of the last `days` UTC
+// days, today included. It is merged into keep sets of all retention policies.
+// Backups must be sorted newest-first
+func buildRecentDaysKeepSet(
+	backups []*backups_core.Backup,
+	days int,
+	now time.Time,
+) map[uuid.UUID]bool {
+	keep := make(map[uuid.UUID]bool)
+	if days <= 0 {
+		return keep
+	}
+
+	nowUTC := now.UTC()
+	today := time.Date(nowUTC.Year(), nowUTC.Month(), nowUTC.Day(), 0, 0, 0, 0, time.UTC)
+	firstDay := today.AddDate(0, 0, -(days - 1))
+
+	isDayKept := make(map[string]bool)
+	for _, backup := range backups {
+		if backup.CreatedAt.Before(firstDay) {
+			break
+		}
+
+		dayKey := backup.CreatedAt.UTC().Format("2006-01-02")
+		if !isDayKept[dayKey] {
+			isDayKept[dayKey] = true
+			keep[backup.ID] = true
+		}
+	}
+
+	return keep
+}
+
+func getScheduleDistance(backup *backups_core.Backup, scheduleTime time.Duration) time.Duration {
+	createdAt := backup.CreatedAt.UTC()
+	dayStart := time.Date(
+		createdAt.Year(),
+		createdAt.Month(),
+		createdAt.Day(),
+		0, 0, 0, 0,
+		time.UTC,
+	)
+
+	distance := createdAt.Sub(dayStart.Add(scheduleTime))
+	if distance < 0 {
+		return -distance
+	}
+
+	return distance
+}
+
+func getTimeUntilNextDeletion(
+	backupConfig *backups_config.BackupConfig,
+	backups []*backups_core.Backup,
+	now time.Time,
+) (time.Duration, *backups_core.Backup, error) {
+	deleteAtByBackup := getRetentionDeleteAtByBackup(backupConfig, backups, now)
+
+	var nextBackup *backups_core.Backup
+	var nextDeleteAt time.Time
+
+	for backup, deleteAt := range deleteAtByBackup {
+		if nextBackup == nil || deleteAt.Before(nextDeleteAt) {
+			nextBackup = backup
+			nextDeleteAt = deleteAt
+		}
+	}
+
+	if nextBackup == nil {
+		return 0, nil, ErrNoDeletionScheduled
+	}
+
+	return max(nextDeleteAt.Sub(now), 0), nextBackup, nil
+}
+
+// getRetentionDeleteAtByBackup mirrors the retention clean* passes without deleting
+// anything and returns when each deletable backup is removed. Backups must be
+// sorted newest-first
+func getRetentionDeleteAtByBackup(
+	backupConfig *backups_config.BackupConfig,
+	backups []*backups_core.Backup,
+	now time.Time,
+) map[*backups_core.Backup]time.Time {
+	deleteAtByBackup := make(map[*backups_core.Backup]time.Time)
+
+	switch backupConfig.RetentionPolicyType {
+	case backups_config.RetentionPolicyTypeCount:
+		if backupConfig.RetentionCount > 0 && len(backups) > backupConfig.RetentionCount {
+			for _, backup := range backups[backupConfig.RetentionCount:] {
+				deleteAtByBackup[backup] = backup.CreatedAt.Add(recentBackupGracePeriod)
+			}
+		}
+
+	case backups_config.RetentionPolicyTypeGFS:
+		keepSet := buildGFSKeepSet(
+			backups,
+			backupConfig.RetentionGfsHours,
+			backupConfig.RetentionGfsDays,
+			backupConfig.RetentionGfsWeeks,
+			backupConfig.RetentionGfsMonths,
+			backupConfig.RetentionGfsYears,
+		)
+
+		for _, backup := range backups {
+			if !keepSet[backup.ID] {
+				deleteAtByBackup[backup] = backup.CreatedAt.Add(recentBackupGracePeriod)
+			}
+		}
+
+	case backups_config.RetentionPolicyTypeHotCold:
+		if isHotColdRetentionConfigured(backupConfig) &&
+			backupConfig.ColdRetention != period.PeriodForever {
+			for _, backup := range backups {
+				deleteAtByBackup[backup] = getRetentionDeleteAt(
+					backup,
+					backupConfig.ColdRetention.ToDuration(),
+				)
+			}
+		}
+
+	case backups_config.RetentionPolicyTypeThinning:
+		if backupConfig.BackupInterval != nil && backupConfig.ThinningAfter != "" {
+			runTimes := backupConfig.BackupInterval.NextNRunTimes(now, 2)
+			if len(runTimes) == 2 {
+				keepSet := buildThinningKeepSet(
+					backups,
+					backupConfig.ThinningKeepEvery,
+					runTimes[1].Sub(runTimes[0]),
+					backupConfig.ThinningAfter.ToDuration(),
+					now,
+				)
+
+				for _, backup := range backups {
+					if !keepSet[backup.ID] {
+						deleteAtByBackup[backup] = backup.CreatedAt.Add(recentBackupGracePeriod)
+					}
+				}
+			}
+		}
+
+	case backups_config.RetentionPolicyTypeSchedule:
+		if scheduleTime, err := backupConfig.GetRetentionScheduleTime(); err == nil {
+			keepSet := buildScheduleKeepSet(
+				backups,
+				scheduleTime,
+				backupConfig.RetentionScheduleDays,
+			)
+
+			for _, backup := range backups {
+				if !keepSet[backup.ID] {
+					deleteAtByBackup[backup] = backup.CreatedAt.Add(recentBackupGracePeriod)
+				}
+			}
+		}
+
+	case backups_config.RetentionPolicyTypeUnion:
+		// count and GFS keep sets do not expire on their own, only backups kept
+		// by the time period alone get a deletion time
+		if backupConfig.RetentionTimePeriod != "" &&
+			backupConfig.RetentionTimePeriod != period.PeriodForever {
+			countAndGFSKeepSet := buildCountAndGFSKeepSet(backups, backupConfig)
+
+			for _, backup := range backups {
+				if !countAndGFSKeepSet[backup.ID] {
+					deleteAtByBackup[backup] = getRetentionDeleteAt(
+						backup,
+						backupConfig.RetentionTimePeriod.ToDuration(),
+					)
+				}
+			}
+		}
+
+	default:
+		if backupConfig.RetentionTimePeriod != "" &&
+			backupConfig.RetentionTimePeriod != period.PeriodForever {
+			for _, backup := range backups {
+				deleteAtByBackup[backup] = getRetentionDeleteAt(
+					backup,
+					backupConfig.RetentionTimePeriod.ToDuration(),
+				)
+			}
+		}
+	}
+
+	protectedSet := buildRecentDaysKeepSet(backups, backupConfig.GuaranteeOnePerRecentDay, now)
+
+	for backup, deleteAt := range deleteAtByBackup {
+		if backupConfig.IsRetentionLabelExempt(backup.RetentionLabel) {
+			delete(deleteAtByBackup, backup)
+			continue
+		}
+
+		if protectedSet[backup.ID] {
+			protectionEndsAt := getRecentDayProtectionEnd(
+				backup,
+				backupConfig.GuaranteeOnePerRecentDay,
+			)
+			if protectionEndsAt.After(deleteAt) {
+				deleteAtByBackup[backup] = protectionEndsAt
+			}
+		}
+	}
+
+	return deleteAtByBackup
+}
+
+// getRecentDayProtectionEnd returns when the UTC day of the backup leaves the
+// window of the GuaranteeOnePerRecentDay guarantee
+func getRecentDayProtectionEnd(backup *backups_core.Backup, days int) time.Time {
+	createdAt := backup.CreatedAt.UTC()
+	createdDay := time.Date(
+		createdAt.Year(),
+		createdAt.Month(),
+		createdAt.Day(),
+		0, 0, 0, 0,
+		time.UTC,
+	)
+
+	return createdDay.AddDate(0, 0, days)
+}
+
+// getRetentionDeleteAt returns when a backup leaves the retention period, but not
+// earlier than the grace period protecting fresh backups
+func getRetentionDeleteAt(backup *backups_core.Backup, retention time.Duration) time.Time {
+	expiresAt := backup.CreatedAt.Add(retention)
+	graceEndsAt := backup.CreatedAt.Add(recentBackupGracePeriod)
+
+	if expiresAt.Before(graceEndsAt) {
+		return graceEndsAt
+	}
+
+	return expiresAt
 }
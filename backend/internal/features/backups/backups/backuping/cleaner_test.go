@@ -1,9 +1,19 @@
 package backuping
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"databasus-backend/internal/config"
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
@@ -14,10 +24,15 @@ import (
 	users_testing "databasus-backend/internal/features/users/testing"
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
 	"databasus-backend/internal/storage"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
 	"databasus-backend/internal/util/period"
+	"databasus-backend/internal/util/size"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func Test_CleanOldBackups_DeletesBackupsOlderThanRetentionTimePeriod(t *testing.T) {
@@ -89,7 +104,7 @@ func Test_CleanOldBackups_DeletesBackupsOlderThanRetentionTimePeriod(t *testing.
 	assert.NoError(t, err)
 
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
@@ -145,7 +160,7 @@ func Test_CleanOldBackups_SkipsDatabaseWithForeverRetentionPeriod(t *testing.T)
 	assert.NoError(t, err)
 
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
@@ -204,7 +219,7 @@ func Test_CleanExceededBackups_WhenUnderLimit_NoBackupsDeleted(t *testing.T) {
 	}
 
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanExceededBackups()
+	err = cleaner.cleanExceededBackups(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
@@ -265,7 +280,7 @@ func Test_CleanExceededBackups_WhenOverLimit_DeletesOldestBackups(t *testing.T)
 	}
 
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanExceededBackups()
+	err = cleaner.cleanExceededBackups(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
@@ -283,6 +298,284 @@ func Test_CleanExceededBackups_WhenOverLimit_DeletesOldestBackups(t *testing.T)
 	assert.True(t, remainingIDs[backupIDs[4]])
 }
 
+func Test_Run_WithShortTickerAndOverLimitBackups_BackupsCleanedOnTick(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer func() {
+		cancel()
+
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:            database.ID,
+		IsBackupsEnabled:      true,
+		RetentionPolicyType:   backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod:   period.PeriodForever,
+		StorageID:             &storage.ID,
+		MaxBackupsTotalSizeMB: 30,
+		BackupIntervalID:      interval.ID,
+		BackupInterval:        interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	for i := 0; i < 5; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(4-i) * time.Hour),
+		}
+		assert.NoError(t, backupRepository.Save(backup))
+	}
+
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		TickerInterval: 50 * time.Millisecond,
+	})
+
+	go cleaner.Run(ctx)
+
+	// a pass goes over every database in the test DB, so it may take longer
+	// than a single tick
+	assert.Eventually(t, func() bool {
+		remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+		return err == nil && len(remainingBackups) == 3
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func Test_CleanerHealth_AfterTick_LastTickAtAdvances(t *testing.T) {
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		TickerInterval: 50 * time.Millisecond,
+	})
+
+	initialHealth := cleaner.CleanerHealth()
+	assert.False(t, initialHealth.IsRunning)
+	assert.Nil(t, initialHealth.LastTickAt)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cleaner.Run(ctx)
+
+	var firstTickAt time.Time
+	assert.Eventually(t, func() bool {
+		health := cleaner.CleanerHealth()
+		if health.LastTickAt == nil {
+			return false
+		}
+
+		firstTickAt = *health.LastTickAt
+		return health.IsRunning
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		health := cleaner.CleanerHealth()
+		return health.LastTickAt != nil && health.LastTickAt.After(firstTickAt)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		return !cleaner.CleanerHealth().IsRunning
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func Test_Run_WhenStartedConcurrentlyTwice_ExactlyOneCallPanics(t *testing.T) {
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		TickerInterval: time.Hour,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var panicsCount atomic.Int32
+	var startGroup sync.WaitGroup
+	var doneGroup sync.WaitGroup
+	start := make(chan struct{})
+
+	for range 2 {
+		startGroup.Add(1)
+		doneGroup.Add(1)
+
+		go func() {
+			defer doneGroup.Done()
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					panicsCount.Add(1)
+				}
+			}()
+
+			startGroup.Done()
+			<-start
+			cleaner.Run(ctx)
+		}()
+	}
+
+	startGroup.Wait()
+	close(start)
+
+	assert.Eventually(t, func() bool {
+		return panicsCount.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	doneGroup.Wait()
+
+	assert.Equal(t, int32(1), panicsCount.Load())
+}
+
+func Test_CleanExceededBackups_WhenOverLimit_RecordsPostCleanupUsage(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	cleaner := GetBackupCleaner()
+	recordedUsage := make(map[uuid.UUID]float64)
+	cleaner.SetUsageRecorder(func(databaseID uuid.UUID, totalSizeMB float64) {
+		recordedUsage[databaseID] = totalSizeMB
+	})
+
+	defer func() {
+		cleaner.SetUsageRecorder(func(databaseID uuid.UUID, totalSizeMB float64) {})
+
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:            database.ID,
+		IsBackupsEnabled:      true,
+		RetentionPolicyType:   backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod:   period.PeriodForever,
+		StorageID:             &storage.ID,
+		MaxBackupsTotalSizeMB: 30,
+		BackupIntervalID:      interval.ID,
+		BackupInterval:        interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	for i := 0; i < 5; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(4-i) * time.Hour),
+		}
+		err = backupRepository.Save(backup)
+		assert.NoError(t, err)
+	}
+
+	err = cleaner.cleanExceededBackups(context.Background())
+	assert.NoError(t, err)
+
+	recordedSizeMB, isRecorded := recordedUsage[database.ID]
+	assert.True(t, isRecorded)
+	assert.Equal(t, float64(30), recordedSizeMB)
+
+	// nothing to delete on the second run, usage is still recorded
+	delete(recordedUsage, database.ID)
+
+	err = cleaner.cleanExceededBackups(context.Background())
+	assert.NoError(t, err)
+
+	recordedSizeMB, isRecorded = recordedUsage[database.ID]
+	assert.True(t, isRecorded)
+	assert.Equal(t, float64(30), recordedSizeMB)
+}
+
+func Test_CleanExceededBackups_WhenOverLimit_DeletionsRecordedWithSizeLimitReason(
+	t *testing.T,
+) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	for i := 0; i < 5; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(4-i) * time.Hour),
+		}
+		assert.NoError(t, backupRepository.Save(backup))
+	}
+
+	cleaner := GetBackupCleaner()
+	statsBefore := cleaner.GetStats()
+
+	err := cleaner.cleanExceededBackupsForDatabase(context.Background(), database.ID, 30)
+	assert.NoError(t, err)
+
+	statsAfter := cleaner.GetStats()
+	assert.Equal(
+		t,
+		int64(2),
+		statsAfter.DeletedByReason[backups_core.DeletionReasonSizeLimit]-
+			statsBefore.DeletedByReason[backups_core.DeletionReasonSizeLimit],
+	)
+	assert.Equal(
+		t,
+		statsBefore.DeletedByReason[backups_core.DeletionReasonTimePeriod],
+		statsAfter.DeletedByReason[backups_core.DeletionReasonTimePeriod],
+	)
+}
+
 func Test_CleanExceededBackups_SkipsInProgressBackups(t *testing.T) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -348,7 +641,7 @@ func Test_CleanExceededBackups_SkipsInProgressBackups(t *testing.T) {
 	assert.NoError(t, err)
 
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanExceededBackups()
+	err = cleaner.cleanExceededBackups(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
@@ -415,7 +708,7 @@ func Test_CleanExceededBackups_WithZeroLimit_SkipsDatabase(t *testing.T) {
 	}
 
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanExceededBackups()
+	err = cleaner.cleanExceededBackups(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
@@ -491,13 +784,13 @@ func Test_GetTotalSizeByDatabase_CalculatesCorrectly(t *testing.T) {
 	assert.InDelta(t, 36.0, totalSize, 0.1)
 }
 
-func Test_CleanByCount_KeepsNewestNBackups_DeletesOlder(t *testing.T) {
+func Test_GetTotalSizeBytesByDatabase_With10000TinyBackups_SumsExactly(t *testing.T) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
-	storage := storages.CreateTestStorage(workspace.ID)
+	testStorage := storages.CreateTestStorage(workspace.ID)
 	notifier := notifiers.CreateTestNotifier(workspace.ID)
-	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
 
 	defer func() {
 		backups, _ := backupRepository.FindByDatabaseID(database.ID)
@@ -508,68 +801,51 @@ func Test_CleanByCount_KeepsNewestNBackups_DeletesOlder(t *testing.T) {
 		databases.RemoveTestDatabase(database)
 		time.Sleep(50 * time.Millisecond)
 		notifiers.RemoveTestNotifier(notifier)
-		storages.RemoveTestStorage(storage.ID)
+		storages.RemoveTestStorage(testStorage.ID)
 		workspaces_testing.RemoveTestWorkspace(workspace, router)
 	}()
 
-	interval := createTestInterval()
-
-	backupConfig := &backups_config.BackupConfig{
-		DatabaseID:          database.ID,
-		IsBackupsEnabled:    true,
-		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
-		RetentionCount:      3,
-		StorageID:           &storage.ID,
-		BackupIntervalID:    interval.ID,
-		BackupInterval:      interval,
-	}
-	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
-	assert.NoError(t, err)
+	const backupsCount = 10000
+	const backupSizeBytes = 7
 
 	now := time.Now().UTC()
-	var backupIDs []uuid.UUID
-	for i := 0; i < 5; i++ {
-		backup := &backups_core.Backup{
-			ID:           uuid.New(),
-			DatabaseID:   database.ID,
-			StorageID:    storage.ID,
-			Status:       backups_core.BackupStatusCompleted,
-			BackupSizeMb: 10,
-			CreatedAt: now.Add(
-				-time.Duration(4-i) * time.Hour,
-			), // oldest first in loop, newest = i=4
-		}
-		err = backupRepository.Save(backup)
-		assert.NoError(t, err)
-		backupIDs = append(backupIDs, backup.ID)
+	tinyBackups := make([]*backups_core.Backup, 0, backupsCount)
+	for i := 0; i < backupsCount; i++ {
+		tinyBackups = append(tinyBackups, &backups_core.Backup{
+			ID:              uuid.New(),
+			FileName:        uuid.New().String(),
+			DatabaseID:      database.ID,
+			StorageID:       testStorage.ID,
+			Status:          backups_core.BackupStatusCompleted,
+			BackupSizeBytes: backupSizeBytes,
+			CreatedAt:       now.Add(-time.Duration(i) * time.Second),
+		})
 	}
 
-	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	err := storage.GetDb().CreateInBatches(tinyBackups, 1000).Error
 	assert.NoError(t, err)
 
-	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	totalSizeBytes, err := backupRepository.GetTotalSizeBytesByDatabase(
+		context.Background(),
+		database.ID,
+	)
 	assert.NoError(t, err)
-	assert.Equal(t, 3, len(remainingBackups))
+	assert.Equal(t, int64(backupsCount*backupSizeBytes), totalSizeBytes)
 
-	remainingIDs := make(map[uuid.UUID]bool)
-	for _, backup := range remainingBackups {
-		remainingIDs[backup.ID] = true
-	}
-	assert.False(t, remainingIDs[backupIDs[0]], "Oldest backup should be deleted")
-	assert.False(t, remainingIDs[backupIDs[1]], "2nd oldest backup should be deleted")
-	assert.True(t, remainingIDs[backupIDs[2]], "3rd backup should remain")
-	assert.True(t, remainingIDs[backupIDs[3]], "4th backup should remain")
-	assert.True(t, remainingIDs[backupIDs[4]], "Newest backup should remain")
+	totalSizeMB, err := backupRepository.GetTotalSizeByDatabase(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, size.BytesToMB(backupsCount*backupSizeBytes), totalSizeMB)
 }
 
-func Test_CleanByCount_WhenUnderLimit_NoBackupsDeleted(t *testing.T) {
+func Test_CleanByRetentionPolicy_WithEmptyPolicyAndPeriod_DeletesNothingAndLogsWarning(
+	t *testing.T,
+) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
-	storage := storages.CreateTestStorage(workspace.ID)
+	testStorage := storages.CreateTestStorage(workspace.ID)
 	notifier := notifiers.CreateTestNotifier(workspace.ID)
-	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
 
 	defer func() {
 		backups, _ := backupRepository.FindByDatabaseID(database.ID)
@@ -580,7 +856,7 @@ func Test_CleanByCount_WhenUnderLimit_NoBackupsDeleted(t *testing.T) {
 		databases.RemoveTestDatabase(database)
 		time.Sleep(50 * time.Millisecond)
 		notifiers.RemoveTestNotifier(notifier)
-		storages.RemoveTestStorage(storage.ID)
+		storages.RemoveTestStorage(testStorage.ID)
 		workspaces_testing.RemoveTestWorkspace(workspace, router)
 	}()
 
@@ -589,38 +865,52 @@ func Test_CleanByCount_WhenUnderLimit_NoBackupsDeleted(t *testing.T) {
 	backupConfig := &backups_config.BackupConfig{
 		DatabaseID:          database.ID,
 		IsBackupsEnabled:    true,
-		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
-		RetentionCount:      10,
-		StorageID:           &storage.ID,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod: period.PeriodWeek,
+		StorageID:           &testStorage.ID,
 		BackupIntervalID:    interval.ID,
 		BackupInterval:      interval,
 	}
 	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
 	assert.NoError(t, err)
 
-	for i := 0; i < 5; i++ {
-		backup := &backups_core.Backup{
-			ID:           uuid.New(),
-			DatabaseID:   database.ID,
-			StorageID:    storage.ID,
-			Status:       backups_core.BackupStatusCompleted,
-			BackupSizeMb: 10,
-			CreatedAt:    time.Now().UTC().Add(-time.Duration(i) * time.Hour),
-		}
-		err = backupRepository.Save(backup)
-		assert.NoError(t, err)
-	}
+	// such rows cannot be saved through the service, they come from old data
+	err = storage.GetDb().
+		Model(&backups_config.BackupConfig{}).
+		Where("database_id = ?", database.ID).
+		Updates(map[string]any{"retention_policy_type": "", "retention_time_period": ""}).
+		Error
+	assert.NoError(t, err)
 
-	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	oldBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    testStorage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    time.Now().UTC().Add(-365 * 24 * time.Hour),
+	}
+	err = backupRepository.Save(oldBackup)
+	assert.NoError(t, err)
+
+	var logs bytes.Buffer
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		Logger: slog.New(slog.NewTextHandler(&logs, nil)),
+	})
+
+	err = cleaner.cleanByRetentionPolicy(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
 	assert.NoError(t, err)
-	assert.Equal(t, 5, len(remainingBackups))
+	assert.Len(t, remainingBackups, 1)
+
+	assert.Contains(t, logs.String(), "backups are kept forever")
+	assert.Contains(t, logs.String(), database.ID.String())
+	assert.Equal(t, int64(1), cleaner.GetStats().MisconfiguredConfigs)
 }
 
-func Test_CleanByCount_DoesNotDeleteInProgressBackups(t *testing.T) {
+func Test_CleanByCount_KeepsNewestNBackups_DeletesOlder(t *testing.T) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
@@ -647,7 +937,7 @@ func Test_CleanByCount_DoesNotDeleteInProgressBackups(t *testing.T) {
 		DatabaseID:          database.ID,
 		IsBackupsEnabled:    true,
 		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
-		RetentionCount:      2,
+		RetentionCount:      3,
 		StorageID:           &storage.ID,
 		BackupIntervalID:    interval.ID,
 		BackupInterval:      interval,
@@ -656,48 +946,43 @@ func Test_CleanByCount_DoesNotDeleteInProgressBackups(t *testing.T) {
 	assert.NoError(t, err)
 
 	now := time.Now().UTC()
-
-	for i := 0; i < 3; i++ {
+	var backupIDs []uuid.UUID
+	for i := 0; i < 5; i++ {
 		backup := &backups_core.Backup{
 			ID:           uuid.New(),
 			DatabaseID:   database.ID,
 			StorageID:    storage.ID,
 			Status:       backups_core.BackupStatusCompleted,
 			BackupSizeMb: 10,
-			CreatedAt:    now.Add(-time.Duration(3-i) * time.Hour),
+			CreatedAt: now.Add(
+				-time.Duration(4-i) * time.Hour,
+			), // oldest first in loop, newest = i=4
 		}
 		err = backupRepository.Save(backup)
 		assert.NoError(t, err)
+		backupIDs = append(backupIDs, backup.ID)
 	}
 
-	inProgressBackup := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusInProgress,
-		BackupSizeMb: 5,
-		CreatedAt:    now,
-	}
-	err = backupRepository.Save(inProgressBackup)
-	assert.NoError(t, err)
-
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
 	assert.NoError(t, err)
+	assert.Equal(t, 3, len(remainingBackups))
 
-	var inProgressFound bool
+	remainingIDs := make(map[uuid.UUID]bool)
 	for _, backup := range remainingBackups {
-		if backup.ID == inProgressBackup.ID {
-			inProgressFound = true
-		}
+		remainingIDs[backup.ID] = true
 	}
-	assert.True(t, inProgressFound, "In-progress backup should not be deleted by count policy")
+	assert.False(t, remainingIDs[backupIDs[0]], "Oldest backup should be deleted")
+	assert.False(t, remainingIDs[backupIDs[1]], "2nd oldest backup should be deleted")
+	assert.True(t, remainingIDs[backupIDs[2]], "3rd backup should remain")
+	assert.True(t, remainingIDs[backupIDs[3]], "4th backup should remain")
+	assert.True(t, remainingIDs[backupIDs[4]], "Newest backup should remain")
 }
 
-func Test_CleanByGFS_KeepsCorrectBackupsPerSlot(t *testing.T) {
+func Test_CleanByRetentionPolicy_WithCanceledContext_NoBackupsDeleted(t *testing.T) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
@@ -723,11 +1008,8 @@ func Test_CleanByGFS_KeepsCorrectBackupsPerSlot(t *testing.T) {
 	backupConfig := &backups_config.BackupConfig{
 		DatabaseID:          database.ID,
 		IsBackupsEnabled:    true,
-		RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
-		RetentionGfsDays:    3,
-		RetentionGfsWeeks:   0,
-		RetentionGfsMonths:  0,
-		RetentionGfsYears:   0,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      1,
 		StorageID:           &storage.ID,
 		BackupIntervalID:    interval.ID,
 		BackupInterval:      interval,
@@ -736,43 +1018,108 @@ func Test_CleanByGFS_KeepsCorrectBackupsPerSlot(t *testing.T) {
 	assert.NoError(t, err)
 
 	now := time.Now().UTC()
-
-	// Create 5 backups on 5 different days; only the 3 newest days should be kept
-	var backupIDs []uuid.UUID
-	for i := 0; i < 5; i++ {
+	for i := 0; i < 3; i++ {
 		backup := &backups_core.Backup{
 			ID:           uuid.New(),
 			DatabaseID:   database.ID,
 			StorageID:    storage.ID,
 			Status:       backups_core.BackupStatusCompleted,
 			BackupSizeMb: 10,
-			CreatedAt:    now.Add(-time.Duration(4-i) * 24 * time.Hour).Truncate(24 * time.Hour),
+			CreatedAt:    now.Add(-time.Duration(i+1) * 24 * time.Hour),
 		}
 		err = backupRepository.Save(backup)
 		assert.NoError(t, err)
-		backupIDs = append(backupIDs, backup.ID)
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	err = cleaner.cleanByRetentionPolicy(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = cleaner.cleanExceededBackups(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 3)
+}
+
+func Test_CleanByCount_WithExemptRetentionLabel_KeepsLabeledBackup(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:            database.ID,
+		IsBackupsEnabled:      true,
+		RetentionPolicyType:   backups_config.RetentionPolicyTypeCount,
+		RetentionCount:        1,
+		RetentionExemptLabels: []string{"release"},
+		StorageID:             &storage.ID,
+		BackupIntervalID:      interval.ID,
+		BackupInterval:        interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	saveBackup := func(retentionLabel string, createdAt time.Time) uuid.UUID {
+		backup := &backups_core.Backup{
+			ID:             uuid.New(),
+			DatabaseID:     database.ID,
+			StorageID:      storage.ID,
+			RetentionLabel: retentionLabel,
+			Status:         backups_core.BackupStatusCompleted,
+			BackupSizeMb:   10,
+			CreatedAt:      createdAt,
+		}
+		assert.NoError(t, backupRepository.Save(backup))
+
+		return backup.ID
+	}
+
+	releaseBackupID := saveBackup("release", now.Add(-3*time.Hour))
+	dailyBackupID := saveBackup("daily", now.Add(-2*time.Hour))
+	newestBackupID := saveBackup("", now.Add(-time.Hour))
+
+	err = GetBackupCleaner().cleanByRetentionPolicy(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
 	assert.NoError(t, err)
-	assert.Equal(t, 3, len(remainingBackups))
 
 	remainingIDs := make(map[uuid.UUID]bool)
 	for _, backup := range remainingBackups {
 		remainingIDs[backup.ID] = true
 	}
-	assert.False(t, remainingIDs[backupIDs[0]], "Oldest daily backup should be deleted")
-	assert.False(t, remainingIDs[backupIDs[1]], "2nd oldest daily backup should be deleted")
-	assert.True(t, remainingIDs[backupIDs[2]], "3rd backup should remain")
-	assert.True(t, remainingIDs[backupIDs[3]], "4th backup should remain")
-	assert.True(t, remainingIDs[backupIDs[4]], "Newest backup should remain")
+	assert.True(t, remainingIDs[releaseBackupID], "release backup is exempt from retention")
+	assert.False(t, remainingIDs[dailyBackupID], "daily backup is not exempt")
+	assert.True(t, remainingIDs[newestBackupID], "newest backup is kept by count")
 }
 
-func Test_CleanByGFS_WithWeeklyAndMonthlySlots_KeepsWiderSpread(t *testing.T) {
+func Test_CleanByRetentionPolicy_WithOneShotOverride_AppliesOnceThenStoredConfig(
+	t *testing.T,
+) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
@@ -798,11 +1145,8 @@ func Test_CleanByGFS_WithWeeklyAndMonthlySlots_KeepsWiderSpread(t *testing.T) {
 	backupConfig := &backups_config.BackupConfig{
 		DatabaseID:          database.ID,
 		IsBackupsEnabled:    true,
-		RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
-		RetentionGfsDays:    2,
-		RetentionGfsWeeks:   2,
-		RetentionGfsMonths:  1,
-		RetentionGfsYears:   0,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      2,
 		StorageID:           &storage.ID,
 		BackupIntervalID:    interval.ID,
 		BackupInterval:      interval,
@@ -811,57 +1155,54 @@ func Test_CleanByGFS_WithWeeklyAndMonthlySlots_KeepsWiderSpread(t *testing.T) {
 	assert.NoError(t, err)
 
 	now := time.Now().UTC()
-
-	// Create one backup per week for 6 weeks (each on Monday of that week)
-	// GFS should keep: 2 daily (most recent 2 unique days) + 2 weekly + 1 monthly = up to 5 unique
-	var createdIDs []uuid.UUID
-	for i := 0; i < 6; i++ {
-		weekOffset := time.Duration(5-i) * 7 * 24 * time.Hour
-		backup := &backups_core.Backup{
+	for i := 0; i < 5; i++ {
+		err = backupRepository.Save(&backups_core.Backup{
 			ID:           uuid.New(),
 			DatabaseID:   database.ID,
 			StorageID:    storage.ID,
 			Status:       backups_core.BackupStatusCompleted,
 			BackupSizeMb: 10,
-			CreatedAt:    now.Add(-weekOffset).Truncate(24 * time.Hour),
-		}
-		err = backupRepository.Save(backup)
+			CreatedAt:    now.Add(-time.Duration(i+1) * time.Hour),
+		})
 		assert.NoError(t, err)
-		createdIDs = append(createdIDs, backup.ID)
 	}
 
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	overrideCallsCount := 0
+	cleaner.SetOneShotRetentionOverride(
+		database.ID,
+		func(config *backups_config.BackupConfig) *backups_config.BackupConfig {
+			overrideCallsCount++
+			config.RetentionCount = 10
+			return config
+		},
+	)
+
+	err = cleaner.cleanByRetentionPolicy(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
 	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 5)
 
-	// We should have at most 5 backups kept (2 daily + 2 weekly + 1 monthly, but with overlap possible)
-	// The exact count depends on how many unique periods are covered
-	assert.LessOrEqual(t, len(remainingBackups), 5)
-	assert.GreaterOrEqual(t, len(remainingBackups), 1)
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
 
-	// The two most recent backups should always be retained (daily slots)
-	remainingIDs := make(map[uuid.UUID]bool)
-	for _, backup := range remainingBackups {
-		remainingIDs[backup.ID] = true
-	}
-	assert.True(t, remainingIDs[createdIDs[4]], "Second newest backup should be retained (daily)")
-	assert.True(t, remainingIDs[createdIDs[5]], "Newest backup should be retained (daily)")
+	remainingBackups, err = backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 2)
+	assert.Equal(t, 1, overrideCallsCount)
 }
 
-// Test_DeleteBackup_WhenStorageDeleteFails_BackupStillRemovedFromDatabase verifies resilience
-// when storage becomes unavailable. Even if storage.DeleteFile fails (e.g., storage is offline,
-// credentials changed, or storage was deleted), the backup record should still be removed from
-// the database. This prevents orphaned backup records when storage is no longer accessible.
-func Test_DeleteBackup_WhenStorageDeleteFails_BackupStillRemovedFromDatabase(t *testing.T) {
+func Test_RunTick_WithOneShotOverrideSkippingDatabase_SizeLimitNotAppliedInThatTick(
+	t *testing.T,
+) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
-	testStorage := storages.CreateTestStorage(workspace.ID)
+	storage := storages.CreateTestStorage(workspace.ID)
 	notifier := notifiers.CreateTestNotifier(workspace.ID)
-	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
 
 	defer func() {
 		backups, _ := backupRepository.FindByDatabaseID(database.ID)
@@ -872,38 +1213,68 @@ func Test_DeleteBackup_WhenStorageDeleteFails_BackupStillRemovedFromDatabase(t *
 		databases.RemoveTestDatabase(database)
 		time.Sleep(50 * time.Millisecond)
 		notifiers.RemoveTestNotifier(notifier)
-		storages.RemoveTestStorage(testStorage.ID)
+		storages.RemoveTestStorage(storage.ID)
 		workspaces_testing.RemoveTestWorkspace(workspace, router)
 	}()
 
-	backup := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    testStorage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    time.Now().UTC(),
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:            database.ID,
+		IsBackupsEnabled:      true,
+		RetentionPolicyType:   backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod:   period.PeriodForever,
+		StorageID:             &storage.ID,
+		MaxBackupsTotalSizeMB: 30,
+		BackupIntervalID:      interval.ID,
+		BackupInterval:        interval,
 	}
-	err := backupRepository.Save(backup)
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
 	assert.NoError(t, err)
 
-	cleaner := GetBackupCleaner()
+	now := time.Now().UTC()
+	for i := 0; i < 5; i++ {
+		err = backupRepository.Save(&backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(4-i) * time.Hour),
+		})
+		assert.NoError(t, err)
+	}
 
-	err = cleaner.DeleteBackup(backup)
-	assert.NoError(t, err, "DeleteBackup should succeed even when storage file doesn't exist")
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{})
+	cleaner.SetOneShotRetentionOverride(
+		database.ID,
+		func(config *backups_config.BackupConfig) *backups_config.BackupConfig {
+			return nil
+		},
+	)
 
-	deletedBackup, err := backupRepository.FindByID(backup.ID)
-	assert.Error(t, err, "Backup should not exist in database")
-	assert.Nil(t, deletedBackup)
+	cleaner.runTick(context.Background())
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 5)
+
+	cleaner.runTick(context.Background())
+
+	remainingBackups, err = backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 3)
 }
 
-func Test_CleanByGFS_WithHourlySlots_KeepsCorrectBackups(t *testing.T) {
+func Test_ReportRetentionCleanup_InReportOnlyMode_NoBackupsDeletedAndWouldDeleteCounted(
+	t *testing.T,
+) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
-	testStorage := storages.CreateTestStorage(workspace.ID)
+	storage := storages.CreateTestStorage(workspace.ID)
 	notifier := notifiers.CreateTestNotifier(workspace.ID)
-	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
 
 	defer func() {
 		backups, _ := backupRepository.FindByDatabaseID(database.ID)
@@ -914,7 +1285,7 @@ func Test_CleanByGFS_WithHourlySlots_KeepsCorrectBackups(t *testing.T) {
 		databases.RemoveTestDatabase(database)
 		time.Sleep(50 * time.Millisecond)
 		notifiers.RemoveTestNotifier(notifier)
-		storages.RemoveTestStorage(testStorage.ID)
+		storages.RemoveTestStorage(storage.ID)
 		workspaces_testing.RemoveTestWorkspace(workspace, router)
 	}()
 
@@ -923,9 +1294,9 @@ func Test_CleanByGFS_WithHourlySlots_KeepsCorrectBackups(t *testing.T) {
 	backupConfig := &backups_config.BackupConfig{
 		DatabaseID:          database.ID,
 		IsBackupsEnabled:    true,
-		RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
-		RetentionGfsHours:   3,
-		StorageID:           &testStorage.ID,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      3,
+		StorageID:           &storage.ID,
 		BackupIntervalID:    interval.ID,
 		BackupInterval:      interval,
 	}
@@ -933,257 +1304,2282 @@ func Test_CleanByGFS_WithHourlySlots_KeepsCorrectBackups(t *testing.T) {
 	assert.NoError(t, err)
 
 	now := time.Now().UTC()
-
-	// Create 5 backups spaced 1 hour apart; only the 3 newest hours should be kept
-	var backupIDs []uuid.UUID
 	for i := 0; i < 5; i++ {
 		backup := &backups_core.Backup{
 			ID:           uuid.New(),
 			DatabaseID:   database.ID,
-			StorageID:    testStorage.ID,
+			StorageID:    storage.ID,
 			Status:       backups_core.BackupStatusCompleted,
 			BackupSizeMb: 10,
-			CreatedAt:    now.Add(-time.Duration(4-i) * time.Hour).Truncate(time.Hour),
+			CreatedAt:    now.Add(-time.Duration(4-i) * time.Hour),
 		}
-		err = backupRepository.Save(backup)
-		assert.NoError(t, err)
-		backupIDs = append(backupIDs, backup.ID)
+		assert.NoError(t, backupRepository.Save(backup))
 	}
 
-	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	var logs bytes.Buffer
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		Logger:       slog.New(slog.NewTextHandler(&logs, nil)),
+		IsReportOnly: true,
+	})
+
+	err = cleaner.reportRetentionCleanup(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
 	assert.NoError(t, err)
-	assert.Equal(t, 3, len(remainingBackups))
+	assert.Len(t, remainingBackups, 5)
 
-	remainingIDs := make(map[uuid.UUID]bool)
-	for _, backup := range remainingBackups {
-		remainingIDs[backup.ID] = true
-	}
-	assert.False(t, remainingIDs[backupIDs[0]], "Oldest hourly backup should be deleted")
-	assert.False(t, remainingIDs[backupIDs[1]], "2nd oldest hourly backup should be deleted")
-	assert.True(t, remainingIDs[backupIDs[2]], "3rd backup should remain")
-	assert.True(t, remainingIDs[backupIDs[3]], "4th backup should remain")
-	assert.True(t, remainingIDs[backupIDs[4]], "Newest backup should remain")
+	// other databases left in the test DB may add to the counter
+	assert.GreaterOrEqual(t, cleaner.GetStats().WouldDelete, int64(2))
+	assert.Contains(t, logs.String(), database.ID.String())
 }
 
-func Test_BuildGFSKeepSet(t *testing.T) {
-	// Fixed reference time: a Wednesday mid-month to avoid boundary edge cases in the default tests.
-	// Use time.Date for determinism across test runs.
-	ref := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC) // Wednesday, 2025-06-18
+func Test_ReportRetentionCleanup_WhenStorageUnavailable_DatabaseNotReported(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
 
-	day := 24 * time.Hour
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		storages.GetStorageService().SetAvailability(storage.ID, true)
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      3,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	for i := 0; i < 5; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(4-i) * time.Hour),
+		}
+		assert.NoError(t, backupRepository.Save(backup))
+	}
+
+	err = storages.GetStorageService().SetAvailability(storage.ID, false)
+	assert.NoError(t, err)
+
+	var logs bytes.Buffer
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		Logger:       slog.New(slog.NewTextHandler(&logs, nil)),
+		IsReportOnly: true,
+	})
+
+	err = cleaner.reportRetentionCleanup(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 5)
+	assert.NotContains(t, logs.String(), database.ID.String())
+}
+
+func Test_CleanByCount_WithGuaranteeOnePerRecentDay_KeepsNewestBackupOfEachRecentDay(
+	t *testing.T,
+) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:               database.ID,
+		IsBackupsEnabled:         true,
+		RetentionPolicyType:      backups_config.RetentionPolicyTypeCount,
+		RetentionCount:           1,
+		GuaranteeOnePerRecentDay: 3,
+		StorageID:                &storage.ID,
+		BackupIntervalID:         interval.ID,
+		BackupInterval:           interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	// two backups on each of yesterday, 2 days ago and 4 days ago
+	newestBackupByDay := make(map[int]uuid.UUID)
+	olderBackupByDay := make(map[int]uuid.UUID)
+	for _, day := range []int{1, 2, 4} {
+		for _, hour := range []int{10, 20} {
+			backup := &backups_core.Backup{
+				ID:           uuid.New(),
+				DatabaseID:   database.ID,
+				StorageID:    storage.ID,
+				Status:       backups_core.BackupStatusCompleted,
+				BackupSizeMb: 10,
+				CreatedAt:    today.AddDate(0, 0, -day).Add(time.Duration(hour) * time.Hour),
+			}
+			err = backupRepository.Save(backup)
+			assert.NoError(t, err)
+
+			if hour == 20 {
+				newestBackupByDay[day] = backup.ID
+			} else {
+				olderBackupByDay[day] = backup.ID
+			}
+		}
+	}
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(remainingBackups))
+
+	remainingIDs := make(map[uuid.UUID]bool)
+	for _, backup := range remainingBackups {
+		remainingIDs[backup.ID] = true
+	}
+	assert.True(t, remainingIDs[newestBackupByDay[1]], "Newest backup should remain")
+	assert.True(t, remainingIDs[newestBackupByDay[2]], "Protected day backup should remain")
+	assert.False(t, remainingIDs[olderBackupByDay[1]])
+	assert.False(t, remainingIDs[olderBackupByDay[2]])
+	assert.False(t, remainingIDs[newestBackupByDay[4]], "Day outside the window")
+	assert.False(t, remainingIDs[olderBackupByDay[4]])
+}
+
+func Test_CleanByCount_WhenUnderLimit_NoBackupsDeleted(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      10,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    time.Now().UTC().Add(-time.Duration(i) * time.Hour),
+		}
+		err = backupRepository.Save(backup)
+		assert.NoError(t, err)
+	}
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(remainingBackups))
+}
+
+func Test_CleanByCount_DoesNotDeleteInProgressBackups(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      2,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	for i := 0; i < 3; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(3-i) * time.Hour),
+		}
+		err = backupRepository.Save(backup)
+		assert.NoError(t, err)
+	}
+
+	inProgressBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusInProgress,
+		BackupSizeMb: 5,
+		CreatedAt:    now,
+	}
+	err = backupRepository.Save(inProgressBackup)
+	assert.NoError(t, err)
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+
+	var inProgressFound bool
+	for _, backup := range remainingBackups {
+		if backup.ID == inProgressBackup.ID {
+			inProgressFound = true
+		}
+	}
+	assert.True(t, inProgressFound, "In-progress backup should not be deleted by count policy")
+}
+
+func Test_CleanByGFS_KeepsCorrectBackupsPerSlot(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
+		RetentionGfsDays:    3,
+		RetentionGfsWeeks:   0,
+		RetentionGfsMonths:  0,
+		RetentionGfsYears:   0,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	// Create 5 backups on 5 different days; only the 3 newest days should be kept
+	var backupIDs []uuid.UUID
+	for i := 0; i < 5; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(4-i) * 24 * time.Hour).Truncate(24 * time.Hour),
+		}
+		err = backupRepository.Save(backup)
+		assert.NoError(t, err)
+		backupIDs = append(backupIDs, backup.ID)
+	}
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(remainingBackups))
+
+	remainingIDs := make(map[uuid.UUID]bool)
+	for _, backup := range remainingBackups {
+		remainingIDs[backup.ID] = true
+	}
+	assert.False(t, remainingIDs[backupIDs[0]], "Oldest daily backup should be deleted")
+	assert.False(t, remainingIDs[backupIDs[1]], "2nd oldest daily backup should be deleted")
+	assert.True(t, remainingIDs[backupIDs[2]], "3rd backup should remain")
+	assert.True(t, remainingIDs[backupIDs[3]], "4th backup should remain")
+	assert.True(t, remainingIDs[backupIDs[4]], "Newest backup should remain")
+}
+
+func Test_CleanByGFS_WithConsolidation_ExpendableTailDeletedInSingleBatch(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	backupStorage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, backupStorage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(backupStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:           database.ID,
+		IsBackupsEnabled:     true,
+		RetentionPolicyType:  backups_config.RetentionPolicyTypeGFS,
+		RetentionGfsDays:     3,
+		ConsolidateAfterDays: 7,
+		StorageID:            &backupStorage.ID,
+		BackupIntervalID:     interval.ID,
+		BackupInterval:       interval,
+	}
+	backupConfig, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	// the 3 newest days fill the daily slots, the old tail fills nothing
+	var keptIDs []uuid.UUID
+	for i := range 3 {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    backupStorage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(i) * 24 * time.Hour).Truncate(24 * time.Hour),
+		}
+		err = backupRepository.Save(backup)
+		assert.NoError(t, err)
+		keptIDs = append(keptIDs, backup.ID)
+	}
+
+	for i := range 10 {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    backupStorage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 1,
+			CreatedAt:    now.Add(-time.Duration(10+i) * 24 * time.Hour),
+		}
+		err = backupRepository.Save(backup)
+		assert.NoError(t, err)
+	}
+
+	var backupDeleteStatementsCount atomic.Int32
+	callbackName := "test:count_backup_delete_statements"
+	err = storage.GetDb().Callback().Delete().After("gorm:delete").Register(
+		callbackName,
+		func(db *gorm.DB) {
+			if db.Statement.Table == "backups" {
+				backupDeleteStatementsCount.Add(1)
+			}
+		},
+	)
+	assert.NoError(t, err)
+	defer func() {
+		_ = storage.GetDb().Callback().Delete().Remove(callbackName)
+	}()
+
+	cleaner := GetBackupCleaner()
+	reason := backups_core.DeletionReasonConsolidation
+	consolidatedBefore := cleaner.GetStats().DeletedByReason[reason]
+
+	err = cleaner.cleanByGFS(context.Background(), backupConfig)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), backupDeleteStatementsCount.Load())
+	assert.Equal(t, int64(10), cleaner.GetStats().DeletedByReason[reason]-consolidatedBefore)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 3)
+	for _, backup := range remainingBackups {
+		assert.Contains(t, keptIDs, backup.ID)
+	}
+}
+
+func Test_CleanByGFS_WithWeeklyAndMonthlySlots_KeepsWiderSpread(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
+		RetentionGfsDays:    2,
+		RetentionGfsWeeks:   2,
+		RetentionGfsMonths:  1,
+		RetentionGfsYears:   0,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	// Create one backup per week for 6 weeks (each on Monday of that week)
+	// GFS should keep: 2 daily (most recent 2 unique days) + 2 weekly + 1 monthly = up to 5 unique
+	var createdIDs []uuid.UUID
+	for i := 0; i < 6; i++ {
+		weekOffset := time.Duration(5-i) * 7 * 24 * time.Hour
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-weekOffset).Truncate(24 * time.Hour),
+		}
+		err = backupRepository.Save(backup)
+		assert.NoError(t, err)
+		createdIDs = append(createdIDs, backup.ID)
+	}
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+
+	// We should have at most 5 backups kept (2 daily + 2 weekly + 1 monthly, but with overlap possible)
+	// The exact count depends on how many unique periods are covered
+	assert.LessOrEqual(t, len(remainingBackups), 5)
+	assert.GreaterOrEqual(t, len(remainingBackups), 1)
+
+	// The two most recent backups should always be retained (daily slots)
+	remainingIDs := make(map[uuid.UUID]bool)
+	for _, backup := range remainingBackups {
+		remainingIDs[backup.ID] = true
+	}
+	assert.True(t, remainingIDs[createdIDs[4]], "Second newest backup should be retained (daily)")
+	assert.True(t, remainingIDs[createdIDs[5]], "Newest backup should be retained (daily)")
+}
+
+// Test_DeleteBackup_WhenStorageDeleteFails_BackupStillRemovedFromDatabase verifies resilience
+// when storage becomes unavailable. Even if storage.DeleteFile fails (e.g., storage is offline,
+// credentials changed, or storage was deleted), the backup record should still be removed from
+// the database. This prevents orphaned backup records when storage is no longer accessible.
+func Test_DeleteBackup_WhenStorageDeleteFails_BackupStillRemovedFromDatabase(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	testStorage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(testStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    testStorage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    time.Now().UTC(),
+	}
+	err := backupRepository.Save(backup)
+	assert.NoError(t, err)
+
+	cleaner := GetBackupCleaner()
+
+	err = cleaner.DeleteBackup(context.Background(), backup, backups_core.DeletionReasonManual)
+	assert.NoError(t, err, "DeleteBackup should succeed even when storage file doesn't exist")
+
+	deletedBackup, err := backupRepository.FindByID(backup.ID)
+	assert.Error(t, err, "Backup should not exist in database")
+	assert.Nil(t, deletedBackup)
+}
+
+func Test_DeleteBackup_WithPreloadedStorage_NoStorageLookupNeeded(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	testStorage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(testStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	for i, status := range []backups_core.BackupStatus{
+		backups_core.BackupStatusCompleted,
+		backups_core.BackupStatusCompleted,
+		backups_core.BackupStatusFailed,
+	} {
+		backupID := uuid.New()
+		err := backupRepository.Save(&backups_core.Backup{
+			ID:           backupID,
+			FileName:     backupID.String(),
+			DatabaseID:   database.ID,
+			StorageID:    testStorage.ID,
+			Status:       status,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(i+1) * time.Hour),
+		})
+		assert.NoError(t, err)
+	}
+
+	completedBackups, err := backupRepository.FindCompletedByDatabaseWithStorage(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, completedBackups, 2)
+
+	for _, backup := range completedBackups {
+		assert.NotNil(t, backup.Storage)
+		assert.Equal(t, testStorage.ID, backup.Storage.ID)
+		assert.NotNil(t, backup.Storage.LocalStorage)
+	}
+
+	// without a storage service any storage lookup would panic
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{})
+	cleaner.storageService = nil
+
+	for _, backup := range completedBackups {
+		err := cleaner.DeleteBackup(context.Background(), backup, backups_core.DeletionReasonManual)
+		assert.NoError(t, err)
+	}
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 1)
+	assert.Equal(t, backups_core.BackupStatusFailed, remainingBackups[0].Status)
+}
+
+func Test_CleanByGFS_WithHourlySlots_KeepsCorrectBackups(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	testStorage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, testStorage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(testStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
+		RetentionGfsHours:   3,
+		StorageID:           &testStorage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	// Create 5 backups spaced 1 hour apart; only the 3 newest hours should be kept
+	var backupIDs []uuid.UUID
+	for i := 0; i < 5; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    testStorage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(4-i) * time.Hour).Truncate(time.Hour),
+		}
+		err = backupRepository.Save(backup)
+		assert.NoError(t, err)
+		backupIDs = append(backupIDs, backup.ID)
+	}
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(remainingBackups))
+
+	remainingIDs := make(map[uuid.UUID]bool)
+	for _, backup := range remainingBackups {
+		remainingIDs[backup.ID] = true
+	}
+	assert.False(t, remainingIDs[backupIDs[0]], "Oldest hourly backup should be deleted")
+	assert.False(t, remainingIDs[backupIDs[1]], "2nd oldest hourly backup should be deleted")
+	assert.True(t, remainingIDs[backupIDs[2]], "3rd backup should remain")
+	assert.True(t, remainingIDs[backupIDs[3]], "4th backup should remain")
+	assert.True(t, remainingIDs[backupIDs[4]], "Newest backup should remain")
+}
+
+func Test_BuildGFSKeepSet(t *testing.T) {
+	// Fixed reference time: a Wednesday mid-month to avoid boundary edge cases in the default tests.
+	// Use time.Date for determinism across test runs.
+	ref := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC) // Wednesday, 2025-06-18
+
+	day := 24 * time.Hour
 	week := 7 * day
 
-	newBackup := func(createdAt time.Time) *backups_core.Backup {
-		return &backups_core.Backup{ID: uuid.New(), CreatedAt: createdAt}
+	newBackup := func(createdAt time.Time) *backups_core.Backup {
+		return &backups_core.Backup{ID: uuid.New(), CreatedAt: createdAt}
+	}
+
+	// backupsEveryDay returns n backups, newest-first, each 1 day apart.
+	backupsEveryDay := func(n int) []*backups_core.Backup {
+		bs := make([]*backups_core.Backup, n)
+		for i := 0; i < n; i++ {
+			bs[i] = newBackup(ref.Add(-time.Duration(i) * day))
+		}
+		return bs
+	}
+
+	// backupsEveryWeek returns n backups, newest-first, each 7 days apart.
+	backupsEveryWeek := func(n int) []*backups_core.Backup {
+		bs := make([]*backups_core.Backup, n)
+		for i := 0; i < n; i++ {
+			bs[i] = newBackup(ref.Add(-time.Duration(i) * week))
+		}
+		return bs
+	}
+
+	hour := time.Hour
+
+	// backupsEveryHour returns n backups, newest-first, each 1 hour apart.
+	backupsEveryHour := func(n int) []*backups_core.Backup {
+		bs := make([]*backups_core.Backup, n)
+		for i := 0; i < n; i++ {
+			bs[i] = newBackup(ref.Add(-time.Duration(i) * hour))
+		}
+		return bs
+	}
+
+	tests := []struct {
+		name         string
+		backups      []*backups_core.Backup
+		hours        int
+		days         int
+		weeks        int
+		months       int
+		years        int
+		keptIndices  []int   // which indices in backups should be kept
+		deletedRange *[2]int // optional: all indices in [from, to) must be deleted
+	}{
+		{
+			name:        "OnlyHourlySlots_KeepsNewest3Of5",
+			backups:     backupsEveryHour(5),
+			hours:       3,
+			keptIndices: []int{0, 1, 2},
+		},
+		{
+			name: "SameHourDedup_OnlyNewestKeptForHourlySlot",
+			backups: []*backups_core.Backup{
+				newBackup(ref.Truncate(hour).Add(45 * time.Minute)),
+				newBackup(ref.Truncate(hour).Add(10 * time.Minute)),
+			},
+			hours:       1,
+			keptIndices: []int{0},
+		},
+		{
+			name:        "OnlyDailySlots_KeepsNewest3Of5",
+			backups:     backupsEveryDay(5),
+			days:        3,
+			keptIndices: []int{0, 1, 2},
+		},
+		{
+			name:        "OnlyDailySlots_FewerBackupsThanSlots_KeepsAll",
+			backups:     backupsEveryDay(2),
+			days:        5,
+			keptIndices: []int{0, 1},
+		},
+		{
+			name:        "OnlyWeeklySlots_KeepsNewest2Weeks",
+			backups:     backupsEveryWeek(4),
+			weeks:       2,
+			keptIndices: []int{0, 1},
+		},
+		{
+			name: "OnlyMonthlySlots_KeepsNewest2Months",
+			backups: []*backups_core.Backup{
+				newBackup(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)),
+				newBackup(time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)),
+				newBackup(time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)),
+			},
+			months:      2,
+			keptIndices: []int{0, 1},
+		},
+		{
+			name: "OnlyYearlySlots_KeepsNewest2Years",
+			backups: []*backups_core.Backup{
+				newBackup(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)),
+				newBackup(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)),
+				newBackup(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)),
+			},
+			years:       2,
+			keptIndices: []int{0, 1},
+		},
+		{
+			name: "SameDayDedup_OnlyNewestKeptForDailySlot",
+			backups: []*backups_core.Backup{
+				// Two backups on the same day; newest-first order
+				newBackup(ref.Truncate(day).Add(10 * time.Hour)),
+				newBackup(ref.Truncate(day).Add(2 * time.Hour)),
+			},
+			days:        1,
+			keptIndices: []int{0},
+		},
+		{
+			name: "SameWeekDedup_OnlyNewestKeptForWeeklySlot",
+			backups: []*backups_core.Backup{
+				// ref is Wednesday; add Thursday of same week
+				newBackup(ref.Add(1 * day)), // Thursday same week
+				newBackup(ref),              // Wednesday same week
+			},
+			weeks:       1,
+			keptIndices: []int{0},
+		},
+		{
+			name: "AdditiveSlots_NewestFillsDailyAndWeeklyAndMonthly",
+			// Newest backup fills daily + weekly + monthly simultaneously
+			backups: []*backups_core.Backup{
+				newBackup(time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)), // newest
+				newBackup(time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)), // 1 week ago
+				newBackup(time.Date(2025, 5, 18, 12, 0, 0, 0, time.UTC)), // 1 month ago
+				newBackup(time.Date(2025, 4, 18, 12, 0, 0, 0, time.UTC)), // 2 months ago
+			},
+			days:        1,
+			weeks:       2,
+			months:      2,
+			keptIndices: []int{0, 1, 2},
+		},
+		{
+			name: "YearBoundary_CorrectlySplitsAcrossYears",
+			backups: []*backups_core.Backup{
+				newBackup(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)),
+				newBackup(time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)),
+				newBackup(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)),
+				newBackup(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)),
+			},
+			years:       2,
+			keptIndices: []int{0, 1}, // 2025 and 2024 kept; 2024-06 and 2023 deleted
+		},
+		{
+			name: "ISOWeekBoundary_Jan1UsesCorrectISOWeek",
+			// 2025-01-01 is ISO week 1 of 2025; 2024-12-28 is ISO week 52 of 2024
+			backups: []*backups_core.Backup{
+				newBackup(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)),   // ISO week 2025-W01
+				newBackup(time.Date(2024, 12, 28, 12, 0, 0, 0, time.UTC)), // ISO week 2024-W52
+			},
+			weeks:       2,
+			keptIndices: []int{0, 1}, // different ISO weeks → both kept
+		},
+		{
+			name:        "EmptyBackups_ReturnsEmptyKeepSet",
+			backups:     []*backups_core.Backup{},
+			hours:       3,
+			days:        3,
+			weeks:       2,
+			months:      1,
+			years:       1,
+			keptIndices: []int{},
+		},
+		{
+			name:        "AllZeroSlots_KeepsNothing",
+			backups:     backupsEveryDay(5),
+			hours:       0,
+			days:        0,
+			weeks:       0,
+			months:      0,
+			years:       0,
+			keptIndices: []int{},
+		},
+		{
+			name:    "AllSlotsActive_FullCombination",
+			backups: backupsEveryWeek(12),
+			days:    2,
+			weeks:   3,
+			months:  2,
+			years:   1,
+			// 2 daily (indices 0,1) + 3rd weekly slot (index 2) + 2nd monthly slot (index 3 or later).
+			// Additive slots: newest fills daily+weekly+monthly+yearly; each subsequent week fills another weekly,
+			// and a backup ~4 weeks later fills the 2nd monthly slot.
+			keptIndices: []int{0, 1, 2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			keepSet := buildGFSKeepSet(tc.backups, tc.hours, tc.days, tc.weeks, tc.months, tc.years)
+
+			keptIndexSet := make(map[int]bool, len(tc.keptIndices))
+			for _, idx := range tc.keptIndices {
+				keptIndexSet[idx] = true
+			}
+
+			for i, backup := range tc.backups {
+				if keptIndexSet[i] {
+					assert.True(t, keepSet[backup.ID], "backup at index %d should be kept", i)
+				} else {
+					assert.False(t, keepSet[backup.ID], "backup at index %d should be deleted", i)
+				}
+			}
+		})
+	}
+}
+
+func Test_CleanByTimePeriod_SkipsRecentBackup_EvenIfOlderThanRetention(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	// Retention period is 1 day — any backup older than 1 day should be deleted.
+	// But the recent backup was created only 30 minutes ago and must be preserved.
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod: period.PeriodDay,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	oldBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-2 * 24 * time.Hour),
+	}
+	recentBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-30 * time.Minute),
+	}
+
+	err = backupRepository.Save(oldBackup)
+	assert.NoError(t, err)
+	err = backupRepository.Save(recentBackup)
+	assert.NoError(t, err)
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(remainingBackups))
+	assert.Equal(t, recentBackup.ID, remainingBackups[0].ID)
+}
+
+func Test_DeleteBackup_WhenBackupIsRecent_ManualDeletionIgnoresGracePeriod(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod: period.PeriodDay,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	recentBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    time.Now().UTC().Add(-5 * time.Minute),
+	}
+	err = backupRepository.Save(recentBackup)
+	assert.NoError(t, err)
+	assert.True(t, isRecentBackup(recentBackup))
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(remainingBackups))
+
+	err = cleaner.DeleteBackup(
+		context.Background(),
+		recentBackup,
+		backups_core.DeletionReasonManual,
+	)
+	assert.NoError(t, err)
+
+	remainingBackups, err = backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(remainingBackups))
+}
+
+func Test_CleanByCount_SkipsRecentBackup_EvenIfOverLimit(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	// Retention count is 2 — 4 backups exist so 2 should be deleted.
+	// The oldest backup in the "excess" tail was made 30 min ago — it must be preserved.
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      2,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	oldBackup1 := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-5 * time.Hour),
+	}
+	oldBackup2 := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-3 * time.Hour),
+	}
+	// This backup is 3rd newest and would normally be deleted — but it is recent.
+	recentExcessBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-30 * time.Minute),
+	}
+	newestBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-10 * time.Minute),
+	}
+
+	for _, b := range []*backups_core.Backup{oldBackup1, oldBackup2, recentExcessBackup, newestBackup} {
+		err = backupRepository.Save(b)
+		assert.NoError(t, err)
+	}
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+
+	remainingIDs := make(map[uuid.UUID]bool)
+	for _, backup := range remainingBackups {
+		remainingIDs[backup.ID] = true
+	}
+
+	assert.False(t, remainingIDs[oldBackup1.ID], "Oldest non-recent backup should be deleted")
+	assert.False(t, remainingIDs[oldBackup2.ID], "2nd oldest non-recent backup should be deleted")
+	assert.True(
+		t,
+		remainingIDs[recentExcessBackup.ID],
+		"Recent backup must be preserved despite being over limit",
+	)
+	assert.True(t, remainingIDs[newestBackup.ID], "Newest backup should be preserved")
+}
+
+func Test_AnalyzeGFSStability_WithHourlyBackupsOverThreeWeeks_NoBackupOscillates(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(21 * 24 * time.Hour)
+
+	var backups []*backups_core.Backup
+	var referenceTimes []time.Time
+	for createdAt := start; createdAt.Before(end); createdAt = createdAt.Add(time.Hour) {
+		backups = append(backups, &backups_core.Backup{ID: uuid.New(), CreatedAt: createdAt})
+
+		// cleaner ticks shortly after each backup
+		referenceTimes = append(referenceTimes, createdAt.Add(5*time.Minute))
+	}
+
+	unstableBackups := AnalyzeGFSStability(
+		backups,
+		GFSSlots{Hours: 24, Days: 7, Weeks: 4, Months: 3, Years: 1},
+		referenceTimes,
+	)
+
+	assert.Empty(t, unstableBackups)
+}
+
+func Test_GFSKeepSetDelta_WhenTodayBackupArrives_OnlyExpectedBackupsChange(t *testing.T) {
+	today := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	// one backup per day for the 4 days before today, newest first
+	var previousDaysBackups []*backups_core.Backup
+	for day := 1; day <= 4; day++ {
+		previousDaysBackups = append(previousDaysBackups, &backups_core.Backup{
+			ID:        uuid.New(),
+			CreatedAt: today.AddDate(0, 0, -day),
+		})
+	}
+
+	earlierTodayBackup := &backups_core.Backup{
+		ID:        uuid.New(),
+		CreatedAt: today.Add(-6 * time.Hour),
+	}
+	withEarlierTodayBackups := append(
+		[]*backups_core.Backup{earlierTodayBackup},
+		previousDaysBackups...,
+	)
+
+	tests := []struct {
+		name            string
+		before          []*backups_core.Backup
+		slots           GFSSlots
+		expectedRemoved []uuid.UUID
+	}{
+		{
+			name:            "free daily slot keeps every previous backup",
+			before:          previousDaysBackups,
+			slots:           GFSSlots{Days: 7},
+			expectedRemoved: nil,
+		},
+		{
+			name:            "full daily slots drop the oldest day",
+			before:          previousDaysBackups,
+			slots:           GFSSlots{Days: 4},
+			expectedRemoved: []uuid.UUID{previousDaysBackups[3].ID},
+		},
+		{
+			name:            "newer backup of the same day takes its daily slot",
+			before:          withEarlierTodayBackups,
+			slots:           GFSSlots{Days: 7},
+			expectedRemoved: []uuid.UUID{earlierTodayBackup.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todayBackup := &backups_core.Backup{ID: uuid.New(), CreatedAt: today}
+			after := append([]*backups_core.Backup{todayBackup}, tt.before...)
+
+			added, removed := GFSKeepSetDelta(tt.before, after, tt.slots)
+
+			assert.Equal(t, []uuid.UUID{todayBackup.ID}, added)
+			assert.Equal(t, tt.expectedRemoved, removed)
+		})
+	}
+}
+
+func Test_CalculateGFSSlotFills_WithSparseBackups_ReportsUnfilledSlots(t *testing.T) {
+	// Monday and Wednesday of the same ISO week, two backups on Wednesday
+	backups := []*backups_core.Backup{
+		{ID: uuid.New(), CreatedAt: time.Date(2026, 3, 4, 18, 0, 0, 0, time.UTC)},
+		{ID: uuid.New(), CreatedAt: time.Date(2026, 3, 4, 6, 0, 0, 0, time.UTC)},
+		{ID: uuid.New(), CreatedAt: time.Date(2026, 3, 2, 6, 0, 0, 0, time.UTC)},
+	}
+
+	fills := calculateGFSSlotFills(
+		backups,
+		GFSSlots{Hours: 2, Days: 7, Weeks: 4, Months: 12},
+	)
+
+	assert.Equal(t, map[string]SlotFill{
+		"hourly":  {Filled: 2, Total: 2},
+		"daily":   {Filled: 2, Total: 7},
+		"weekly":  {Filled: 1, Total: 4},
+		"monthly": {Filled: 1, Total: 12},
+	}, fills)
+}
+
+func Test_CleanByGFS_SkipsRecentBackup_WhenNotInKeepSet(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	// Keep only 1 daily slot. We create 2 old backups plus two recent backups on today.
+	// Backups are ordered newest-first, so the 15-min-old backup fills the single daily slot.
+	// The 30-min-old backup is the same day → not in the GFS keep-set, but it is still recent
+	// (within grace period) and must be preserved.
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
+		RetentionGfsDays:    1,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	oldBackup1 := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-3 * 24 * time.Hour).Truncate(24 * time.Hour),
+	}
+	oldBackup2 := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-2 * 24 * time.Hour).Truncate(24 * time.Hour),
+	}
+	// Newest backup today — will fill the single GFS daily slot.
+	newestTodayBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-15 * time.Minute),
+	}
+	// Slightly older backup, also today — NOT in GFS keep-set (duplicate day),
+	// but within the 60-minute grace period so it must survive.
+	recentNotInKeepSet := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-30 * time.Minute),
+	}
+
+	for _, b := range []*backups_core.Backup{oldBackup1, oldBackup2, newestTodayBackup, recentNotInKeepSet} {
+		err = backupRepository.Save(b)
+		assert.NoError(t, err)
+	}
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+
+	remainingIDs := make(map[uuid.UUID]bool)
+	for _, backup := range remainingBackups {
+		remainingIDs[backup.ID] = true
+	}
+
+	assert.False(t, remainingIDs[oldBackup1.ID], "Old backup 1 should be deleted by GFS")
+	assert.False(t, remainingIDs[oldBackup2.ID], "Old backup 2 should be deleted by GFS")
+	assert.True(
+		t,
+		remainingIDs[newestTodayBackup.ID],
+		"Newest backup fills GFS daily slot and must remain",
+	)
+	assert.True(
+		t,
+		remainingIDs[recentNotInKeepSet.ID],
+		"Recent backup not in keep-set must be preserved by grace period",
+	)
+}
+
+func Test_CleanExceededBackups_SkipsRecentBackup_WhenOverTotalSizeLimit(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	// Total size limit is 10 MB. We have two backups of 8 MB each (16 MB total).
+	// The oldest backup was created 30 minutes ago — within the grace period.
+	// The cleaner must stop and leave both backups intact.
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:            database.ID,
+		IsBackupsEnabled:      true,
+		RetentionPolicyType:   backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod:   period.PeriodForever,
+		StorageID:             &storage.ID,
+		MaxBackupsTotalSizeMB: 10,
+		BackupIntervalID:      interval.ID,
+		BackupInterval:        interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	olderRecentBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 8,
+		CreatedAt:    now.Add(-30 * time.Minute),
+	}
+	newerRecentBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 8,
+		CreatedAt:    now.Add(-10 * time.Minute),
+	}
+
+	err = backupRepository.Save(olderRecentBackup)
+	assert.NoError(t, err)
+	err = backupRepository.Save(newerRecentBackup)
+	assert.NoError(t, err)
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanExceededBackups(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		2,
+		len(remainingBackups),
+		"Both recent backups must be preserved even though total size exceeds limit",
+	)
+}
+
+func Test_CleanExceededBackups_WhenRecentBackupsAloneExceedLimit_OlderBackupsKept(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:            database.ID,
+		IsBackupsEnabled:      true,
+		RetentionPolicyType:   backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod:   period.PeriodForever,
+		StorageID:             &storage.ID,
+		MaxBackupsTotalSizeMB: 10,
+		BackupIntervalID:      interval.ID,
+		BackupInterval:        interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+
+	// deleting the old backup would not bring 12 MB of recent backups under 10 MB
+	oldBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 5,
+		CreatedAt:    now.Add(-48 * time.Hour),
+	}
+	recentBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 12,
+		CreatedAt:    now.Add(-10 * time.Minute),
+	}
+
+	err = backupRepository.Save(oldBackup)
+	assert.NoError(t, err)
+	err = backupRepository.Save(recentBackup)
+	assert.NoError(t, err)
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanExceededBackups(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 2)
+}
+func Test_CleanByHotColdRetention_WithFixedClock_MovesHotBackupsAndDeletesColdBackups(
+	t *testing.T,
+) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	hotStorage := storages.CreateTestStorage(workspace.ID)
+	coldStorage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, hotStorage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(hotStorage.ID)
+		storages.RemoveTestStorage(coldStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeHotCold,
+		HotRetention:        period.PeriodWeek,
+		ColdRetention:       period.PeriodMonth,
+		StorageID:           &hotStorage.ID,
+		ColdStorageID:       &coldStorage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	fixedNow := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	hotBackup := createHotColdTestBackup(t, database.ID, hotStorage, fixedNow.AddDate(0, 0, -3))
+	expiredHotBackup := createHotColdTestBackup(
+		t,
+		database.ID,
+		hotStorage,
+		fixedNow.AddDate(0, 0, -10),
+	)
+	expiredColdBackup := createHotColdTestBackup(
+		t,
+		database.ID,
+		hotStorage,
+		fixedNow.AddDate(0, 0, -40),
+	)
+
+	cleaner := GetBackupCleaner()
+
+	// first pass: hot retention expired for one backup, cold retention for another
+	err = cleaner.cleanByHotColdRetention(context.Background(), backupConfig, fixedNow)
+	assert.NoError(t, err)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(remainingBackups))
+
+	storageByBackupID := make(map[uuid.UUID]uuid.UUID)
+	for _, backup := range remainingBackups {
+		storageByBackupID[backup.ID] = backup.StorageID
 	}
 
-	// backupsEveryDay returns n backups, newest-first, each 1 day apart.
-	backupsEveryDay := func(n int) []*backups_core.Backup {
-		bs := make([]*backups_core.Backup, n)
-		for i := 0; i < n; i++ {
-			bs[i] = newBackup(ref.Add(-time.Duration(i) * day))
+	assert.Equal(t, hotStorage.ID, storageByBackupID[hotBackup.ID])
+	assert.Equal(t, coldStorage.ID, storageByBackupID[expiredHotBackup.ID])
+	assert.NotContains(t, storageByBackupID, expiredColdBackup.ID)
+
+	// second pass a month later: cold retention expired for the moved backup too
+	err = cleaner.cleanByHotColdRetention(
+		context.Background(),
+		backupConfig,
+		fixedNow.AddDate(0, 0, 25),
+	)
+	assert.NoError(t, err)
+
+	remainingBackups, err = backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(remainingBackups))
+	assert.Equal(t, hotBackup.ID, remainingBackups[0].ID)
+	assert.Equal(t, coldStorage.ID, remainingBackups[0].StorageID)
+}
+
+func Test_BuildThinningKeepSet_WithEveryTwoAndHourlyBackups_KeepsEverySecondOldBackup(
+	t *testing.T,
+) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+	thinAfter := 24 * time.Hour
+
+	// 4 recent backups followed by 12 backups older than thinAfter, newest first
+	var backups []*backups_core.Backup
+	for i := 0; i < 16; i++ {
+		backups = append(backups, &backups_core.Backup{
+			ID:        uuid.New(),
+			CreatedAt: now.Add(-20*time.Hour - time.Duration(i)*time.Hour),
+		})
+	}
+
+	keepSet := buildThinningKeepSet(backups, 2, time.Hour, thinAfter, now)
+
+	for i := 0; i < 4; i++ {
+		assert.True(t, keepSet[backups[i].ID], "recent backup at index %d should be kept", i)
+	}
+
+	for i := 4; i < 16; i++ {
+		isEverySecond := (i-4)%2 == 0
+		assert.Equal(
+			t,
+			isEverySecond,
+			keepSet[backups[i].ID],
+			"old backup at index %d has unexpected keep state",
+			i,
+		)
+	}
+
+	var keptBackups []*backups_core.Backup
+	for _, backup := range backups {
+		if keepSet[backup.ID] {
+			keptBackups = append(keptBackups, backup)
 		}
-		return bs
 	}
 
-	// backupsEveryWeek returns n backups, newest-first, each 7 days apart.
-	backupsEveryWeek := func(n int) []*backups_core.Backup {
-		bs := make([]*backups_core.Backup, n)
-		for i := 0; i < n; i++ {
-			bs[i] = newBackup(ref.Add(-time.Duration(i) * week))
+	secondPassKeepSet := buildThinningKeepSet(keptBackups, 2, time.Hour, thinAfter, now)
+	for _, backup := range keptBackups {
+		assert.True(t, secondPassKeepSet[backup.ID], "second pass must not thin kept backups")
+	}
+}
+
+func Test_BuildScheduleKeepSet_WithSeveralBackupsPerDay_KeepsClosestToScheduleTime(
+	t *testing.T,
+) {
+	newestDay := time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC)
+	backupTimesOfDay := []time.Duration{
+		12 * time.Hour,
+		3*time.Hour + 20*time.Minute,
+		2*time.Hour + 55*time.Minute,
+		1 * time.Hour,
+	}
+
+	// 4 days of backups, newest first
+	var backups []*backups_core.Backup
+	closestBackupByDay := make(map[int]*backups_core.Backup)
+	for day := 0; day < 4; day++ {
+		for _, timeOfDay := range backupTimesOfDay {
+			backup := &backups_core.Backup{
+				ID:        uuid.New(),
+				CreatedAt: newestDay.AddDate(0, 0, -day).Add(timeOfDay),
+			}
+			backups = append(backups, backup)
+
+			if timeOfDay == 2*time.Hour+55*time.Minute {
+				closestBackupByDay[day] = backup
+			}
 		}
-		return bs
 	}
 
-	hour := time.Hour
+	keepSet := buildScheduleKeepSet(backups, 3*time.Hour, 3)
 
-	// backupsEveryHour returns n backups, newest-first, each 1 hour apart.
-	backupsEveryHour := func(n int) []*backups_core.Backup {
-		bs := make([]*backups_core.Backup, n)
-		for i := 0; i < n; i++ {
-			bs[i] = newBackup(ref.Add(-time.Duration(i) * hour))
+	assert.True(t, keepSet[backups[0].ID], "newest backup must be kept")
+	for day := 0; day < 3; day++ {
+		assert.True(t, keepSet[closestBackupByDay[day].ID], "day %d closest backup", day)
+	}
+	assert.False(t, keepSet[closestBackupByDay[3].ID], "day outside the window")
+	assert.Len(t, keepSet, 4)
+}
+
+func Test_BuildRecentDaysKeepSet_WithSeveralBackupsPerDay_KeepsNewestOfEachDay(t *testing.T) {
+	now := time.Date(2025, 6, 18, 15, 0, 0, 0, time.UTC)
+	newestDay := time.Date(2025, 6, 18, 0, 0, 0, 0, time.UTC)
+
+	// 4 days of backups, newest first
+	var backups []*backups_core.Backup
+	newestBackupByDay := make(map[int]*backups_core.Backup)
+	for day := 0; day < 4; day++ {
+		for _, hour := range []int{12, 6, 1} {
+			backup := &backups_core.Backup{
+				ID:        uuid.New(),
+				CreatedAt: newestDay.AddDate(0, 0, -day).Add(time.Duration(hour) * time.Hour),
+			}
+			backups = append(backups, backup)
+
+			if hour == 12 {
+				newestBackupByDay[day] = backup
+			}
 		}
-		return bs
 	}
 
-	tests := []struct {
-		name         string
-		backups      []*backups_core.Backup
-		hours        int
-		days         int
-		weeks        int
-		months       int
-		years        int
-		keptIndices  []int   // which indices in backups should be kept
-		deletedRange *[2]int // optional: all indices in [from, to) must be deleted
-	}{
-		{
-			name:        "OnlyHourlySlots_KeepsNewest3Of5",
-			backups:     backupsEveryHour(5),
-			hours:       3,
-			keptIndices: []int{0, 1, 2},
-		},
-		{
-			name: "SameHourDedup_OnlyNewestKeptForHourlySlot",
-			backups: []*backups_core.Backup{
-				newBackup(ref.Truncate(hour).Add(45 * time.Minute)),
-				newBackup(ref.Truncate(hour).Add(10 * time.Minute)),
-			},
-			hours:       1,
-			keptIndices: []int{0},
-		},
-		{
-			name:        "OnlyDailySlots_KeepsNewest3Of5",
-			backups:     backupsEveryDay(5),
-			days:        3,
-			keptIndices: []int{0, 1, 2},
-		},
-		{
-			name:        "OnlyDailySlots_FewerBackupsThanSlots_KeepsAll",
-			backups:     backupsEveryDay(2),
-			days:        5,
-			keptIndices: []int{0, 1},
-		},
-		{
-			name:        "OnlyWeeklySlots_KeepsNewest2Weeks",
-			backups:     backupsEveryWeek(4),
-			weeks:       2,
-			keptIndices: []int{0, 1},
-		},
-		{
-			name: "OnlyMonthlySlots_KeepsNewest2Months",
-			backups: []*backups_core.Backup{
-				newBackup(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)),
-				newBackup(time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)),
-				newBackup(time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)),
-			},
-			months:      2,
-			keptIndices: []int{0, 1},
-		},
-		{
-			name: "OnlyYearlySlots_KeepsNewest2Years",
-			backups: []*backups_core.Backup{
-				newBackup(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)),
-				newBackup(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)),
-				newBackup(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)),
-			},
-			years:       2,
-			keptIndices: []int{0, 1},
-		},
-		{
-			name: "SameDayDedup_OnlyNewestKeptForDailySlot",
-			backups: []*backups_core.Backup{
-				// Two backups on the same day; newest-first order
-				newBackup(ref.Truncate(day).Add(10 * time.Hour)),
-				newBackup(ref.Truncate(day).Add(2 * time.Hour)),
-			},
-			days:        1,
-			keptIndices: []int{0},
-		},
-		{
-			name: "SameWeekDedup_OnlyNewestKeptForWeeklySlot",
-			backups: []*backups_core.Backup{
-				// ref is Wednesday; add Thursday of same week
-				newBackup(ref.Add(1 * day)), // Thursday same week
-				newBackup(ref),              // Wednesday same week
-			},
-			weeks:       1,
-			keptIndices: []int{0},
-		},
-		{
-			name: "AdditiveSlots_NewestFillsDailyAndWeeklyAndMonthly",
-			// Newest backup fills daily + weekly + monthly simultaneously
-			backups: []*backups_core.Backup{
-				newBackup(time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)), // newest
-				newBackup(time.Date(2025, 6, 11, 12, 0, 0, 0, time.UTC)), // 1 week ago
-				newBackup(time.Date(2025, 5, 18, 12, 0, 0, 0, time.UTC)), // 1 month ago
-				newBackup(time.Date(2025, 4, 18, 12, 0, 0, 0, time.UTC)), // 2 months ago
-			},
-			days:        1,
-			weeks:       2,
-			months:      2,
-			keptIndices: []int{0, 1, 2},
+	keepSet := buildRecentDaysKeepSet(backups, 3, now)
+
+	for day := 0; day < 3; day++ {
+		assert.True(t, keepSet[newestBackupByDay[day].ID], "day %d newest backup", day)
+	}
+	assert.False(t, keepSet[newestBackupByDay[3].ID], "day outside the window")
+	assert.Len(t, keepSet, 3)
+	assert.Empty(t, buildRecentDaysKeepSet(backups, 0, now))
+}
+
+func Test_BuildMostConservativeKeepSet_WithBackupKeptByCountOnly_KeepsBackup(t *testing.T) {
+	now := time.Now().UTC()
+
+	// 4 backups older than the time period, newest first
+	var backups []*backups_core.Backup
+	for i := 0; i < 4; i++ {
+		backups = append(backups, &backups_core.Backup{
+			ID:        uuid.New(),
+			CreatedAt: now.Add(-30*time.Hour - time.Duration(i)*time.Hour),
+		})
+	}
+
+	config := &backups_config.BackupConfig{
+		RetentionPolicyType: backups_config.RetentionPolicyTypeUnion,
+		RetentionTimePeriod: period.PeriodDay,
+		RetentionCount:      3,
+		RetentionGfsDays:    1,
+	}
+
+	gfsKeepSet := buildGFSKeepSet(backups, 0, 1, 0, 0, 0)
+	assert.False(t, gfsKeepSet[backups[1].ID], "GFS alone should not keep the second backup")
+
+	keepSet := BuildMostConservativeKeepSet(backups, config)
+
+	assert.True(t, keepSet[backups[0].ID], "newest backup is kept by count and GFS")
+	assert.True(t, keepSet[backups[1].ID], "backup kept by count only must survive")
+	assert.True(t, keepSet[backups[2].ID], "backup kept by count only must survive")
+	assert.False(t, keepSet[backups[3].ID], "backup kept by no policy must be deleted")
+}
+
+func Test_BuildMostConservativeKeepSet_WithBackupKeptByTimePeriodOnly_KeepsBackup(t *testing.T) {
+	now := time.Now().UTC()
+
+	// 3 backups within the time period, newest first
+	var backups []*backups_core.Backup
+	for i := 0; i < 3; i++ {
+		backups = append(backups, &backups_core.Backup{
+			ID:        uuid.New(),
+			CreatedAt: now.Add(-time.Duration(i+1) * time.Hour),
+		})
+	}
+
+	config := &backups_config.BackupConfig{
+		RetentionPolicyType: backups_config.RetentionPolicyTypeUnion,
+		RetentionTimePeriod: period.PeriodDay,
+		RetentionCount:      1,
+		RetentionGfsDays:    1,
+	}
+
+	keepSet := BuildMostConservativeKeepSet(backups, config)
+
+	assert.Len(t, keepSet, 3)
+}
+
+func Test_CleanByRetentionPolicy_WhenOneDatabasePanics_OtherDatabasesStillCleaned(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	panickingDatabase := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	healthyDatabase := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		for _, database := range []*databases.Database{panickingDatabase, healthyDatabase} {
+			backups, _ := backupRepository.FindByDatabaseID(database.ID)
+			for _, backup := range backups {
+				backupRepository.DeleteByID(backup.ID)
+			}
+
+			databases.RemoveTestDatabase(database)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	for _, database := range []*databases.Database{panickingDatabase, healthyDatabase} {
+		interval := createTestInterval()
+
+		backupConfig := &backups_config.BackupConfig{
+			DatabaseID:          database.ID,
+			IsBackupsEnabled:    true,
+			RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+			RetentionCount:      1,
+			StorageID:           &storage.ID,
+			BackupIntervalID:    interval.ID,
+			BackupInterval:      interval,
+		}
+		_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+		assert.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			err = backupRepository.Save(&backups_core.Backup{
+				ID:           uuid.New(),
+				DatabaseID:   database.ID,
+				StorageID:    storage.ID,
+				Status:       backups_core.BackupStatusCompleted,
+				BackupSizeMb: 10,
+				CreatedAt:    now.Add(-time.Duration(i+2) * time.Hour),
+			})
+			assert.NoError(t, err)
+		}
+	}
+
+	panickingListener := &mockBackupRemoveListener{
+		onBeforeBackupRemove: func(backup *backups_core.Backup) error {
+			if backup.DatabaseID == panickingDatabase.ID {
+				panic("simulated repository failure")
+			}
+
+			return nil
 		},
-		{
-			name: "YearBoundary_CorrectlySplitsAcrossYears",
-			backups: []*backups_core.Backup{
-				newBackup(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)),
-				newBackup(time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)),
-				newBackup(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)),
-				newBackup(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)),
-			},
-			years:       2,
-			keptIndices: []int{0, 1}, // 2025 and 2024 kept; 2024-06 and 2023 deleted
+	}
+
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		Listeners: []backups_core.BackupRemoveListener{panickingListener},
+	})
+
+	assert.NotPanics(t, func() {
+		err := cleaner.cleanByRetentionPolicy(context.Background())
+		assert.NoError(t, err)
+	})
+
+	panickingBackups, err := backupRepository.FindByDatabaseID(panickingDatabase.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(panickingBackups))
+
+	healthyBackups, err := backupRepository.FindByDatabaseID(healthyDatabase.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(healthyBackups))
+
+	assert.Equal(t, int64(1), cleaner.GetStats().Errors)
+}
+
+func Test_GetTimeUntilNextDeletion_ForEachPolicyType_ReturnsSoonestDeletion(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+
+	// hourly backups created 10 minutes, 1h10m, 2h10m, ... ago, newest first
+	newBackups := func(count int) []*backups_core.Backup {
+		backups := make([]*backups_core.Backup, count)
+		for i := range backups {
+			backups[i] = &backups_core.Backup{
+				ID:        uuid.New(),
+				CreatedAt: now.Add(-10*time.Minute - time.Duration(i)*time.Hour),
+			}
+		}
+
+		return backups
+	}
+
+	t.Run("TimePeriod returns time until oldest backup leaves retention", func(t *testing.T) {
+		backups := newBackups(3)
+		backupConfig := &backups_config.BackupConfig{
+			RetentionPolicyType: backups_config.RetentionPolicyTypeTimePeriod,
+			RetentionTimePeriod: period.PeriodDay,
+		}
+
+		duration, backup, err := getTimeUntilNextDeletion(backupConfig, backups, now)
+		assert.NoError(t, err)
+		assert.Equal(t, backups[2].ID, backup.ID)
+		assert.Equal(t, 24*time.Hour-2*time.Hour-10*time.Minute, duration)
+	})
+
+	t.Run("TimePeriod forever returns sentinel", func(t *testing.T) {
+		backupConfig := &backups_config.BackupConfig{
+			RetentionPolicyType: backups_config.RetentionPolicyTypeTimePeriod,
+			RetentionTimePeriod: period.PeriodForever,
+		}
+
+		_, backup, err := getTimeUntilNextDeletion(backupConfig, newBackups(3), now)
+		assert.ErrorIs(t, err, ErrNoDeletionScheduled)
+		assert.Nil(t, backup)
+	})
+
+	t.Run("Count over limit is bounded by grace period", func(t *testing.T) {
+		backups := newBackups(3)
+		backups[2].CreatedAt = now.Add(-20 * time.Minute)
+		backupConfig := &backups_config.BackupConfig{
+			RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+			RetentionCount:      2,
+		}
+
+		duration, backup, err := getTimeUntilNextDeletion(backupConfig, backups, now)
+		assert.NoError(t, err)
+		assert.Equal(t, backups[2].ID, backup.ID)
+		assert.Equal(t, recentBackupGracePeriod-20*time.Minute, duration)
+	})
+
+	t.Run("Count under limit returns sentinel", func(t *testing.T) {
+		backupConfig := &backups_config.BackupConfig{
+			RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+			RetentionCount:      5,
+		}
+
+		_, _, err := getTimeUntilNextDeletion(backupConfig, newBackups(3), now)
+		assert.ErrorIs(t, err, ErrNoDeletionScheduled)
+	})
+
+	t.Run("GFS backup outside keep set is deletable immediately", func(t *testing.T) {
+		backups := newBackups(3)
+		backupConfig := &backups_config.BackupConfig{
+			RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
+			RetentionGfsHours:   2,
+		}
+
+		duration, backup, err := getTimeUntilNextDeletion(backupConfig, backups, now)
+		assert.NoError(t, err)
+		assert.Equal(t, backups[2].ID, backup.ID)
+		assert.Equal(t, time.Duration(0), duration)
+	})
+
+	t.Run("HotCold returns time until cold retention ends", func(t *testing.T) {
+		backups := newBackups(2)
+		coldStorageID := uuid.New()
+		backupConfig := &backups_config.BackupConfig{
+			RetentionPolicyType: backups_config.RetentionPolicyTypeHotCold,
+			HotRetention:        period.PeriodDay,
+			ColdRetention:       period.PeriodWeek,
+			ColdStorageID:       &coldStorageID,
+		}
+
+		duration, backup, err := getTimeUntilNextDeletion(backupConfig, backups, now)
+		assert.NoError(t, err)
+		assert.Equal(t, backups[1].ID, backup.ID)
+		assert.Equal(t, 7*24*time.Hour-time.Hour-10*time.Minute, duration)
+	})
+
+	t.Run("Count over limit waits until recent day guarantee ends", func(t *testing.T) {
+		backups := []*backups_core.Backup{
+			{ID: uuid.New(), CreatedAt: now.Add(-10 * time.Minute)},
+			{ID: uuid.New(), CreatedAt: now.Add(-24 * time.Hour)},
+		}
+		backupConfig := &backups_config.BackupConfig{
+			RetentionPolicyType:      backups_config.RetentionPolicyTypeCount,
+			RetentionCount:           1,
+			GuaranteeOnePerRecentDay: 2,
+		}
+
+		// yesterday's only backup stays protected until the end of today
+		duration, backup, err := getTimeUntilNextDeletion(backupConfig, backups, now)
+		assert.NoError(t, err)
+		assert.Equal(t, backups[1].ID, backup.ID)
+		assert.Equal(t, 12*time.Hour, duration)
+	})
+}
+
+func Test_FindBackupNewerThanNewestKept_WhenKeepSetSkipsNewestBackup_ReturnsIt(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+
+	newestBackup := &backups_core.Backup{ID: uuid.New(), CreatedAt: now}
+	middleBackup := &backups_core.Backup{ID: uuid.New(), CreatedAt: now.Add(-24 * time.Hour)}
+	oldestBackup := &backups_core.Backup{ID: uuid.New(), CreatedAt: now.Add(-48 * time.Hour)}
+	backups := []*backups_core.Backup{newestBackup, middleBackup, oldestBackup}
+
+	brokenKeepSet := map[uuid.UUID]bool{middleBackup.ID: true}
+	assert.Equal(t, newestBackup, findBackupNewerThanNewestKept(backups, brokenKeepSet))
+
+	assert.Equal(t, newestBackup, findBackupNewerThanNewestKept(backups, map[uuid.UUID]bool{}))
+
+	validKeepSet := map[uuid.UUID]bool{newestBackup.ID: true, oldestBackup.ID: true}
+	assert.Nil(t, findBackupNewerThanNewestKept(backups, validKeepSet))
+}
+
+func Test_BuildGFSKeepSet_WithDailyBackupsOverYear_NeverSkipsNewestBackup(t *testing.T) {
+	now := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+
+	var backups []*backups_core.Backup
+	for i := 0; i < 400; i++ {
+		backups = append(backups, &backups_core.Backup{
+			ID:        uuid.New(),
+			CreatedAt: now.Add(-time.Duration(i) * 24 * time.Hour),
+		})
+	}
+
+	slotConfigs := [][5]int{
+		{0, 7, 0, 0, 0},
+		{0, 0, 4, 0, 0},
+		{0, 0, 0, 12, 0},
+		{0, 0, 0, 0, 1},
+		{24, 7, 4, 12, 3},
+	}
+
+	for _, slots := range slotConfigs {
+		keepSet := buildGFSKeepSet(backups, slots[0], slots[1], slots[2], slots[3], slots[4])
+
+		assert.Nil(
+			t,
+			findBackupNewerThanNewestKept(backups, keepSet),
+			"keep set for slots %v deletes a backup newer than the newest kept one",
+			slots,
+		)
+	}
+}
+
+func Test_BuildGFSKeepSet_WithBackupsInRepeatedDSTHour_KeepsBothInDistinctHourlySlots(
+	t *testing.T,
+) {
+	// on a fall back 01:30 happens twice, first in EDT and then in EST
+	edt := time.FixedZone("EDT", -4*60*60)
+	est := time.FixedZone("EST", -5*60*60)
+
+	secondRepeatedHourBackup := &backups_core.Backup{
+		ID:        uuid.New(),
+		CreatedAt: time.Date(2024, 11, 3, 1, 30, 0, 0, est),
+	}
+	firstRepeatedHourBackup := &backups_core.Backup{
+		ID:        uuid.New(),
+		CreatedAt: time.Date(2024, 11, 3, 1, 30, 0, 0, edt),
+	}
+	olderBackup := &backups_core.Backup{
+		ID:        uuid.New(),
+		CreatedAt: time.Date(2024, 11, 3, 0, 30, 0, 0, edt),
+	}
+
+	backups := []*backups_core.Backup{
+		secondRepeatedHourBackup,
+		firstRepeatedHourBackup,
+		olderBackup,
+	}
+
+	keepSet := buildGFSKeepSet(backups, 2, 0, 0, 0, 0)
+
+	assert.True(t, keepSet[secondRepeatedHourBackup.ID])
+	assert.True(t, keepSet[firstRepeatedHourBackup.ID])
+	assert.False(t, keepSet[olderBackup.ID])
+}
+
+func Test_GetBackupAgeReferenceTime_WhenStorageReportsModTime_UsesStorageTime(t *testing.T) {
+	createdAt := time.Date(2025, 6, 18, 12, 0, 0, 0, time.UTC)
+	modTime := createdAt.Add(-90 * 24 * time.Hour)
+
+	backup := &backups_core.Backup{
+		ID:        uuid.New(),
+		FileName:  "imported-backup",
+		CreatedAt: createdAt,
+	}
+
+	referenceTime := getBackupAgeReferenceTime(
+		backup,
+		&stubFileModTimeGetter{modTime: modTime, isSupported: true},
+		encryption.GetFieldEncryptor(),
+		logger.GetLogger(),
+	)
+	assert.Equal(t, modTime, referenceTime)
+
+	referenceTime = getBackupAgeReferenceTime(
+		backup,
+		&stubFileModTimeGetter{modTime: modTime, isSupported: false},
+		encryption.GetFieldEncryptor(),
+		logger.GetLogger(),
+	)
+	assert.Equal(t, createdAt, referenceTime, "unsupported storage must fall back to CreatedAt")
+}
+
+type stubFileModTimeGetter struct {
+	modTime     time.Time
+	isSupported bool
+}
+
+func (s *stubFileModTimeGetter) GetFileModTime(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+) (time.Time, bool, error) {
+	return s.modTime, s.isSupported, nil
+}
+
+func Test_FindDeletionCandidates_ForEachPolicy_MatchesInGoRetentionLogic(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+
+	backupAges := []time.Duration{
+		30 * time.Minute,
+		2 * time.Hour,
+		26 * time.Hour,
+		50 * time.Hour,
+		4 * 24 * time.Hour,
+		9 * 24 * time.Hour,
+		16 * 24 * time.Hour,
+		40 * 24 * time.Hour,
+		100 * 24 * time.Hour,
+		400 * 24 * time.Hour,
+	}
+	for _, age := range backupAges {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-age),
+		}
+		err := backupRepository.Save(backup)
+		assert.NoError(t, err)
+	}
+
+	completedBackups, err := backupRepository.FindByDatabaseIdAndStatus(
+		context.Background(),
+		database.ID,
+		backups_core.BackupStatusCompleted,
+	)
+	assert.NoError(t, err)
+
+	timeOfDay := "04:00"
+	dailyInterval := &intervals.Interval{
+		Interval:  intervals.IntervalDaily,
+		TimeOfDay: &timeOfDay,
+	}
+
+	configs := map[string]*backups_config.BackupConfig{
+		"time period": {
+			RetentionPolicyType: backups_config.RetentionPolicyTypeTimePeriod,
+			RetentionTimePeriod: period.PeriodWeek,
 		},
-		{
-			name: "ISOWeekBoundary_Jan1UsesCorrectISOWeek",
-			// 2025-01-01 is ISO week 1 of 2025; 2024-12-28 is ISO week 52 of 2024
-			backups: []*backups_core.Backup{
-				newBackup(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)),   // ISO week 2025-W01
-				newBackup(time.Date(2024, 12, 28, 12, 0, 0, 0, time.UTC)), // ISO week 2024-W52
-			},
-			weeks:       2,
-			keptIndices: []int{0, 1}, // different ISO weeks → both kept
+		"count": {
+			RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+			RetentionCount:      3,
 		},
-		{
-			name:        "EmptyBackups_ReturnsEmptyKeepSet",
-			backups:     []*backups_core.Backup{},
-			hours:       3,
-			days:        3,
-			weeks:       2,
-			months:      1,
-			years:       1,
-			keptIndices: []int{},
+		"GFS": {
+			RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
+			RetentionGfsDays:    3,
+			RetentionGfsWeeks:   2,
+			RetentionGfsMonths:  2,
 		},
-		{
-			name:        "AllZeroSlots_KeepsNothing",
-			backups:     backupsEveryDay(5),
-			hours:       0,
-			days:        0,
-			weeks:       0,
-			months:      0,
-			years:       0,
-			keptIndices: []int{},
+		"hot cold": {
+			RetentionPolicyType: backups_config.RetentionPolicyTypeHotCold,
+			HotRetention:        period.PeriodWeek,
+			ColdRetention:       period.PeriodMonth,
+			ColdStorageID:       &storage.ID,
 		},
-		{
-			name:    "AllSlotsActive_FullCombination",
-			backups: backupsEveryWeek(12),
-			days:    2,
-			weeks:   3,
-			months:  2,
-			years:   1,
-			// 2 daily (indices 0,1) + 3rd weekly slot (index 2) + 2nd monthly slot (index 3 or later).
-			// Additive slots: newest fills daily+weekly+monthly+yearly; each subsequent week fills another weekly,
-			// and a backup ~4 weeks later fills the 2nd monthly slot.
-			keptIndices: []int{0, 1, 2, 3},
+		"thinning": {
+			RetentionPolicyType: backups_config.RetentionPolicyTypeThinning,
+			ThinningKeepEvery:   2,
+			ThinningAfter:       period.PeriodDay,
+			BackupInterval:      dailyInterval,
 		},
 	}
 
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			keepSet := buildGFSKeepSet(tc.backups, tc.hours, tc.days, tc.weeks, tc.months, tc.years)
+	cleaner := GetBackupCleaner()
 
-			keptIndexSet := make(map[int]bool, len(tc.keptIndices))
-			for _, idx := range tc.keptIndices {
-				keptIndexSet[idx] = true
-			}
+	for name, backupConfig := range configs {
+		t.Run(name, func(t *testing.T) {
+			backupConfig.DatabaseID = database.ID
 
-			for i, backup := range tc.backups {
-				if keptIndexSet[i] {
-					assert.True(t, keepSet[backup.ID], "backup at index %d should be kept", i)
-				} else {
-					assert.False(t, keepSet[backup.ID], "backup at index %d should be deleted", i)
+			var expectedIDs []uuid.UUID
+			deleteAtByBackup := getRetentionDeleteAtByBackup(backupConfig, completedBackups, now)
+			for backup, deleteAt := range deleteAtByBackup {
+				if !deleteAt.After(now) {
+					expectedIDs = append(expectedIDs, backup.ID)
 				}
 			}
+
+			candidates, err := cleaner.findDeletionCandidates(
+				context.Background(),
+				backupConfig,
+				now,
+			)
+			assert.NoError(t, err)
+
+			var candidateIDs []uuid.UUID
+			for _, candidate := range candidates {
+				candidateIDs = append(candidateIDs, candidate.ID)
+			}
+
+			assert.NotEmpty(t, expectedIDs)
+			assert.ElementsMatch(t, expectedIDs, candidateIDs)
 		})
 	}
+
+	t.Run("hot cold without cold storage", func(t *testing.T) {
+		backupConfig := &backups_config.BackupConfig{
+			DatabaseID:          database.ID,
+			RetentionPolicyType: backups_config.RetentionPolicyTypeHotCold,
+			HotRetention:        period.PeriodWeek,
+			ColdRetention:       period.PeriodMonth,
+		}
+
+		candidates, err := cleaner.findDeletionCandidates(context.Background(), backupConfig, now)
+		assert.NoError(t, err)
+		assert.Empty(t, candidates)
+		assert.Empty(t, getRetentionDeleteAtByBackup(backupConfig, completedBackups, now))
+	})
 }
 
-func Test_CleanByTimePeriod_SkipsRecentBackup_EvenIfOlderThanRetention(t *testing.T) {
+func Test_CleanByDatabaseID_WhenCleanupFailsThenSucceeds_LastCleanupErrorRecordedAndCleared(
+	t *testing.T,
+) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
@@ -1205,156 +3601,233 @@ func Test_CleanByTimePeriod_SkipsRecentBackup_EvenIfOlderThanRetention(t *testin
 	}()
 
 	interval := createTestInterval()
+	configService := backups_config.GetBackupConfigService()
 
-	// Retention period is 1 day — any backup older than 1 day should be deleted.
-	// But the recent backup was created only 30 minutes ago and must be preserved.
-	backupConfig := &backups_config.BackupConfig{
+	_, err := configService.SaveBackupConfig(&backups_config.BackupConfig{
 		DatabaseID:          database.ID,
 		IsBackupsEnabled:    true,
-		RetentionPolicyType: backups_config.RetentionPolicyTypeTimePeriod,
-		RetentionTimePeriod: period.PeriodDay,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      1,
 		StorageID:           &storage.ID,
 		BackupIntervalID:    interval.ID,
 		BackupInterval:      interval,
-	}
-	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	})
 	assert.NoError(t, err)
 
 	now := time.Now().UTC()
-
-	oldBackup := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-2 * 24 * time.Hour),
+	for i := 0; i < 3; i++ {
+		err = backupRepository.Save(&backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(i+2) * time.Hour),
+		})
+		assert.NoError(t, err)
 	}
-	recentBackup := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-30 * time.Minute),
+
+	isStorageDown := true
+	failingListener := &mockBackupRemoveListener{
+		onBeforeBackupRemove: func(backup *backups_core.Backup) error {
+			if isStorageDown {
+				panic("storage is down")
+			}
+
+			return nil
+		},
 	}
 
-	err = backupRepository.Save(oldBackup)
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		BackupConfigService: configService,
+		Listeners:           []backups_core.BackupRemoveListener{failingListener},
+	})
+
+	backupConfig, err := configService.GetBackupConfigByDbId(database.ID)
 	assert.NoError(t, err)
-	err = backupRepository.Save(recentBackup)
+	cleaner.cleanByDatabaseID(context.Background(), backupConfig)
+
+	failedConfig, err := configService.GetBackupConfigByDbId(database.ID)
 	assert.NoError(t, err)
+	assert.Contains(t, failedConfig.LastCleanupError, "storage is down")
+	assert.NotNil(t, failedConfig.LastCleanupErrorAt)
 
-	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	isStorageDown = false
+	cleaner.cleanByDatabaseID(context.Background(), failedConfig)
+
+	recoveredConfig, err := configService.GetBackupConfigByDbId(database.ID)
 	assert.NoError(t, err)
+	assert.Empty(t, recoveredConfig.LastCleanupError)
+	assert.Nil(t, recoveredConfig.LastCleanupErrorAt)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(remainingBackups))
-	assert.Equal(t, recentBackup.ID, remainingBackups[0].ID)
 }
 
-func Test_CleanByCount_SkipsRecentBackup_EvenIfOverLimit(t *testing.T) {
+func Test_CleanByRetentionPolicy_WhenBothDatabasesOverdue_HigherPriorityCleanedFirst(
+	t *testing.T,
+) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
 	storage := storages.CreateTestStorage(workspace.ID)
 	notifier := notifiers.CreateTestNotifier(workspace.ID)
-	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	normalDatabase := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+	criticalDatabase := databases.CreateTestDatabase(workspace.ID, storage, notifier)
 
 	defer func() {
-		backups, _ := backupRepository.FindByDatabaseID(database.ID)
-		for _, backup := range backups {
-			backupRepository.DeleteByID(backup.ID)
+		for _, database := range []*databases.Database{normalDatabase, criticalDatabase} {
+			backups, _ := backupRepository.FindByDatabaseID(database.ID)
+			for _, backup := range backups {
+				backupRepository.DeleteByID(backup.ID)
+			}
+
+			databases.RemoveTestDatabase(database)
 		}
 
-		databases.RemoveTestDatabase(database)
 		time.Sleep(50 * time.Millisecond)
 		notifiers.RemoveTestNotifier(notifier)
 		storages.RemoveTestStorage(storage.ID)
 		workspaces_testing.RemoveTestWorkspace(workspace, router)
 	}()
 
-	interval := createTestInterval()
-
-	// Retention count is 2 — 4 backups exist so 2 should be deleted.
-	// The oldest backup in the "excess" tail was made 30 min ago — it must be preserved.
-	backupConfig := &backups_config.BackupConfig{
-		DatabaseID:          database.ID,
-		IsBackupsEnabled:    true,
-		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
-		RetentionCount:      2,
-		StorageID:           &storage.ID,
-		BackupIntervalID:    interval.ID,
-		BackupInterval:      interval,
+	now := time.Now().UTC()
+	priorityByDatabaseID := map[uuid.UUID]int{
+		normalDatabase.ID:   0,
+		criticalDatabase.ID: 10,
 	}
-	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
-	assert.NoError(t, err)
 
-	now := time.Now().UTC()
+	for _, database := range []*databases.Database{normalDatabase, criticalDatabase} {
+		interval := createTestInterval()
+
+		_, err := backups_config.GetBackupConfigService().SaveBackupConfig(
+			&backups_config.BackupConfig{
+				DatabaseID:          database.ID,
+				IsBackupsEnabled:    true,
+				RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+				RetentionCount:      1,
+				CleanerPriority:     priorityByDatabaseID[database.ID],
+				StorageID:           &storage.ID,
+				BackupIntervalID:    interval.ID,
+				BackupInterval:      interval,
+			},
+		)
+		assert.NoError(t, err)
 
-	oldBackup1 := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-5 * time.Hour),
-	}
-	oldBackup2 := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-3 * time.Hour),
-	}
-	// This backup is 3rd newest and would normally be deleted — but it is recent.
-	recentExcessBackup := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-30 * time.Minute),
+		for i := 0; i < 2; i++ {
+			err = backupRepository.Save(&backups_core.Backup{
+				ID:           uuid.New(),
+				DatabaseID:   database.ID,
+				StorageID:    storage.ID,
+				Status:       backups_core.BackupStatusCompleted,
+				BackupSizeMb: 10,
+				CreatedAt:    now.Add(-time.Duration(i+2) * time.Hour),
+			})
+			assert.NoError(t, err)
+		}
 	}
-	newestBackup := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-10 * time.Minute),
+
+	var cleanedDatabaseIDs []uuid.UUID
+	orderListener := &mockBackupRemoveListener{
+		onBeforeBackupRemove: func(backup *backups_core.Backup) error {
+			if _, isTestDatabase := priorityByDatabaseID[backup.DatabaseID]; isTestDatabase {
+				cleanedDatabaseIDs = append(cleanedDatabaseIDs, backup.DatabaseID)
+			}
+
+			return nil
+		},
 	}
 
-	for _, b := range []*backups_core.Backup{oldBackup1, oldBackup2, recentExcessBackup, newestBackup} {
-		err = backupRepository.Save(b)
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		Listeners: []backups_core.BackupRemoveListener{orderListener},
+	})
+
+	err := cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	assert.Equal(t, []uuid.UUID{criticalDatabase.ID, normalDatabase.ID}, cleanedDatabaseIDs)
+}
+
+func Test_CleanByRetentionPolicy_WhenStorageIsUnavailable_SkipsDatabase(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(
+		&backups_config.BackupConfig{
+			DatabaseID:          database.ID,
+			IsBackupsEnabled:    true,
+			RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+			RetentionCount:      1,
+			StorageID:           &storage.ID,
+			BackupIntervalID:    interval.ID,
+			BackupInterval:      interval,
+		},
+	)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	for i := 0; i < 3; i++ {
+		err = backupRepository.Save(&backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(i+2) * time.Hour),
+		})
 		assert.NoError(t, err)
 	}
 
+	err = storages.GetStorageService().SetAvailability(storage.ID, false)
+	assert.NoError(t, err)
+
+	isAvailable, err := storages.GetStorageService().IsAvailable(storage.ID)
+	assert.NoError(t, err)
+	assert.False(t, isAvailable)
+
 	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
+	err = cleaner.cleanByRetentionPolicy(context.Background())
 	assert.NoError(t, err)
 
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
 	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 3)
 
-	remainingIDs := make(map[uuid.UUID]bool)
-	for _, backup := range remainingBackups {
-		remainingIDs[backup.ID] = true
-	}
+	err = storages.GetStorageService().SetAvailability(storage.ID, true)
+	assert.NoError(t, err)
 
-	assert.False(t, remainingIDs[oldBackup1.ID], "Oldest non-recent backup should be deleted")
-	assert.False(t, remainingIDs[oldBackup2.ID], "2nd oldest non-recent backup should be deleted")
-	assert.True(
-		t,
-		remainingIDs[recentExcessBackup.ID],
-		"Recent backup must be preserved despite being over limit",
-	)
-	assert.True(t, remainingIDs[newestBackup.ID], "Newest backup should be preserved")
+	err = cleaner.cleanByRetentionPolicy(context.Background())
+	assert.NoError(t, err)
+
+	remainingBackups, err = backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 1)
 }
 
-func Test_CleanByGFS_SkipsRecentBackup_WhenNotInKeepSet(t *testing.T) {
+// Mock listener for testing
+func Test_CleanByRetentionPolicy_WhenLastBackupBecomesDeletable_NotificationSentOnce(
+	t *testing.T,
+) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
@@ -1377,92 +3850,67 @@ func Test_CleanByGFS_SkipsRecentBackup_WhenNotInKeepSet(t *testing.T) {
 
 	interval := createTestInterval()
 
-	// Keep only 1 daily slot. We create 2 old backups plus two recent backups on today.
-	// Backups are ordered newest-first, so the 15-min-old backup fills the single daily slot.
-	// The 30-min-old backup is the same day → not in the GFS keep-set, but it is still recent
-	// (within grace period) and must be preserved.
 	backupConfig := &backups_config.BackupConfig{
 		DatabaseID:          database.ID,
 		IsBackupsEnabled:    true,
-		RetentionPolicyType: backups_config.RetentionPolicyTypeGFS,
-		RetentionGfsDays:    1,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod: period.PeriodWeek,
 		StorageID:           &storage.ID,
 		BackupIntervalID:    interval.ID,
 		BackupInterval:      interval,
+		SendNotificationsOn: []backups_config.BackupNotificationType{
+			backups_config.NotificationLastBackupDeletion,
+		},
 	}
 	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
 	assert.NoError(t, err)
 
-	now := time.Now().UTC()
-
-	oldBackup1 := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-3 * 24 * time.Hour).Truncate(24 * time.Hour),
-	}
-	oldBackup2 := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-2 * 24 * time.Hour).Truncate(24 * time.Hour),
-	}
-	// Newest backup today — will fill the single GFS daily slot.
-	newestTodayBackup := &backups_core.Backup{
+	lastBackup := &backups_core.Backup{
 		ID:           uuid.New(),
 		DatabaseID:   database.ID,
 		StorageID:    storage.ID,
 		Status:       backups_core.BackupStatusCompleted,
 		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-15 * time.Minute),
+		CreatedAt:    time.Now().UTC().Add(-10 * 24 * time.Hour),
 	}
-	// Slightly older backup, also today — NOT in GFS keep-set (duplicate day),
-	// but within the 60-minute grace period so it must survive.
-	recentNotInKeepSet := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 10,
-		CreatedAt:    now.Add(-30 * time.Minute),
+	err = backupRepository.Save(lastBackup)
+	assert.NoError(t, err)
+
+	mockNotificationSender := &MockNotificationSender{}
+	mockNotificationSender.On(
+		"SendNotification",
+		mock.Anything,
+		mock.MatchedBy(func(title string) bool {
+			return strings.Contains(title, "Last backup")
+		}),
+		mock.Anything,
+	).Return()
+
+	// the deletion keeps failing, so the backup stays deletable on each pass
+	lockingListener := &mockBackupRemoveListener{
+		onBeforeBackupRemove: func(backup *backups_core.Backup) error {
+			return errors.New("backup is locked")
+		},
 	}
 
-	for _, b := range []*backups_core.Backup{oldBackup1, oldBackup2, newestTodayBackup, recentNotInKeepSet} {
-		err = backupRepository.Save(b)
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		Listeners:          []backups_core.BackupRemoveListener{lockingListener},
+		NotificationSender: mockNotificationSender,
+	})
+
+	for range 3 {
+		err = cleaner.cleanByRetentionPolicy(context.Background())
 		assert.NoError(t, err)
 	}
 
-	cleaner := GetBackupCleaner()
-	err = cleaner.cleanByRetentionPolicy()
-	assert.NoError(t, err)
-
 	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
 	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 1)
 
-	remainingIDs := make(map[uuid.UUID]bool)
-	for _, backup := range remainingBackups {
-		remainingIDs[backup.ID] = true
-	}
-
-	assert.False(t, remainingIDs[oldBackup1.ID], "Old backup 1 should be deleted by GFS")
-	assert.False(t, remainingIDs[oldBackup2.ID], "Old backup 2 should be deleted by GFS")
-	assert.True(
-		t,
-		remainingIDs[newestTodayBackup.ID],
-		"Newest backup fills GFS daily slot and must remain",
-	)
-	assert.True(
-		t,
-		remainingIDs[recentNotInKeepSet.ID],
-		"Recent backup not in keep-set must be preserved by grace period",
-	)
+	mockNotificationSender.AssertNumberOfCalls(t, "SendNotification", 1)
 }
 
-func Test_CleanExceededBackups_SkipsRecentBackup_WhenOverTotalSizeLimit(t *testing.T) {
+func Test_CleanByDatabaseID_WithRecordingTracer_SpansHaveDeletionAttributes(t *testing.T) {
 	router := CreateTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
 	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
@@ -1485,61 +3933,69 @@ func Test_CleanExceededBackups_SkipsRecentBackup_WhenOverTotalSizeLimit(t *testi
 
 	interval := createTestInterval()
 
-	// Total size limit is 10 MB. We have two backups of 8 MB each (16 MB total).
-	// The oldest backup was created 30 minutes ago — within the grace period.
-	// The cleaner must stop and leave both backups intact.
 	backupConfig := &backups_config.BackupConfig{
-		DatabaseID:            database.ID,
-		IsBackupsEnabled:      true,
-		RetentionPolicyType:   backups_config.RetentionPolicyTypeTimePeriod,
-		RetentionTimePeriod:   period.PeriodForever,
-		StorageID:             &storage.ID,
-		MaxBackupsTotalSizeMB: 10,
-		BackupIntervalID:      interval.ID,
-		BackupInterval:        interval,
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      1,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
 	}
 	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
 	assert.NoError(t, err)
 
 	now := time.Now().UTC()
-
-	olderRecentBackup := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 8,
-		CreatedAt:    now.Add(-30 * time.Minute),
-	}
-	newerRecentBackup := &backups_core.Backup{
-		ID:           uuid.New(),
-		DatabaseID:   database.ID,
-		StorageID:    storage.ID,
-		Status:       backups_core.BackupStatusCompleted,
-		BackupSizeMb: 8,
-		CreatedAt:    now.Add(-10 * time.Minute),
+	for i := 0; i < 3; i++ {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(i+1) * 24 * time.Hour),
+		}
+		assert.NoError(t, backupRepository.Save(backup))
 	}
 
-	err = backupRepository.Save(olderRecentBackup)
-	assert.NoError(t, err)
-	err = backupRepository.Save(newerRecentBackup)
-	assert.NoError(t, err)
+	tracer := &recordingTracer{}
 
-	cleaner := GetBackupCleaner()
-	err = cleaner.cleanExceededBackups()
-	assert.NoError(t, err)
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		Tracer:         tracer,
+		TickerInterval: time.Minute,
+	})
 
-	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	savedConfig, err := backups_config.GetBackupConfigService().GetBackupConfigByDbId(database.ID)
 	assert.NoError(t, err)
+
+	cleaner.cleanByDatabaseID(context.Background(), savedConfig)
+
+	deleteSpans := tracer.findSpans(deleteBackupSpanName)
+	assert.Len(t, deleteSpans, 2)
+	for _, span := range deleteSpans {
+		assert.True(t, span.isEnded)
+		assert.Equal(t, database.ID.String(), span.attributes[spanAttributeDatabaseID])
+		assert.Equal(
+			t,
+			string(backups_core.DeletionReasonCount),
+			span.attributes[spanAttributeDeletionReason],
+		)
+		assert.Equal(t, 10.0, span.attributes[spanAttributeFreedMB])
+	}
+
+	cleanSpans := tracer.findSpans(cleanDatabaseSpanName)
+	assert.Len(t, cleanSpans, 1)
+	assert.True(t, cleanSpans[0].isEnded)
+	assert.Equal(t, database.ID.String(), cleanSpans[0].attributes[spanAttributeDatabaseID])
 	assert.Equal(
 		t,
-		2,
-		len(remainingBackups),
-		"Both recent backups must be preserved even though total size exceeds limit",
+		string(backups_config.RetentionPolicyTypeCount),
+		cleanSpans[0].attributes[spanAttributePolicy],
 	)
+	assert.Equal(t, 2, cleanSpans[0].attributes[spanAttributeDeletedCount])
+	assert.Equal(t, 20.0, cleanSpans[0].attributes[spanAttributeFreedMB])
 }
 
-// Mock listener for testing
 type mockBackupRemoveListener struct {
 	onBeforeBackupRemove func(*backups_core.Backup) error
 }
@@ -1552,6 +4008,57 @@ func (m *mockBackupRemoveListener) OnBeforeBackupRemove(backup *backups_core.Bac
 	return nil
 }
 
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (r *recordingTracer) Start(
+	ctx context.Context,
+	spanName string,
+) (context.Context, backups_core.Span) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	span := &recordedSpan{name: spanName, attributes: map[string]any{}}
+	r.spans = append(r.spans, span)
+
+	return ctx, span
+}
+
+func (r *recordingTracer) findSpans(spanName string) []*recordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var spans []*recordedSpan
+	for _, span := range r.spans {
+		if span.name == spanName {
+			spans = append(spans, span)
+		}
+	}
+
+	return spans
+}
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]any
+	errs       []error
+	isEnded    bool
+}
+
+func (s *recordedSpan) SetAttribute(key string, value any) {
+	s.attributes[key] = value
+}
+
+func (s *recordedSpan) RecordError(err error) {
+	s.errs = append(s.errs, err)
+}
+
+func (s *recordedSpan) End() {
+	s.isEnded = true
+}
+
 func createTestInterval() *intervals.Interval {
 	timeOfDay := "04:00"
 	interval := &intervals.Interval{
@@ -1566,3 +4073,89 @@ func createTestInterval() *intervals.Interval {
 
 	return interval
 }
+
+func createHotColdTestBackup(
+	t *testing.T,
+	databaseID uuid.UUID,
+	storage *storages.Storage,
+	createdAt time.Time,
+) *backups_core.Backup {
+	backup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   databaseID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    createdAt,
+	}
+	backup.FileName = backup.ID.String()
+
+	err := storage.SaveFile(
+		context.Background(),
+		encryption.GetFieldEncryptor(),
+		logger.GetLogger(),
+		backup.FileName,
+		strings.NewReader("test backup content"),
+	)
+	assert.NoError(t, err)
+
+	err = backupRepository.Save(backup)
+	assert.NoError(t, err)
+
+	return backup
+}
+
+func Test_CleanBackupsWithoutFileName_WhenBackupHasEmptyFileName_RemovesRowWithoutStorageCall(
+	t *testing.T,
+) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	// local storage resolves files relative to the data folder, so a storage call
+	// with an empty file name would remove this metadata file
+	sentinelFilePath := filepath.Join(config.GetEnv().DataFolder, ".metadata")
+	err := os.WriteFile(sentinelFilePath, []byte("sentinel"), 0o644)
+	assert.NoError(t, err)
+
+	defer func() {
+		_ = os.Remove(sentinelFilePath)
+
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupWithoutFileName := &backups_core.Backup{
+		ID:           uuid.New(),
+		FileName:     "",
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusFailed,
+		BackupSizeMb: 0,
+		CreatedAt:    time.Now().UTC().Add(-2 * time.Hour),
+	}
+	err = backupRepository.Save(backupWithoutFileName)
+	assert.NoError(t, err)
+
+	cleaner := GetBackupCleaner()
+	err = cleaner.cleanBackupsWithoutFileName(context.Background())
+	assert.NoError(t, err)
+
+	deletedBackup, err := backupRepository.FindByID(backupWithoutFileName.ID)
+	assert.Error(t, err)
+	assert.Nil(t, deletedBackup)
+
+	_, err = os.Stat(sentinelFilePath)
+	assert.NoError(t, err, "storage must not be called for backup without file name")
+}
@@ -0,0 +1,37 @@
+package backuping
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeletionJobStatus string
+
+const (
+	DeletionJobStatusPending    DeletionJobStatus = "PENDING"
+	DeletionJobStatusInProgress DeletionJobStatus = "IN_PROGRESS"
+	DeletionJobStatusCompleted  DeletionJobStatus = "COMPLETED"
+)
+
+// DeletionJob deletes backups of a database created before CreatedBefore in
+// batches. LastProcessedBackupID lets the job continue after a restart
+type DeletionJob struct {
+	ID            uuid.UUID         `json:"id"            gorm:"column:id;type:uuid;primaryKey"`
+	DatabaseID    uuid.UUID         `json:"databaseId"    gorm:"column:database_id;type:uuid;not null"`
+	CreatedBefore time.Time         `json:"createdBefore" gorm:"column:created_before;type:timestamptz;not null"`
+	Status        DeletionJobStatus `json:"status"        gorm:"column:status;type:text;not null"`
+
+	TotalCount     int `json:"totalCount"     gorm:"column:total_count;type:int;not null"`
+	CompletedCount int `json:"completedCount" gorm:"column:completed_count;type:int;not null"`
+	FailedCount    int `json:"failedCount"    gorm:"column:failed_count;type:int;not null"`
+
+	LastProcessedBackupID *uuid.UUID `json:"lastProcessedBackupId" gorm:"column:last_processed_backup_id;type:uuid"`
+
+	CreatedAt   time.Time  `json:"createdAt"   gorm:"column:created_at;type:timestamptz;not null"`
+	CompletedAt *time.Time `json:"completedAt" gorm:"column:completed_at;type:timestamptz"`
+}
+
+func (DeletionJob) TableName() string {
+	return "deletion_jobs"
+}
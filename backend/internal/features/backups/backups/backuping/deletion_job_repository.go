@@ -0,0 +1,57 @@
+package backuping
+
+import (
+	"databasus-backend/internal/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DeletionJobRepository struct{}
+
+func (r *DeletionJobRepository) Save(job *DeletionJob) error {
+	db := storage.GetDb()
+
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+		if job.CreatedAt.IsZero() {
+			job.CreatedAt = time.Now().UTC()
+		}
+
+		return db.Create(job).Error
+	}
+
+	return db.Save(job).Error
+}
+
+func (r *DeletionJobRepository) FindByID(id uuid.UUID) (*DeletionJob, error) {
+	var job DeletionJob
+
+	if err := storage.
+		GetDb().
+		Where("id = ?", id).
+		First(&job).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *DeletionJobRepository) FindUnfinished() ([]*DeletionJob, error) {
+	var jobs []*DeletionJob
+
+	if err := storage.
+		GetDb().
+		Where("status != ?", DeletionJobStatusCompleted).
+		Order("created_at ASC").
+		Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
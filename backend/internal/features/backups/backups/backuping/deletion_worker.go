@@ -0,0 +1,206 @@
+package backuping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+)
+
+const (
+	deletionJobTickerInterval = 10 * time.Second
+	// deletionJobBatchSize caps deletions per job per tick so mass deletion
+	// does not flood the storages with requests
+	deletionJobBatchSize = 100
+)
+
+var ErrDeletionJobNotFound = errors.New("deletion job not found")
+
+type DeletionJobWorker struct {
+	deletionJobRepository *DeletionJobRepository
+	backupRepository      *backups_core.BackupRepository
+	backupCleaner         *BackupCleaner
+	logger                *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (w *DeletionJobWorker) Run(ctx context.Context) {
+	wasAlreadyRun := w.hasRun.Load()
+
+	w.runOnce.Do(func() {
+		w.hasRun.Store(true)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(deletionJobTickerInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := w.processUnfinishedJobs(ctx); err != nil {
+					w.logger.Error("Failed to process deletion jobs", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", w))
+	}
+}
+
+func (w *DeletionJobWorker) CreateDeletionJob(
+	databaseID uuid.UUID,
+	createdBefore time.Time,
+) (*DeletionJob, error) {
+	totalCount, err := w.backupRepository.CountForDeletion(databaseID, createdBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &DeletionJob{
+		DatabaseID:    databaseID,
+		CreatedBefore: createdBefore,
+		Status:        DeletionJobStatusPending,
+		TotalCount:    int(totalCount),
+	}
+
+	if err := w.deletionJobRepository.Save(job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (w *DeletionJobWorker) GetDeletionJobProgress(jobID uuid.UUID) (*DeletionJob, error) {
+	job, err := w.deletionJobRepository.FindByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job == nil {
+		return nil, ErrDeletionJobNotFound
+	}
+
+	return job, nil
+}
+
+func (w *DeletionJobWorker) processUnfinishedJobs(ctx context.Context) error {
+	jobs, err := w.deletionJobRepository.FindUnfinished()
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := w.processJobBatch(ctx, job, deletionJobBatchSize); err != nil {
+			w.logger.Error(
+				"Failed to process deletion job",
+				"jobId", job.ID,
+				"databaseId", job.DatabaseID,
+				"error", err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// processJobBatch saves progress after every backup, so a restart never
+// processes the same backup twice. A batch that is not allowed to run is
+// retried on the next tick
+func (w *DeletionJobWorker) processJobBatch(
+	ctx context.Context,
+	job *DeletionJob,
+	batchSize int,
+) error {
+	backupConfig, err := w.backupCleaner.backupConfigService.GetBackupConfigByDbId(job.DatabaseID)
+	if err != nil {
+		return err
+	}
+
+	if err := w.backupCleaner.checkCleanupAllowed(backupConfig); err != nil {
+		w.logger.Info(
+			"Deletion job is paused",
+			"jobId", job.ID,
+			"databaseId", job.DatabaseID,
+			"reason", err,
+		)
+		return nil
+	}
+
+	if !w.backupCleaner.backupMutexRegistry.TryLock(job.DatabaseID) {
+		w.logger.Info(
+			"Backup is in progress, skipping deletion job batch",
+			"jobId", job.ID,
+			"databaseId", job.DatabaseID,
+		)
+		return nil
+	}
+	defer w.backupCleaner.backupMutexRegistry.Unlock(job.DatabaseID)
+
+	backups, err := w.backupRepository.FindForDeletion(
+		job.DatabaseID,
+		job.CreatedBefore,
+		job.LastProcessedBackupID,
+		batchSize,
+	)
+	if err != nil {
+		return err
+	}
+
+	job.Status = DeletionJobStatusInProgress
+
+	for _, backup := range backups {
+		// the cursor moves past failed backups, a deletion aborted by shutdown
+		// must not be counted as failed and skipped forever
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := w.backupCleaner.DeleteBackup(ctx, backup, backups_core.DeletionReasonManual)
+		if err != nil {
+			job.FailedCount++
+			w.logger.Error(
+				"Failed to delete backup by deletion job",
+				"jobId", job.ID,
+				"backupId", backup.ID,
+				"error", err,
+			)
+		} else {
+			job.CompletedCount++
+		}
+
+		backupID := backup.ID
+		job.LastProcessedBackupID = &backupID
+
+		if err := w.deletionJobRepository.Save(job); err != nil {
+			return err
+		}
+	}
+
+	if len(backups) < batchSize {
+		completedAt := time.Now().UTC()
+		job.Status = DeletionJobStatusCompleted
+		job.CompletedAt = &completedAt
+	}
+
+	return w.deletionJobRepository.Save(job)
+}
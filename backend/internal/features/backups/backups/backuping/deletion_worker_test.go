@@ -0,0 +1,205 @@
+package backuping
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/util/logger"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DeletionJob_WhenInterruptedMidway_ResumesWithoutDeletingTwice(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	for i := 0; i < 5; i++ {
+		err := backupRepository.Save(&backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(i+1) * time.Hour),
+		})
+		assert.NoError(t, err)
+	}
+
+	var mu sync.Mutex
+	deletionsByBackupID := make(map[uuid.UUID]int)
+	countingListener := &mockBackupRemoveListener{
+		onBeforeBackupRemove: func(backup *backups_core.Backup) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			deletionsByBackupID[backup.ID]++
+			return nil
+		},
+	}
+
+	cleaner := CreateTestBackupCleaner(TestBackupCleanerOptions{
+		Listeners: []backups_core.BackupRemoveListener{countingListener},
+	})
+
+	newWorker := func() *DeletionJobWorker {
+		return &DeletionJobWorker{
+			&DeletionJobRepository{},
+			backupRepository,
+			cleaner,
+			logger.GetLogger(),
+			sync.Once{},
+			atomic.Bool{},
+		}
+	}
+
+	job, err := newWorker().CreateDeletionJob(database.ID, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, job.TotalCount)
+
+	err = newWorker().processJobBatch(context.Background(), job, 2)
+	assert.NoError(t, err)
+
+	// simulate process restart: a new worker picks the job up from the database
+	restartedWorker := newWorker()
+	for i := 0; i < 5; i++ {
+		persistedJob, err := restartedWorker.GetDeletionJobProgress(job.ID)
+		assert.NoError(t, err)
+
+		if persistedJob.Status == DeletionJobStatusCompleted {
+			break
+		}
+
+		err = restartedWorker.processJobBatch(context.Background(), persistedJob, 2)
+		assert.NoError(t, err)
+	}
+
+	finishedJob, err := restartedWorker.GetDeletionJobProgress(job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, DeletionJobStatusCompleted, finishedJob.Status)
+	assert.Equal(t, 5, finishedJob.CompletedCount)
+	assert.Equal(t, 0, finishedJob.FailedCount)
+	assert.NotNil(t, finishedJob.CompletedAt)
+
+	assert.Len(t, deletionsByBackupID, 5)
+	for backupID, deletionsCount := range deletionsByBackupID {
+		assert.Equal(t, 1, deletionsCount, "backup %s deleted more than once", backupID)
+	}
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, remainingBackups)
+}
+
+func Test_DeletionJob_WhenDeletionNotAllowed_BatchSkippedAndBackupsKept(t *testing.T) {
+	tests := []struct {
+		name           string
+		isReadOnly     bool
+		isBackupLocked bool
+	}{
+		{"read-only database", true, false},
+		{"backup in progress", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := CreateTestRouter()
+			owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+			workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+			storage := storages.CreateTestStorage(workspace.ID)
+			notifier := notifiers.CreateTestNotifier(workspace.ID)
+			database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+			defer func() {
+				backups, _ := backupRepository.FindByDatabaseID(database.ID)
+				for _, backup := range backups {
+					backupRepository.DeleteByID(backup.ID)
+				}
+
+				databases.RemoveTestDatabase(database)
+				time.Sleep(50 * time.Millisecond)
+				notifiers.RemoveTestNotifier(notifier)
+				storages.RemoveTestStorage(storage.ID)
+				workspaces_testing.RemoveTestWorkspace(workspace, router)
+			}()
+
+			configService := backups_config.GetBackupConfigService()
+			backupConfig, err := configService.GetBackupConfigByDbId(database.ID)
+			assert.NoError(t, err)
+			backupConfig.IsReadOnlyMode = tt.isReadOnly
+			backupConfig.StorageID = &storage.ID
+			backupConfig.Storage = storage
+			_, err = configService.SaveBackupConfig(backupConfig)
+			assert.NoError(t, err)
+
+			now := time.Now().UTC()
+			err = backupRepository.Save(&backups_core.Backup{
+				ID:           uuid.New(),
+				DatabaseID:   database.ID,
+				StorageID:    storage.ID,
+				Status:       backups_core.BackupStatusCompleted,
+				BackupSizeMb: 10,
+				CreatedAt:    now.Add(-time.Hour),
+			})
+			assert.NoError(t, err)
+
+			if tt.isBackupLocked {
+				backupMutexRegistry.Lock(database.ID)
+				defer backupMutexRegistry.Unlock(database.ID)
+			}
+
+			worker := &DeletionJobWorker{
+				&DeletionJobRepository{},
+				backupRepository,
+				CreateTestBackupCleaner(TestBackupCleanerOptions{}),
+				logger.GetLogger(),
+				sync.Once{},
+				atomic.Bool{},
+			}
+
+			job, err := worker.CreateDeletionJob(database.ID, now)
+			assert.NoError(t, err)
+
+			err = worker.processJobBatch(context.Background(), job, 10)
+			assert.NoError(t, err)
+
+			persistedJob, err := worker.GetDeletionJobProgress(job.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, DeletionJobStatusPending, persistedJob.Status)
+			assert.Equal(t, 0, persistedJob.CompletedCount)
+
+			remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+			assert.NoError(t, err)
+			assert.Len(t, remainingBackups, 1)
+		})
+	}
+}
@@ -7,9 +7,11 @@ import (
 
 	"github.com/google/uuid"
 
+	"databasus-backend/internal/config"
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	"databasus-backend/internal/features/backups/backups/usecases"
 	backups_config "databasus-backend/internal/features/backups/config"
+	backups_settings "databasus-backend/internal/features/backups/settings"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
@@ -24,6 +26,16 @@ var backupRepository = &backups_core.BackupRepository{}
 
 var taskCancelManager = tasks_cancellation.GetTaskCancelManager()
 
+var backupMutexRegistry = &BackupMutexRegistry{
+	sync.Mutex{},
+	make(map[uuid.UUID]*sync.Mutex),
+}
+
+var lastBackupAlertCache = cache_utils.NewCacheUtil[time.Time](
+	cache_utils.GetValkeyClient(),
+	"last_backup_alert:",
+)
+
 var backupCleaner = &BackupCleaner{
 	backupRepository,
 	storages.GetStorageService(),
@@ -31,10 +43,43 @@ var backupCleaner = &BackupCleaner{
 	encryption.GetFieldEncryptor(),
 	logger.GetLogger(),
 	[]backups_core.BackupRemoveListener{},
+	backupMutexRegistry,
+	func(databaseID uuid.UUID, totalSizeMB float64) {},
+	noopTracer{},
+	databases.GetDatabaseService(),
+	notifiers.GetNotifierService(),
+	lastBackupAlertCache,
+	cleanerTickerInterval,
+	config.GetEnv().IsCleanerReportOnly,
+	atomic.Int64{},
+	atomic.Int64{},
+	atomic.Int64{},
+	sync.Map{},
+	sync.Map{},
+	atomic.Bool{},
+	atomic.Bool{},
+	atomic.Int64{},
+	atomic.Int64{},
+	atomic.Int64{},
+}
+
+var deletionJobWorker = &DeletionJobWorker{
+	&DeletionJobRepository{},
+	backupRepository,
+	backupCleaner,
+	logger.GetLogger(),
 	sync.Once{},
 	atomic.Bool{},
 }
 
+var scheduledCleanupJob = &ScheduledCleanupJob{
+	&ScheduledCleanupRepository{},
+	backupCleaner,
+	logger.GetLogger(),
+	cleanerTickerInterval,
+	atomic.Bool{},
+}
+
 var backupNodesRegistry = &BackupNodesRegistry{
 	cache_utils.GetValkeyClient(),
 	logger.GetLogger(),
@@ -61,6 +106,7 @@ var backuperNode = &BackuperNode{
 	backupNodesRegistry,
 	logger.GetLogger(),
 	usecases.GetCreateBackupUsecase(),
+	backupMutexRegistry,
 	getNodeID(),
 	time.Time{},
 	sync.Once{},
@@ -73,6 +119,7 @@ var backupsScheduler = &BackupsScheduler{
 	taskCancelManager,
 	backupNodesRegistry,
 	databases.GetDatabaseService(),
+	backups_settings.GetBackupSystemSettingsService(),
 	time.Now().UTC(),
 	logger.GetLogger(),
 	make(map[uuid.UUID]BackupToNodeRelation),
@@ -96,3 +143,15 @@ func GetBackupNodesRegistry() *BackupNodesRegistry {
 func GetBackupCleaner() *BackupCleaner {
 	return backupCleaner
 }
+
+func GetDeletionJobWorker() *DeletionJobWorker {
+	return deletionJobWorker
+}
+
+func GetScheduledCleanupJob() *ScheduledCleanupJob {
+	return scheduledCleanupJob
+}
+
+func GetBackupMutexRegistry() *BackupMutexRegistry {
+	return backupMutexRegistry
+}
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
 )
 
 type BackupToNodeRelation struct {
@@ -32,3 +34,34 @@ type BackupCompletionMessage struct {
 	NodeID   uuid.UUID `json:"nodeId"`
 	BackupID uuid.UUID `json:"backupId"`
 }
+
+type CleanerStats struct {
+	Errors int64 `json:"errors"`
+	// MisconfiguredConfigs counts cleanups skipped because the config has no
+	// usable retention settings
+	MisconfiguredConfigs int64 `json:"misconfiguredConfigs"`
+	// WouldDelete counts backups found for deletion in report-only mode
+	WouldDelete     int64                                 `json:"wouldDelete"`
+	DeletedByReason map[backups_core.DeletionReason]int64 `json:"deletedByReason"`
+}
+
+type CleanerHealthStatus struct {
+	IsRunning bool `json:"isRunning"`
+	// LastTickAt is nil until the first tick completes
+	LastTickAt         *time.Time `json:"lastTickAt"`
+	LastTickDurationMs int64      `json:"lastTickDurationMs"`
+	// LastTickErrorsCount counts failed passes and databases failed within them
+	LastTickErrorsCount int64 `json:"lastTickErrorsCount"`
+}
+
+type RetentionCleanupResult struct {
+	DeletedCount int         `json:"deletedCount"`
+	FreedMB      float64     `json:"freedMb"`
+	BackupIDs    []uuid.UUID `json:"backupIds"`
+	Errors       []string    `json:"errors"`
+}
+
+type SlotFill struct {
+	Filled int `json:"filled"`
+	Total  int `json:"total"`
+}
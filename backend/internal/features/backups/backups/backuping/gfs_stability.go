@@ -0,0 +1,180 @@
+package backuping
+
+import (
+	"slices"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+
+	"github.com/google/uuid"
+)
+
+type GFSSlots struct {
+	Hours  int
+	Days   int
+	Weeks  int
+	Months int
+	Years  int
+}
+
+// AnalyzeGFSStability evaluates the GFS keep-set at each reference time and returns
+// backups that were deletable at some time and kept again at a later one. Such
+// backups would be deleted by the cleaner although retention expects them later,
+// so an empty result means retention is monotonic for the given backups
+func AnalyzeGFSStability(
+	backups []*backups_core.Backup,
+	slots GFSSlots,
+	times []time.Time,
+) map[uuid.UUID]bool {
+	unstableBackups := make(map[uuid.UUID]bool)
+	wasDeletable := make(map[uuid.UUID]bool)
+
+	sortedTimes := slices.Clone(times)
+	slices.SortFunc(sortedTimes, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	for _, referenceTime := range sortedTimes {
+		existingBackups := getBackupsCreatedUntil(backups, referenceTime)
+
+		keepSet := buildGFSKeepSet(
+			existingBackups,
+			slots.Hours,
+			slots.Days,
+			slots.Weeks,
+			slots.Months,
+			slots.Years,
+		)
+
+		for _, backup := range existingBackups {
+			if keepSet[backup.ID] {
+				if wasDeletable[backup.ID] {
+					unstableBackups[backup.ID] = true
+				}
+
+				continue
+			}
+
+			if referenceTime.Sub(backup.CreatedAt) >= recentBackupGracePeriod {
+				wasDeletable[backup.ID] = true
+			}
+		}
+	}
+
+	return unstableBackups
+}
+
+// GFSKeepSetDelta compares GFS keep-sets of the same database before and after
+// new backups arrived. Added are backups kept only after, removed are backups
+// kept before which still exist but are not kept after. A removal is expected
+// only when a slot overflows or a newer backup takes the period of the removed
+// one, any other removal means the keep-set is not monotonic
+func GFSKeepSetDelta(
+	before []*backups_core.Backup,
+	after []*backups_core.Backup,
+	slots GFSSlots,
+) (added []uuid.UUID, removed []uuid.UUID) {
+	beforeKeepSet := buildGFSKeepSetForSlots(getBackupsSortedNewestFirst(before), slots)
+	afterKeepSet := buildGFSKeepSetForSlots(getBackupsSortedNewestFirst(after), slots)
+
+	isInAfter := make(map[uuid.UUID]bool, len(after))
+	for _, backup := range after {
+		isInAfter[backup.ID] = true
+
+		if afterKeepSet[backup.ID] && !beforeKeepSet[backup.ID] {
+			added = append(added, backup.ID)
+		}
+	}
+
+	for _, backup := range before {
+		if beforeKeepSet[backup.ID] && isInAfter[backup.ID] && !afterKeepSet[backup.ID] {
+			removed = append(removed, backup.ID)
+		}
+	}
+
+	return added, removed
+}
+
+func buildGFSKeepSetForSlots(
+	backups []*backups_core.Backup,
+	slots GFSSlots,
+) map[uuid.UUID]bool {
+	return buildGFSKeepSet(backups, slots.Hours, slots.Days, slots.Weeks, slots.Months, slots.Years)
+}
+
+func getBackupsSortedNewestFirst(backups []*backups_core.Backup) []*backups_core.Backup {
+	sortedBackups := slices.Clone(backups)
+	slices.SortFunc(sortedBackups, func(a, b *backups_core.Backup) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return sortedBackups
+}
+
+// getBackupsCreatedUntil returns backups existing at the reference time sorted
+// newest-first, as buildGFSKeepSet expects
+func getBackupsCreatedUntil(
+	backups []*backups_core.Backup,
+	referenceTime time.Time,
+) []*backups_core.Backup {
+	existingBackups := make([]*backups_core.Backup, 0, len(backups))
+	for _, backup := range backups {
+		if !backup.CreatedAt.After(referenceTime) {
+			existingBackups = append(existingBackups, backup)
+		}
+	}
+
+	slices.SortFunc(existingBackups, func(a, b *backups_core.Backup) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return existingBackups
+}
+
+// calculateGFSSlotFills counts distinct periods of each active slot like
+// buildGFSKeepSet does, so Filled is what the cleaner keeps for the slot
+func calculateGFSSlotFills(
+	backups []*backups_core.Backup,
+	slots GFSSlots,
+) map[string]SlotFill {
+	hoursSeen := make(map[int64]bool)
+	daysSeen := make(map[string]bool)
+	weeksSeen := make(map[string]bool)
+	monthsSeen := make(map[string]bool)
+	yearsSeen := make(map[string]bool)
+
+	for _, backup := range backups {
+		hourKey, dayKey, weekKey, monthKey, yearKey := getGFSBucketKeys(backup.CreatedAt)
+
+		hoursSeen[hourKey] = true
+		daysSeen[dayKey] = true
+		weeksSeen[weekKey] = true
+		monthsSeen[monthKey] = true
+		yearsSeen[yearKey] = true
+	}
+
+	fills := make(map[string]SlotFill)
+
+	for _, slot := range []struct {
+		name        string
+		total       int
+		periodsSeen int
+	}{
+		{"hourly", slots.Hours, len(hoursSeen)},
+		{"daily", slots.Days, len(daysSeen)},
+		{"weekly", slots.Weeks, len(weeksSeen)},
+		{"monthly", slots.Months, len(monthsSeen)},
+		{"yearly", slots.Years, len(yearsSeen)},
+	} {
+		if slot.total <= 0 {
+			continue
+		}
+
+		fills[slot.name] = SlotFill{
+			Filled: min(slot.periodsSeen, slot.total),
+			Total:  slot.total,
+		}
+	}
+
+	return fills
+}
@@ -3,6 +3,7 @@ package backuping
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -29,6 +32,15 @@ func (m *MockNotificationSender) SendNotification(
 	m.Called(notifier, title, message)
 }
 
+func (m *MockNotificationSender) DeliverNotification(
+	notifier *notifiers.Notifier,
+	title string,
+	message string,
+) error {
+	args := m.Called(notifier, title, message)
+	return args.Error(0)
+}
+
 type CreateFailedBackupUsecase struct{}
 
 func (uc *CreateFailedBackupUsecase) Execute(
@@ -43,6 +55,31 @@ func (uc *CreateFailedBackupUsecase) Execute(
 	return nil, errors.New("backup failed")
 }
 
+// CreatePartiallyUploadedBackupUsecase uploads a part of the backup file and fails
+type CreatePartiallyUploadedBackupUsecase struct{}
+
+func (uc *CreatePartiallyUploadedBackupUsecase) Execute(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	backupConfig *backups_config.BackupConfig,
+	database *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+) (*common.BackupMetadata, error) {
+	err := storage.SaveFile(
+		ctx,
+		encryption.GetFieldEncryptor(),
+		logger.GetLogger(),
+		backup.FileName,
+		strings.NewReader("partial backup"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, errors.New("backup failed")
+}
+
 type CreateSuccessBackupUsecase struct{}
 
 func (uc *CreateSuccessBackupUsecase) Execute(
@@ -139,6 +176,20 @@ func (uc *CreateMediumBackupUsecase) Execute(
 	}, nil
 }
 
+// CreatePanickingBackupUsecase simulates a crash inside the backup tool wrapper
+type CreatePanickingBackupUsecase struct{}
+
+func (uc *CreatePanickingBackupUsecase) Execute(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	backupConfig *backups_config.BackupConfig,
+	database *databases.Database,
+	storage *storages.Storage,
+	backupProgressListener func(completedMBs float64),
+) (*common.BackupMetadata, error) {
+	panic("backup use case crashed")
+}
+
 // MockTrackingBackupUsecase tracks backup use case calls for testing parallel execution
 type MockTrackingBackupUsecase struct {
 	callCount       atomic.Int32
@@ -0,0 +1,31 @@
+package backuping
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScheduledCleanupStatus string
+
+const (
+	ScheduledCleanupStatusPending   ScheduledCleanupStatus = "PENDING"
+	ScheduledCleanupStatusCompleted ScheduledCleanupStatus = "COMPLETED"
+	ScheduledCleanupStatusFailed    ScheduledCleanupStatus = "FAILED"
+	ScheduledCleanupStatusCancelled ScheduledCleanupStatus = "CANCELLED"
+)
+
+// ScheduledCleanup runs the retention cleanup of a database once at ScheduledAt,
+// e.g. right after a compliance hold expires
+type ScheduledCleanup struct {
+	ID          uuid.UUID              `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	DatabaseID  uuid.UUID              `json:"databaseId"  gorm:"column:database_id;type:uuid;not null"`
+	ScheduledAt time.Time              `json:"scheduledAt" gorm:"column:scheduled_at;type:timestamptz;not null"`
+	CreatedBy   uuid.UUID              `json:"createdBy"   gorm:"column:created_by;type:uuid;not null"`
+	ExecutedAt  *time.Time             `json:"executedAt"  gorm:"column:executed_at;type:timestamptz"`
+	Status      ScheduledCleanupStatus `json:"status"      gorm:"column:status;type:text;not null"`
+}
+
+func (ScheduledCleanup) TableName() string {
+	return "scheduled_cleanups"
+}
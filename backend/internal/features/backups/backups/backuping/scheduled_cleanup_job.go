@@ -0,0 +1,162 @@
+package backuping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrScheduledCleanupNotFound   = errors.New("scheduled cleanup not found")
+	ErrScheduledCleanupNotPending = errors.New("scheduled cleanup is already executed or cancelled")
+	ErrScheduledCleanupInPast     = errors.New("scheduled cleanup time must be in the future")
+)
+
+// ScheduledCleanupJob executes due scheduled cleanups with the same cadence as
+// the cleaner
+type ScheduledCleanupJob struct {
+	scheduledCleanupRepository *ScheduledCleanupRepository
+	backupCleaner              *BackupCleaner
+	logger                     *slog.Logger
+
+	tickerInterval time.Duration
+
+	hasRun atomic.Bool
+}
+
+func (j *ScheduledCleanupJob) Run(ctx context.Context) {
+	if !j.hasRun.CompareAndSwap(false, true) {
+		panic(fmt.Sprintf("%T.Run() called multiple times", j))
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	ticker := time.NewTicker(j.tickerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.executeDueCleanups(ctx, time.Now().UTC()); err != nil {
+				j.logger.Error("Failed to execute scheduled cleanups", "error", err)
+			}
+		}
+	}
+}
+
+func (j *ScheduledCleanupJob) Schedule(
+	databaseID uuid.UUID,
+	at time.Time,
+	createdBy uuid.UUID,
+) (*ScheduledCleanup, error) {
+	if !at.After(time.Now().UTC()) {
+		return nil, ErrScheduledCleanupInPast
+	}
+
+	scheduledCleanup := &ScheduledCleanup{
+		DatabaseID:  databaseID,
+		ScheduledAt: at.UTC(),
+		CreatedBy:   createdBy,
+		Status:      ScheduledCleanupStatusPending,
+	}
+
+	if err := j.scheduledCleanupRepository.Save(scheduledCleanup); err != nil {
+		return nil, err
+	}
+
+	return scheduledCleanup, nil
+}
+
+// Cancel cancels a pending cleanup. databaseID guards against cancelling a
+// cleanup of another database by its ID
+func (j *ScheduledCleanupJob) Cancel(databaseID, scheduledCleanupID uuid.UUID) error {
+	scheduledCleanup, err := j.scheduledCleanupRepository.FindByID(scheduledCleanupID)
+	if err != nil {
+		return err
+	}
+
+	if scheduledCleanup == nil || scheduledCleanup.DatabaseID != databaseID {
+		return ErrScheduledCleanupNotFound
+	}
+
+	if scheduledCleanup.Status != ScheduledCleanupStatusPending {
+		return ErrScheduledCleanupNotPending
+	}
+
+	scheduledCleanup.Status = ScheduledCleanupStatusCancelled
+
+	return j.scheduledCleanupRepository.Save(scheduledCleanup)
+}
+
+func (j *ScheduledCleanupJob) executeDueCleanups(ctx context.Context, now time.Time) error {
+	dueCleanups, err := j.scheduledCleanupRepository.FindDue(now)
+	if err != nil {
+		return err
+	}
+
+	for _, scheduledCleanup := range dueCleanups {
+		// cleanups not started before shutdown stay pending for the next run
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := j.executeCleanup(ctx, scheduledCleanup); err != nil {
+			j.logger.Error(
+				"Failed to save scheduled cleanup",
+				"scheduledCleanupId", scheduledCleanup.ID,
+				"databaseId", scheduledCleanup.DatabaseID,
+				"error", err,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (j *ScheduledCleanupJob) executeCleanup(
+	ctx context.Context,
+	scheduledCleanup *ScheduledCleanup,
+) error {
+	result, cleanupErr := j.backupCleaner.ForceRetentionCleanup(
+		ctx,
+		scheduledCleanup.DatabaseID,
+		false,
+	)
+	if cleanupErr == nil && len(result.Errors) > 0 {
+		cleanupErr = errors.New(strings.Join(result.Errors, "; "))
+	}
+
+	executedAt := time.Now().UTC()
+	scheduledCleanup.ExecutedAt = &executedAt
+	scheduledCleanup.Status = ScheduledCleanupStatusCompleted
+
+	if cleanupErr != nil {
+		scheduledCleanup.Status = ScheduledCleanupStatusFailed
+
+		j.logger.Error(
+			"Scheduled cleanup failed",
+			"scheduledCleanupId", scheduledCleanup.ID,
+			"databaseId", scheduledCleanup.DatabaseID,
+			"error", cleanupErr,
+		)
+	} else {
+		j.logger.Info(
+			"Scheduled cleanup executed",
+			"scheduledCleanupId", scheduledCleanup.ID,
+			"databaseId", scheduledCleanup.DatabaseID,
+			"deletedCount", result.DeletedCount,
+		)
+	}
+
+	return j.scheduledCleanupRepository.Save(scheduledCleanup)
+}
@@ -0,0 +1,105 @@
+package backuping
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/notifiers"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExecuteDueCleanups_WithDueAndFutureCleanups_OnlyDueCleanupExecuted(t *testing.T) {
+	router := CreateTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		notifiers.RemoveTestNotifier(notifier)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	interval := createTestInterval()
+
+	backupConfig := &backups_config.BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+		RetentionCount:      2,
+		StorageID:           &storage.ID,
+		BackupIntervalID:    interval.ID,
+		BackupInterval:      interval,
+	}
+	_, err := backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	for i := 0; i < 5; i++ {
+		err := backupRepository.Save(&backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    now.Add(-time.Duration(i+1) * time.Hour),
+		})
+		assert.NoError(t, err)
+	}
+
+	job := GetScheduledCleanupJob()
+	repository := &ScheduledCleanupRepository{}
+
+	dueCleanup := &ScheduledCleanup{
+		DatabaseID:  database.ID,
+		ScheduledAt: now.Add(-time.Minute),
+		CreatedBy:   uuid.New(),
+		Status:      ScheduledCleanupStatusPending,
+	}
+	assert.NoError(t, repository.Save(dueCleanup))
+
+	futureCleanup, err := job.Schedule(database.ID, now.Add(time.Hour), uuid.New())
+	assert.NoError(t, err)
+
+	err = job.executeDueCleanups(context.Background(), now)
+	assert.NoError(t, err)
+
+	executedCleanup, err := repository.FindByID(dueCleanup.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ScheduledCleanupStatusCompleted, executedCleanup.Status)
+	assert.NotNil(t, executedCleanup.ExecutedAt)
+
+	pendingCleanup, err := repository.FindByID(futureCleanup.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ScheduledCleanupStatusPending, pendingCleanup.Status)
+	assert.Nil(t, pendingCleanup.ExecutedAt)
+
+	remainingBackups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 2)
+
+	err = job.Cancel(database.ID, futureCleanup.ID)
+	assert.NoError(t, err)
+
+	err = job.Cancel(database.ID, dueCleanup.ID)
+	assert.ErrorIs(t, err, ErrScheduledCleanupNotPending)
+}
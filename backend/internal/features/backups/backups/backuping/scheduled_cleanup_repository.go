@@ -0,0 +1,54 @@
+package backuping
+
+import (
+	"databasus-backend/internal/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ScheduledCleanupRepository struct{}
+
+func (r *ScheduledCleanupRepository) Save(scheduledCleanup *ScheduledCleanup) error {
+	db := storage.GetDb()
+
+	if scheduledCleanup.ID == uuid.Nil {
+		scheduledCleanup.ID = uuid.New()
+
+		return db.Create(scheduledCleanup).Error
+	}
+
+	return db.Save(scheduledCleanup).Error
+}
+
+func (r *ScheduledCleanupRepository) FindByID(id uuid.UUID) (*ScheduledCleanup, error) {
+	var scheduledCleanup ScheduledCleanup
+
+	if err := storage.
+		GetDb().
+		Where("id = ?", id).
+		First(&scheduledCleanup).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &scheduledCleanup, nil
+}
+
+func (r *ScheduledCleanupRepository) FindDue(now time.Time) ([]*ScheduledCleanup, error) {
+	var scheduledCleanups []*ScheduledCleanup
+
+	if err := storage.
+		GetDb().
+		Where("status = ? AND scheduled_at <= ?", ScheduledCleanupStatusPending, now).
+		Order("scheduled_at ASC").
+		Find(&scheduledCleanups).Error; err != nil {
+		return nil, err
+	}
+
+	return scheduledCleanups, nil
+}
@@ -13,6 +13,7 @@ import (
 	"databasus-backend/internal/config"
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_config "databasus-backend/internal/features/backups/config"
+	backups_settings "databasus-backend/internal/features/backups/settings"
 	"databasus-backend/internal/features/databases"
 	task_cancellation "databasus-backend/internal/features/tasks/cancellation"
 	files_utils "databasus-backend/internal/util/files"
@@ -31,6 +32,8 @@ type BackupsScheduler struct {
 	backupNodesRegistry *BackupNodesRegistry
 	databaseService     *databases.DatabaseService
 
+	backupSystemSettingsService *backups_settings.BackupSystemSettingsService
+
 	lastBackupTime time.Time
 	logger         *slog.Logger
 
@@ -87,7 +90,7 @@ func (s *BackupsScheduler) Run(ctx context.Context) {
 					s.logger.Error("Failed to check dead nodes and fail backups", "error", err)
 				}
 
-				if err := s.runPendingBackups(); err != nil {
+				if err := s.runPendingBackups(ctx); err != nil {
 					s.logger.Error("Failed to run pending backups", "error", err)
 				}
 
@@ -117,139 +120,23 @@ func (s *BackupsScheduler) IsBackupNodesAvailable() bool {
 }
 
 func (s *BackupsScheduler) StartBackup(database *databases.Database, isCallNotifier bool) {
-	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(database.ID)
-	if err != nil {
-		s.logger.Error("Failed to get backup config by database ID", "error", err)
-		return
-	}
-
-	if backupConfig.StorageID == nil {
-		s.logger.Error("Backup config storage ID is nil", "databaseId", database.ID)
-		return
-	}
-
-	// Check for existing in-progress backups
-	inProgressBackups, err := s.backupRepository.FindByDatabaseIdAndStatus(
-		database.ID,
-		backups_core.BackupStatusInProgress,
-	)
-	if err != nil {
-		s.logger.Error(
-			"Failed to check for in-progress backups",
-			"databaseId",
-			database.ID,
-			"error",
-			err,
-		)
-		return
-	}
-
-	if len(inProgressBackups) > 0 {
-		s.logger.Warn(
-			"Backup already in progress for database, skipping new backup",
-			"databaseId",
-			database.ID,
-			"existingBackupId",
-			inProgressBackups[0].ID,
-		)
-		return
-	}
-
-	leastBusyNodeID, err := s.calculateLeastBusyNode()
-	if err != nil {
-		s.logger.Error(
-			"Failed to calculate least busy node",
-			"databaseId",
-			backupConfig.DatabaseID,
-			"error",
-			err,
-		)
-		return
-	}
-
-	backupID := uuid.New()
-	timestamp := time.Now().UTC()
-
-	backup := &backups_core.Backup{
-		ID: backupID,
-		FileName: fmt.Sprintf(
-			"%s-%s-%s",
-			files_utils.SanitizeFilename(database.Name),
-			timestamp.Format("20060102-150405"),
-			backupID.String(),
-		),
-		DatabaseID:   backupConfig.DatabaseID,
-		StorageID:    *backupConfig.StorageID,
-		Status:       backups_core.BackupStatusInProgress,
-		BackupSizeMb: 0,
-		CreatedAt:    timestamp,
-	}
-
-	if err := s.backupRepository.Save(backup); err != nil {
-		s.logger.Error(
-			"Failed to save backup",
-			"databaseId",
-			backupConfig.DatabaseID,
-			"error",
-			err,
-		)
-		return
-	}
-
-	if err := s.backupNodesRegistry.IncrementBackupsInProgress(*leastBusyNodeID); err != nil {
-		s.logger.Error(
-			"Failed to increment backups in progress",
-			"nodeId",
-			leastBusyNodeID,
-			"backupId",
-			backup.ID,
-			"error",
-			err,
-		)
-		return
-	}
+	s.startBackup(database, isCallNotifier, nil, "")
+}
 
-	if err := s.backupNodesRegistry.AssignBackupToNode(*leastBusyNodeID, backup.ID, isCallNotifier); err != nil {
-		s.logger.Error(
-			"Failed to submit backup",
-			"nodeId",
-			leastBusyNodeID,
-			"backupId",
-			backup.ID,
-			"error",
-			err,
-		)
-		if decrementErr := s.backupNodesRegistry.DecrementBackupsInProgress(*leastBusyNodeID); decrementErr != nil {
-			s.logger.Error(
-				"Failed to decrement backups in progress after submit failure",
-				"nodeId",
-				leastBusyNodeID,
-				"error",
-				decrementErr,
-			)
-		}
-		return
-	}
+// StartLabeledBackup starts a backup of the database with the retention label
+func (s *BackupsScheduler) StartLabeledBackup(database *databases.Database, retentionLabel string) {
+	s.startBackup(database, true, nil, retentionLabel)
+}
 
-	if relation, exists := s.backupToNodeRelations[*leastBusyNodeID]; exists {
-		relation.BackupsIDs = append(relation.BackupsIDs, backup.ID)
-		s.backupToNodeRelations[*leastBusyNodeID] = relation
-	} else {
-		s.backupToNodeRelations[*leastBusyNodeID] = BackupToNodeRelation{
-			*leastBusyNodeID,
-			[]uuid.UUID{backup.ID},
-		}
-	}
+// StartGroupBackup starts a backup of the database as a member of the backup group
+func (s *BackupsScheduler) StartGroupBackup(database *databases.Database, groupID uuid.UUID) {
+	s.startBackup(database, true, &groupID, "")
+}
 
-	s.logger.Info(
-		"Successfully triggered scheduled backup",
-		"databaseId",
-		backupConfig.DatabaseID,
-		"backupId",
-		backup.ID,
-		"nodeId",
-		leastBusyNodeID,
-	)
+// CheckBackupCreationAllowed returns an error while backup creation is paused
+// system-wide, so callers can report it instead of silently skipping backups
+func (s *BackupsScheduler) CheckBackupCreationAllowed() error {
+	return s.backupSystemSettingsService.CheckBackupCreationAllowed()
 }
 
 // GetRemainedBackupTryCount returns the number of remaining backup tries for a given backup.
@@ -301,18 +188,27 @@ func (s *BackupsScheduler) GetRemainedBackupTryCount(lastBackup *backups_core.Ba
 	return maxFailedTriesCount - len(lastFailedBackups)
 }
 
-func (s *BackupsScheduler) runPendingBackups() error {
-	enabledBackupConfigs, err := s.backupConfigService.GetBackupConfigsWithEnabledBackups()
+// runPendingBackups starts backups of databases which are due since their last
+// completed backup. The newest backup of any status is checked again, so a
+// failed backup is repeated only by the retry policy and not on every tick
+func (s *BackupsScheduler) runPendingBackups(ctx context.Context) error {
+	if err := s.CheckBackupCreationAllowed(); err != nil {
+		s.logger.Warn("Skipping pending backups", "error", err)
+		return nil
+	}
+
+	backupTriggers, err := s.backupConfigService.ListDatabasesNeedingBackup(ctx)
 	if err != nil {
 		return err
 	}
 
-	for _, backupConfig := range enabledBackupConfigs {
-		if backupConfig.BackupInterval == nil {
+	for _, backupTrigger := range backupTriggers {
+		backupConfig := backupTrigger.Config
+		if backupConfig.IsReadOnlyMode {
 			continue
 		}
 
-		lastBackup, err := s.backupRepository.FindLastByDatabaseID(backupConfig.DatabaseID)
+		lastBackup, err := s.backupRepository.FindNewestByDatabaseID(backupConfig.DatabaseID)
 		if err != nil {
 			s.logger.Error(
 				"Failed to get last backup for database",
@@ -329,10 +225,13 @@ func (s *BackupsScheduler) runPendingBackups() error {
 			lastBackupTime = &lastBackup.CreatedAt
 		}
 
+		now := time.Now().UTC()
 		remainedBackupTryCount := s.GetRemainedBackupTryCount(lastBackup)
 
-		if backupConfig.BackupInterval.ShouldTriggerBackup(time.Now().UTC(), lastBackupTime) ||
-			remainedBackupTryCount > 0 {
+		isRetryDue := remainedBackupTryCount > 0 &&
+			isRetryDelayElapsed(backupConfig, lastBackup, remainedBackupTryCount, now)
+
+		if backupConfig.BackupInterval.ShouldTriggerBackup(now, lastBackupTime) || isRetryDue {
 			s.logger.Info(
 				"Triggering scheduled backup",
 				"databaseId",
@@ -381,7 +280,8 @@ func (s *BackupsScheduler) failBackupsInProgress() error {
 		failMessage := "Backup failed due to application restart"
 		backup.FailMessage = &failMessage
 		backup.Status = backups_core.BackupStatusFailed
-		backup.BackupSizeMb = 0
+		backup.SetSizeBytes(0)
+		s.backuperNode.removeFailedBackupFile(backupConfig, backup)
 
 		s.backuperNode.SendBackupNotification(
 			backupConfig,
@@ -549,7 +449,22 @@ func (s *BackupsScheduler) checkDeadNodesAndFailBackups() error {
 			failMessage := "Backup failed due to node unavailability"
 			backup.FailMessage = &failMessage
 			backup.Status = backups_core.BackupStatusFailed
-			backup.BackupSizeMb = 0
+			backup.SetSizeBytes(0)
+
+			backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(backup.DatabaseID)
+			if err != nil {
+				s.logger.Error(
+					"Failed to get backup config for dead node backup",
+					"nodeId",
+					nodeID,
+					"backupId",
+					backupID,
+					"error",
+					err,
+				)
+			} else {
+				s.backuperNode.removeFailedBackupFile(backupConfig, backup)
+			}
 
 			if err := s.backupRepository.Save(backup); err != nil {
 				s.logger.Error(
@@ -590,3 +505,178 @@ func (s *BackupsScheduler) checkDeadNodesAndFailBackups() error {
 
 	return nil
 }
+
+func (s *BackupsScheduler) startBackup(
+	database *databases.Database,
+	isCallNotifier bool,
+	groupID *uuid.UUID,
+	retentionLabel string,
+) {
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(database.ID)
+	if err != nil {
+		s.logger.Error("Failed to get backup config by database ID", "error", err)
+		return
+	}
+
+	if backupConfig.StorageID == nil {
+		s.logger.Error("Backup config storage ID is nil", "databaseId", database.ID)
+		return
+	}
+
+	if backupConfig.IsReadOnlyMode {
+		s.logger.Warn("Database is in read-only mode, skipping backup", "databaseId", database.ID)
+		return
+	}
+
+	if err := s.CheckBackupCreationAllowed(); err != nil {
+		s.logger.Warn("Skipping backup", "databaseId", database.ID, "error", err)
+		return
+	}
+
+	// Check for existing in-progress backups
+	inProgressBackups, err := s.backupRepository.FindByDatabaseIdAndStatus(
+		context.Background(),
+		database.ID,
+		backups_core.BackupStatusInProgress,
+	)
+	if err != nil {
+		s.logger.Error(
+			"Failed to check for in-progress backups",
+			"databaseId",
+			database.ID,
+			"error",
+			err,
+		)
+		return
+	}
+
+	if len(inProgressBackups) > 0 {
+		s.logger.Warn(
+			"Backup already in progress for database, skipping new backup",
+			"databaseId",
+			database.ID,
+			"existingBackupId",
+			inProgressBackups[0].ID,
+		)
+		return
+	}
+
+	leastBusyNodeID, err := s.calculateLeastBusyNode()
+	if err != nil {
+		s.logger.Error(
+			"Failed to calculate least busy node",
+			"databaseId",
+			backupConfig.DatabaseID,
+			"error",
+			err,
+		)
+		return
+	}
+
+	backupID := uuid.New()
+	timestamp := time.Now().UTC()
+
+	backup := &backups_core.Backup{
+		ID: backupID,
+		FileName: fmt.Sprintf(
+			"%s-%s-%s",
+			files_utils.SanitizeFilename(database.Name),
+			timestamp.Format("20060102-150405"),
+			backupID.String(),
+		),
+		DatabaseID:     backupConfig.DatabaseID,
+		StorageID:      *backupConfig.StorageID,
+		GroupID:        groupID,
+		RetentionLabel: retentionLabel,
+		Status:         backups_core.BackupStatusInProgress,
+		BackupSizeMb:   0,
+		StorageClass:   backupConfig.StorageClass,
+		CreatedAt:      timestamp,
+	}
+
+	if backupConfig.Encryption == backups_config.BackupEncryptionEncrypted {
+		backup.EncryptionKeyID = backupConfig.EncryptionKeyID
+	}
+
+	if err := s.backupRepository.Save(backup); err != nil {
+		s.logger.Error(
+			"Failed to save backup",
+			"databaseId",
+			backupConfig.DatabaseID,
+			"error",
+			err,
+		)
+		return
+	}
+
+	if err := s.backupNodesRegistry.IncrementBackupsInProgress(*leastBusyNodeID); err != nil {
+		s.logger.Error(
+			"Failed to increment backups in progress",
+			"nodeId",
+			leastBusyNodeID,
+			"backupId",
+			backup.ID,
+			"error",
+			err,
+		)
+		return
+	}
+
+	if err := s.backupNodesRegistry.AssignBackupToNode(*leastBusyNodeID, backup.ID, isCallNotifier); err != nil {
+		s.logger.Error(
+			"Failed to submit backup",
+			"nodeId",
+			leastBusyNodeID,
+			"backupId",
+			backup.ID,
+			"error",
+			err,
+		)
+		if decrementErr := s.backupNodesRegistry.DecrementBackupsInProgress(*leastBusyNodeID); decrementErr != nil {
+			s.logger.Error(
+				"Failed to decrement backups in progress after submit failure",
+				"nodeId",
+				leastBusyNodeID,
+				"error",
+				decrementErr,
+			)
+		}
+		return
+	}
+
+	if relation, exists := s.backupToNodeRelations[*leastBusyNodeID]; exists {
+		relation.BackupsIDs = append(relation.BackupsIDs, backup.ID)
+		s.backupToNodeRelations[*leastBusyNodeID] = relation
+	} else {
+		s.backupToNodeRelations[*leastBusyNodeID] = BackupToNodeRelation{
+			*leastBusyNodeID,
+			[]uuid.UUID{backup.ID},
+		}
+	}
+
+	s.logger.Info(
+		"Successfully triggered scheduled backup",
+		"databaseId",
+		backupConfig.DatabaseID,
+		"backupId",
+		backup.ID,
+		"nodeId",
+		leastBusyNodeID,
+	)
+}
+
+func isRetryDelayElapsed(
+	backupConfig *backups_config.BackupConfig,
+	failedBackup *backups_core.Backup,
+	remainedBackupTryCount int,
+	now time.Time,
+) bool {
+	failedTriesCount := backupConfig.MaxFailedTriesCount - remainedBackupTryCount
+	retryDelay := backupConfig.GetRetryDelay(failedTriesCount)
+
+	failedAt := failedBackup.CreatedAt.Add(
+		time.Duration(failedBackup.BackupDurationMs) * time.Millisecond,
+	)
+
+	return !now.Before(failedAt.Add(retryDelay))
+}
@@ -1,8 +1,10 @@
 package backuping
 
 import (
+	"context"
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_config "databasus-backend/internal/features/backups/config"
+	backups_settings "databasus-backend/internal/features/backups/settings"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/intervals"
 	"databasus-backend/internal/features/notifiers"
@@ -11,7 +13,10 @@ import (
 	users_testing "databasus-backend/internal/features/users/testing"
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
 	cache_utils "databasus-backend/internal/util/cache"
+	"databasus-backend/internal/util/encryption"
+	"databasus-backend/internal/util/logger"
 	"databasus-backend/internal/util/period"
+	"strings"
 	"testing"
 	"time"
 
@@ -75,7 +80,7 @@ func Test_RunPendingBackups_WhenLastBackupWasYesterday_CreatesNewBackup(t *testi
 		CreatedAt: time.Now().UTC().Add(-24 * time.Hour),
 	})
 
-	GetBackupsScheduler().runPendingBackups()
+	GetBackupsScheduler().runPendingBackups(context.Background())
 
 	// Wait for backup to complete (runs in goroutine)
 	WaitForBackupCompletion(t, database.ID, 1, 10*time.Second)
@@ -145,7 +150,7 @@ func Test_RunPendingBackups_WhenLastBackupWasRecentlyCompleted_SkipsBackup(t *te
 		CreatedAt: time.Now().UTC().Add(-1 * time.Hour),
 	})
 
-	GetBackupsScheduler().runPendingBackups()
+	GetBackupsScheduler().runPendingBackups(context.Background())
 
 	time.Sleep(100 * time.Millisecond)
 
@@ -218,7 +223,7 @@ func Test_RunPendingBackups_WhenLastBackupFailedAndRetriesDisabled_SkipsBackup(t
 		CreatedAt: time.Now().UTC().Add(-1 * time.Hour),
 	})
 
-	GetBackupsScheduler().runPendingBackups()
+	GetBackupsScheduler().runPendingBackups(context.Background())
 
 	time.Sleep(100 * time.Millisecond)
 
@@ -291,7 +296,7 @@ func Test_RunPendingBackups_WhenLastBackupFailedAndRetriesEnabled_CreatesNewBack
 		CreatedAt: time.Now().UTC().Add(-1 * time.Hour),
 	})
 
-	GetBackupsScheduler().runPendingBackups()
+	GetBackupsScheduler().runPendingBackups(context.Background())
 
 	// Wait for backup to complete (runs in goroutine)
 	WaitForBackupCompletion(t, database.ID, 1, 10*time.Second)
@@ -367,7 +372,7 @@ func Test_RunPendingBackups_WhenFailedBackupsExceedMaxRetries_SkipsBackup(t *tes
 		})
 	}
 
-	GetBackupsScheduler().runPendingBackups()
+	GetBackupsScheduler().runPendingBackups(context.Background())
 
 	time.Sleep(100 * time.Millisecond)
 
@@ -433,7 +438,7 @@ func Test_RunPendingBackups_WhenBackupsDisabled_SkipsBackup(t *testing.T) {
 		CreatedAt: time.Now().UTC().Add(-24 * time.Hour),
 	})
 
-	GetBackupsScheduler().runPendingBackups()
+	GetBackupsScheduler().runPendingBackups(context.Background())
 
 	time.Sleep(100 * time.Millisecond)
 
@@ -445,6 +450,74 @@ func Test_RunPendingBackups_WhenBackupsDisabled_SkipsBackup(t *testing.T) {
 	time.Sleep(200 * time.Millisecond)
 }
 
+func Test_RunPendingBackups_WhenBackupCreationPausedGlobally_SkipsBackup(t *testing.T) {
+	cache_utils.ClearAllCache()
+	backuperNode := CreateTestBackuperNode()
+	cancel := StartBackuperNodeForTest(t, backuperNode)
+	defer StopBackuperNodeForTest(t, cancel, backuperNode)
+
+	user := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+	router := CreateTestRouter()
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", user, router)
+	storage := storages.CreateTestStorage(workspace.ID)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+	database := databases.CreateTestDatabase(workspace.ID, storage, notifier)
+
+	settingsService := backups_settings.GetBackupSystemSettingsService()
+	err := settingsService.PauseAll("storage incident", user.UserID)
+	assert.NoError(t, err)
+
+	defer func() {
+		_ = settingsService.ResumeAll(user.UserID)
+
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		notifiers.RemoveTestNotifier(notifier)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupConfig, err := backups_config.GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	timeOfDay := "04:00"
+	backupConfig.BackupInterval = &intervals.Interval{
+		Interval:  intervals.IntervalDaily,
+		TimeOfDay: &timeOfDay,
+	}
+	backupConfig.IsBackupsEnabled = true
+	backupConfig.RetentionPolicyType = backups_config.RetentionPolicyTypeTimePeriod
+	backupConfig.RetentionTimePeriod = period.PeriodWeek
+	backupConfig.Storage = storage
+	backupConfig.StorageID = &storage.ID
+
+	_, err = backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	// add old backup that would trigger new backup if not paused
+	backupRepository.Save(&backups_core.Backup{
+		DatabaseID: database.ID,
+		StorageID:  storage.ID,
+
+		Status: backups_core.BackupStatusCompleted,
+
+		CreatedAt: time.Now().UTC().Add(-24 * time.Hour),
+	})
+
+	GetBackupsScheduler().runPendingBackups(context.Background())
+
+	time.Sleep(100 * time.Millisecond)
+
+	backups, err := backupRepository.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
 func Test_CheckDeadNodesAndFailBackups_WhenNodeDies_FailsBackupAndCleansUpRegistry(t *testing.T) {
 	cache_utils.ClearAllCache()
 
@@ -489,6 +562,7 @@ func Test_CheckDeadNodesAndFailBackups_WhenNodeDies_FailsBackupAndCleansUpRegist
 	backupConfig.RetentionTimePeriod = period.PeriodWeek
 	backupConfig.Storage = storage
 	backupConfig.StorageID = &storage.ID
+	backupConfig.ShouldDeleteFailedBackupFiles = true
 
 	_, err = backups_config.GetBackupConfigService().SaveBackupConfig(backupConfig)
 	assert.NoError(t, err)
@@ -520,6 +594,21 @@ func Test_CheckDeadNodesAndFailBackups_WhenNodeDies_FailsBackupAndCleansUpRegist
 	}
 	assert.True(t, foundStat, "Node stats should be present")
 
+	// the node died after uploading the backup and its metadata
+	fileName := backups[0].ID.String()
+	backups[0].FileName = fileName
+	assert.NoError(t, backupRepository.Save(backups[0]))
+	for _, name := range []string{fileName, fileName + ".metadata"} {
+		err = storage.SaveFile(
+			context.Background(),
+			encryption.GetFieldEncryptor(),
+			logger.GetLogger(),
+			name,
+			strings.NewReader("partial upload"),
+		)
+		assert.NoError(t, err)
+	}
+
 	// Simulate node death by setting heartbeat older than 2-minute threshold
 	oldHeartbeat := time.Now().UTC().Add(-3 * time.Minute)
 	err = UpdateNodeHeartbeatDirectly(mockNodeID, 100, oldHeartbeat)
@@ -536,6 +625,13 @@ func Test_CheckDeadNodesAndFailBackups_WhenNodeDies_FailsBackupAndCleansUpRegist
 	assert.Equal(t, backups_core.BackupStatusFailed, backups[0].Status)
 	assert.NotNil(t, backups[0].FailMessage)
 	assert.Contains(t, *backups[0].FailMessage, "node unavailability")
+	assert.Empty(t, backups[0].FileName)
+	assert.True(t, backups[0].IsFailedFileDeleted)
+
+	for _, name := range []string{fileName, fileName + ".metadata"} {
+		_, err = storage.GetFile(encryption.GetFieldEncryptor(), name)
+		assert.Error(t, err)
+	}
 
 	// Verify Valkey counter was decremented after backup failed
 	stats, err = backupNodesRegistry.GetBackupNodesStats()
@@ -1176,7 +1272,7 @@ func Test_RunPendingBackups_WhenLastBackupFailedWithIsSkipRetry_SkipsBackupEvenW
 	})
 
 	// Verify GetRemainedBackupTryCount returns 0 even though retries are enabled
-	lastBackup, err := backupRepository.FindLastByDatabaseID(database.ID)
+	lastBackup, err := backupRepository.FindNewestByDatabaseID(database.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, lastBackup)
 
@@ -1184,7 +1280,7 @@ func Test_RunPendingBackups_WhenLastBackupFailedWithIsSkipRetry_SkipsBackupEvenW
 	assert.Equal(t, 0, remainedTries, "Should return 0 tries when IsSkipRetry is true")
 
 	// Run the scheduler
-	GetBackupsScheduler().runPendingBackups()
+	GetBackupsScheduler().runPendingBackups(context.Background())
 
 	time.Sleep(100 * time.Millisecond)
 
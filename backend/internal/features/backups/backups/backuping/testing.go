@@ -3,6 +3,7 @@ package backuping
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	"databasus-backend/internal/features/backups/backups/usecases"
 	backups_config "databasus-backend/internal/features/backups/config"
+	backups_settings "databasus-backend/internal/features/backups/settings"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
@@ -48,6 +50,7 @@ func CreateTestBackuperNode() *BackuperNode {
 		backupNodesRegistry: backupNodesRegistry,
 		logger:              logger.GetLogger(),
 		createBackupUseCase: usecases.GetCreateBackupUsecase(),
+		backupMutexRegistry: backupMutexRegistry,
 		nodeID:              uuid.New(),
 		lastHeartbeat:       time.Time{},
 		runOnce:             sync.Once{},
@@ -68,6 +71,7 @@ func CreateTestBackuperNodeWithUseCase(useCase backups_core.CreateBackupUsecase)
 		backupNodesRegistry: backupNodesRegistry,
 		logger:              logger.GetLogger(),
 		createBackupUseCase: useCase,
+		backupMutexRegistry: backupMutexRegistry,
 		nodeID:              uuid.New(),
 		lastHeartbeat:       time.Time{},
 		runOnce:             sync.Once{},
@@ -87,7 +91,66 @@ func CreateTestScheduler() *BackupsScheduler {
 		backuperNode:          CreateTestBackuperNode(),
 		runOnce:               sync.Once{},
 		hasRun:                atomic.Bool{},
+
+		backupSystemSettingsService: backups_settings.GetBackupSystemSettingsService(),
+	}
+}
+
+// TestBackupCleanerOptions replaces dependencies of the cleaner built by
+// CreateTestBackupCleaner, zero fields keep the production defaults
+type TestBackupCleanerOptions struct {
+	Logger              *slog.Logger
+	BackupConfigService *backups_config.BackupConfigService
+	Listeners           []backups_core.BackupRemoveListener
+	Tracer              backups_core.Tracer
+	NotificationSender  backups_core.NotificationSender
+	TickerInterval      time.Duration
+	IsReportOnly        bool
+}
+
+func CreateTestBackupCleaner(opts TestBackupCleanerOptions) *BackupCleaner {
+	cleaner := &BackupCleaner{
+		backupRepository:      backupRepository,
+		storageService:        storages.GetStorageService(),
+		backupConfigService:   backups_config.GetBackupConfigService(),
+		fieldEncryptor:        encryption.GetFieldEncryptor(),
+		logger:                logger.GetLogger(),
+		backupRemoveListeners: []backups_core.BackupRemoveListener{},
+		backupMutexRegistry:   backupMutexRegistry,
+		usageRecorder:         func(databaseID uuid.UUID, totalSizeMB float64) {},
+		tracer:                noopTracer{},
+		databaseService:       databases.GetDatabaseService(),
+		notificationSender:    notifiers.GetNotifierService(),
+		lastBackupAlertCache:  lastBackupAlertCache,
+		tickerInterval:        cleanerTickerInterval,
+		isReportOnly:          opts.IsReportOnly,
+	}
+
+	if opts.Logger != nil {
+		cleaner.logger = opts.Logger
 	}
+
+	if opts.BackupConfigService != nil {
+		cleaner.backupConfigService = opts.BackupConfigService
+	}
+
+	if opts.Listeners != nil {
+		cleaner.backupRemoveListeners = opts.Listeners
+	}
+
+	if opts.Tracer != nil {
+		cleaner.tracer = opts.Tracer
+	}
+
+	if opts.NotificationSender != nil {
+		cleaner.notificationSender = opts.NotificationSender
+	}
+
+	if opts.TickerInterval > 0 {
+		cleaner.tickerInterval = opts.TickerInterval
+	}
+
+	return cleaner
 }
 
 // WaitForBackupCompletion waits for a new backup to be created and completed (or failed)
@@ -0,0 +1,42 @@
+package backuping
+
+import (
+	"context"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+)
+
+const (
+	cleanDatabaseSpanName = "backup_cleaner.clean_database"
+	deleteBackupSpanName  = "backup_cleaner.delete_backup"
+
+	spanAttributeDatabaseID     = "database.id"
+	spanAttributeBackupID       = "backup.id"
+	spanAttributePolicy         = "retention.policy"
+	spanAttributeDeletionReason = "deletion.reason"
+	spanAttributeDeletedCount   = "cleanup.deleted_count"
+	spanAttributeFreedMB        = "cleanup.freed_mb"
+)
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string) (context.Context, backups_core.Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(_ string, _ any) {}
+
+func (noopSpan) RecordError(_ error) {}
+
+func (noopSpan) End() {}
+
+// cleanupTally sums deletions of a single database cleanup, DeleteBackup finds
+// it in the context because the clean passes do not report what they deleted
+type cleanupTally struct {
+	deletedCount int
+	freedMB      float64
+}
+
+type cleanupTallyKey struct{}
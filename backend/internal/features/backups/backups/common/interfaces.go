@@ -1,6 +1,9 @@
 package common
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 type CountingWriter struct {
 	Writer       io.Writer
@@ -20,3 +23,20 @@ func (cw *CountingWriter) GetBytesWritten() int64 {
 func NewCountingWriter(writer io.Writer) *CountingWriter {
 	return &CountingWriter{Writer: writer}
 }
+
+// TimingWriter accumulates time spent in Write of the wrapped writer
+type TimingWriter struct {
+	Writer   io.Writer
+	Duration time.Duration
+}
+
+func (tw *TimingWriter) Write(p []byte) (n int, err error) {
+	start := time.Now()
+	n, err = tw.Writer.Write(p)
+	tw.Duration += time.Since(start)
+	return n, err
+}
+
+func NewTimingWriter(writer io.Writer) *TimingWriter {
+	return &TimingWriter{Writer: writer}
+}
@@ -5,6 +5,7 @@ import (
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	backups_download "databasus-backend/internal/features/backups/backups/download"
 	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
 	users_middleware "databasus-backend/internal/features/users/middleware"
 	files_utils "databasus-backend/internal/util/files"
 	"fmt"
@@ -25,7 +26,29 @@ func (c *BackupController) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/backups", c.MakeBackup)
 	router.POST("/backups/:id/download-token", c.GenerateDownloadToken)
 	router.DELETE("/backups/:id", c.DeleteBackup)
+	router.GET("/backups/:id/deletion-impact", c.GetBackupDeletionImpact)
 	router.POST("/backups/:id/cancel", c.CancelBackup)
+	router.GET("/workspaces/:id/retention-policies", c.ListRetentionPolicies)
+	router.GET("/workspaces/:id/storage-usage", c.GetStorageUsageSummary)
+	router.POST("/databases/:id/backup-retention/cleanup", c.ForceRetentionCleanup)
+	router.POST("/databases/:id/backup-cleanup/schedule", c.ScheduleCleanup)
+	router.DELETE(
+		"/databases/:id/backup-cleanup/schedule/:scheduleId",
+		c.CancelScheduledCleanup,
+	)
+	router.POST("/databases/:id/backups/storage-class-migration", c.MigrateBackupsStorageClass)
+	router.GET("/migration-jobs/:id/status", c.GetMigrationJobStatus)
+	router.POST("/databases/:id/backups/deletion-jobs", c.CreateDeletionJob)
+	router.GET("/deletion-jobs/:id/status", c.GetDeletionJobStatus)
+	router.GET("/databases/:id/backup-health", c.GetDatabaseBackupHealth)
+	router.GET("/databases/:id/compliance-score", c.GetBackupPolicyComplianceScore)
+	router.GET("/databases/:id/restore-points", c.GetDatabaseRestorePoints)
+	router.GET("/workspaces/:id/backup-coverage", c.GetWorkspaceBackupCoverage)
+	router.GET("/workspaces/:id/backup-inventory", c.GetWorkspaceBackupInventory)
+	router.GET("/workspaces/:id/backups", c.GetWorkspaceBackupsByStatus)
+	router.POST("/storages/:id/credentials-rotation", c.RotateStorageCredentials)
+	router.GET("/storages/:id/cost-estimate", c.GetStorageCostEstimate)
+	router.POST("/backup-configs/compare", c.CompareBackupConfigs)
 }
 
 // RegisterPublicRoutes registers routes that don't require Bearer authentication
@@ -100,7 +123,8 @@ func (c *BackupController) MakeBackup(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.backupService.MakeBackupWithAuth(user, request.DatabaseID); err != nil {
+	err := c.backupService.MakeBackupWithAuth(user, request.DatabaseID, request.RetentionLabel)
+	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -139,6 +163,38 @@ func (c *BackupController) DeleteBackup(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// GetBackupDeletionImpact
+// @Summary Get impact of a backup deletion
+// @Description Get whether the backup is the newest one, is fresh, locked or kept by the retention policy, together with a warning to show before the deletion
+// @Tags backups
+// @Produce json
+// @Param id path string true "Backup ID"
+// @Success 200 {object} BackupDeletionImpact
+// @Failure 400
+// @Failure 401
+// @Router /backups/{id}/deletion-impact [get]
+func (c *BackupController) GetBackupDeletionImpact(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup ID"})
+		return
+	}
+
+	impact, err := c.backupService.GetBackupDeletionImpactWithAuth(user, id)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, impact)
+}
+
 // CancelBackup
 // @Summary Cancel an in-progress backup
 // @Description Cancel a backup that is currently in progress
@@ -170,6 +226,669 @@ func (c *BackupController) CancelBackup(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// ListRetentionPolicies
+// @Summary List retention policies of a workspace
+// @Description Get retention policy summary with compliance flag for every database in the workspace
+// @Tags backups
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Success 200 {array} RetentionPolicySummary
+// @Failure 400
+// @Failure 401
+// @Router /workspaces/{id}/retention-policies [get]
+func (c *BackupController) ListRetentionPolicies(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	summaries, err := c.backupService.ListRetentionPolicies(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summaries)
+}
+
+// GetStorageUsageSummary
+// @Summary Get storage usage of a workspace
+// @Description Get backups size per storage and per database with estimated monthly cost of storages that have it configured
+// @Tags backups
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Success 200 {object} StorageUsageSummary
+// @Failure 400
+// @Failure 401
+// @Router /workspaces/{id}/storage-usage [get]
+func (c *BackupController) GetStorageUsageSummary(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	summary, err := c.backupService.GetStorageUsageSummary(user, workspaceID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+// ForceRetentionCleanup
+// @Summary Run retention cleanup now
+// @Description Apply the retention policy and the total size limit of the database immediately. With dry_run only backups that would be deleted are returned
+// @Tags backups
+// @Produce json
+// @Param id path string true "Database ID"
+// @Param dry_run query bool false "Do not delete anything"
+// @Success 200 {object} backuping.RetentionCleanupResult
+// @Failure 400
+// @Failure 401
+// @Router /databases/{id}/backup-retention/cleanup [post]
+func (c *BackupController) ForceRetentionCleanup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	isDryRun := ctx.Query("dry_run") == "true"
+
+	result, err := c.backupService.ForceRetentionCleanupWithAuth(
+		ctx.Request.Context(),
+		user,
+		databaseID,
+		isDryRun,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// ScheduleCleanup
+// @Summary Schedule retention cleanup
+// @Description Run the retention cleanup of the database once at the given time, e.g. when a compliance hold expires
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param id path string true "Database ID"
+// @Param request body ScheduleCleanupRequest true "Time of the cleanup"
+// @Success 201 {object} backuping.ScheduledCleanup
+// @Failure 400
+// @Failure 401
+// @Router /databases/{id}/backup-cleanup/schedule [post]
+func (c *BackupController) ScheduleCleanup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var request ScheduleCleanupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scheduledCleanup, err := c.backupService.CreateScheduledCleanupWithAuth(
+		user,
+		databaseID,
+		request.At,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, scheduledCleanup)
+}
+
+// CancelScheduledCleanup
+// @Summary Cancel scheduled retention cleanup
+// @Description Cancel a scheduled retention cleanup which has not run yet
+// @Tags backups
+// @Param id path string true "Database ID"
+// @Param scheduleId path string true "Scheduled cleanup ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Router /databases/{id}/backup-cleanup/schedule/{scheduleId} [delete]
+func (c *BackupController) CancelScheduledCleanup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	scheduledCleanupID, err := uuid.Parse(ctx.Param("scheduleId"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid scheduled cleanup ID"})
+		return
+	}
+
+	if err := c.backupService.CancelScheduledCleanupWithAuth(
+		user,
+		databaseID,
+		scheduledCleanupID,
+	); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// MigrateBackupsStorageClass
+// @Summary Move backups into another storage class
+// @Description Start a background job that copies completed backups older than the given number of days into the target S3 storage class
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param id path string true "Database ID"
+// @Param request body MigrateStorageClassRequest true "Target storage class and minimal backup age"
+// @Success 202 {object} backups_core.MigrationJob
+// @Failure 400
+// @Failure 401
+// @Router /databases/{id}/backups/storage-class-migration [post]
+func (c *BackupController) MigrateBackupsStorageClass(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var request MigrateStorageClassRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := c.backupService.MigrateBackupsToNewStorageClassWithAuth(
+		ctx.Request.Context(),
+		user,
+		databaseID,
+		&request,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, job)
+}
+
+// GetMigrationJobStatus
+// @Summary Get storage class migration progress
+// @Tags backups
+// @Produce json
+// @Param id path string true "Migration job ID"
+// @Success 200 {object} backups_core.MigrationJob
+// @Failure 400
+// @Failure 401
+// @Router /migration-jobs/{id}/status [get]
+func (c *BackupController) GetMigrationJobStatus(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	jobID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid migration job ID"})
+		return
+	}
+
+	job, err := c.backupService.GetMigrationJobWithAuth(user, jobID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
+
+// CreateDeletionJob
+// @Summary Delete old backups in the background
+// @Description Start a resumable job that deletes backups of the database older than the given number of days in batches. Batches wait while the database is read-only, its storages are unavailable or a backup is in progress
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param id path string true "Database ID"
+// @Param request body CreateDeletionJobRequest true "Minimal backup age"
+// @Success 202 {object} backuping.DeletionJob
+// @Failure 400
+// @Failure 401
+// @Router /databases/{id}/backups/deletion-jobs [post]
+func (c *BackupController) CreateDeletionJob(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	var request CreateDeletionJobRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := c.backupService.CreateDeletionJobWithAuth(user, databaseID, &request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, job)
+}
+
+// GetDeletionJobStatus
+// @Summary Get backup deletion job progress
+// @Tags backups
+// @Produce json
+// @Param id path string true "Deletion job ID"
+// @Success 200 {object} backuping.DeletionJob
+// @Failure 400
+// @Failure 401
+// @Router /deletion-jobs/{id}/status [get]
+func (c *BackupController) GetDeletionJobStatus(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	jobID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid deletion job ID"})
+		return
+	}
+
+	job, err := c.backupService.GetDeletionJobWithAuth(user, jobID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
+
+// GetDatabaseBackupHealth
+// @Summary Get backup health of a database
+// @Description Get last backup, next scheduled run, size, compliance, RPO and failures of the last 30 days in a single call. The result is cached for 60 seconds
+// @Tags backups
+// @Produce json
+// @Param id path string true "Database ID"
+// @Success 200 {object} DatabaseBackupHealth
+// @Failure 400
+// @Failure 401
+// @Router /databases/{id}/backup-health [get]
+func (c *BackupController) GetDatabaseBackupHealth(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	health, err := c.backupService.GetDatabaseBackupHealthWithAuth(
+		ctx.Request.Context(),
+		user,
+		databaseID,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, health)
+}
+
+// GetBackupPolicyComplianceScore
+// @Summary Get backup compliance score of a database
+// @Description Score the backup setup of the database from 0 to 100 with the breakdown of weighted checks: backups enabled, recent backup, encryption, retention policy, notifier and RPO
+// @Tags backups
+// @Produce json
+// @Param id path string true "Database ID"
+// @Success 200 {object} ComplianceScore
+// @Failure 400
+// @Failure 401
+// @Router /databases/{id}/compliance-score [get]
+func (c *BackupController) GetBackupPolicyComplianceScore(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	score, err := c.backupService.GetBackupPolicyComplianceScoreWithAuth(user, databaseID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, score)
+}
+
+// GetDatabaseRestorePoints
+// @Summary Get restore points of a database
+// @Description Get states the database can be restored to, newest first
+// @Tags backups
+// @Produce json
+// @Param id path string true "Database ID"
+// @Success 200 {array} RestorePoint
+// @Failure 400
+// @Failure 401
+// @Router /databases/{id}/restore-points [get]
+func (c *BackupController) GetDatabaseRestorePoints(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	databaseID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid database ID"})
+		return
+	}
+
+	restorePoints, err := c.backupService.GetDatabaseRestorePointsWithAuth(
+		ctx.Request.Context(),
+		user,
+		databaseID,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, restorePoints)
+}
+
+// GetWorkspaceBackupCoverage
+// @Summary Get backup coverage of a workspace
+// @Description Get how many databases of the workspace meet their RPO, have backups disabled or were never backed up, and which database has the oldest backup. The result is cached for 5 minutes
+// @Tags backups
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Success 200 {object} WorkspaceCoverageReport
+// @Failure 400
+// @Failure 401
+// @Router /workspaces/{id}/backup-coverage [get]
+func (c *BackupController) GetWorkspaceBackupCoverage(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	report, err := c.backupService.GetWorkspaceBackupCoverage(
+		ctx.Request.Context(),
+		user,
+		workspaceID,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// GetWorkspaceBackupInventory
+// @Summary Get backups of a workspace kept in a storage
+// @Description Get all backups of the workspace databases stored in the given storage with their total size. With includeMetadata the files listed by the storage itself are returned too, to verify storage content before decommissioning
+// @Tags backups
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Param storageId query string true "Storage ID"
+// @Param includeMetadata query bool false "List files of the storage"
+// @Success 200 {object} BackupInventory
+// @Failure 400
+// @Failure 401
+// @Router /workspaces/{id}/backup-inventory [get]
+func (c *BackupController) GetWorkspaceBackupInventory(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request GetBackupInventoryRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	storageID, err := uuid.Parse(request.StorageID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storageId"})
+		return
+	}
+
+	inventory, err := c.backupService.GetWorkspaceBackupInventoryWithAuth(
+		user,
+		workspaceID,
+		storageID,
+		request.IncludeMetadata,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, inventory)
+}
+
+// GetWorkspaceBackupsByStatus
+// @Summary Get backups of a workspace by status
+// @Description Get backups of all workspace databases in the given status with the database name, newest first. Used to monitor in-progress and failed backups
+// @Tags backups
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Param status query string true "Backup status" Enums(IN_PROGRESS, COMPLETED, FAILED, CANCELED)
+// @Param limit query int false "Max number of backups" default(50)
+// @Success 200 {array} backups_core.BackupWithDatabase
+// @Failure 400
+// @Failure 401
+// @Router /workspaces/{id}/backups [get]
+func (c *BackupController) GetWorkspaceBackupsByStatus(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	workspaceID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid workspace ID"})
+		return
+	}
+
+	var request GetBackupsByStatusRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	backups, err := c.backupService.GetBackupsByStatusWithAuth(
+		ctx.Request.Context(),
+		user,
+		workspaceID,
+		backups_core.BackupStatus(request.Status),
+		request.Limit,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, backups)
+}
+
+// RotateStorageCredentials
+// @Summary Rotate storage credentials
+// @Description Replace access keys of the storage. Keys are saved only if the storage accepts them, then the newest backup of every database on the storage is checked to be readable
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param id path string true "Storage ID"
+// @Param request body storages.StorageCredentials true "New credentials"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Router /storages/{id}/credentials-rotation [post]
+func (c *BackupController) RotateStorageCredentials(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	storageID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	var request storages.StorageCredentials
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = c.backupService.RotateStorageCredentialsWithAuth(
+		ctx.Request.Context(),
+		user,
+		storageID,
+		request,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "storage credentials rotated successfully"})
+}
+
+// GetStorageCostEstimate
+// @Summary Estimate storage cost
+// @Description Estimate monthly and total cost of keeping the given size in the storage for the given number of months, based on the price configured for the storage
+// @Tags backups
+// @Produce json
+// @Param id path string true "Storage ID"
+// @Param sizeGB query number true "Projected size in GB"
+// @Param months query int true "Number of months"
+// @Success 200 {object} CostEstimate
+// @Failure 400
+// @Failure 401
+// @Router /storages/{id}/cost-estimate [get]
+func (c *BackupController) GetStorageCostEstimate(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	storageID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid storage ID"})
+		return
+	}
+
+	var request GetStorageCostEstimateRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	estimate, err := c.backupService.GetStorageProviderCostEstimateWithAuth(
+		user,
+		storageID,
+		request.SizeGB,
+		request.Months,
+	)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, estimate)
+}
+
 // GenerateDownloadToken
 // @Summary Generate short-lived download token
 // @Description Generate a token for downloading a backup file (valid for 5 minutes)
@@ -291,8 +1010,7 @@ func (c *BackupController) GetFile(ctx *gin.Context) {
 
 	filename := c.generateBackupFilename(backup, database)
 
-	if backup.BackupSizeMb > 0 {
-		sizeBytes := int64(backup.BackupSizeMb * 1024 * 1024)
+	if sizeBytes := backup.GetSizeBytes(); sizeBytes > 0 {
 		ctx.Header("Content-Length", fmt.Sprintf("%d", sizeBytes))
 	}
 
@@ -312,6 +1030,42 @@ func (c *BackupController) GetFile(ctx *gin.Context) {
 
 type MakeBackupRequest struct {
 	DatabaseID uuid.UUID `json:"database_id" binding:"required"`
+	// RetentionLabel is optional, e.g. "release" for backups the retention
+	// policy of the database is configured to keep
+	RetentionLabel string `json:"retention_label"`
+}
+
+// CompareBackupConfigs
+// @Summary Compare two backup configs
+// @Description Compare retention of two backup configs before migrating from A to B. Returns changed retention fields and estimated change of stored backups count and size
+// @Tags backups
+// @Accept json
+// @Produce json
+// @Param request body CompareBackupConfigsRequest true "Current config A and planned config B"
+// @Success 200 {object} BackupConfigComparison
+// @Failure 400
+// @Failure 401
+// @Router /backup-configs/compare [post]
+func (c *BackupController) CompareBackupConfigs(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request CompareBackupConfigsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comparison, err := c.backupService.CompareBackupConfigsWithAuth(user, &request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comparison)
 }
 
 func (c *BackupController) generateBackupFilename(
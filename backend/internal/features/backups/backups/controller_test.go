@@ -3,6 +3,7 @@ package backups
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -27,8 +28,11 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/databases/databases/postgresql"
+	"databasus-backend/internal/features/intervals"
+	plans "databasus-backend/internal/features/plan"
 	"databasus-backend/internal/features/storages"
 	local_storage "databasus-backend/internal/features/storages/models/local"
+	s3_storage "databasus-backend/internal/features/storages/models/s3"
 	users_dto "databasus-backend/internal/features/users/dto"
 	users_enums "databasus-backend/internal/features/users/enums"
 	users_services "databasus-backend/internal/features/users/services"
@@ -37,6 +41,8 @@ import (
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
 	"databasus-backend/internal/util/encryption"
 	files_utils "databasus-backend/internal/util/files"
+	"databasus-backend/internal/util/period"
+	"databasus-backend/internal/util/size"
 	test_utils "databasus-backend/internal/util/testing"
 	"databasus-backend/internal/util/tools"
 )
@@ -1249,6 +1255,48 @@ func Test_GenerateDownloadToken_BlockedWhenDownloadInProgress(t *testing.T) {
 	workspaces_testing.RemoveTestWorkspace(workspace, router)
 }
 
+func Test_MakeBackup_WhenDatabaseIsInReadOnlyMode_BackupRejected(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	configService := backups_config.GetBackupConfigService()
+	config, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	config.IsBackupsEnabled = false
+	config.IsReadOnlyMode = true
+	config.StorageID = &storage.ID
+	config.Storage = storage
+	_, err = configService.SaveBackupConfig(config)
+	assert.NoError(t, err)
+
+	existingBackup := createTestBackup(database, owner)
+
+	testResp := test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backups",
+		"Bearer "+owner.Token,
+		MakeBackupRequest{DatabaseID: database.ID},
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(testResp.Body), "read-only mode")
+
+	backupRepo := &backups_core.BackupRepository{}
+	backups, err := backupRepo.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, backups, 1)
+	assert.Equal(t, existingBackup.ID, backups[0].ID)
+
+	databases.RemoveTestDatabase(database)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
 func Test_MakeBackup_VerifyBackupAndMetadataFilesExistInStorage(t *testing.T) {
 	router := createTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -1886,3 +1934,1933 @@ func Test_DeleteBackup_RemovesBackupAndMetadataFilesFromDisk(t *testing.T) {
 	_, err = os.Stat(metadataFilePath)
 	assert.True(t, os.IsNotExist(err), "metadata file should be removed from disk after deletion")
 }
+
+func Test_PurgeDatabase_WithCompletedBackups_DeletesBackupsAndConfig(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	configService := backups_config.GetBackupConfigService()
+	backupConfig, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	backupConfig.IsBackupsEnabled = true
+	backupConfig.StorageID = &storage.ID
+	backupConfig.Storage = storage
+	_, err = configService.SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupRepo := &backups_core.BackupRepository{}
+	for _, sizeMB := range []float64{10, 15} {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			FileName:     "purge-test-" + uuid.New().String(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: sizeMB,
+			CreatedAt:    time.Now().UTC(),
+		}
+		err = backupRepo.Save(backup)
+		assert.NoError(t, err)
+	}
+
+	report, err := GetBackupService().PurgeDatabase(context.Background(), database.ID)
+	assert.NoError(t, err)
+
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, 2, report.DeletedBackupCount)
+	assert.Equal(t, float64(25), report.FreedMB)
+
+	remainingBackups, err := backupRepo.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, remainingBackups)
+
+	// config was deleted, so a fresh disabled default config is returned
+	recreatedConfig, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	assert.False(t, recreatedConfig.IsBackupsEnabled)
+}
+
+func Test_PurgeDatabase_WhenBackupFileDeletionFails_NoBackupsOrConfigDeleted(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	configService := backups_config.GetBackupConfigService()
+	backupConfig, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	backupConfig.IsBackupsEnabled = true
+	backupConfig.StorageID = &storage.ID
+	backupConfig.Storage = storage
+	_, err = configService.SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var backupIDs []uuid.UUID
+	for range 2 {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			FileName:     "purge-test-" + uuid.New().String(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    time.Now().UTC(),
+		}
+		assert.NoError(t, backupRepo.Save(backup))
+		backupIDs = append(backupIDs, backup.ID)
+	}
+
+	backupService := *GetBackupService()
+	backupService.backupCleaner = backuping.CreateTestBackupCleaner(
+		backuping.TestBackupCleanerOptions{
+			Listeners: []backups_core.BackupRemoveListener{
+				&failingBackupRemoveListener{failingBackupID: backupIDs[1]},
+			},
+		},
+	)
+
+	report, err := backupService.PurgeDatabase(context.Background(), database.ID)
+	assert.NoError(t, err)
+
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, 0, report.DeletedBackupCount)
+
+	remainingBackups, err := backupRepo.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 2)
+
+	keptConfig, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	assert.True(t, keptConfig.IsBackupsEnabled)
+}
+
+type failingBackupRemoveListener struct {
+	failingBackupID uuid.UUID
+}
+
+func (l *failingBackupRemoveListener) OnBeforeBackupRemove(backup *backups_core.Backup) error {
+	if backup.ID == l.failingBackupID {
+		return errors.New("backup cannot be removed")
+	}
+
+	return nil
+}
+
+func Test_ForceRetentionCleanup_WithDryRun_ReportsBackupsWithoutDeleting(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	configService := backups_config.GetBackupConfigService()
+	backupConfig, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	backupConfig.IsBackupsEnabled = true
+	backupConfig.StorageID = &storage.ID
+	backupConfig.Storage = storage
+	backupConfig.RetentionPolicyType = backups_config.RetentionPolicyTypeCount
+	backupConfig.RetentionCount = 1
+	_, err = configService.SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	backupRepo := &backups_core.BackupRepository{}
+
+	oldBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-2 * time.Hour),
+	}
+	newBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 10,
+		CreatedAt:    now,
+	}
+	assert.NoError(t, backupRepo.Save(oldBackup))
+	assert.NoError(t, backupRepo.Save(newBackup))
+
+	var result backuping.RetentionCleanupResult
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf(
+			"/api/v1/databases/%s/backup-retention/cleanup?dry_run=true",
+			database.ID.String(),
+		),
+		"Bearer "+owner.Token,
+		nil,
+		http.StatusOK,
+		&result,
+	)
+
+	assert.Equal(t, 1, result.DeletedCount)
+	assert.Equal(t, float64(10), result.FreedMB)
+	assert.Equal(t, []uuid.UUID{oldBackup.ID}, result.BackupIDs)
+	assert.Empty(t, result.Errors)
+
+	remainingBackups, err := backupRepo.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 2)
+}
+
+func Test_ForceRetentionCleanup_WhenDatabaseIsGuarded_NothingDeletedAndReasonReported(
+	t *testing.T,
+) {
+	tests := []struct {
+		name                 string
+		isReadOnly           bool
+		isStorageUnavailable bool
+		isDryRun             bool
+		expectedError        error
+	}{
+		{"read-only database", true, false, false, backuping.ErrCleanupDatabaseReadOnly},
+		{"read-only database dry run", true, false, true, backuping.ErrCleanupDatabaseReadOnly},
+		{
+			"unavailable storage",
+			false,
+			true,
+			false,
+			backuping.ErrCleanupStoragesUnavailable,
+		},
+		{
+			"unavailable storage dry run",
+			false,
+			true,
+			true,
+			backuping.ErrCleanupStoragesUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := createTestRouter()
+			owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+			workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+			database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+			storage := createTestStorage(workspace.ID)
+			backupRepo := &backups_core.BackupRepository{}
+
+			defer func() {
+				backups, _ := backupRepo.FindByDatabaseID(database.ID)
+				for _, backup := range backups {
+					backupRepo.DeleteByID(backup.ID)
+				}
+
+				databases.RemoveTestDatabase(database)
+				time.Sleep(50 * time.Millisecond)
+				storages.RemoveTestStorage(storage.ID)
+				workspaces_testing.RemoveTestWorkspace(workspace, router)
+			}()
+
+			configService := backups_config.GetBackupConfigService()
+			backupConfig, err := configService.GetBackupConfigByDbId(database.ID)
+			assert.NoError(t, err)
+
+			backupConfig.IsBackupsEnabled = true
+			backupConfig.IsReadOnlyMode = tt.isReadOnly
+			backupConfig.StorageID = &storage.ID
+			backupConfig.Storage = storage
+			backupConfig.RetentionPolicyType = backups_config.RetentionPolicyTypeCount
+			backupConfig.RetentionCount = 1
+			_, err = configService.SaveBackupConfig(backupConfig)
+			assert.NoError(t, err)
+
+			now := time.Now().UTC()
+			for i := range 3 {
+				assert.NoError(t, backupRepo.Save(&backups_core.Backup{
+					ID:           uuid.New(),
+					DatabaseID:   database.ID,
+					StorageID:    storage.ID,
+					Status:       backups_core.BackupStatusCompleted,
+					BackupSizeMb: 10,
+					CreatedAt:    now.Add(-time.Duration(i+2) * time.Hour),
+				}))
+			}
+
+			if tt.isStorageUnavailable {
+				err = storages.GetStorageService().SetAvailability(storage.ID, false)
+				assert.NoError(t, err)
+			}
+
+			result, err := GetBackupService().ForceRetentionCleanup(
+				context.Background(),
+				database.ID,
+				tt.isDryRun,
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, result.DeletedCount)
+			assert.Empty(t, result.BackupIDs)
+			assert.Equal(t, []string{tt.expectedError.Error()}, result.Errors)
+
+			remainingBackups, err := backupRepo.FindByDatabaseID(database.ID)
+			assert.NoError(t, err)
+			assert.Len(t, remainingBackups, 3)
+		})
+	}
+}
+
+func Test_ForceRetentionCleanup_DryRunOverSizeLimitWithFailedBackup_FailedBackupReported(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	configService := backups_config.GetBackupConfigService()
+	backupConfig, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	backupConfig.IsBackupsEnabled = true
+	backupConfig.StorageID = &storage.ID
+	backupConfig.Storage = storage
+	backupConfig.RetentionPolicyType = backups_config.RetentionPolicyTypeTimePeriod
+	backupConfig.RetentionTimePeriod = period.PeriodForever
+	backupConfig.MaxBackupsTotalSizeMB = 60
+	_, err = configService.SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	failedBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusFailed,
+		BackupSizeMb: 50,
+		CreatedAt:    now.AddDate(0, 0, -3),
+	}
+	assert.NoError(t, backupRepo.Save(failedBackup))
+
+	for _, daysAgo := range []int{2, 1} {
+		assert.NoError(t, backupRepo.Save(&backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 25,
+			CreatedAt:    now.AddDate(0, 0, -daysAgo),
+		}))
+	}
+
+	result, err := GetBackupService().ForceRetentionCleanup(
+		context.Background(),
+		database.ID,
+		true,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{failedBackup.ID}, result.BackupIDs)
+	assert.InDelta(t, 50.0, result.FreedMB, 0.001)
+
+	remainingBackups, err := backupRepo.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, remainingBackups, 3)
+}
+
+func Test_MigrateBackupsStorageClass_WhenStorageHasNoClasses_JobFinishesAsFailed(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	backupRepo := &backups_core.BackupRepository{}
+
+	oldBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		FileName:     "old-backup",
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		StorageClass: backups_config.StorageClassStandard,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-40 * 24 * time.Hour),
+	}
+	recentBackup := &backups_core.Backup{
+		ID:           uuid.New(),
+		FileName:     "recent-backup",
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		StorageClass: backups_config.StorageClassStandard,
+		BackupSizeMb: 10,
+		CreatedAt:    now.Add(-time.Hour),
+	}
+	assert.NoError(t, backupRepo.Save(oldBackup))
+	assert.NoError(t, backupRepo.Save(recentBackup))
+
+	var job backups_core.MigrationJob
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/databases/%s/backups/storage-class-migration", database.ID),
+		"Bearer "+owner.Token,
+		MigrateStorageClassRequest{
+			TargetStorageClass: string(backups_config.StorageClassGlacier),
+			OlderThanDays:      30,
+		},
+		http.StatusAccepted,
+		&job,
+	)
+	assert.Equal(t, 1, job.TotalCount)
+
+	var status backups_core.MigrationJob
+	assert.Eventually(t, func() bool {
+		test_utils.MakeGetRequestAndUnmarshal(
+			t,
+			router,
+			fmt.Sprintf("/api/v1/migration-jobs/%s/status", job.ID),
+			"Bearer "+owner.Token,
+			http.StatusOK,
+			&status,
+		)
+
+		return status.Status != backups_core.MigrationJobStatusInProgress
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// local storages have no storage classes, so the backup must stay untouched
+	assert.Equal(t, backups_core.MigrationJobStatusFailed, status.Status)
+	assert.Equal(t, 1, status.FailedCount)
+	assert.NotNil(t, status.LastError)
+
+	unchangedBackup, err := backupRepo.FindByID(oldBackup.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, backups_config.StorageClassStandard, unchangedBackup.StorageClass)
+}
+
+func Test_MigrateBackupsStorageClass_WhenMigrationPanics_JobFinishesAsFailed(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupRepo := &backups_core.BackupRepository{}
+	job := &backups_core.MigrationJob{
+		ID:                 uuid.New(),
+		DatabaseID:         database.ID,
+		TargetStorageClass: backups_config.StorageClassGlacier,
+		Status:             backups_core.MigrationJobStatusInProgress,
+		TotalCount:         1,
+		CreatedAt:          time.Now().UTC(),
+	}
+	assert.NoError(t, backupRepo.SaveMigrationJob(job))
+
+	// a nil backup makes the migration panic on its first access
+	assert.NotPanics(t, func() {
+		GetBackupService().migrateBackupsStorageClass(
+			context.Background(),
+			*job,
+			[]*backups_core.Backup{nil},
+		)
+	})
+
+	savedJob, err := backupRepo.FindMigrationJobByID(job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, backups_core.MigrationJobStatusFailed, savedJob.Status)
+	assert.NotNil(t, savedJob.CompletedAt)
+	assert.NotNil(t, savedJob.LastError)
+	assert.Contains(t, *savedJob.LastError, "panic")
+}
+
+func Test_CreateDeletionJob_WithOldAndRecentBackups_OnlyOldBackupsCountedAndStatusVisibleToMembers(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	outsider := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	for _, createdAt := range []time.Time{now.Add(-40 * 24 * time.Hour), now.Add(-time.Hour)} {
+		assert.NoError(t, backupRepo.Save(&backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    createdAt,
+		}))
+	}
+
+	var job backuping.DeletionJob
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/databases/%s/backups/deletion-jobs", database.ID),
+		"Bearer "+owner.Token,
+		CreateDeletionJobRequest{OlderThanDays: 30},
+		http.StatusAccepted,
+		&job,
+	)
+	assert.Equal(t, 1, job.TotalCount)
+
+	var status backuping.DeletionJob
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/deletion-jobs/%s/status", job.ID),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&status,
+	)
+	assert.Equal(t, job.ID, status.ID)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/deletion-jobs/%s/status", job.ID),
+		"Bearer "+outsider.Token,
+		http.StatusBadRequest,
+	)
+}
+func Test_GetStorageUsageSummary_WithStorageCost_ReturnsSizesAndEstimatedCost(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	costPerGBPerMonth := 0.02
+	storage.CostPerGBPerMonth = &costPerGBPerMonth
+	_, err := (&storages.StorageRepository{}).Save(storage)
+	assert.NoError(t, err)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupRepo := &backups_core.BackupRepository{}
+	for _, status := range []backups_core.BackupStatus{
+		backups_core.BackupStatusCompleted,
+		backups_core.BackupStatusInProgress,
+	} {
+		backup := &backups_core.Backup{
+			ID:              uuid.New(),
+			DatabaseID:      database.ID,
+			StorageID:       storage.ID,
+			Status:          status,
+			BackupSizeBytes: 2 * size.BytesInGB,
+			CreatedAt:       time.Now().UTC(),
+		}
+		assert.NoError(t, backupRepo.Save(backup))
+	}
+
+	var summary StorageUsageSummary
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/workspaces/%s/storage-usage", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&summary,
+	)
+
+	assert.InDelta(t, 2.0, summary.TotalSizeGB, 0.0001)
+
+	assert.Len(t, summary.ByStorage, 1)
+	assert.Equal(t, storage.ID, summary.ByStorage[0].StorageID)
+	assert.InDelta(t, 2.0, summary.ByStorage[0].SizeGB, 0.0001)
+
+	assert.Len(t, summary.ByDatabase, 1)
+	assert.Equal(t, database.ID, summary.ByDatabase[0].DatabaseID)
+
+	assert.NotNil(t, summary.EstimatedMonthlyCostUSD)
+	assert.InDelta(t, 0.04, *summary.EstimatedMonthlyCostUSD, 0.0001)
+}
+
+func Test_GetStorageCostEstimate_WithStorageCost_ReturnsMonthlyBreakdown(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := createTestStorage(workspace.ID)
+
+	costPerGBPerMonth := 0.02
+	storage.CostPerGBPerMonth = &costPerGBPerMonth
+	_, err := (&storages.StorageRepository{}).Save(storage)
+	assert.NoError(t, err)
+
+	defer func() {
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var estimate CostEstimate
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf(
+			"/api/v1/storages/%s/cost-estimate?sizeGB=50&months=12",
+			storage.ID.String(),
+		),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&estimate,
+	)
+
+	assert.False(t, estimate.IsCostUnknown)
+	assert.InDelta(t, 1.0, estimate.MonthlyCostUSD, 0.0001)
+	assert.InDelta(t, 12.0, estimate.TotalCostUSD, 0.0001)
+	assert.Len(t, estimate.CostBreakdown, 12)
+	assert.Equal(t, 12, estimate.CostBreakdown[11].Month)
+	assert.InDelta(t, 12.0, estimate.CostBreakdown[11].CumulativeCostUSD, 0.0001)
+}
+
+func Test_GetDatabaseBackupHealth_WithCompletedAndFailedBackups_ReturnsAggregatedHealth(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	configService := backups_config.GetBackupConfigService()
+	config, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	config.IsBackupsEnabled = true
+	config.StorageID = &storage.ID
+	config.Storage = storage
+	_, err = configService.SaveBackupConfig(config)
+	assert.NoError(t, err)
+
+	completedBackup := createTestBackup(database, owner)
+
+	failMessage := "backup failed"
+	failedBackup := &backups_core.Backup{
+		ID:          uuid.New(),
+		DatabaseID:  database.ID,
+		StorageID:   storage.ID,
+		Status:      backups_core.BackupStatusFailed,
+		FailMessage: &failMessage,
+		CreatedAt:   completedBackup.CreatedAt.Add(time.Minute),
+	}
+	backupRepo := &backups_core.BackupRepository{}
+	err = backupRepo.Save(failedBackup)
+	assert.NoError(t, err)
+
+	var health DatabaseBackupHealth
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/databases/%s/backup-health", database.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&health,
+	)
+
+	assert.Equal(t, database.ID, health.DatabaseID)
+	assert.NotNil(t, health.LastBackupStatus)
+	assert.Equal(t, backups_core.BackupStatusFailed, *health.LastBackupStatus)
+	assert.NotNil(t, health.NextScheduledBackup)
+	assert.True(t, health.IsRPOMet)
+	assert.Equal(t, 1, health.RecentFailureCount)
+	assert.NotNil(t, health.SuccessRateLast30Days)
+	assert.InDelta(t, 50.0, *health.SuccessRateLast30Days, 0.001)
+	assert.Greater(t, health.TotalSizeMB, 0.0)
+
+	databases.RemoveTestDatabase(database)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_GetBackupPolicyComplianceScore_WithoutEncryptionAndNotifier_ReturnsPartialScore(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	configService := backups_config.GetBackupConfigService()
+	config, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	config.IsBackupsEnabled = true
+	config.RetentionPolicyType = backups_config.RetentionPolicyTypeCount
+	config.RetentionCount = 5
+	config.Encryption = backups_config.BackupEncryptionNone
+	config.StorageID = &storage.ID
+	config.Storage = storage
+	_, err = configService.SaveBackupConfig(config)
+	assert.NoError(t, err)
+
+	createTestBackup(database, owner)
+
+	var score ComplianceScore
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/databases/%s/compliance-score", database.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&score,
+	)
+
+	assert.Equal(t, 100, score.MaxScore)
+	assert.Equal(t, 75, score.Score)
+
+	unmetItemNames := []string{}
+	for _, item := range score.Breakdown {
+		if !item.IsMet {
+			assert.Equal(t, 0, item.Points)
+			unmetItemNames = append(unmetItemNames, item.Name)
+		}
+	}
+	assert.ElementsMatch(t, []string{"encryption_enabled", "notifier_configured"}, unmetItemNames)
+
+	databases.RemoveTestDatabase(database)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_SumSizeMBByDateAndCountByDate_GroupsCompletedBackupsByDay(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepository := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepository.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepository.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+	outOfWindow := today.AddDate(0, 0, -10)
+
+	backupsToSave := []*backups_core.Backup{
+		{Status: backups_core.BackupStatusCompleted, BackupSizeMb: 10, CreatedAt: today},
+		{Status: backups_core.BackupStatusCompleted, BackupSizeMb: 5, CreatedAt: today},
+		{Status: backups_core.BackupStatusFailed, BackupSizeMb: 100, CreatedAt: today},
+		{Status: backups_core.BackupStatusCompleted, BackupSizeMb: 7, CreatedAt: yesterday},
+		{Status: backups_core.BackupStatusCompleted, BackupSizeMb: 50, CreatedAt: outOfWindow},
+	}
+	for _, backup := range backupsToSave {
+		backup.DatabaseID = database.ID
+		backup.StorageID = storage.ID
+
+		err := backupRepository.Save(backup)
+		assert.NoError(t, err)
+	}
+
+	sizeByDate, err := backupRepository.SumSizeMBByDate(database.ID, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(sizeByDate))
+	assert.InDelta(t, 15.0, sizeByDate[today.Format("2006-01-02")], 0.01)
+	assert.InDelta(t, 7.0, sizeByDate[yesterday.Format("2006-01-02")], 0.01)
+
+	countByDate, err := backupRepository.CountByDate(database.ID, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(countByDate))
+	assert.Equal(t, 2, countByDate[today.Format("2006-01-02")])
+	assert.Equal(t, 1, countByDate[yesterday.Format("2006-01-02")])
+}
+
+func Test_GetDatabasesApproachingStorageQuota_WithDatabaseAboveThreshold_ReturnsWarning(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	limitedDatabase := createTestDatabase("Limited Database", workspace.ID, owner.Token, router)
+	unlimitedDatabase := createTestDatabase("Unlimited Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		for _, database := range []*databases.Database{limitedDatabase, unlimitedDatabase} {
+			backups, _ := backupRepo.FindByDatabaseID(database.ID)
+			for _, backup := range backups {
+				backupRepo.DeleteByID(backup.ID)
+			}
+
+			databases.RemoveTestDatabase(database)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	configService := backups_config.GetBackupConfigService()
+	config, err := configService.GetBackupConfigByDbId(limitedDatabase.ID)
+	assert.NoError(t, err)
+	config.StorageID = &storage.ID
+	config.Storage = storage
+	config.MaxBackupsTotalSizeMB = 100
+	_, err = configService.SaveBackupConfig(config)
+	assert.NoError(t, err)
+
+	for _, database := range []*databases.Database{limitedDatabase, unlimitedDatabase} {
+		for _, backup := range []struct {
+			sizeMB float64
+			age    time.Duration
+		}{
+			{30, 7 * 24 * time.Hour},
+			{60, 0},
+		} {
+			assert.NoError(t, backupRepo.Save(&backups_core.Backup{
+				ID:           uuid.New(),
+				DatabaseID:   database.ID,
+				StorageID:    storage.ID,
+				Status:       backups_core.BackupStatusCompleted,
+				BackupSizeMb: backup.sizeMB,
+				CreatedAt:    time.Now().UTC().Add(-backup.age),
+			}))
+		}
+	}
+
+	warnings, err := GetBackupService().GetDatabasesApproachingStorageQuota(workspace.ID, 80)
+	assert.NoError(t, err)
+
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, limitedDatabase.ID, warnings[0].DatabaseID)
+	assert.Equal(t, int64(100), warnings[0].LimitMB)
+	assert.InDelta(t, 90.0, warnings[0].CurrentSizeMB, 0.001)
+	assert.InDelta(t, 90.0, warnings[0].UsedPercent, 0.001)
+
+	// backups doubled in a week, so the 90 MB total grows ~8.6 MB a day, 10 MB are left
+	assert.NotNil(t, warnings[0].EstimatedDaysUntilFull)
+	assert.Equal(t, 2, *warnings[0].EstimatedDaysUntilFull)
+}
+
+func Test_SimulatePlanChange_WithDowngrade_ConfigInvalidAndOverLimitDataReported(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	configService := backups_config.GetBackupConfigService()
+	config, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	config.StorageID = &storage.ID
+	config.Storage = storage
+	config.RetentionPolicyType = backups_config.RetentionPolicyTypeTimePeriod
+	config.RetentionTimePeriod = period.PeriodYear
+	config.MaxBackupsTotalSizeMB = 0
+	_, err = configService.SaveBackupConfig(config)
+	assert.NoError(t, err)
+
+	now := time.Now().UTC()
+	backupsToSave := []*backups_core.Backup{
+		{BackupSizeMb: 80, CreatedAt: now.AddDate(0, 0, -60)},
+		{BackupSizeMb: 50, CreatedAt: now},
+	}
+	for _, backup := range backupsToSave {
+		backup.ID = uuid.New()
+		backup.DatabaseID = database.ID
+		backup.StorageID = storage.ID
+		backup.Status = backups_core.BackupStatusCompleted
+		assert.NoError(t, backupRepo.Save(backup))
+	}
+
+	impact, err := GetBackupService().SimulatePlanChange(database.ID, &plans.DatabasePlan{
+		DatabaseID:            database.ID,
+		MaxBackupsTotalSizeMB: 100,
+		MaxStoragePeriod:      period.PeriodMonth,
+	})
+	assert.NoError(t, err)
+
+	assert.False(t, impact.IsConfigValid)
+	assert.NotEmpty(t, impact.ValidationError)
+
+	assert.InDelta(t, 130.0, impact.CurrentSizeMB, 0.001)
+	assert.Equal(t, int64(100), impact.NewTotalSizeLimitMB)
+	assert.InDelta(t, 30.0, impact.OverSizeLimitMB, 0.001)
+
+	assert.Equal(t, 1, impact.OverPeriodLimitCount)
+	assert.InDelta(t, 80.0, impact.OverPeriodLimitMB, 0.001)
+}
+
+func Test_SimulatePlanChange_WithUnknownStoragePeriod_NoBackupsReportedOverPeriodLimit(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	configService := backups_config.GetBackupConfigService()
+	config, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	config.StorageID = &storage.ID
+	config.Storage = storage
+	config.RetentionPolicyType = backups_config.RetentionPolicyTypeTimePeriod
+	config.RetentionTimePeriod = period.PeriodYear
+	_, err = configService.SaveBackupConfig(config)
+	assert.NoError(t, err)
+
+	backup := &backups_core.Backup{
+		ID:           uuid.New(),
+		DatabaseID:   database.ID,
+		StorageID:    storage.ID,
+		Status:       backups_core.BackupStatusCompleted,
+		BackupSizeMb: 80,
+		CreatedAt:    time.Now().UTC().AddDate(0, 0, -60),
+	}
+	assert.NoError(t, backupRepo.Save(backup))
+
+	impact, err := GetBackupService().SimulatePlanChange(database.ID, &plans.DatabasePlan{
+		DatabaseID:       database.ID,
+		MaxStoragePeriod: period.TimePeriod("BOGUS"),
+	})
+	assert.NoError(t, err)
+
+	assert.False(t, impact.IsConfigValid)
+	assert.Contains(t, impact.ValidationError, "BOGUS")
+	assert.Equal(t, 0, impact.OverPeriodLimitCount)
+	assert.Zero(t, impact.OverPeriodLimitMB)
+}
+
+func Test_GetDatabaseRestorePoints_WithCompletedAndFailedBackups_ReturnsCompletedNewestFirst(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	saveBackup := func(status backups_core.BackupStatus, createdAt time.Time) uuid.UUID {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   database.ID,
+			StorageID:    storage.ID,
+			Status:       status,
+			BackupSizeMb: 10,
+			CreatedAt:    createdAt,
+		}
+		assert.NoError(t, backupRepo.Save(backup))
+
+		return backup.ID
+	}
+
+	olderBackupID := saveBackup(backups_core.BackupStatusCompleted, now.Add(-2*time.Hour))
+	newerBackupID := saveBackup(backups_core.BackupStatusCompleted, now.Add(-time.Hour))
+	saveBackup(backups_core.BackupStatusFailed, now)
+
+	var restorePoints []*RestorePoint
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/databases/%s/restore-points", database.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&restorePoints,
+	)
+
+	assert.Len(t, restorePoints, 2)
+	assert.Equal(t, newerBackupID, restorePoints[0].BackupID)
+	assert.Equal(t, olderBackupID, restorePoints[1].BackupID)
+
+	for _, restorePoint := range restorePoints {
+		assert.Equal(t, backups_core.BackupTypeFull, restorePoint.BackupType)
+		assert.Equal(t, 1, restorePoint.ChainLength)
+		assert.True(t, restorePoint.IsRestorable)
+		assert.InDelta(t, 10.0, restorePoint.SizeMB, 0.001)
+	}
+}
+
+func Test_GetWorkspaceBackupCoverage_WithBackedUpAndDisabledDatabases_ReturnsCoverage(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	backedUpDatabase := createTestDatabase("Backed Up Database", workspace.ID, owner.Token, router)
+	disabledDatabase := createTestDatabase("Disabled Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	configService := backups_config.GetBackupConfigService()
+
+	backedUpConfig, err := configService.GetBackupConfigByDbId(backedUpDatabase.ID)
+	assert.NoError(t, err)
+	backedUpConfig.IsBackupsEnabled = true
+	backedUpConfig.StorageID = &storage.ID
+	backedUpConfig.Storage = storage
+	_, err = configService.SaveBackupConfig(backedUpConfig)
+	assert.NoError(t, err)
+
+	disabledConfig, err := configService.GetBackupConfigByDbId(disabledDatabase.ID)
+	assert.NoError(t, err)
+	disabledConfig.IsBackupsEnabled = false
+	_, err = configService.SaveBackupConfig(disabledConfig)
+	assert.NoError(t, err)
+
+	createTestBackup(backedUpDatabase, owner)
+
+	var report WorkspaceCoverageReport
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/workspaces/%s/backup-coverage", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&report,
+	)
+
+	assert.Equal(t, 2, report.TotalDatabases)
+	assert.Equal(t, 1, report.DatabasesWithinRPO)
+	assert.Equal(t, 1, report.DatabasesWithBackupsDisabled)
+	assert.Equal(t, 1, report.DatabasesNeverBacked)
+	assert.NotNil(t, report.WorstDatabase)
+	assert.Equal(t, backedUpDatabase.ID, report.WorstDatabase.DatabaseID)
+	assert.Less(t, report.AverageLastBackupAgeHours, 1.0)
+
+	databases.RemoveTestDatabase(backedUpDatabase)
+	databases.RemoveTestDatabase(disabledDatabase)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_GetWorkspaceBackupInventory_WithMetadata_ReturnsBackupsAndStorageFiles(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	firstDatabase := createTestDatabase("First Database", workspace.ID, owner.Token, router)
+	secondDatabase := createTestDatabase("Second Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	firstBackup := createTestBackup(firstDatabase, owner)
+	secondBackup := createTestBackup(secondDatabase, owner)
+
+	var inventory BackupInventory
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf(
+			"/api/v1/workspaces/%s/backup-inventory?storageId=%s&includeMetadata=true",
+			workspace.ID.String(),
+			storage.ID.String(),
+		),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&inventory,
+	)
+
+	assert.Len(t, inventory.Backups, 2)
+	assert.Equal(t, 2, inventory.DatabaseCount)
+	expectedSizeMB := firstBackup.BackupSizeMb + secondBackup.BackupSizeMb
+	assert.InDelta(t, expectedSizeMB, inventory.TotalSizeMB, 0.001)
+	assert.True(t, inventory.IsFileListSupported)
+
+	listedFileNames := make([]string, 0, len(inventory.StorageFileList))
+	for _, file := range inventory.StorageFileList {
+		listedFileNames = append(listedFileNames, file.Name)
+	}
+	assert.Contains(t, listedFileNames, firstBackup.ID.String())
+	assert.Contains(t, listedFileNames, secondBackup.ID.String())
+
+	otherOwner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	otherWorkspace := workspaces_testing.CreateTestWorkspace("Other Workspace", otherOwner, router)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf(
+			"/api/v1/workspaces/%s/backup-inventory?storageId=%s",
+			otherWorkspace.ID.String(),
+			storage.ID.String(),
+		),
+		"Bearer "+otherOwner.Token,
+		http.StatusBadRequest,
+	)
+
+	databases.RemoveTestDatabase(firstDatabase)
+	databases.RemoveTestDatabase(secondDatabase)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+	workspaces_testing.RemoveTestWorkspace(otherWorkspace, router)
+}
+
+func Test_GetWorkspaceBackupInventory_ForSharedSystemStorage_ListsOnlyWorkspaceFiles(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	otherOwner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	otherWorkspace := workspaces_testing.CreateTestWorkspace("Other Workspace", otherOwner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	otherDatabase := createTestDatabase(
+		"Other Database",
+		otherWorkspace.ID,
+		otherOwner.Token,
+		router,
+	)
+
+	systemStorage, err := (&storages.StorageRepository{}).Save(&storages.Storage{
+		WorkspaceID:  workspace.ID,
+		Type:         storages.StorageTypeLocal,
+		Name:         "Test System Storage " + uuid.New().String(),
+		IsSystem:     true,
+		LocalStorage: &local_storage.LocalStorage{},
+	})
+	assert.NoError(t, err)
+
+	backupRepo := &backups_core.BackupRepository{}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	saveBackupWithFiles := func(databaseID uuid.UUID) *backups_core.Backup {
+		backup := &backups_core.Backup{
+			ID:           uuid.New(),
+			DatabaseID:   databaseID,
+			StorageID:    systemStorage.ID,
+			Status:       backups_core.BackupStatusCompleted,
+			BackupSizeMb: 10,
+			CreatedAt:    time.Now().UTC(),
+		}
+		backup.FileName = backup.ID.String()
+		assert.NoError(t, backupRepo.Save(backup))
+
+		for _, fileName := range []string{backup.FileName, backup.FileName + ".metadata"} {
+			assert.NoError(t, systemStorage.SaveFile(
+				context.Background(),
+				encryption.GetFieldEncryptor(),
+				logger,
+				fileName,
+				strings.NewReader("content"),
+			))
+		}
+
+		return backup
+	}
+
+	workspaceBackup := saveBackupWithFiles(database.ID)
+	otherWorkspaceBackup := saveBackupWithFiles(otherDatabase.ID)
+
+	defer func() {
+		for _, backup := range []*backups_core.Backup{workspaceBackup, otherWorkspaceBackup} {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		databases.RemoveTestDatabase(otherDatabase)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(systemStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+		workspaces_testing.RemoveTestWorkspace(otherWorkspace, router)
+	}()
+
+	var inventory BackupInventory
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf(
+			"/api/v1/workspaces/%s/backup-inventory?storageId=%s&includeMetadata=true",
+			otherWorkspace.ID.String(),
+			systemStorage.ID.String(),
+		),
+		"Bearer "+otherOwner.Token,
+		http.StatusOK,
+		&inventory,
+	)
+
+	assert.Len(t, inventory.Backups, 1)
+	assert.Equal(t, otherWorkspaceBackup.ID, inventory.Backups[0].ID)
+
+	listedFileNames := make([]string, 0, len(inventory.StorageFileList))
+	for _, file := range inventory.StorageFileList {
+		listedFileNames = append(listedFileNames, file.Name)
+	}
+	assert.ElementsMatch(
+		t,
+		[]string{otherWorkspaceBackup.FileName, otherWorkspaceBackup.FileName + ".metadata"},
+		listedFileNames,
+	)
+}
+
+func Test_GetWorkspaceBackupsByStatus_WithFailedAndCompletedBackups_ReturnsOnlyFailed(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Monitored Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	saveBackup := func(status backups_core.BackupStatus, createdAt time.Time) uuid.UUID {
+		backup := &backups_core.Backup{
+			ID:         uuid.New(),
+			DatabaseID: database.ID,
+			StorageID:  storage.ID,
+			Status:     status,
+			CreatedAt:  createdAt,
+		}
+		assert.NoError(t, backupRepo.Save(backup))
+
+		return backup.ID
+	}
+
+	olderFailedID := saveBackup(backups_core.BackupStatusFailed, now.Add(-2*time.Hour))
+	newerFailedID := saveBackup(backups_core.BackupStatusFailed, now.Add(-time.Hour))
+	saveBackup(backups_core.BackupStatusCompleted, now)
+
+	var failedBackups []*backups_core.BackupWithDatabase
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/workspaces/%s/backups?status=FAILED", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&failedBackups,
+	)
+
+	assert.Len(t, failedBackups, 2)
+	assert.Equal(t, newerFailedID, failedBackups[0].ID)
+	assert.Equal(t, olderFailedID, failedBackups[1].ID)
+	assert.Equal(t, "Monitored Database", failedBackups[0].DatabaseName)
+
+	var limitedBackups []*backups_core.BackupWithDatabase
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/workspaces/%s/backups?status=FAILED&limit=1", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&limitedBackups,
+	)
+	assert.Len(t, limitedBackups, 1)
+
+	test_utils.MakeGetRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/workspaces/%s/backups?status=UNKNOWN", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusBadRequest,
+	)
+}
+
+func Test_WouldExceedQuota_WithEstimatesAroundLimits_ReturnsExpectedResult(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	configService := backups_config.GetBackupConfigService()
+	config, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	config.MaxBackupSizeMB = 50
+	config.MaxBackupsTotalSizeMB = 100
+	_, err = configService.SaveBackupConfig(config)
+	assert.NoError(t, err)
+
+	for range 2 {
+		assert.NoError(t, backupRepo.Save(&backups_core.Backup{
+			ID:              uuid.New(),
+			DatabaseID:      database.ID,
+			StorageID:       storage.ID,
+			Status:          backups_core.BackupStatusCompleted,
+			BackupSizeBytes: 30 * size.BytesInMB,
+			CreatedAt:       time.Now().UTC(),
+		}))
+	}
+
+	tests := []struct {
+		name            string
+		estimatedSizeMb float64
+		isExceeded      bool
+	}{
+		{
+			name:            "estimate under total quota is allowed",
+			estimatedSizeMb: 30,
+			isExceeded:      false,
+		},
+		{
+			name:            "estimate reaching total quota exactly is allowed",
+			estimatedSizeMb: 40,
+			isExceeded:      false,
+		},
+		{
+			name:            "estimate over total quota exceeds it",
+			estimatedSizeMb: 41,
+			isExceeded:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isExceeded, err := GetBackupService().WouldExceedQuota(database.ID, tt.estimatedSizeMb)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.isExceeded, isExceeded)
+		})
+	}
+}
+
+func Test_RotateStorageCredentials_WhenStorageIsNotAccessible_KeepsOldCredentials(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	storageID := uuid.New()
+	storage, err := (&storages.StorageRepository{}).Save(&storages.Storage{
+		ID:          storageID,
+		WorkspaceID: workspace.ID,
+		Type:        storages.StorageTypeS3,
+		Name:        "Test S3 Storage " + uuid.New().String(),
+		S3Storage: &s3_storage.S3Storage{
+			StorageID:   storageID,
+			S3Bucket:    "test-bucket",
+			S3Region:    "us-east-1",
+			S3AccessKey: "old-access-key",
+			S3SecretKey: "old-secret-key",
+			S3Endpoint:  "http://127.0.0.1:1",
+		},
+	})
+	assert.NoError(t, err)
+
+	err = GetBackupService().RotateStorageCredentials(
+		context.Background(),
+		nil,
+		storage.ID,
+		storages.StorageCredentials{AccessKey: "new-access-key", SecretKey: "new-secret-key"},
+	)
+	assert.ErrorIs(t, err, storages.ErrInvalidCredentials)
+
+	savedStorage, err := storages.GetStorageService().GetStorageByID(storage.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "old-access-key", savedStorage.S3Storage.S3AccessKey)
+	assert.Equal(t, "old-secret-key", savedStorage.S3Storage.S3SecretKey)
+
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_RotateStorageCredentials_WhenMemberRotatesSystemStorage_ReturnsBadRequest(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	storageID := uuid.New()
+	storage, err := (&storages.StorageRepository{}).Save(&storages.Storage{
+		ID:          storageID,
+		WorkspaceID: workspace.ID,
+		Type:        storages.StorageTypeS3,
+		Name:        "Test System S3 Storage " + uuid.New().String(),
+		IsSystem:    true,
+		S3Storage: &s3_storage.S3Storage{
+			StorageID:   storageID,
+			S3Bucket:    "test-bucket",
+			S3Region:    "us-east-1",
+			S3AccessKey: "old-access-key",
+			S3SecretKey: "old-secret-key",
+			S3Endpoint:  "http://127.0.0.1:1",
+		},
+	})
+	assert.NoError(t, err)
+
+	response := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/storages/%s/credentials-rotation", storage.ID.String()),
+		"Bearer "+owner.Token,
+		storages.StorageCredentials{AccessKey: "new-access-key", SecretKey: "new-secret-key"},
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(response.Body), "insufficient permissions")
+
+	savedStorage, err := storages.GetStorageService().GetStorageByID(storage.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "old-access-key", savedStorage.S3Storage.S3AccessKey)
+
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_RotateStorageCredentials_ForLocalStorage_ReturnsBadRequest(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	storage := createTestStorage(workspace.ID)
+
+	response := test_utils.MakePostRequest(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/storages/%s/credentials-rotation", storage.ID.String()),
+		"Bearer "+owner.Token,
+		storages.StorageCredentials{AccessKey: "access-key", SecretKey: "secret-key"},
+		http.StatusBadRequest,
+	)
+	assert.Contains(t, string(response.Body), storages.ErrCredentialsRotationNotSupported.Error())
+
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_GetBackupDeletionImpact_ForNewestFreshBackup_ReturnsWarning(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	configService := backups_config.GetBackupConfigService()
+	config, err := configService.GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	config.IsBackupsEnabled = true
+	config.StorageID = &storage.ID
+	config.Storage = storage
+	_, err = configService.SaveBackupConfig(config)
+	assert.NoError(t, err)
+
+	backup := createTestBackup(database, owner)
+
+	var impact BackupDeletionImpact
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/backups/%s/deletion-impact", backup.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&impact,
+	)
+
+	assert.Equal(t, backup.ID, impact.BackupID)
+	assert.True(t, impact.IsNewest)
+	assert.True(t, impact.IsWithinGracePeriod)
+	assert.True(t, impact.IsRetentionOverride)
+	assert.False(t, impact.IsLocked)
+	assert.False(t, impact.HasDependentIncrementals)
+	assert.Contains(t, impact.Warning, "most recent backup")
+
+	databases.RemoveTestDatabase(database)
+	time.Sleep(50 * time.Millisecond)
+	storages.RemoveTestStorage(storage.ID)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_ListRetentionPolicies_WhenBackupsDisabled_ReturnsNonCompliantSummary(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var summaries []RetentionPolicySummary
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		fmt.Sprintf("/api/v1/workspaces/%s/retention-policies", workspace.ID.String()),
+		"Bearer "+owner.Token,
+		http.StatusOK,
+		&summaries,
+	)
+
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, database.ID, summaries[0].DatabaseID)
+	assert.Equal(t, "Test Database", summaries[0].DatabaseName)
+	assert.False(t, summaries[0].IsBackupsEnabled)
+	assert.NotEmpty(t, summaries[0].RetentionSummary)
+	assert.Equal(t, "non-compliant: backups disabled", summaries[0].ComplianceFlag)
+}
+
+func Test_MigrateStorageID_WithBackupsOnOldStorage_AllBackupsReferenceNewStorage(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	oldStorage := createTestStorage(workspace.ID)
+	newStorage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(oldStorage.ID)
+		storages.RemoveTestStorage(newStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	for range 10 {
+		assert.NoError(t, backupRepo.Save(&backups_core.Backup{
+			ID:         uuid.New(),
+			DatabaseID: database.ID,
+			StorageID:  oldStorage.ID,
+			Status:     backups_core.BackupStatusCompleted,
+			CreatedAt:  time.Now().UTC(),
+		}))
+	}
+
+	migratedCount, err := backupRepo.MigrateStorageID(oldStorage.ID, newStorage.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), migratedCount)
+
+	backups, err := backupRepo.FindByDatabaseID(database.ID)
+	assert.NoError(t, err)
+	assert.Len(t, backups, 10)
+	for _, backup := range backups {
+		assert.Equal(t, newStorage.ID, backup.StorageID)
+	}
+
+	oldStorageBackups, err := backupRepo.FindByStorageID(oldStorage.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, oldStorageBackups)
+}
+
+func Test_CreatePointInTimeBackup_ForPostgresDatabase_ReturnsPITRNotSupported(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backup, err := GetBackupService().CreatePointInTimeBackup(
+		context.Background(),
+		database.ID,
+		time.Now().UTC().Add(-time.Hour),
+		owner.UserID,
+	)
+
+	assert.ErrorIs(t, err, backups_core.ErrPITRNotSupported)
+	assert.Nil(t, backup)
+}
+
+func Test_FindDuplicateBackups_WithTwoIdenticalChecksums_BackupsGrouped(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	now := time.Now().UTC()
+	saveBackup := func(checksum string, createdAt time.Time) uuid.UUID {
+		backup := &backups_core.Backup{
+			ID:         uuid.New(),
+			DatabaseID: database.ID,
+			StorageID:  storage.ID,
+			Status:     backups_core.BackupStatusCompleted,
+			Checksum:   &checksum,
+			CreatedAt:  createdAt,
+		}
+		assert.NoError(t, backupRepo.Save(backup))
+
+		return backup.ID
+	}
+
+	olderDuplicateID := saveBackup("same-checksum", now.Add(-2*time.Hour))
+	newerDuplicateID := saveBackup("same-checksum", now.Add(-time.Hour))
+	saveBackup("unique-checksum", now)
+
+	duplicateGroups, err := backupRepo.FindDuplicateBackups(database.ID)
+	assert.NoError(t, err)
+
+	assert.Len(t, duplicateGroups, 1)
+	assert.Len(t, duplicateGroups[0], 2)
+	assert.Equal(t, newerDuplicateID, duplicateGroups[0][0].ID)
+	assert.Equal(t, olderDuplicateID, duplicateGroups[0][1].ID)
+}
+
+func Test_FindAllByStatus_WithBackupsOfSeveralDatabases_ReturnsOldMatchingBackupsOfAll(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	firstDatabase := createTestDatabase("First Database", workspace.ID, owner.Token, router)
+	secondDatabase := createTestDatabase("Second Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+	now := time.Now().UTC()
+
+	defer func() {
+		for _, database := range []*databases.Database{firstDatabase, secondDatabase} {
+			backups, _ := backupRepo.FindByDatabaseID(database.ID)
+			for _, backup := range backups {
+				backupRepo.DeleteByID(backup.ID)
+			}
+
+			databases.RemoveTestDatabase(database)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	saveBackup := func(
+		database *databases.Database,
+		status backups_core.BackupStatus,
+		createdAt time.Time,
+	) uuid.UUID {
+		backup := &backups_core.Backup{
+			ID:         uuid.New(),
+			DatabaseID: database.ID,
+			StorageID:  storage.ID,
+			Status:     status,
+			CreatedAt:  createdAt,
+		}
+		assert.NoError(t, backupRepo.Save(backup))
+
+		return backup.ID
+	}
+
+	firstStaleID := saveBackup(
+		firstDatabase,
+		backups_core.BackupStatusInProgress,
+		now.Add(-2*time.Hour),
+	)
+	secondStaleID := saveBackup(
+		secondDatabase,
+		backups_core.BackupStatusInProgress,
+		now.Add(-3*time.Hour),
+	)
+	freshID := saveBackup(secondDatabase, backups_core.BackupStatusInProgress, now)
+	completedID := saveBackup(
+		firstDatabase,
+		backups_core.BackupStatusCompleted,
+		now.Add(-2*time.Hour),
+	)
+
+	foundBackups, err := backupRepo.FindAllByStatus(
+		backups_core.BackupStatusInProgress,
+		now.Add(-time.Hour),
+	)
+	assert.NoError(t, err)
+
+	foundIDs := make(map[uuid.UUID]bool)
+	for _, backup := range foundBackups {
+		assert.Equal(t, backups_core.BackupStatusInProgress, backup.Status)
+		foundIDs[backup.ID] = true
+	}
+
+	assert.True(t, foundIDs[firstStaleID])
+	assert.True(t, foundIDs[secondStaleID])
+	assert.False(t, foundIDs[freshID])
+	assert.False(t, foundIDs[completedID])
+}
+
+func Test_CompareBackupConfigs_WhenRetentionCountReduced_ReturnsNegativeImpact(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database, _, storage := createTestDatabaseWithBackups(workspace, owner, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	timeOfDay := "04:00"
+	interval := &intervals.Interval{Interval: intervals.IntervalDaily, TimeOfDay: &timeOfDay}
+
+	request := CompareBackupConfigsRequest{
+		ConfigA: backups_config.BackupConfigDTO{
+			DatabaseID:          database.ID,
+			RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+			RetentionCount:      10,
+			BackupInterval:      interval,
+		},
+		ConfigB: backups_config.BackupConfigDTO{
+			DatabaseID:          database.ID,
+			RetentionPolicyType: backups_config.RetentionPolicyTypeCount,
+			RetentionCount:      5,
+			BackupInterval:      interval,
+		},
+	}
+
+	var comparison BackupConfigComparison
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-configs/compare",
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&comparison,
+	)
+
+	assert.Equal(t, []string{"retentionCount"}, comparison.ChangedFields)
+	assert.Equal(t, -5, comparison.EstimatedBackupCountImpact)
+	assert.InDelta(t, -52.5, comparison.EstimatedStorageImpactMB, 0.001)
+	assert.True(t, comparison.IsRetentionReduced)
+	assert.True(t, comparison.RequiresApproval)
+}
+
+func Test_CheckIntegrity_WhenBackupFileIsNotDump_StoresFailedResult(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	storage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(storage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupID := uuid.New()
+	backup := &backups_core.Backup{
+		ID:         backupID,
+		DatabaseID: database.ID,
+		StorageID:  storage.ID,
+		FileName:   backupID.String(),
+		Status:     backups_core.BackupStatusCompleted,
+		CreatedAt:  time.Now().UTC(),
+	}
+	assert.NoError(t, backupRepo.Save(backup))
+
+	err := storage.SaveFile(
+		context.Background(),
+		encryption.GetFieldEncryptor(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		backup.FileName,
+		strings.NewReader("plain text instead of a dump"),
+	)
+	assert.NoError(t, err)
+
+	result, err := GetBackupService().CheckIntegrity(context.Background(), backup.ID)
+	assert.NoError(t, err)
+	assert.False(t, result.IsPassed)
+	assert.Contains(t, result.ErrorDetail, "does not look like")
+
+	latestResult, err := backupRepo.FindLatestIntegrityCheckResult(database.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, latestResult)
+	assert.Equal(t, result.ID, latestResult.ID)
+	assert.Equal(t, backup.ID, latestResult.BackupID)
+}
@@ -1,5 +1,11 @@
 package backups_core
 
+import "time"
+
+// RecentBackupGracePeriod protects fresh backups from automatic cleanup, e.g.
+// while a retention policy is being changed right after a backup
+const RecentBackupGracePeriod = 60 * time.Minute
+
 type BackupStatus string
 
 const (
@@ -8,3 +14,38 @@ const (
 	BackupStatusFailed     BackupStatus = "FAILED"
 	BackupStatusCanceled   BackupStatus = "CANCELED"
 )
+
+// BackupType is FULL for every backup while no engine supports incremental ones
+type BackupType string
+
+const (
+	BackupTypeFull BackupType = "FULL"
+)
+
+// DeletionReason tells why a backup was deleted, so logs, stats and
+// notifications do not have to guess it from the calling code path
+type DeletionReason string
+
+const (
+	DeletionReasonTimePeriod    DeletionReason = "TIME_PERIOD"
+	DeletionReasonCount         DeletionReason = "COUNT"
+	DeletionReasonGFS           DeletionReason = "GFS"
+	DeletionReasonConsolidation DeletionReason = "CONSOLIDATION"
+	DeletionReasonThinning      DeletionReason = "THINNING"
+	DeletionReasonSchedule      DeletionReason = "SCHEDULE"
+	DeletionReasonUnion         DeletionReason = "UNION"
+	DeletionReasonColdRetention DeletionReason = "COLD_RETENTION"
+	DeletionReasonSizeLimit     DeletionReason = "SIZE_LIMIT"
+	DeletionReasonManual        DeletionReason = "MANUAL"
+	DeletionReasonOrphaned      DeletionReason = "ORPHANED"
+	DeletionReasonDatabasePurge DeletionReason = "DATABASE_PURGE"
+	DeletionReasonStorageChange DeletionReason = "STORAGE_CHANGE"
+)
+
+type MigrationJobStatus string
+
+const (
+	MigrationJobStatusInProgress MigrationJobStatus = "IN_PROGRESS"
+	MigrationJobStatusCompleted  MigrationJobStatus = "COMPLETED"
+	MigrationJobStatusFailed     MigrationJobStatus = "FAILED"
+)
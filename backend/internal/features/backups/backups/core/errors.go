@@ -0,0 +1,7 @@
+package backups_core
+
+import "errors"
+
+var ErrPITRNotSupported = errors.New(
+	"point-in-time backups are not supported for this database type",
+)
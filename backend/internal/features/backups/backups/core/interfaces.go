@@ -8,6 +8,8 @@ import (
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/notifiers"
 	"databasus-backend/internal/features/storages"
+
+	"github.com/google/uuid"
 )
 
 type NotificationSender interface {
@@ -16,6 +18,11 @@ type NotificationSender interface {
 		title string,
 		message string,
 	)
+	DeliverNotification(
+		notifier *notifiers.Notifier,
+		title string,
+		message string,
+	) error
 }
 
 type CreateBackupUsecase interface {
@@ -32,3 +39,18 @@ type CreateBackupUsecase interface {
 type BackupRemoveListener interface {
 	OnBeforeBackupRemove(backup *Backup) error
 }
+
+// UsageRecorder receives the total size of database backups after each cleanup
+type UsageRecorder func(databaseID uuid.UUID, totalSizeMB float64)
+
+// Tracer starts spans around cleanup operations. It mirrors the shape of the
+// OpenTelemetry tracer, so an adapter is enough to export the spans
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+type Span interface {
+	SetAttribute(key string, value any)
+	RecordError(err error)
+	End()
+}
@@ -2,9 +2,12 @@ package backups_core
 
 import (
 	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/size"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Backup struct {
@@ -14,17 +17,125 @@ type Backup struct {
 	DatabaseID uuid.UUID `json:"databaseId" gorm:"column:database_id;type:uuid;not null"`
 	StorageID  uuid.UUID `json:"storageId"  gorm:"column:storage_id;type:uuid;not null"`
 
+	// GroupID is set when the backup was started as part of a backup group
+	GroupID *uuid.UUID `json:"groupId" gorm:"column:group_id;type:uuid"`
+
+	// RetentionLabel is the retention class set by the backup creator, e.g.
+	// "release". Labels listed in the config are never deleted by retention
+	RetentionLabel string `json:"retentionLabel" gorm:"column:retention_label;type:text;not null;default:''"`
+
 	Status      BackupStatus `json:"status"      gorm:"column:status;not null"`
 	FailMessage *string      `json:"failMessage" gorm:"column:fail_message"`
 	IsSkipRetry bool         `json:"isSkipRetry" gorm:"column:is_skip_retry;type:boolean;not null"`
 
-	BackupSizeMb float64 `json:"backupSizeMb" gorm:"column:backup_size_mb;default:0"`
+	// IsFailedFileDeleted marks failed backups whose partial file was removed on
+	// failure. Their empty FileName is expected and must not get the row cleaned
+	IsFailedFileDeleted bool `json:"isFailedFileDeleted" gorm:"column:is_failed_file_deleted;type:boolean;not null;default:false"`
+
+	// BackupSizeBytes is the authoritative size, BackupSizeMb is derived from it for display
+	BackupSizeBytes int64   `json:"backupSizeBytes" gorm:"column:backup_size_bytes;type:bigint;not null;default:0"`
+	BackupSizeMb    float64 `json:"backupSizeMb"    gorm:"column:backup_size_mb;default:0"`
 
 	BackupDurationMs int64 `json:"backupDurationMs" gorm:"column:backup_duration_ms;default:0"`
+	// CompressionDurationSeconds is time spent in the compression writer. It stays 0 when
+	// compression is done by the dump tool itself (e.g. pg_dump)
+	CompressionDurationSeconds float64 `json:"compressionDurationSeconds" gorm:"column:compression_duration_seconds;type:double precision;not null;default:0"`
 
 	EncryptionSalt *string                         `json:"-"          gorm:"column:encryption_salt"`
 	EncryptionIV   *string                         `json:"-"          gorm:"column:encryption_iv"`
 	Encryption     backups_config.BackupEncryption `json:"encryption" gorm:"column:encryption;type:text;not null;default:'NONE'"`
 
+	// EncryptionKeyID is the workspace key the backup was encrypted with, nil
+	// for the instance master key. Decryption must use it even after the
+	// config switches to another key
+	EncryptionKeyID *uuid.UUID `json:"encryptionKeyId" gorm:"column:encryption_key_id;type:uuid"`
+
+	// StorageClass is copied from the config when the backup is created, so it
+	// stays correct after the config changes
+	StorageClass backups_config.StorageClass `json:"storageClass" gorm:"column:storage_class;type:text;not null;default:'STANDARD'"`
+
+	// Checksum is the SHA-256 hex digest of the backup file, nil when it was not
+	// computed. Equal checksums of one database mean the data did not change
+	Checksum *string `json:"checksum" gorm:"column:checksum;type:text"`
+
 	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+
+	// Storage is loaded only by FindCompletedByDatabaseWithStorage and never
+	// saved together with the backup
+	Storage *storages.Storage `json:"-" gorm:"foreignKey:StorageID"`
+}
+
+func (b *Backup) BeforeSave(tx *gorm.DB) error {
+	// prefer bytes when present, otherwise keep bytes in sync with MB
+	// set by older code paths
+	if b.BackupSizeBytes > 0 {
+		b.BackupSizeMb = size.BytesToMB(b.BackupSizeBytes)
+	} else if b.BackupSizeMb > 0 {
+		b.BackupSizeBytes = size.MBToBytes(b.BackupSizeMb)
+	}
+
+	return nil
+}
+
+func (b *Backup) SetSizeBytes(sizeBytes int64) {
+	b.BackupSizeBytes = sizeBytes
+	b.BackupSizeMb = size.BytesToMB(sizeBytes)
+}
+
+func (b *Backup) GetSizeBytes() int64 {
+	if b.BackupSizeBytes > 0 {
+		return b.BackupSizeBytes
+	}
+
+	return size.MBToBytes(b.BackupSizeMb)
+}
+
+type DatabaseStorageSize struct {
+	DatabaseID uuid.UUID
+	StorageID  uuid.UUID
+	SizeBytes  int64
+}
+
+// BackupWithDatabase is a backup listed across databases, with the database name
+// so it can be shown without loading every database
+type BackupWithDatabase struct {
+	Backup
+
+	DatabaseName string `json:"databaseName" gorm:"column:database_name"`
+}
+
+// MigrationJob tracks moving the backups of a database into another storage class
+type MigrationJob struct {
+	ID                 uuid.UUID                   `json:"id"                 gorm:"column:id;type:uuid;primaryKey"`
+	DatabaseID         uuid.UUID                   `json:"databaseId"         gorm:"column:database_id;type:uuid;not null"`
+	TargetStorageClass backups_config.StorageClass `json:"targetStorageClass" gorm:"column:target_storage_class;type:text;not null"`
+	Status             MigrationJobStatus          `json:"status"             gorm:"column:status;type:text;not null"`
+
+	TotalCount    int     `json:"totalCount"    gorm:"column:total_count;type:int;not null"`
+	MigratedCount int     `json:"migratedCount" gorm:"column:migrated_count;type:int;not null"`
+	FailedCount   int     `json:"failedCount"   gorm:"column:failed_count;type:int;not null"`
+	LastError     *string `json:"lastError"     gorm:"column:last_error;type:text"`
+
+	CreatedAt   time.Time  `json:"createdAt"   gorm:"column:created_at"`
+	CompletedAt *time.Time `json:"completedAt" gorm:"column:completed_at"`
+}
+
+func (j *MigrationJob) TableName() string {
+	return "backup_migration_jobs"
+}
+
+// IntegrityCheckResult records one check of whether a backup can be read
+// back and starts with the header of a dump of its database type
+type IntegrityCheckResult struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	BackupID    uuid.UUID `json:"backupId"    gorm:"column:backup_id;type:uuid;not null"`
+	DatabaseID  uuid.UUID `json:"databaseId"  gorm:"column:database_id;type:uuid;not null"`
+	IsPassed    bool      `json:"isPassed"    gorm:"column:is_passed;type:boolean;not null"`
+	ErrorDetail string    `json:"errorDetail" gorm:"column:error_detail;type:text;not null"`
+	DurationMs  int64     `json:"durationMs"  gorm:"column:duration_ms;type:bigint;not null"`
+	CreatedAt   time.Time `json:"createdAt"   gorm:"column:created_at"`
+}
+
+func (r *IntegrityCheckResult) TableName() string {
+	return "backup_integrity_checks"
 }
@@ -1,7 +1,11 @@
 package backups_core
 
 import (
+	"context"
+	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/storage"
+	"databasus-backend/internal/util/period"
+	"databasus-backend/internal/util/size"
 	"errors"
 
 	"time"
@@ -10,6 +14,8 @@ import (
 	"gorm.io/gorm"
 )
 
+const findAllByStatusPageSize = 500
+
 type BackupRepository struct{}
 
 func (r *BackupRepository) Save(backup *Backup) error {
@@ -22,11 +28,13 @@ func (r *BackupRepository) Save(backup *Backup) error {
 	isNew := backup.ID == uuid.Nil
 	if isNew {
 		backup.ID = uuid.New()
-		return db.Create(backup).
+		return db.Omit("Storage").
+			Create(backup).
 			Error
 	}
 
-	return db.Save(backup).
+	return db.Omit("Storage").
+		Save(backup).
 		Error
 }
 
@@ -44,6 +52,33 @@ func (r *BackupRepository) FindByDatabaseID(databaseID uuid.UUID) ([]*Backup, er
 	return backups, nil
 }
 
+// FindCompletedByDatabaseWithStorage loads the storage of every backup in the
+// same query, so deleting many backups does not look the storage up per backup
+func (r *BackupRepository) FindCompletedByDatabaseWithStorage(
+	databaseID uuid.UUID,
+) ([]*Backup, error) {
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		Preload("Storage").
+		Preload("Storage.LocalStorage").
+		Preload("Storage.S3Storage").
+		Preload("Storage.GoogleDriveStorage").
+		Preload("Storage.NASStorage").
+		Preload("Storage.AzureBlobStorage").
+		Preload("Storage.FTPStorage").
+		Preload("Storage.SFTPStorage").
+		Preload("Storage.RcloneStorage").
+		Where("database_id = ? AND status = ?", databaseID, BackupStatusCompleted).
+		Order("created_at DESC").
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
 func (r *BackupRepository) FindByDatabaseIDWithLimit(
 	databaseID uuid.UUID,
 	limit int,
@@ -66,6 +101,30 @@ func (r *BackupRepository) FindByDatabaseIDWithLimit(
 	return backups, nil
 }
 
+func (r *BackupRepository) FindWithDatabaseByWorkspaceIDAndStatus(
+	ctx context.Context,
+	workspaceID uuid.UUID,
+	status BackupStatus,
+	limit int,
+) ([]*BackupWithDatabase, error) {
+	backups := []*BackupWithDatabase{}
+
+	if err := storage.
+		GetDb().
+		WithContext(ctx).
+		Table("backups").
+		Select("backups.*, databases.name AS database_name").
+		Joins("JOIN databases ON databases.id = backups.database_id").
+		Where("databases.workspace_id = ? AND backups.status = ?", workspaceID, status).
+		Order("backups.created_at DESC").
+		Limit(limit).
+		Scan(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
 func (r *BackupRepository) FindByStorageID(storageID uuid.UUID) ([]*Backup, error) {
 	var backups []*Backup
 
@@ -80,12 +139,33 @@ func (r *BackupRepository) FindByStorageID(storageID uuid.UUID) ([]*Backup, erro
 	return backups, nil
 }
 
-func (r *BackupRepository) FindLastByDatabaseID(databaseID uuid.UUID) (*Backup, error) {
+// FindNewestByDatabaseID returns the most recently created backup of the database,
+// limited to the given statuses when any are passed
+func (r *BackupRepository) FindNewestByDatabaseID(
+	databaseID uuid.UUID,
+	statuses ...BackupStatus,
+) (*Backup, error) {
+	return r.findFirstByDatabaseID(databaseID, "created_at DESC", statuses)
+}
+
+// FindOldestByDatabaseID returns the earliest created backup of the database,
+// limited to the given statuses when any are passed
+func (r *BackupRepository) FindOldestByDatabaseID(
+	databaseID uuid.UUID,
+	statuses ...BackupStatus,
+) (*Backup, error) {
+	return r.findFirstByDatabaseID(databaseID, "created_at ASC", statuses)
+}
+
+func (r *BackupRepository) FindLastByDatabaseIDAndGroupID(
+	databaseID uuid.UUID,
+	groupID uuid.UUID,
+) (*Backup, error) {
 	var backup Backup
 
 	if err := storage.
 		GetDb().
-		Where("database_id = ?", databaseID).
+		Where("database_id = ? AND group_id = ?", databaseID, groupID).
 		Order("created_at DESC").
 		First(&backup).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -111,6 +191,22 @@ func (r *BackupRepository) FindByID(id uuid.UUID) (*Backup, error) {
 	return &backup, nil
 }
 
+// FindBackupChain returns the backups needed to restore the backup, from the
+// base one. All backups are full for now, so the chain is the backup itself or
+// empty when the backup does not exist
+func (r *BackupRepository) FindBackupChain(backupID uuid.UUID) ([]*Backup, error) {
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		Where("id = ?", backupID).
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
 func (r *BackupRepository) FindByStatus(status BackupStatus) ([]*Backup, error) {
 	var backups []*Backup
 
@@ -125,6 +221,178 @@ func (r *BackupRepository) FindByStatus(status BackupStatus) ([]*Backup, error)
 	return backups, nil
 }
 
+// FindAllByStatus returns backups of every database with the status created
+// before olderThan, ordered by ID. Rows are loaded page by page so a large
+// backlog does not have to be read by a single query
+func (r *BackupRepository) FindAllByStatus(
+	status BackupStatus,
+	olderThan time.Time,
+) ([]*Backup, error) {
+	var backups []*Backup
+	var afterID *uuid.UUID
+
+	for {
+		page, err := r.findByStatusPage(status, olderThan, afterID, findAllByStatusPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		backups = append(backups, page...)
+
+		if len(page) < findAllByStatusPageSize {
+			return backups, nil
+		}
+
+		afterID = &page[len(page)-1].ID
+	}
+}
+
+func (r *BackupRepository) FindWithoutFileNameExcludingInProgress(
+	ctx context.Context,
+) ([]*Backup, error) {
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		WithContext(ctx).
+		Where(
+			"file_name = '' AND status != ? AND is_failed_file_deleted = FALSE",
+			BackupStatusInProgress,
+		).
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+// FindForDeletion returns finished backups ordered by ID, starting after afterID
+// when it is set. Ordering by ID keeps the cursor stable while rows are deleted
+func (r *BackupRepository) FindForDeletion(
+	databaseID uuid.UUID,
+	createdBefore time.Time,
+	afterID *uuid.UUID,
+	limit int,
+) ([]*Backup, error) {
+	var backups []*Backup
+
+	query := storage.
+		GetDb().
+		Where(
+			"database_id = ? AND created_at < ? AND status != ?",
+			databaseID,
+			createdBefore,
+			BackupStatusInProgress,
+		)
+
+	if afterID != nil {
+		query = query.Where("id > ?", *afterID)
+	}
+
+	if err := query.
+		Order("id ASC").
+		Limit(limit).
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+// FindDeletionCandidates returns backups which the retention policy of the config
+// may delete, newest first. Backups younger than RecentBackupGracePeriod are
+// excluded. GFS, thinning, schedule and union keep sets depend on every completed backup, so for
+// them all completed backups are returned and the caller applies the keep set
+// and the grace period
+func (r *BackupRepository) FindDeletionCandidates(
+	ctx context.Context,
+	config *backups_config.BackupConfig,
+	now time.Time,
+) ([]*Backup, error) {
+	graceCutoff := now.Add(-RecentBackupGracePeriod)
+
+	switch config.RetentionPolicyType {
+	case backups_config.RetentionPolicyTypeGFS,
+		backups_config.RetentionPolicyTypeThinning,
+		backups_config.RetentionPolicyTypeSchedule,
+		backups_config.RetentionPolicyTypeUnion:
+		return r.FindByDatabaseIdAndStatus(ctx, config.DatabaseID, BackupStatusCompleted)
+
+	case backups_config.RetentionPolicyTypeCount:
+		if config.RetentionCount <= 0 {
+			return []*Backup{}, nil
+		}
+
+		beyondCountBackups := storage.
+			GetDb().
+			WithContext(ctx).
+			Model(&Backup{}).
+			Where("database_id = ? AND status = ?", config.DatabaseID, BackupStatusCompleted).
+			Order("created_at DESC").
+			Offset(config.RetentionCount)
+
+		return r.findCandidates(
+			storage.GetDb().WithContext(ctx).Table("(?) AS backups", beyondCountBackups),
+			graceCutoff,
+		)
+
+	case backups_config.RetentionPolicyTypeHotCold:
+		if config.ColdRetention == "" || config.ColdRetention == period.PeriodForever {
+			return []*Backup{}, nil
+		}
+
+		coldDeadline := now.Add(-config.ColdRetention.ToDuration())
+
+		return r.findCandidates(
+			storage.GetDb().WithContext(ctx).Where(
+				"database_id = ? AND status = ? AND created_at < ?",
+				config.DatabaseID,
+				BackupStatusCompleted,
+				coldDeadline,
+			),
+			graceCutoff,
+		)
+
+	default:
+		if config.RetentionTimePeriod == "" || config.RetentionTimePeriod == period.PeriodForever {
+			return []*Backup{}, nil
+		}
+
+		retentionDeadline := now.Add(-config.RetentionTimePeriod.ToDuration())
+
+		return r.findCandidates(
+			storage.GetDb().WithContext(ctx).Where(
+				"database_id = ? AND created_at < ?",
+				config.DatabaseID,
+				retentionDeadline,
+			),
+			graceCutoff,
+		)
+	}
+}
+
+func (r *BackupRepository) CountForDeletion(
+	databaseID uuid.UUID,
+	createdBefore time.Time,
+) (int64, error) {
+	var count int64
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Where(
+			"database_id = ? AND created_at < ? AND status != ?",
+			databaseID,
+			createdBefore,
+			BackupStatusInProgress,
+		).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (r *BackupRepository) FindByStorageIdAndStatus(
 	storageID uuid.UUID,
 	status BackupStatus,
@@ -143,6 +411,7 @@ func (r *BackupRepository) FindByStorageIdAndStatus(
 }
 
 func (r *BackupRepository) FindByDatabaseIdAndStatus(
+	ctx context.Context,
 	databaseID uuid.UUID,
 	status BackupStatus,
 ) ([]*Backup, error) {
@@ -150,6 +419,7 @@ func (r *BackupRepository) FindByDatabaseIdAndStatus(
 
 	if err := storage.
 		GetDb().
+		WithContext(ctx).
 		Where("database_id = ? AND status = ?", databaseID, status).
 		Order("created_at DESC").
 		Find(&backups).Error; err != nil {
@@ -159,10 +429,101 @@ func (r *BackupRepository) FindByDatabaseIdAndStatus(
 	return backups, nil
 }
 
+// FindDuplicateBackups groups completed backups of the database by equal checksum.
+// Only groups of two or more backups are returned, each ordered newest first
+func (r *BackupRepository) FindDuplicateBackups(databaseID uuid.UUID) ([][]*Backup, error) {
+	db := storage.GetDb()
+
+	duplicateChecksums := db.
+		Model(&Backup{}).
+		Select("checksum").
+		Where(
+			"database_id = ? AND status = ? AND checksum IS NOT NULL",
+			databaseID,
+			BackupStatusCompleted,
+		).
+		Group("checksum").
+		Having("COUNT(*) > 1")
+
+	var backups []*Backup
+	if err := db.
+		Where(
+			"database_id = ? AND status = ? AND checksum IN (?)",
+			databaseID,
+			BackupStatusCompleted,
+			duplicateChecksums,
+		).
+		Order("checksum, created_at DESC").
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	groups := [][]*Backup{}
+	for i, backup := range backups {
+		if i == 0 || *backup.Checksum != *backups[i-1].Checksum {
+			groups = append(groups, []*Backup{})
+		}
+
+		groups[len(groups)-1] = append(groups[len(groups)-1], backup)
+	}
+
+	return groups, nil
+}
+
 func (r *BackupRepository) DeleteByID(id uuid.UUID) error {
 	return storage.GetDb().Delete(&Backup{}, "id = ?", id).Error
 }
 
+// DeleteByIDs removes all the backups with a single statement and returns the
+// number of deleted records
+func (r *BackupRepository) DeleteByIDs(ids []uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := storage.GetDb().Where("id IN ?", ids).Delete(&Backup{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteByDatabaseIDWithConfig deletes all backup rows of the database together
+// with its backup config in one transaction, so a failure never leaves backups
+// without a config or the other way round
+func (r *BackupRepository) DeleteByDatabaseIDWithConfig(databaseID uuid.UUID) error {
+	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("database_id = ?", databaseID).Delete(&Backup{}).Error; err != nil {
+			return err
+		}
+
+		backupConfigRepository := &backups_config.BackupConfigRepository{}
+
+		return backupConfigRepository.DeleteByDatabaseIDInTx(tx, databaseID)
+	})
+}
+
+// MigrateStorageID repoints all backups of the old storage to the new one after
+// their files were moved, returning the number of updated backups
+func (r *BackupRepository) MigrateStorageID(oldStorageID, newStorageID uuid.UUID) (int64, error) {
+	var migratedCount int64
+
+	err := storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&Backup{}).
+			Where("storage_id = ?", oldStorageID).
+			Update("storage_id", newStorageID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		migratedCount = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return migratedCount, nil
+}
+
 func (r *BackupRepository) FindBackupsBeforeDate(
 	databaseID uuid.UUID,
 	date time.Time,
@@ -180,6 +541,23 @@ func (r *BackupRepository) FindBackupsBeforeDate(
 	return backups, nil
 }
 
+func (r *BackupRepository) FindBackupsAfterDate(
+	databaseID uuid.UUID,
+	date time.Time,
+) ([]*Backup, error) {
+	var backups []*Backup
+
+	if err := storage.
+		GetDb().
+		Where("database_id = ? AND created_at >= ?", databaseID, date).
+		Order("created_at ASC").
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
 func (r *BackupRepository) FindByDatabaseIDWithPagination(
 	databaseID uuid.UUID,
 	limit, offset int,
@@ -214,21 +592,85 @@ func (r *BackupRepository) CountByDatabaseID(databaseID uuid.UUID) (int64, error
 }
 
 func (r *BackupRepository) GetTotalSizeByDatabase(databaseID uuid.UUID) (float64, error) {
-	var totalSize float64
+	totalSizeBytes, err := r.GetTotalSizeBytesByDatabase(context.Background(), databaseID)
+	if err != nil {
+		return 0, err
+	}
+
+	return size.BytesToMB(totalSizeBytes), nil
+}
+
+func (r *BackupRepository) GetTotalSizeBytesByDatabase(
+	ctx context.Context,
+	databaseID uuid.UUID,
+) (int64, error) {
+	var totalSizeBytes int64
 
 	if err := storage.
 		GetDb().
+		WithContext(ctx).
 		Model(&Backup{}).
-		Select("COALESCE(SUM(backup_size_mb), 0)").
+		Select("COALESCE(SUM(backup_size_bytes), 0)").
 		Where("database_id = ? AND status != ?", databaseID, BackupStatusInProgress).
-		Scan(&totalSize).Error; err != nil {
+		Scan(&totalSizeBytes).Error; err != nil {
+		return 0, err
+	}
+
+	return totalSizeBytes, nil
+}
+
+// GetTotalSizeBytesCreatedAfterByDatabase sums finished backups created at or
+// after createdAfter, the same backups GetTotalSizeBytesByDatabase counts
+func (r *BackupRepository) GetTotalSizeBytesCreatedAfterByDatabase(
+	ctx context.Context,
+	databaseID uuid.UUID,
+	createdAfter time.Time,
+) (int64, error) {
+	var totalSizeBytes int64
+
+	if err := storage.
+		GetDb().
+		WithContext(ctx).
+		Model(&Backup{}).
+		Select("COALESCE(SUM(backup_size_bytes), 0)").
+		Where(
+			"database_id = ? AND status != ? AND created_at >= ?",
+			databaseID,
+			BackupStatusInProgress,
+			createdAfter,
+		).
+		Scan(&totalSizeBytes).Error; err != nil {
 		return 0, err
 	}
 
-	return totalSize, nil
+	return totalSizeBytes, nil
+}
+
+// GetSizeBytesByDatabaseAndStorage sums sizes of finished backups of the databases
+// grouped by database and storage
+func (r *BackupRepository) GetSizeBytesByDatabaseAndStorage(
+	databaseIDs []uuid.UUID,
+) ([]*DatabaseStorageSize, error) {
+	sizes := []*DatabaseStorageSize{}
+	if len(databaseIDs) == 0 {
+		return sizes, nil
+	}
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Select("database_id, storage_id, COALESCE(SUM(backup_size_bytes), 0) AS size_bytes").
+		Where("database_id IN ? AND status != ?", databaseIDs, BackupStatusInProgress).
+		Group("database_id, storage_id").
+		Scan(&sizes).Error; err != nil {
+		return nil, err
+	}
+
+	return sizes, nil
 }
 
 func (r *BackupRepository) FindOldestByDatabaseExcludingInProgress(
+	ctx context.Context,
 	databaseID uuid.UUID,
 	limit int,
 ) ([]*Backup, error) {
@@ -236,6 +678,7 @@ func (r *BackupRepository) FindOldestByDatabaseExcludingInProgress(
 
 	if err := storage.
 		GetDb().
+		WithContext(ctx).
 		Where("database_id = ? AND status != ?", databaseID, BackupStatusInProgress).
 		Order("created_at ASC").
 		Limit(limit).
@@ -245,3 +688,223 @@ func (r *BackupRepository) FindOldestByDatabaseExcludingInProgress(
 
 	return backups, nil
 }
+
+// SumSizeMBByDate returns total size of completed backups per day ("YYYY-MM-DD")
+// for the last `days` days, including today
+func (r *BackupRepository) SumSizeMBByDate(
+	databaseID uuid.UUID,
+	days int,
+) (map[string]float64, error) {
+	if days <= 0 {
+		return nil, errors.New("days must be greater than 0")
+	}
+
+	var rows []struct {
+		Day            string
+		TotalSizeBytes int64
+	}
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Select("TO_CHAR(DATE(created_at), 'YYYY-MM-DD') AS day, COALESCE(SUM(backup_size_bytes), 0) AS total_size_bytes").
+		Where(
+			"database_id = ? AND created_at >= ? AND status = ?",
+			databaseID,
+			getStartOfDaysWindow(days),
+			BackupStatusCompleted,
+		).
+		Group("DATE(created_at)").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	sizeByDate := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		sizeByDate[row.Day] = size.BytesToMB(row.TotalSizeBytes)
+	}
+
+	return sizeByDate, nil
+}
+
+// CountByDate returns count of completed backups per day ("YYYY-MM-DD")
+// for the last `days` days, including today
+func (r *BackupRepository) CountByDate(
+	databaseID uuid.UUID,
+	days int,
+) (map[string]int, error) {
+	if days <= 0 {
+		return nil, errors.New("days must be greater than 0")
+	}
+
+	var rows []struct {
+		Day   string
+		Count int
+	}
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Select("TO_CHAR(DATE(created_at), 'YYYY-MM-DD') AS day, COUNT(*) AS count").
+		Where(
+			"database_id = ? AND created_at >= ? AND status = ?",
+			databaseID,
+			getStartOfDaysWindow(days),
+			BackupStatusCompleted,
+		).
+		Group("DATE(created_at)").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	countByDate := make(map[string]int, len(rows))
+	for _, row := range rows {
+		countByDate[row.Day] = row.Count
+	}
+
+	return countByDate, nil
+}
+
+// CountByStatusAfterDate returns count of backups per status created after date
+func (r *BackupRepository) CountByStatusAfterDate(
+	databaseID uuid.UUID,
+	date time.Time,
+) (map[BackupStatus]int, error) {
+	var rows []struct {
+		Status BackupStatus
+		Count  int
+	}
+
+	if err := storage.
+		GetDb().
+		Model(&Backup{}).
+		Select("status, COUNT(*) AS count").
+		Where("database_id = ? AND created_at > ?", databaseID, date).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	countByStatus := make(map[BackupStatus]int, len(rows))
+	for _, row := range rows {
+		countByStatus[row.Status] = row.Count
+	}
+
+	return countByStatus, nil
+}
+
+func getStartOfDaysWindow(days int) time.Time {
+	now := time.Now().UTC()
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return startOfToday.AddDate(0, 0, -(days - 1))
+}
+
+func (r *BackupRepository) SaveMigrationJob(job *MigrationJob) error {
+	return storage.GetDb().Save(job).Error
+}
+
+func (r *BackupRepository) FindMigrationJobByID(id uuid.UUID) (*MigrationJob, error) {
+	var job MigrationJob
+
+	if err := storage.GetDb().Where("id = ?", id).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *BackupRepository) SaveIntegrityCheckResult(result *IntegrityCheckResult) error {
+	return storage.GetDb().Save(result).Error
+}
+
+func (r *BackupRepository) FindLatestIntegrityCheckResult(
+	databaseID uuid.UUID,
+) (*IntegrityCheckResult, error) {
+	var result IntegrityCheckResult
+
+	if err := storage.
+		GetDb().
+		Where("database_id = ?", databaseID).
+		Order("created_at DESC").
+		First(&result).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (r *BackupRepository) findFirstByDatabaseID(
+	databaseID uuid.UUID,
+	order string,
+	statuses []BackupStatus,
+) (*Backup, error) {
+	var backup Backup
+
+	query := storage.
+		GetDb().
+		Where("database_id = ?", databaseID)
+
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	if err := query.
+		Order(order).
+		First(&backup).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &backup, nil
+}
+
+func (r *BackupRepository) findByStatusPage(
+	status BackupStatus,
+	olderThan time.Time,
+	afterID *uuid.UUID,
+	limit int,
+) ([]*Backup, error) {
+	var backups []*Backup
+
+	query := storage.
+		GetDb().
+		Where("status = ? AND created_at < ?", status, olderThan)
+
+	if afterID != nil {
+		query = query.Where("id > ?", *afterID)
+	}
+
+	if err := query.
+		Order("id ASC").
+		Limit(limit).
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
+
+func (r *BackupRepository) findCandidates(query *gorm.DB, graceCutoff time.Time) ([]*Backup, error) {
+	var backups []*Backup
+
+	if err := query.
+		Where("created_at < ?", graceCutoff).
+		Order("created_at DESC").
+		Find(&backups).Error; err != nil {
+		return nil, err
+	}
+
+	return backups, nil
+}
@@ -3,6 +3,7 @@ package backups
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 
 	audit_logs "databasus-backend/internal/features/audit_logs"
 	"databasus-backend/internal/features/backups/backups/backuping"
@@ -11,11 +12,13 @@ import (
 	"databasus-backend/internal/features/backups/backups/usecases"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/features/notifiers"
+	plans "databasus-backend/internal/features/plan"
 	"databasus-backend/internal/features/storages"
 	task_cancellation "databasus-backend/internal/features/tasks/cancellation"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	cache_utils "databasus-backend/internal/util/cache"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/logger"
 )
@@ -31,7 +34,8 @@ var backupService = &BackupService{
 	notifiers.GetNotifierService(),
 	notifiers.GetNotifierService(),
 	backups_config.GetBackupConfigService(),
-	encryption_secrets.GetSecretKeyService(),
+	plans.GetDatabasePlanService(),
+	encryption_keys.GetWorkspaceEncryptionKeyService(),
 	encryption.GetFieldEncryptor(),
 	usecases.GetCreateBackupUsecase(),
 	logger.GetLogger(),
@@ -42,6 +46,57 @@ var backupService = &BackupService{
 	backups_download.GetDownloadTokenService(),
 	backuping.GetBackupsScheduler(),
 	backuping.GetBackupCleaner(),
+	backuping.GetScheduledCleanupJob(),
+	backuping.GetDeletionJobWorker(),
+	cache_utils.NewCacheUtil[DatabaseBackupHealth](
+		cache_utils.GetValkeyClient(),
+		"backup_health:",
+	),
+	cache_utils.NewCacheUtil[WorkspaceCoverageReport](
+		cache_utils.GetValkeyClient(),
+		"workspace_backup_coverage:",
+	),
+	cache_utils.NewCacheUtil[int](
+		cache_utils.GetValkeyClient(),
+		"backup_compliance_score:",
+	),
+}
+
+var backupEncryptionVerificationJob = &BackupEncryptionVerificationJob{
+	backupService,
+	backupRepository,
+	logger.GetLogger(),
+	sync.Once{},
+	atomic.Bool{},
+}
+
+var backupIntegrityCheckJob = &BackupIntegrityCheckJob{
+	backupService,
+	backupRepository,
+	backups_config.GetBackupConfigService(),
+	logger.GetLogger(),
+	sync.Once{},
+	atomic.Bool{},
+}
+
+var storageQuotaWarningJob = &StorageQuotaWarningJob{
+	backupService,
+	workspaces_services.GetWorkspaceService(),
+	logger.GetLogger(),
+	sync.Once{},
+	atomic.Bool{},
+}
+
+var missedBackupCheckJob = &MissedBackupCheckJob{
+	backupService,
+	backups_config.GetBackupConfigService(),
+	cache_utils.NewCacheUtil[time.Time](
+		cache_utils.GetValkeyClient(),
+		"missed_backup_alert:",
+	),
+	logger.GetLogger(),
+	sync.Once{},
+	atomic.Bool{},
 }
 
 var backupController = &BackupController{
@@ -56,6 +111,22 @@ func GetBackupController() *BackupController {
 	return backupController
 }
 
+func GetBackupEncryptionVerificationJob() *BackupEncryptionVerificationJob {
+	return backupEncryptionVerificationJob
+}
+
+func GetBackupIntegrityCheckJob() *BackupIntegrityCheckJob {
+	return backupIntegrityCheckJob
+}
+
+func GetStorageQuotaWarningJob() *StorageQuotaWarningJob {
+	return storageQuotaWarningJob
+}
+
+func GetMissedBackupCheckJob() *MissedBackupCheckJob {
+	return missedBackupCheckJob
+}
+
 var (
 	setupOnce sync.Once
 	isSetup   atomic.Bool
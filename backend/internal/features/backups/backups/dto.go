@@ -3,7 +3,12 @@ package backups
 import (
 	backups_core "databasus-backend/internal/features/backups/backups/core"
 	"databasus-backend/internal/features/backups/backups/encryption"
+	backups_config "databasus-backend/internal/features/backups/config"
+	"databasus-backend/internal/features/storages"
 	"io"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type GetBackupsRequest struct {
@@ -19,6 +24,91 @@ type GetBackupsResponse struct {
 	Offset  int                    `json:"offset"`
 }
 
+type EncryptionVerificationResult struct {
+	BackupID      uuid.UUID `json:"backupId"`
+	IsDecryptable bool      `json:"isDecryptable"`
+	// KeyID is a fingerprint of the master key used for verification
+	KeyID       string `json:"keyId"`
+	ErrorDetail string `json:"errorDetail"`
+}
+
+type MissedBackup struct {
+	ExpectedAt time.Time `json:"expectedAt"`
+	GapHours   float64   `json:"gapHours"`
+}
+
+type ScheduledBackupInfo struct {
+	NextRunAt           time.Time `json:"nextRunAt"`
+	IsEnabled           bool      `json:"isEnabled"`
+	IntervalDescription string    `json:"intervalDescription"`
+}
+
+type PurgeReport struct {
+	DeletedBackupCount int     `json:"deletedBackupCount"`
+	FreedMB            float64 `json:"freedMb"`
+	Errors             []error `json:"-"`
+}
+
+type RetentionPolicySummary struct {
+	DatabaseID          uuid.UUID                          `json:"databaseId"`
+	DatabaseName        string                             `json:"databaseName"`
+	RetentionPolicyType backups_config.RetentionPolicyType `json:"retentionPolicyType"`
+	RetentionSummary    string                             `json:"retentionSummary"`
+	IsBackupsEnabled    bool                               `json:"isBackupsEnabled"`
+	IsReadOnlyMode      bool                               `json:"isReadOnlyMode"`
+	// LastModified is the last retention change, nil if retention was never changed
+	LastModified   *time.Time `json:"lastModified"`
+	ComplianceFlag string     `json:"complianceFlag"`
+}
+
+type StorageUsageSummary struct {
+	TotalSizeGB float64                `json:"totalSizeGb"`
+	ByStorage   []StorageUsage         `json:"byStorage"`
+	ByDatabase  []DatabaseStorageUsage `json:"byDatabase"`
+	// EstimatedMonthlyCostUSD counts only storages with configured cost,
+	// nil when none of them has it
+	EstimatedMonthlyCostUSD *float64 `json:"estimatedMonthlyCostUsd"`
+}
+
+type StorageUsage struct {
+	StorageID   uuid.UUID `json:"storageId"`
+	StorageName string    `json:"storageName"`
+	SizeGB      float64   `json:"sizeGb"`
+}
+
+type DatabaseStorageUsage struct {
+	DatabaseID uuid.UUID `json:"databaseId"`
+	SizeGB     float64   `json:"sizeGb"`
+}
+
+type ScheduleCleanupRequest struct {
+	At time.Time `json:"at" binding:"required"`
+}
+
+type GetStorageCostEstimateRequest struct {
+	SizeGB float64 `form:"sizeGB" binding:"required,gt=0"`
+	Months int     `form:"months" binding:"required,gt=0"`
+}
+
+type CostEstimate struct {
+	StorageID       uuid.UUID `json:"storageId"`
+	ProjectedSizeGB float64   `json:"projectedSizeGb"`
+	Months          int       `json:"months"`
+
+	// IsCostUnknown is true when the storage has no price configured, all
+	// costs are 0 then
+	IsCostUnknown  bool                   `json:"isCostUnknown"`
+	MonthlyCostUSD float64                `json:"monthlyCostUsd"`
+	TotalCostUSD   float64                `json:"totalCostUsd"`
+	CostBreakdown  []MonthlyCostDataPoint `json:"costBreakdown"`
+}
+
+type MonthlyCostDataPoint struct {
+	Month             int     `json:"month"`
+	CostUSD           float64 `json:"costUsd"`
+	CumulativeCostUSD float64 `json:"cumulativeCostUsd"`
+}
+
 type DecryptionReaderCloser struct {
 	*encryption.DecryptionReader
 	BaseReader io.ReadCloser
@@ -27,3 +117,183 @@ type DecryptionReaderCloser struct {
 func (r *DecryptionReaderCloser) Close() error {
 	return r.BaseReader.Close()
 }
+
+type DatabaseBackupHealth struct {
+	DatabaseID uuid.UUID `json:"databaseId"`
+
+	LastBackupAt     *time.Time                 `json:"lastBackupAt"`
+	LastBackupStatus *backups_core.BackupStatus `json:"lastBackupStatus"`
+
+	// NextScheduledBackup is nil when backups are disabled
+	NextScheduledBackup *ScheduledBackupInfo `json:"nextScheduledBackup"`
+
+	TotalSizeMB float64 `json:"totalSizeMb"`
+	// TotalSizeLimitMB is 0 when the total size is unlimited
+	TotalSizeLimitMB int64 `json:"totalSizeLimitMb"`
+
+	ComplianceFlag string `json:"complianceFlag"`
+	IsRPOMet       bool   `json:"isRpoMet"`
+
+	// SuccessRateLast30Days is a percentage, nil when no backup finished in 30 days
+	SuccessRateLast30Days *float64 `json:"successRateLast30Days"`
+	RecentFailureCount    int      `json:"recentFailureCount"`
+}
+
+// ComplianceScore sums weighted checks of the backup setup of the database into
+// a single number for dashboards
+type ComplianceScore struct {
+	DatabaseID uuid.UUID             `json:"databaseId"`
+	Score      int                   `json:"score"`
+	MaxScore   int                   `json:"maxScore"`
+	Breakdown  []ComplianceScoreItem `json:"breakdown"`
+}
+
+type ComplianceScoreItem struct {
+	Name      string `json:"name"`
+	IsMet     bool   `json:"isMet"`
+	Points    int    `json:"points"`
+	MaxPoints int    `json:"maxPoints"`
+}
+
+type WorkspaceCoverageReport struct {
+	TotalDatabases               int `json:"totalDatabases"`
+	DatabasesWithinRPO           int `json:"databasesWithinRpo"`
+	DatabasesWithBackupsDisabled int `json:"databasesWithBackupsDisabled"`
+	DatabasesNeverBacked         int `json:"databasesNeverBacked"`
+
+	// AverageLastBackupAgeHours counts only databases with a completed backup
+	AverageLastBackupAgeHours float64 `json:"averageLastBackupAgeHours"`
+	// WorstDatabase is the one with the oldest last completed backup, nil when
+	// no database of the workspace has one
+	WorstDatabase *DatabaseRPOStatus `json:"worstDatabase"`
+}
+
+type QuotaWarning struct {
+	DatabaseID    uuid.UUID `json:"databaseId"`
+	CurrentSizeMB float64   `json:"currentSizeMb"`
+	LimitMB       int64     `json:"limitMb"`
+	UsedPercent   float64   `json:"usedPercent"`
+	// EstimatedDaysUntilFull is nil when the backups did not grow recently
+	EstimatedDaysUntilFull *int `json:"estimatedDaysUntilFull"`
+}
+
+// PlanChangeImpact describes what a plan change would do to a database. Data
+// over the new limits is not deleted by the change itself, the cleaner removes
+// it on its next passes
+type PlanChangeImpact struct {
+	DatabaseID uuid.UUID `json:"databaseId"`
+
+	IsConfigValid bool `json:"isConfigValid"`
+	// ValidationError is empty when the backup config stays valid
+	ValidationError string `json:"validationError"`
+
+	CurrentSizeMB float64 `json:"currentSizeMb"`
+	// NewTotalSizeLimitMB is 0 when the total size stays unlimited
+	NewTotalSizeLimitMB int64   `json:"newTotalSizeLimitMb"`
+	OverSizeLimitMB     float64 `json:"overSizeLimitMb"`
+
+	// OverPeriodLimit* count finished backups older than the storage period of
+	// the new plan
+	OverPeriodLimitCount int     `json:"overPeriodLimitCount"`
+	OverPeriodLimitMB    float64 `json:"overPeriodLimitMb"`
+}
+
+type DatabaseRPOStatus struct {
+	DatabaseID   uuid.UUID `json:"databaseId"`
+	DatabaseName string    `json:"databaseName"`
+
+	// LastBackupAt is the time of the newest completed backup
+	LastBackupAt     *time.Time `json:"lastBackupAt"`
+	IsBackupsEnabled bool       `json:"isBackupsEnabled"`
+	IsRPOMet         bool       `json:"isRpoMet"`
+}
+
+// BackupDeletionImpact describes what is lost when the backup is deleted, so
+// the user can be warned before confirming the deletion
+type BackupDeletionImpact struct {
+	BackupID uuid.UUID `json:"backupId"`
+
+	// IsNewest is true for the newest completed backup, the latest restore point
+	IsNewest bool `json:"isNewest"`
+	// HasDependentIncrementals is always false while all backups are full ones
+	HasDependentIncrementals bool `json:"hasDependentIncrementals"`
+	IsWithinGracePeriod      bool `json:"isWithinGracePeriod"`
+	// IsLocked is true when the backup is in progress or the database is in
+	// read-only mode, where existing backups are meant to be preserved
+	IsLocked bool `json:"isLocked"`
+	// IsRetentionOverride is true when the retention policy would keep the backup
+	IsRetentionOverride bool `json:"isRetentionOverride"`
+
+	// Warning is empty when the deletion has no notable impact
+	Warning string `json:"warning"`
+}
+
+// ChainIntegrityReport tells whether every backup needed to restore the backup
+// exists and is completed. Backups are ordered from the base one
+type ChainIntegrityReport struct {
+	IsRestorable bool `json:"isRestorable"`
+	ChainLength  int  `json:"chainLength"`
+	// BrokenAt is the first missing or not completed link, nil for a sound chain
+	BrokenAt         *uuid.UUID             `json:"brokenAt"`
+	TotalChainSizeMB float64                `json:"totalChainSizeMb"`
+	Backups          []*backups_core.Backup `json:"backups"`
+}
+
+type RestorePoint struct {
+	BackupID uuid.UUID `json:"backupId"`
+	// PointInTime is the state of the database the restore brings back, the
+	// creation time of the newest backup in the chain
+	PointInTime  time.Time               `json:"pointInTime"`
+	BackupType   backups_core.BackupType `json:"backupType"`
+	ChainLength  int                     `json:"chainLength"`
+	IsRestorable bool                    `json:"isRestorable"`
+	SizeMB       float64                 `json:"sizeMb"`
+}
+
+type CompareBackupConfigsRequest struct {
+	ConfigA backups_config.BackupConfigDTO `json:"configA" binding:"required"`
+	ConfigB backups_config.BackupConfigDTO `json:"configB" binding:"required"`
+}
+
+// BackupConfigComparison describes the effect of replacing config A with config
+// B. Estimates are 0 when the retained backups count of either config cannot be
+// estimated, e.g. for FOREVER retention
+type BackupConfigComparison struct {
+	ChangedFields []string `json:"changedFields"`
+	// EstimatedStorageImpactMB is positive when config B needs more storage
+	EstimatedStorageImpactMB   float64 `json:"estimatedStorageImpactMb"`
+	EstimatedBackupCountImpact int     `json:"estimatedBackupCountImpact"`
+	IsRetentionReduced         bool    `json:"isRetentionReduced"`
+	// RequiresApproval is true when switching to config B deletes existing backups
+	RequiresApproval bool `json:"requiresApproval"`
+}
+
+type MigrateStorageClassRequest struct {
+	TargetStorageClass string `json:"targetStorageClass" binding:"required"`
+	OlderThanDays      int    `json:"olderThanDays"`
+}
+
+type CreateDeletionJobRequest struct {
+	OlderThanDays int `json:"olderThanDays" binding:"required,min=1"`
+}
+
+type GetBackupsByStatusRequest struct {
+	Status string `form:"status" binding:"required"`
+	Limit  int    `form:"limit"`
+}
+
+type GetBackupInventoryRequest struct {
+	StorageID       string `form:"storageId"       binding:"required"`
+	IncludeMetadata bool   `form:"includeMetadata"`
+}
+
+type BackupInventory struct {
+	Backups       []*backups_core.Backup `json:"backups"`
+	TotalSizeMB   float64                `json:"totalSizeMb"`
+	DatabaseCount int                    `json:"databaseCount"`
+
+	// StorageFileList is filled only on request and only for storages that can
+	// list their files, so it can be compared with Backups before decommissioning
+	IsFileListSupported bool                       `json:"isFileListSupported"`
+	StorageFileList     []storages.StorageFileInfo `json:"storageFileList,omitempty"`
+}
@@ -0,0 +1,96 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+)
+
+const encryptionVerificationInterval = 7 * 24 * time.Hour
+
+// BackupEncryptionVerificationJob periodically checks that encrypted backups can still
+// be decrypted, so a rotated or lost master key is noticed before a restore is needed
+type BackupEncryptionVerificationJob struct {
+	backupService    *BackupService
+	backupRepository *backups_core.BackupRepository
+	logger           *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (j *BackupEncryptionVerificationJob) Run(ctx context.Context) {
+	wasAlreadyRun := j.hasRun.Load()
+
+	j.runOnce.Do(func() {
+		j.hasRun.Store(true)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(encryptionVerificationInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := j.verifyEncryptedBackups(ctx); err != nil {
+					j.logger.Error("Failed to verify encrypted backups", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", j))
+	}
+}
+
+func (j *BackupEncryptionVerificationJob) verifyEncryptedBackups(ctx context.Context) error {
+	completedBackups, err := j.backupRepository.FindByStatus(backups_core.BackupStatusCompleted)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range completedBackups {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if backup.Encryption != backups_config.BackupEncryptionEncrypted {
+			continue
+		}
+
+		result, err := j.backupService.VerifyEncryption(ctx, backup.ID)
+		if err != nil {
+			j.logger.Error(
+				"Failed to verify backup encryption",
+				"backupId", backup.ID,
+				"databaseId", backup.DatabaseID,
+				"error", err,
+			)
+			continue
+		}
+
+		if !result.IsDecryptable {
+			j.logger.Error(
+				"Encrypted backup cannot be decrypted",
+				"backupId", backup.ID,
+				"databaseId", backup.DatabaseID,
+				"keyId", result.KeyID,
+				"errorDetail", result.ErrorDetail,
+			)
+		}
+	}
+
+	return nil
+}
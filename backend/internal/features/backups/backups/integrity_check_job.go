@@ -0,0 +1,138 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	backups_config "databasus-backend/internal/features/backups/config"
+)
+
+const integrityCheckTickInterval = 1 * time.Hour
+
+// BackupIntegrityCheckJob checks the integrity of the newest completed backup of
+// databases that opted in, once per their configured interval
+type BackupIntegrityCheckJob struct {
+	backupService       *BackupService
+	backupRepository    *backups_core.BackupRepository
+	backupConfigService *backups_config.BackupConfigService
+	logger              *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (j *BackupIntegrityCheckJob) Run(ctx context.Context) {
+	wasAlreadyRun := j.hasRun.Load()
+
+	j.runOnce.Do(func() {
+		j.hasRun.Store(true)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(integrityCheckTickInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := j.checkDueBackups(ctx, time.Now().UTC()); err != nil {
+					j.logger.Error("Failed to check backups integrity", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", j))
+	}
+}
+
+func (j *BackupIntegrityCheckJob) checkDueBackups(ctx context.Context, now time.Time) error {
+	backupConfigs, err := j.backupConfigService.GetBackupConfigsWithEnabledBackups()
+	if err != nil {
+		return err
+	}
+
+	for _, backupConfig := range backupConfigs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if backupConfig.IntegrityCheckIntervalDays <= 0 {
+			continue
+		}
+
+		isDue, err := j.isCheckDue(backupConfig, now)
+		if err != nil {
+			j.logger.Error(
+				"Failed to check integrity check schedule",
+				"databaseId", backupConfig.DatabaseID,
+				"error", err,
+			)
+			continue
+		}
+
+		if !isDue {
+			continue
+		}
+
+		newestBackup, err := j.backupRepository.FindNewestByDatabaseID(
+			backupConfig.DatabaseID,
+			backups_core.BackupStatusCompleted,
+		)
+		if err != nil || newestBackup == nil {
+			continue
+		}
+
+		result, err := j.backupService.CheckIntegrity(ctx, newestBackup.ID)
+		if err != nil {
+			j.logger.Error(
+				"Failed to check backup integrity",
+				"backupId", newestBackup.ID,
+				"databaseId", backupConfig.DatabaseID,
+				"error", err,
+			)
+			continue
+		}
+
+		if !result.IsPassed {
+			j.logger.Error(
+				"Backup failed integrity check",
+				"backupId", newestBackup.ID,
+				"databaseId", backupConfig.DatabaseID,
+				"errorDetail", result.ErrorDetail,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (j *BackupIntegrityCheckJob) isCheckDue(
+	backupConfig *backups_config.BackupConfig,
+	now time.Time,
+) (bool, error) {
+	latestResult, err := j.backupRepository.FindLatestIntegrityCheckResult(
+		backupConfig.DatabaseID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if latestResult == nil {
+		return true, nil
+	}
+
+	interval := time.Duration(backupConfig.IntegrityCheckIntervalDays) * 24 * time.Hour
+
+	return !latestResult.CreatedAt.Add(interval).After(now), nil
+}
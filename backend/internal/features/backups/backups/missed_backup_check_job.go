@@ -0,0 +1,132 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	backups_config "databasus-backend/internal/features/backups/config"
+	cache_utils "databasus-backend/internal/util/cache"
+)
+
+const (
+	missedBackupCheckInterval = 1 * time.Hour
+	// a slot is reported once its gap exceeds 150% of the interval, so the
+	// window must be wide enough for daily backups to become missed in it
+	missedBackupCheckWindow = 3 * 24 * time.Hour
+)
+
+// MissedBackupCheckJob notifies about scheduled backups which were not made in
+// time. Every missed slot is reported once, the newest reported slot of each
+// database is remembered for the whole window
+type MissedBackupCheckJob struct {
+	backupService              *BackupService
+	backupConfigService        *backups_config.BackupConfigService
+	lastMissedBackupAlertCache *cache_utils.CacheUtil[time.Time]
+	logger                     *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (j *MissedBackupCheckJob) Run(ctx context.Context) {
+	wasAlreadyRun := j.hasRun.Load()
+
+	j.runOnce.Do(func() {
+		j.hasRun.Store(true)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(missedBackupCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := j.notifyAboutMissedBackups(ctx); err != nil {
+					j.logger.Error("Failed to check missed backups", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", j))
+	}
+}
+
+func (j *MissedBackupCheckJob) notifyAboutMissedBackups(ctx context.Context) error {
+	backupConfigs, err := j.backupConfigService.GetBackupConfigsWithEnabledBackups()
+	if err != nil {
+		return err
+	}
+
+	for _, backupConfig := range backupConfigs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if !slices.Contains(
+			backupConfig.SendNotificationsOn,
+			backups_config.NotificationMissedBackup,
+		) {
+			continue
+		}
+
+		missedBackups, err := j.backupService.GetMissedBackups(
+			backupConfig.DatabaseID,
+			missedBackupCheckWindow,
+		)
+		if err != nil {
+			j.logger.Error(
+				"Failed to get missed backups",
+				"databaseId", backupConfig.DatabaseID,
+				"error", err,
+			)
+			continue
+		}
+
+		newMissedBackups := j.filterNotReported(backupConfig, missedBackups)
+		if len(newMissedBackups) == 0 {
+			continue
+		}
+
+		j.backupService.sendMissedBackupsNotification(backupConfig, newMissedBackups)
+
+		lastExpectedAt := newMissedBackups[len(newMissedBackups)-1].ExpectedAt
+		j.lastMissedBackupAlertCache.SetWithExpiration(
+			backupConfig.DatabaseID.String(),
+			&lastExpectedAt,
+			missedBackupCheckWindow,
+		)
+	}
+
+	return nil
+}
+
+func (j *MissedBackupCheckJob) filterNotReported(
+	backupConfig *backups_config.BackupConfig,
+	missedBackups []MissedBackup,
+) []MissedBackup {
+	lastReportedAt := j.lastMissedBackupAlertCache.Get(backupConfig.DatabaseID.String())
+	if lastReportedAt == nil {
+		return missedBackups
+	}
+
+	newMissedBackups := []MissedBackup{}
+	for _, missedBackup := range missedBackups {
+		if missedBackup.ExpectedAt.After(*lastReportedAt) {
+			newMissedBackups = append(newMissedBackups, missedBackup)
+		}
+	}
+
+	return newMissedBackups
+}
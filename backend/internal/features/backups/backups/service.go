@@ -1,11 +1,21 @@
 package backups
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	audit_logs "databasus-backend/internal/features/audit_logs"
 	"databasus-backend/internal/features/backups/backups/backuping"
@@ -14,27 +24,61 @@ import (
 	"databasus-backend/internal/features/backups/backups/encryption"
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
+	"databasus-backend/internal/features/intervals"
 	"databasus-backend/internal/features/notifiers"
+	plans "databasus-backend/internal/features/plan"
 	"databasus-backend/internal/features/storages"
 	task_cancellation "databasus-backend/internal/features/tasks/cancellation"
+	users_enums "databasus-backend/internal/features/users/enums"
 	users_models "databasus-backend/internal/features/users/models"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	cache_utils "databasus-backend/internal/util/cache"
 	util_encryption "databasus-backend/internal/util/encryption"
 	files_utils "databasus-backend/internal/util/files"
+	"databasus-backend/internal/util/period"
+	"databasus-backend/internal/util/size"
 
 	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	encryptionVerificationReadLimit = 1 * 1024 * 1024
+	integrityCheckBufferSize        = 8 * 1024 * 1024
+
+	missedBackupGapRatio       = 1.5
+	missedBackupRunTimesBatch  = 100
+	missedBackupMaxRunTimesNum = 10_000
+
+	backupHealthCacheTTL     = 60 * time.Second
+	backupHealthWindowInDays = 30
+
+	workspaceCoverageCacheTTL      = 5 * time.Minute
+	workspaceCoverageMaxConcurrent = 10
+
+	backupsByStatusDefaultLimit = 50
+	backupsByStatusMaxLimit     = 500
+
+	complianceRecentBackupWindow = 24 * time.Hour
+	// a score that stays low is reported again once the previous one expires
+	lastComplianceScoreTTL = 7 * 24 * time.Hour
+
+	costEstimateMaxMonths = 120
+
+	storageQuotaGrowthWindowInDays = 14
 )
 
 type BackupService struct {
-	databaseService     *databases.DatabaseService
-	storageService      *storages.StorageService
-	backupRepository    *backups_core.BackupRepository
-	notifierService     *notifiers.NotifierService
-	notificationSender  backups_core.NotificationSender
-	backupConfigService *backups_config.BackupConfigService
-	secretKeyService    *encryption_secrets.SecretKeyService
-	fieldEncryptor      util_encryption.FieldEncryptor
+	databaseService      *databases.DatabaseService
+	storageService       *storages.StorageService
+	backupRepository     *backups_core.BackupRepository
+	notifierService      *notifiers.NotifierService
+	notificationSender   backups_core.NotificationSender
+	backupConfigService  *backups_config.BackupConfigService
+	databasePlanService  *plans.DatabasePlanService
+	encryptionKeyService *encryption_keys.WorkspaceEncryptionKeyService
+	fieldEncryptor       util_encryption.FieldEncryptor
 
 	createBackupUseCase backups_core.CreateBackupUsecase
 
@@ -48,6 +92,14 @@ type BackupService struct {
 	downloadTokenService   *backups_download.DownloadTokenService
 	backupSchedulerService *backuping.BackupsScheduler
 	backupCleaner          *backuping.BackupCleaner
+	scheduledCleanupJob    *backuping.ScheduledCleanupJob
+	deletionJobWorker      *backuping.DeletionJobWorker
+
+	backupHealthCache      *cache_utils.CacheUtil[DatabaseBackupHealth]
+	workspaceCoverageCache *cache_utils.CacheUtil[WorkspaceCoverageReport]
+	// lastComplianceScoreCache keeps the previous score of every database, so
+	// the low score alert is sent once when the score drops below the threshold
+	lastComplianceScoreCache *cache_utils.CacheUtil[int]
 }
 
 func (s *BackupService) AddBackupRemoveListener(listener backups_core.BackupRemoveListener) {
@@ -64,18 +116,27 @@ func (s *BackupService) OnBeforeBackupsStorageChange(databaseID uuid.UUID) error
 }
 
 func (s *BackupService) OnBeforeDatabaseRemove(databaseID uuid.UUID) error {
-	err := s.deleteDbBackups(databaseID)
+	report, err := s.PurgeDatabase(context.Background(), databaseID)
 	if err != nil {
 		return err
 	}
 
+	if len(report.Errors) > 0 {
+		return errors.Join(report.Errors...)
+	}
+
 	return nil
 }
 
 func (s *BackupService) MakeBackupWithAuth(
 	user *users_models.User,
 	databaseID uuid.UUID,
+	retentionLabel string,
 ) error {
+	if err := backups_config.ValidateRetentionLabel(retentionLabel); err != nil {
+		return err
+	}
+
 	database, err := s.databaseService.GetDatabaseByID(databaseID)
 	if err != nil {
 		return err
@@ -93,7 +154,23 @@ func (s *BackupService) MakeBackupWithAuth(
 		return errors.New("insufficient permissions to create backup for this database")
 	}
 
-	s.backupSchedulerService.StartBackup(database, true)
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return err
+	}
+
+	if backupConfig.IsReadOnlyMode {
+		return errors.New(
+			"database is in read-only mode: backup creation is disabled, " +
+				"existing backups are preserved",
+		)
+	}
+
+	if err := s.backupSchedulerService.CheckBackupCreationAllowed(); err != nil {
+		return err
+	}
+
+	s.backupSchedulerService.StartLabeledBackup(database, retentionLabel)
 
 	s.auditLogService.WriteAuditLog(
 		fmt.Sprintf("Backup manually initiated for database: %s", database.Name),
@@ -104,6 +181,31 @@ func (s *BackupService) MakeBackupWithAuth(
 	return nil
 }
 
+// CreatePointInTimeBackup would capture the database state at targetTime by WAL
+// replay over a base backup. All supported engines are backed up by logical
+// dumps which cannot be replayed, so ErrPITRNotSupported is returned for every
+// existing database until a physical backup driver is added
+func (s *BackupService) CreatePointInTimeBackup(
+	ctx context.Context,
+	databaseID uuid.UUID,
+	targetTime time.Time,
+	requestedByUserID uuid.UUID,
+) (*backups_core.Backup, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if targetTime.After(time.Now().UTC()) {
+		return nil, errors.New("point-in-time target must not be in the future")
+	}
+
+	if _, err := s.databaseService.GetDatabaseByID(databaseID); err != nil {
+		return nil, err
+	}
+
+	return nil, backups_core.ErrPITRNotSupported
+}
+
 func (s *BackupService) GetBackups(
 	user *users_models.User,
 	databaseID uuid.UUID,
@@ -187,13 +289,177 @@ func (s *BackupService) DeleteBackup(
 		database.WorkspaceID,
 	)
 
-	return s.backupCleaner.DeleteBackup(backup)
+	return s.backupCleaner.DeleteBackup(
+		context.Background(),
+		backup,
+		backups_core.DeletionReasonManual,
+	)
+}
+
+func (s *BackupService) GetBackupDeletionImpact(
+	backupID uuid.UUID,
+) (*BackupDeletionImpact, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(backup.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	newestBackup, err := s.backupRepository.FindNewestByDatabaseID(
+		backup.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &BackupDeletionImpact{
+		BackupID:            backup.ID,
+		IsNewest:            newestBackup != nil && newestBackup.ID == backup.ID,
+		IsWithinGracePeriod: time.Since(backup.CreatedAt) < backups_core.RecentBackupGracePeriod,
+		IsLocked: backup.Status == backups_core.BackupStatusInProgress ||
+			backupConfig.IsReadOnlyMode,
+	}
+
+	if backup.Status == backups_core.BackupStatusCompleted {
+		cleanupPlan, err := s.backupCleaner.ForceRetentionCleanup(
+			context.Background(),
+			backup.DatabaseID,
+			true,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		impact.IsRetentionOverride = !slices.Contains(cleanupPlan.BackupIDs, backup.ID)
+	}
+
+	impact.Warning = getBackupDeletionWarning(impact)
+
+	return impact, nil
+}
+
+func (s *BackupService) GetBackupDeletionImpactWithAuth(
+	user *users_models.User,
+	backupID uuid.UUID,
+) (*BackupDeletionImpact, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.databaseService.GetDatabase(user, backup.DatabaseID); err != nil {
+		return nil, err
+	}
+
+	return s.GetBackupDeletionImpact(backupID)
 }
 
 func (s *BackupService) GetBackup(backupID uuid.UUID) (*backups_core.Backup, error) {
 	return s.backupRepository.FindByID(backupID)
 }
 
+func (s *BackupService) GetIncrementalChainIntegrity(
+	backupID uuid.UUID,
+) (*ChainIntegrityReport, error) {
+	chain, err := s.backupRepository.FindBackupChain(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ChainIntegrityReport{
+		IsRestorable: true,
+		ChainLength:  len(chain),
+		Backups:      chain,
+	}
+
+	if len(chain) == 0 {
+		report.IsRestorable = false
+		report.BrokenAt = &backupID
+
+		return report, nil
+	}
+
+	for _, backup := range chain {
+		report.TotalChainSizeMB += backup.BackupSizeMb
+
+		if report.IsRestorable && backup.Status != backups_core.BackupStatusCompleted {
+			report.IsRestorable = false
+			report.BrokenAt = &backup.ID
+		}
+	}
+
+	return report, nil
+}
+
+// GetDatabaseRestorePoints lists completed backups of the database as states it
+// can be restored to, newest first
+func (s *BackupService) GetDatabaseRestorePoints(
+	ctx context.Context,
+	databaseID uuid.UUID,
+) ([]*RestorePoint, error) {
+	completedBackups, err := s.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		databaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	restorePoints := make([]*RestorePoint, 0, len(completedBackups))
+
+	for _, backup := range completedBackups {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		integrity, err := s.GetIncrementalChainIntegrity(backup.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		restorePoint := &RestorePoint{
+			BackupID:     backup.ID,
+			PointInTime:  backup.CreatedAt,
+			BackupType:   backups_core.BackupTypeFull,
+			ChainLength:  integrity.ChainLength,
+			IsRestorable: integrity.IsRestorable,
+			SizeMB:       integrity.TotalChainSizeMB,
+		}
+
+		for _, chainBackup := range integrity.Backups {
+			if chainBackup.CreatedAt.After(restorePoint.PointInTime) {
+				restorePoint.PointInTime = chainBackup.CreatedAt
+			}
+		}
+
+		restorePoints = append(restorePoints, restorePoint)
+	}
+
+	sort.SliceStable(restorePoints, func(i, j int) bool {
+		return restorePoints[i].PointInTime.After(restorePoints[j].PointInTime)
+	})
+
+	return restorePoints, nil
+}
+
+func (s *BackupService) GetDatabaseRestorePointsWithAuth(
+	ctx context.Context,
+	user *users_models.User,
+	databaseID uuid.UUID,
+) ([]*RestorePoint, error) {
+	if _, err := s.databaseService.GetDatabase(user, databaseID); err != nil {
+		return nil, err
+	}
+
+	return s.GetDatabaseRestorePoints(ctx, databaseID)
+}
+
 func (s *BackupService) CancelBackup(
 	user *users_models.User,
 	backupID uuid.UUID,
@@ -237,6 +503,34 @@ func (s *BackupService) CancelBackup(
 	return nil
 }
 
+// CancelInProgressBackup cancels the running backups of the database. It is
+// used before the database is overwritten by a restore, so the backup does
+// not capture a partially restored state
+func (s *BackupService) CancelInProgressBackup(databaseID uuid.UUID) error {
+	inProgressBackups, err := s.backupRepository.FindByDatabaseIdAndStatus(
+		context.Background(),
+		databaseID,
+		backups_core.BackupStatusInProgress,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, backup := range inProgressBackups {
+		if err := s.taskCancelManager.CancelTask(backup.ID); err != nil {
+			return fmt.Errorf("failed to cancel backup %s: %w", backup.ID, err)
+		}
+
+		s.logger.Info(
+			"Backup cancelled before restore to the same database",
+			"databaseId", databaseID,
+			"backupId", backup.ID,
+		)
+	}
+
+	return nil
+}
+
 func (s *BackupService) GetBackupFile(
 	user *users_models.User,
 	backupID uuid.UUID,
@@ -282,42 +576,38 @@ func (s *BackupService) GetBackupFile(
 	return reader, backup, database, nil
 }
 
-func (s *BackupService) deleteDbBackups(databaseID uuid.UUID) error {
-	dbBackupsInProgress, err := s.backupRepository.FindByDatabaseIdAndStatus(
-		databaseID,
-		backups_core.BackupStatusInProgress,
-	)
+// VerifyEncryption checks that the beginning of the backup file can be decrypted with
+// the current master key and looks like a dump of the database type. Problems with
+// the backup file itself are reported via the result, not via the returned error
+func (s *BackupService) VerifyEncryption(
+	ctx context.Context,
+	backupID uuid.UUID,
+) (*EncryptionVerificationResult, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if len(dbBackupsInProgress) > 0 {
-		return errors.New("backup is in progress, storage cannot be removed")
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, err
 	}
 
-	dbBackups, err := s.backupRepository.FindByDatabaseID(
-		databaseID,
-	)
-	if err != nil {
-		return err
+	result := &EncryptionVerificationResult{
+		BackupID: backup.ID,
 	}
 
-	for _, dbBackup := range dbBackups {
-		err := s.backupCleaner.DeleteBackup(dbBackup)
+	if backup.Encryption == backups_config.BackupEncryptionEncrypted {
+		encryptionKey, err := s.encryptionKeyService.GetBackupKey(backup.EncryptionKeyID)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("failed to get encryption key: %w", err)
 		}
-	}
 
-	return nil
-}
+		result.KeyID = getKeyFingerprint(encryptionKey)
+	}
 
-// GetBackupReader returns a reader for the backup file
-// If encrypted, wraps with DecryptionReader
-func (s *BackupService) getBackupReader(backupID uuid.UUID) (io.ReadCloser, error) {
-	backup, err := s.backupRepository.FindByID(backupID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find backup: %w", err)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
 	storage, err := s.storageService.GetStorageByID(backup.StorageID)
@@ -327,199 +617,2472 @@ func (s *BackupService) getBackupReader(backupID uuid.UUID) (io.ReadCloser, erro
 
 	fileReader, err := storage.GetFile(s.fieldEncryptor, backup.FileName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get backup file: %w", err)
+		result.ErrorDetail = fmt.Sprintf("failed to get backup file: %v", err)
+		return result, nil
 	}
 
-	// If not encrypted, return raw reader
-	if backup.Encryption == backups_config.BackupEncryptionNone {
-		s.logger.Info("Returning non-encrypted backup", "backupId", backupID)
-		return fileReader, nil
+	reader, err := s.wrapWithDecryption(backup, fileReader)
+	if err != nil {
+		result.ErrorDetail = err.Error()
+		return result, nil
 	}
-
-	// Decrypt on-the-fly for encrypted backups
-	if backup.Encryption != backups_config.BackupEncryptionEncrypted {
-		if err := fileReader.Close(); err != nil {
-			s.logger.Error("Failed to close file reader", "error", err)
+	defer func() {
+		if err := reader.Close(); err != nil {
+			s.logger.Error("Failed to close backup reader", "error", err)
 		}
-		return nil, fmt.Errorf("unsupported encryption type: %s", backup.Encryption)
+	}()
+
+	head := make([]byte, encryptionVerificationReadLimit)
+	readBytes, err := io.ReadFull(reader, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		result.ErrorDetail = fmt.Sprintf("failed to decrypt backup file: %v", err)
+		return result, nil
 	}
 
-	if backup.EncryptionSalt == nil || backup.EncryptionIV == nil {
-		if err := fileReader.Close(); err != nil {
-			s.logger.Error("Failed to close file reader", "error", err)
-		}
-		return nil, fmt.Errorf("backup marked as encrypted but missing encryption metadata")
+	expectedMagicBytes := getDumpMagicBytes(database.Type)
+	if !bytes.HasPrefix(head[:readBytes], expectedMagicBytes) {
+		result.ErrorDetail = fmt.Sprintf(
+			"decrypted content does not look like a %s dump",
+			database.Type,
+		)
+		return result, nil
 	}
 
-	// Get master key
-	masterKey, err := s.secretKeyService.GetSecretKey()
+	result.IsDecryptable = true
+
+	return result, nil
+}
+
+// CheckIntegrity reads the whole backup back from its storage, decrypts it and
+// checks that its header is the one of a dump of the database type. It does not
+// restore the dump, so a dump broken inside passes. The result is stored and
+// subscribed notifiers are notified when the check fails
+func (s *BackupService) CheckIntegrity(
+	ctx context.Context,
+	backupID uuid.UUID,
+) (*backups_core.IntegrityCheckResult, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
 	if err != nil {
-		if closeErr := fileReader.Close(); closeErr != nil {
-			s.logger.Error("Failed to close file reader", "error", closeErr)
-		}
-		return nil, fmt.Errorf("failed to get master key: %w", err)
+		return nil, err
 	}
 
-	// Decode salt and IV
-	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
-	if err != nil {
-		if closeErr := fileReader.Close(); closeErr != nil {
-			s.logger.Error("Failed to close file reader", "error", closeErr)
-		}
-		return nil, fmt.Errorf("failed to decode salt: %w", err)
+	if backup.Status != backups_core.BackupStatusCompleted {
+		return nil, errors.New("only completed backups can be checked for integrity")
 	}
 
-	iv, err := base64.StdEncoding.DecodeString(*backup.EncryptionIV)
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
 	if err != nil {
-		if closeErr := fileReader.Close(); closeErr != nil {
-			s.logger.Error("Failed to close file reader", "error", closeErr)
-		}
-		return nil, fmt.Errorf("failed to decode IV: %w", err)
+		return nil, err
 	}
 
-	// Wrap with decrypting reader
-	decryptionReader, err := encryption.NewDecryptionReader(
-		fileReader,
-		masterKey,
-		backup.ID,
-		salt,
-		iv,
-	)
-	if err != nil {
-		if closeErr := fileReader.Close(); closeErr != nil {
-			s.logger.Error("Failed to close file reader", "error", closeErr)
-		}
-		return nil, fmt.Errorf("failed to create decrypting reader: %w", err)
+	startedAt := time.Now().UTC()
+	checkErr := s.checkBackupIntegrity(ctx, backup, database)
+	if checkErr != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	s.logger.Info("Returning encrypted backup with decryption", "backupId", backupID)
+	result := &backups_core.IntegrityCheckResult{
+		ID:         uuid.New(),
+		BackupID:   backup.ID,
+		DatabaseID: backup.DatabaseID,
+		IsPassed:   checkErr == nil,
+		DurationMs: time.Since(startedAt).Milliseconds(),
+		CreatedAt:  time.Now().UTC(),
+	}
+	if checkErr != nil {
+		result.ErrorDetail = checkErr.Error()
+	}
 
-	return &DecryptionReaderCloser{
-		DecryptionReader: decryptionReader,
-		BaseReader:       fileReader,
-	}, nil
+	if err := s.backupRepository.SaveIntegrityCheckResult(result); err != nil {
+		return nil, err
+	}
+
+	if !result.IsPassed {
+		s.sendIntegrityCheckFailedNotification(database, backup, result)
+	}
+
+	return result, nil
 }
 
-func (s *BackupService) GenerateDownloadToken(
-	user *users_models.User,
-	backupID uuid.UUID,
-) (*backups_download.GenerateDownloadTokenResponse, error) {
-	backup, err := s.backupRepository.FindByID(backupID)
+// GetMissedBackups returns scheduled backup times within the window for which no
+// backup was started in time. A slot is missed when the gap between backups around
+// it exceeds 150% of the interval. Nothing is sent, MissedBackupCheckJob notifies
+// about the slots it has not reported yet
+func (s *BackupService) GetMissedBackups(
+	databaseID uuid.UUID,
+	window time.Duration,
+) ([]MissedBackup, error) {
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
 	if err != nil {
 		return nil, err
 	}
 
-	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
-	if err != nil {
-		return nil, err
+	if !backupConfig.IsBackupsEnabled || backupConfig.BackupInterval == nil {
+		return []MissedBackup{}, nil
 	}
 
-	if database.WorkspaceID == nil {
-		return nil, errors.New("cannot download backup for database without workspace")
+	now := time.Now().UTC()
+	windowStart := now.Add(-window)
+
+	expectedTimes := s.getExpectedRunTimes(backupConfig, windowStart, now)
+	if len(expectedTimes) < 2 {
+		return []MissedBackup{}, nil
 	}
 
-	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	firstIntervalDuration := expectedTimes[1].Sub(expectedTimes[0])
+	backups, err := s.backupRepository.FindBackupsAfterDate(
+		databaseID,
+		windowStart.Add(-firstIntervalDuration),
+	)
 	if err != nil {
 		return nil, err
 	}
-	if !canAccess {
-		return nil, errors.New("insufficient permissions to download backup for this database")
+
+	createdAts := make([]time.Time, 0, len(backups))
+	for _, backup := range backups {
+		createdAts = append(createdAts, backup.CreatedAt)
 	}
 
-	token, err := s.downloadTokenService.Generate(backupID, user.ID)
+	return findMissedBackups(expectedTimes, createdAts, now), nil
+}
+
+func (s *BackupService) GetNextScheduledBackup(
+	ctx context.Context,
+	databaseID uuid.UUID,
+) (*ScheduledBackupInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if backupConfig.BackupInterval == nil {
+		return nil, errors.New("backup interval is not configured")
+	}
+
+	nextRunAt, ok := backupConfig.BackupInterval.NextRunTime(time.Now().UTC())
+	if !ok {
+		return nil, errors.New("failed to calculate next backup time")
+	}
+
+	return &ScheduledBackupInfo{
+		NextRunAt:           nextRunAt,
+		IsEnabled:           backupConfig.IsBackupsEnabled,
+		IntervalDescription: backupConfig.BackupInterval.Describe(),
+	}, nil
+}
+
+// PurgeDatabase deletes the files of all backups of the database, firing backup
+// remove listeners, and then all backup rows with the backup config in one
+// transaction. Failures of single files are collected in the report; no row is
+// deleted in that case, so purge can be retried
+func (s *BackupService) PurgeDatabase(
+	ctx context.Context,
+	databaseID uuid.UUID,
+) (*PurgeReport, error) {
+	dbBackupsInProgress, err := s.backupRepository.FindByDatabaseIdAndStatus(
+		ctx,
+		databaseID,
+		backups_core.BackupStatusInProgress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dbBackupsInProgress) > 0 {
+		return nil, errors.New("backup is in progress, database cannot be purged")
+	}
+
+	dbBackups, err := s.backupRepository.FindByDatabaseID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PurgeReport{Errors: []error{}}
+
+	for _, dbBackup := range dbBackups {
+		if err := ctx.Err(); err != nil {
+			report.Errors = append(report.Errors, err)
+			return report, nil
+		}
+
+		if err := s.backupCleaner.DeleteBackupFiles(ctx, dbBackup); err != nil {
+			report.Errors = append(
+				report.Errors,
+				fmt.Errorf("failed to delete backup %s: %w", dbBackup.ID, err),
+			)
+		}
+	}
+
+	if len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	if err := s.backupCleaner.DeletePurgedDatabaseRecords(databaseID, dbBackups); err != nil {
+		report.Errors = append(
+			report.Errors,
+			fmt.Errorf("failed to delete backups and backup config: %w", err),
+		)
+		return report, nil
+	}
+
+	for _, dbBackup := range dbBackups {
+		report.DeletedBackupCount++
+		report.FreedMB += dbBackup.BackupSizeMb
+	}
+
+	return report, nil
+}
+
+func (s *BackupService) ListRetentionPolicies(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) ([]RetentionPolicySummary, error) {
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspace(user, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]RetentionPolicySummary, 0, len(workspaceDatabases))
+	for _, database := range workspaceDatabases {
+		backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(database.ID)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to get backup config for database %s: %w",
+				database.ID,
+				err,
+			)
+		}
+
+		summaries = append(summaries, RetentionPolicySummary{
+			DatabaseID:          database.ID,
+			DatabaseName:        database.Name,
+			RetentionPolicyType: backupConfig.RetentionPolicyType,
+			RetentionSummary:    backupConfig.EffectiveRetentionSummary(),
+			IsBackupsEnabled:    backupConfig.IsBackupsEnabled,
+			IsReadOnlyMode:      backupConfig.IsReadOnlyMode,
+			LastModified:        backupConfig.RetentionPolicyLockedAt,
+			ComplianceFlag:      getRetentionComplianceFlag(backupConfig),
+		})
+	}
+
+	return summaries, nil
+}
+
+func (s *BackupService) GetStorageUsageSummary(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*StorageUsageSummary, error) {
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspace(user, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	databaseIDs := make([]uuid.UUID, 0, len(workspaceDatabases))
+	for _, database := range workspaceDatabases {
+		databaseIDs = append(databaseIDs, database.ID)
+	}
+
+	sizes, err := s.backupRepository.GetSizeBytesByDatabaseAndStorage(databaseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	sizeBytesByStorageID := make(map[uuid.UUID]int64)
+	sizeBytesByDatabaseID := make(map[uuid.UUID]int64)
+	totalSizeBytes := int64(0)
+
+	for _, dbStorageSize := range sizes {
+		sizeBytesByStorageID[dbStorageSize.StorageID] += dbStorageSize.SizeBytes
+		sizeBytesByDatabaseID[dbStorageSize.DatabaseID] += dbStorageSize.SizeBytes
+		totalSizeBytes += dbStorageSize.SizeBytes
+	}
+
+	summary := &StorageUsageSummary{
+		TotalSizeGB: size.BytesToGB(totalSizeBytes),
+		ByStorage:   make([]StorageUsage, 0, len(sizeBytesByStorageID)),
+		ByDatabase:  make([]DatabaseStorageUsage, 0, len(workspaceDatabases)),
+	}
+
+	for _, database := range workspaceDatabases {
+		summary.ByDatabase = append(summary.ByDatabase, DatabaseStorageUsage{
+			DatabaseID: database.ID,
+			SizeGB:     size.BytesToGB(sizeBytesByDatabaseID[database.ID]),
+		})
+	}
+
+	for storageID, sizeBytes := range sizeBytesByStorageID {
+		storage, err := s.storageService.GetStorageByID(storageID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get storage %s: %w", storageID, err)
+		}
+
+		storageSizeGB := size.BytesToGB(sizeBytes)
+		summary.ByStorage = append(summary.ByStorage, StorageUsage{
+			StorageID:   storage.ID,
+			StorageName: storage.Name,
+			SizeGB:      storageSizeGB,
+		})
+
+		if storage.CostPerGBPerMonth != nil {
+			cost := storageSizeGB * *storage.CostPerGBPerMonth
+			if summary.EstimatedMonthlyCostUSD != nil {
+				cost += *summary.EstimatedMonthlyCostUSD
+			}
+
+			summary.EstimatedMonthlyCostUSD = &cost
+		}
+	}
+
+	sort.Slice(summary.ByStorage, func(i, j int) bool {
+		return summary.ByStorage[i].SizeGB > summary.ByStorage[j].SizeGB
+	})
+
+	return summary, nil
+}
+
+func (s *BackupService) ForceRetentionCleanup(
+	ctx context.Context,
+	databaseID uuid.UUID,
+	dryRun bool,
+) (*backuping.RetentionCleanupResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return s.backupCleaner.ForceRetentionCleanup(ctx, databaseID, dryRun)
+}
+
+func (s *BackupService) ForceRetentionCleanupWithAuth(
+	ctx context.Context,
+	user *users_models.User,
+	databaseID uuid.UUID,
+	dryRun bool,
+) (*backuping.RetentionCleanupResult, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot clean up backups for database without workspace")
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to clean up backups for this database")
+	}
+
+	result, err := s.ForceRetentionCleanup(ctx, databaseID, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		s.auditLogService.WriteAuditLog(
+			fmt.Sprintf(
+				"Retention cleanup forced for database: %s, deleted backups: %d",
+				database.Name,
+				result.DeletedCount,
+			),
+			&user.ID,
+			database.WorkspaceID,
+		)
+	}
+
+	return result, nil
+}
+
+// CreateScheduledCleanup schedules the retention cleanup of the database to run
+// once at the given time
+func (s *BackupService) CreateScheduledCleanup(
+	databaseID uuid.UUID,
+	at time.Time,
+	createdBy uuid.UUID,
+) (*backuping.ScheduledCleanup, error) {
+	return s.scheduledCleanupJob.Schedule(databaseID, at, createdBy)
+}
+
+func (s *BackupService) CreateScheduledCleanupWithAuth(
+	user *users_models.User,
+	databaseID uuid.UUID,
+	at time.Time,
+) (*backuping.ScheduledCleanup, error) {
+	database, err := s.getManageableDatabase(user, databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledCleanup, err := s.CreateScheduledCleanup(databaseID, at, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Retention cleanup scheduled for database: %s at %s",
+			database.Name,
+			scheduledCleanup.ScheduledAt.Format(time.RFC3339),
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return scheduledCleanup, nil
+}
+
+func (s *BackupService) CancelScheduledCleanupWithAuth(
+	user *users_models.User,
+	databaseID uuid.UUID,
+	scheduledCleanupID uuid.UUID,
+) error {
+	database, err := s.getManageableDatabase(user, databaseID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.scheduledCleanupJob.Cancel(databaseID, scheduledCleanupID); err != nil {
+		return err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Scheduled retention cleanup cancelled for database: %s", database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return nil
+}
+
+// MigrateBackupsToNewStorageClass moves completed backups older than olderThan
+// into targetClass. The copy runs in the background, progress is tracked by
+// the returned job
+func (s *BackupService) MigrateBackupsToNewStorageClass(
+	ctx context.Context,
+	databaseID uuid.UUID,
+	targetClass string,
+	olderThan time.Duration,
+) (*backups_core.MigrationJob, error) {
+	storageClass := backups_config.StorageClass(targetClass)
+	if !storageClass.IsSupported() {
+		return nil, fmt.Errorf("storage class %q is not supported", targetClass)
+	}
+
+	if olderThan < 0 {
+		return nil, errors.New("older than must not be negative")
+	}
+
+	now := time.Now().UTC()
+
+	oldBackups, err := s.backupRepository.FindBackupsBeforeDate(databaseID, now.Add(-olderThan))
+	if err != nil {
+		return nil, err
+	}
+
+	backupsToMigrate := make([]*backups_core.Backup, 0, len(oldBackups))
+	for _, backup := range oldBackups {
+		if backup.Status == backups_core.BackupStatusCompleted &&
+			backup.FileName != "" &&
+			backup.StorageClass != storageClass {
+			backupsToMigrate = append(backupsToMigrate, backup)
+		}
+	}
+
+	job := &backups_core.MigrationJob{
+		ID:                 uuid.New(),
+		DatabaseID:         databaseID,
+		TargetStorageClass: storageClass,
+		Status:             backups_core.MigrationJobStatusInProgress,
+		TotalCount:         len(backupsToMigrate),
+		CreatedAt:          now,
+	}
+
+	if err := s.backupRepository.SaveMigrationJob(job); err != nil {
+		return nil, err
+	}
+
+	// the job outlives the request that started it
+	go s.migrateBackupsStorageClass(context.WithoutCancel(ctx), *job, backupsToMigrate)
+
+	return job, nil
+}
+
+func (s *BackupService) MigrateBackupsToNewStorageClassWithAuth(
+	ctx context.Context,
+	user *users_models.User,
+	databaseID uuid.UUID,
+	request *MigrateStorageClassRequest,
+) (*backups_core.MigrationJob, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot migrate backups for database without workspace")
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to migrate backups for this database")
+	}
+
+	job, err := s.MigrateBackupsToNewStorageClass(
+		ctx,
+		databaseID,
+		request.TargetStorageClass,
+		time.Duration(request.OlderThanDays)*24*time.Hour,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Storage class migration to %s started for database: %s, backups: %d",
+			job.TargetStorageClass,
+			database.Name,
+			job.TotalCount,
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return job, nil
+}
+
+func (s *BackupService) GetMigrationJobWithAuth(
+	user *users_models.User,
+	jobID uuid.UUID,
+) (*backups_core.MigrationJob, error) {
+	job, err := s.backupRepository.FindMigrationJobByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job == nil {
+		return nil, errors.New("migration job not found")
+	}
+
+	if _, err := s.databaseService.GetDatabase(user, job.DatabaseID); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// CreateDeletionJobWithAuth starts a resumable job deleting backups of the
+// database older than the requested number of days
+func (s *BackupService) CreateDeletionJobWithAuth(
+	user *users_models.User,
+	databaseID uuid.UUID,
+	request *CreateDeletionJobRequest,
+) (*backuping.DeletionJob, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot delete backups for database without workspace")
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to delete backups for this database")
+	}
+
+	job, err := s.deletionJobWorker.CreateDeletionJob(
+		databaseID,
+		time.Now().UTC().Add(-time.Duration(request.OlderThanDays)*24*time.Hour),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Backup deletion job started for database: %s, backups: %d",
+			database.Name,
+			job.TotalCount,
+		),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return job, nil
+}
+
+func (s *BackupService) GetDeletionJobWithAuth(
+	user *users_models.User,
+	jobID uuid.UUID,
+) (*backuping.DeletionJob, error) {
+	job, err := s.deletionJobWorker.GetDeletionJobProgress(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.databaseService.GetDatabase(user, job.DatabaseID); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetDatabaseBackupHealth aggregates what a dashboard card shows about backups of
+// the database. Cards are refreshed for many databases at once, so the result is
+// cached for a minute
+func (s *BackupService) GetDatabaseBackupHealth(
+	ctx context.Context,
+	databaseID uuid.UUID,
+) (*DatabaseBackupHealth, error) {
+	if cachedHealth := s.backupHealthCache.Get(databaseID.String()); cachedHealth != nil {
+		return cachedHealth, nil
+	}
+
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &DatabaseBackupHealth{
+		DatabaseID:       databaseID,
+		TotalSizeLimitMB: backupConfig.MaxBackupsTotalSizeMB,
+		ComplianceFlag:   getRetentionComplianceFlag(backupConfig),
+	}
+
+	// every goroutine fills its own fields of health, so no locking is needed
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		lastBackup, err := s.backupRepository.FindNewestByDatabaseID(databaseID)
+		if err != nil {
+			return err
+		}
+
+		if lastBackup != nil {
+			health.LastBackupAt = &lastBackup.CreatedAt
+			health.LastBackupStatus = &lastBackup.Status
+		}
+
+		return nil
+	})
+
+	group.Go(func() error {
+		if !backupConfig.IsBackupsEnabled || backupConfig.BackupInterval == nil {
+			return nil
+		}
+
+		nextScheduledBackup, err := s.GetNextScheduledBackup(groupCtx, databaseID)
+		if err != nil {
+			return err
+		}
+
+		health.NextScheduledBackup = nextScheduledBackup
+		return nil
+	})
+
+	group.Go(func() error {
+		totalSizeMB, err := s.backupRepository.GetTotalSizeByDatabase(databaseID)
+		if err != nil {
+			return err
+		}
+
+		health.TotalSizeMB = totalSizeMB
+		return nil
+	})
+
+	group.Go(func() error {
+		isRPOMet, err := s.isRPOMet(backupConfig)
+		if err != nil {
+			return err
+		}
+
+		health.IsRPOMet = isRPOMet
+		return nil
+	})
+
+	group.Go(func() error {
+		countByStatus, err := s.backupRepository.CountByStatusAfterDate(
+			databaseID,
+			time.Now().UTC().AddDate(0, 0, -backupHealthWindowInDays),
+		)
+		if err != nil {
+			return err
+		}
+
+		health.RecentFailureCount = countByStatus[backups_core.BackupStatusFailed]
+		health.SuccessRateLast30Days = getSuccessRate(countByStatus)
+		return nil
+	})
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	s.backupHealthCache.SetWithExpiration(databaseID.String(), health, backupHealthCacheTTL)
+
+	return health, nil
+}
+
+func (s *BackupService) GetDatabaseBackupHealthWithAuth(
+	ctx context.Context,
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*DatabaseBackupHealth, error) {
+	if _, err := s.databaseService.GetDatabase(user, databaseID); err != nil {
+		return nil, err
+	}
+
+	return s.GetDatabaseBackupHealth(ctx, databaseID)
+}
+
+// GetBackupPolicyComplianceScore scores the backup setup of the database from 0
+// to 100. Subscribed notifiers are alerted when the score drops below the
+// threshold of the config
+func (s *BackupService) GetBackupPolicyComplianceScore(
+	databaseID uuid.UUID,
+) (*ComplianceScore, error) {
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastCompletedBackup, err := s.backupRepository.FindNewestByDatabaseID(
+		databaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	isRPOMet, err := s.isRPOMet(backupConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationRecipients, err := s.backupConfigService.GetNotificationRecipients(
+		backupConfig,
+		database,
+	)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get some notification recipients",
+			"databaseId", databaseID,
+			"error", err,
+		)
+	}
+
+	hasRecentBackup := lastCompletedBackup != nil &&
+		time.Since(lastCompletedBackup.CreatedAt) <= complianceRecentBackupWindow
+
+	// FOREVER keeps every backup, which is the same as having no retention policy
+	isRetentionPolicySet := backupConfig.RetentionPolicyType != "" &&
+		(backupConfig.RetentionPolicyType != backups_config.RetentionPolicyTypeTimePeriod ||
+			(backupConfig.RetentionTimePeriod != "" &&
+				backupConfig.RetentionTimePeriod != period.PeriodForever))
+
+	score := &ComplianceScore{
+		DatabaseID: databaseID,
+		Breakdown:  []ComplianceScoreItem{},
+	}
+
+	for _, check := range []struct {
+		name   string
+		weight int
+		isMet  bool
+	}{
+		{"backups_enabled", 20, backupConfig.IsBackupsEnabled},
+		{"recent_backup", 20, hasRecentBackup},
+		{
+			"encryption_enabled",
+			15,
+			backupConfig.Encryption == backups_config.BackupEncryptionEncrypted,
+		},
+		{"retention_policy_set", 15, isRetentionPolicySet},
+		{"notifier_configured", 10, len(notificationRecipients) > 0},
+		{"rpo_met", 20, isRPOMet},
+	} {
+		item := ComplianceScoreItem{
+			Name:      check.name,
+			IsMet:     check.isMet,
+			MaxPoints: check.weight,
+		}
+
+		if check.isMet {
+			item.Points = check.weight
+			score.Score += check.weight
+		}
+
+		score.MaxScore += check.weight
+		score.Breakdown = append(score.Breakdown, item)
+	}
+
+	s.checkLowComplianceScore(backupConfig, database, score)
+
+	return score, nil
+}
+
+func (s *BackupService) GetBackupPolicyComplianceScoreWithAuth(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*ComplianceScore, error) {
+	if _, err := s.databaseService.GetDatabase(user, databaseID); err != nil {
+		return nil, err
+	}
+
+	return s.GetBackupPolicyComplianceScore(databaseID)
+}
+
+// GetWorkspaceBackupCoverage summarizes RPO compliance of all databases of the
+// workspace for the dashboard widget. The access check is done before the cache
+// is read, because the cached report is shared by all members of the workspace
+func (s *BackupService) GetWorkspaceBackupCoverage(
+	ctx context.Context,
+	user *users_models.User,
+	workspaceID uuid.UUID,
+) (*WorkspaceCoverageReport, error) {
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspace(user, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachedReport := s.workspaceCoverageCache.Get(workspaceID.String()); cachedReport != nil {
+		return cachedReport, nil
+	}
+
+	statuses := make([]*DatabaseRPOStatus, len(workspaceDatabases))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(workspaceCoverageMaxConcurrent)
+
+	for i, database := range workspaceDatabases {
+		group.Go(func() error {
+			if err := groupCtx.Err(); err != nil {
+				return err
+			}
+
+			status, err := s.getDatabaseRPOStatus(database)
+			if err != nil {
+				return fmt.Errorf("failed to get RPO status of database %s: %w", database.ID, err)
+			}
+
+			statuses[i] = status
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	report := buildWorkspaceCoverageReport(statuses, time.Now().UTC())
+
+	s.workspaceCoverageCache.SetWithExpiration(
+		workspaceID.String(),
+		report,
+		workspaceCoverageCacheTTL,
+	)
+
+	return report, nil
+}
+
+// GetWorkspaceBackupInventory returns backups of the workspace databases kept in
+// the storage. System storages hold backups of other workspaces too, they are
+// left out
+func (s *BackupService) GetWorkspaceBackupInventory(
+	workspaceID uuid.UUID,
+	storageID uuid.UUID,
+	includeMetadata bool,
+) (*BackupInventory, error) {
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if storage.WorkspaceID != workspaceID && !storage.IsSystem {
+		return nil, errors.New("storage does not belong to the workspace")
+	}
+
+	storageBackups, err := s.backupRepository.FindByStorageID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	isWorkspaceDatabaseByID := make(map[uuid.UUID]bool)
+	inventory := &BackupInventory{Backups: []*backups_core.Backup{}}
+
+	for _, backup := range storageBackups {
+		isWorkspaceDatabase, isKnown := isWorkspaceDatabaseByID[backup.DatabaseID]
+		if !isKnown {
+			database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+			if err != nil {
+				return nil, err
+			}
+
+			isWorkspaceDatabase = database.WorkspaceID != nil &&
+				*database.WorkspaceID == workspaceID
+			isWorkspaceDatabaseByID[backup.DatabaseID] = isWorkspaceDatabase
+
+			if isWorkspaceDatabase {
+				inventory.DatabaseCount++
+			}
+		}
+
+		if !isWorkspaceDatabase {
+			continue
+		}
+
+		inventory.Backups = append(inventory.Backups, backup)
+		inventory.TotalSizeMB += backup.BackupSizeMb
+	}
+
+	if includeMetadata {
+		files, isSupported, err := storage.ListBackupFiles(s.fieldEncryptor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of storage: %w", err)
+		}
+
+		// system storages are shared by all workspaces, so files of other
+		// tenants must not be listed
+		if storage.IsSystem {
+			files = filterFilesOfBackups(files, inventory.Backups)
+		}
+
+		inventory.IsFileListSupported = isSupported
+		inventory.StorageFileList = files
+	}
+
+	return inventory, nil
+}
+
+func (s *BackupService) GetWorkspaceBackupInventoryWithAuth(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	storageID uuid.UUID,
+	includeMetadata bool,
+) (*BackupInventory, error) {
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to access workspace")
+	}
+
+	return s.GetWorkspaceBackupInventory(workspaceID, storageID, includeMetadata)
+}
+
+// GetBackupsByStatus lists backups of all workspace databases in the status,
+// newest first
+func (s *BackupService) GetBackupsByStatus(
+	ctx context.Context,
+	workspaceID uuid.UUID,
+	status backups_core.BackupStatus,
+	limit int,
+) ([]*backups_core.BackupWithDatabase, error) {
+	if !slices.Contains([]backups_core.BackupStatus{
+		backups_core.BackupStatusInProgress,
+		backups_core.BackupStatusCompleted,
+		backups_core.BackupStatusFailed,
+		backups_core.BackupStatusCanceled,
+	}, status) {
+		return nil, fmt.Errorf("unknown backup status %q", status)
+	}
+
+	if limit <= 0 {
+		limit = backupsByStatusDefaultLimit
+	}
+	limit = min(limit, backupsByStatusMaxLimit)
+
+	return s.backupRepository.FindWithDatabaseByWorkspaceIDAndStatus(
+		ctx,
+		workspaceID,
+		status,
+		limit,
+	)
+}
+
+func (s *BackupService) GetBackupsByStatusWithAuth(
+	ctx context.Context,
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	status backups_core.BackupStatus,
+	limit int,
+) ([]*backups_core.BackupWithDatabase, error) {
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to access workspace")
+	}
+
+	return s.GetBackupsByStatus(ctx, workspaceID, status, limit)
+}
+
+func (s *BackupService) CompareBackupConfigsWithAuth(
+	user *users_models.User,
+	request *CompareBackupConfigsRequest,
+) (*BackupConfigComparison, error) {
+	configs := make([]*backups_config.BackupConfig, 0, 2)
+
+	for _, configDTO := range []*backups_config.BackupConfigDTO{
+		&request.ConfigA,
+		&request.ConfigB,
+	} {
+		plan, err := s.backupConfigService.GetDatabasePlan(user, configDTO.DatabaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		backupConfig, err := backups_config.FromDTO(configDTO, plan)
+		if err != nil {
+			return nil, err
+		}
+
+		configs = append(configs, backupConfig)
+	}
+
+	return s.CompareBackupConfigs(configs[0], configs[1])
+}
+
+// CompareBackupConfigs estimates the effect of replacing config a with config b.
+// The storage impact is based on the average size of completed backups of the
+// database of config a
+func (s *BackupService) CompareBackupConfigs(
+	a, b *backups_config.BackupConfig,
+) (*BackupConfigComparison, error) {
+	comparison := &BackupConfigComparison{
+		ChangedFields: b.DiffRetentionPolicy(a),
+	}
+
+	retainedCountA, isEstimatedA := estimateRetainedBackupsCount(a)
+	retainedCountB, isEstimatedB := estimateRetainedBackupsCount(b)
+	if !isEstimatedA || !isEstimatedB {
+		return comparison, nil
+	}
+
+	averageBackupSizeMB, err := s.getAverageBackupSizeMB(a.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison.EstimatedBackupCountImpact = retainedCountB - retainedCountA
+	comparison.EstimatedStorageImpactMB = float64(
+		comparison.EstimatedBackupCountImpact,
+	) * averageBackupSizeMB
+	comparison.IsRetentionReduced = comparison.EstimatedBackupCountImpact < 0
+	comparison.RequiresApproval = comparison.IsRetentionReduced
+
+	return comparison, nil
+}
+
+// GetStorageProviderCostEstimate projects the cost of keeping projectedSizeGB in
+// the storage for the given number of months. The size is assumed to stay flat,
+// growth of backups is not modeled
+func (s *BackupService) GetStorageProviderCostEstimate(
+	storageID uuid.UUID,
+	projectedSizeGB float64,
+	months int,
+) (*CostEstimate, error) {
+	if projectedSizeGB <= 0 {
+		return nil, errors.New("projected size must be greater than 0")
+	}
+
+	if months <= 0 || months > costEstimateMaxMonths {
+		return nil, fmt.Errorf("months must be between 1 and %d", costEstimateMaxMonths)
+	}
+
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &CostEstimate{
+		StorageID:       storageID,
+		ProjectedSizeGB: projectedSizeGB,
+		Months:          months,
+		CostBreakdown:   make([]MonthlyCostDataPoint, 0, months),
+	}
+
+	if storage.CostPerGBPerMonth == nil || *storage.CostPerGBPerMonth == 0 {
+		estimate.IsCostUnknown = true
+		return estimate, nil
+	}
+
+	estimate.MonthlyCostUSD = projectedSizeGB * *storage.CostPerGBPerMonth
+
+	for month := 1; month <= months; month++ {
+		estimate.TotalCostUSD += estimate.MonthlyCostUSD
+		estimate.CostBreakdown = append(estimate.CostBreakdown, MonthlyCostDataPoint{
+			Month:             month,
+			CostUSD:           estimate.MonthlyCostUSD,
+			CumulativeCostUSD: estimate.TotalCostUSD,
+		})
+	}
+
+	return estimate, nil
+}
+
+func (s *BackupService) GetStorageProviderCostEstimateWithAuth(
+	user *users_models.User,
+	storageID uuid.UUID,
+	projectedSizeGB float64,
+	months int,
+) (*CostEstimate, error) {
+	if _, err := s.storageService.GetStorage(user, storageID); err != nil {
+		return nil, err
+	}
+
+	return s.GetStorageProviderCostEstimate(storageID, projectedSizeGB, months)
+}
+
+// WouldExceedQuota reports whether a backup of estimatedSizeMb would break the
+// single backup or the total size limit of the database. The stricter of the
+// config and the plan limit applies, 0 means unlimited. Reaching a limit exactly
+// is allowed, like the cleaner keeps backups while their total equals the limit
+func (s *BackupService) WouldExceedQuota(
+	databaseID uuid.UUID,
+	estimatedSizeMb float64,
+) (bool, error) {
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return false, err
+	}
+
+	plan, err := s.databasePlanService.GetDatabasePlan(databaseID)
+	if err != nil {
+		return false, err
+	}
+
+	maxBackupSizeMB := getStricterSizeLimitMB(backupConfig.MaxBackupSizeMB, plan.MaxBackupSizeMB)
+	if maxBackupSizeMB > 0 && estimatedSizeMb > float64(maxBackupSizeMB) {
+		return true, nil
+	}
+
+	maxTotalSizeMB := getStricterSizeLimitMB(
+		backupConfig.MaxBackupsTotalSizeMB,
+		plan.MaxBackupsTotalSizeMB,
+	)
+	if maxTotalSizeMB == 0 {
+		return false, nil
+	}
+
+	totalSizeMB, err := s.backupRepository.GetTotalSizeByDatabase(databaseID)
+	if err != nil {
+		return false, err
+	}
+
+	return totalSizeMB+estimatedSizeMb > float64(maxTotalSizeMB), nil
+}
+
+// GetDatabasesApproachingStorageQuota returns databases of the workspace whose
+// backups take more than thresholdPercent of the total size limit. The stricter
+// of the config and the plan limit applies, like in WouldExceedQuota
+func (s *BackupService) GetDatabasesApproachingStorageQuota(
+	workspaceID uuid.UUID,
+	thresholdPercent float64,
+) ([]*QuotaWarning, error) {
+	if thresholdPercent <= 0 || thresholdPercent > 100 {
+		return nil, errors.New("threshold percent must be between 0 and 100")
+	}
+
+	workspaceDatabases, err := s.databaseService.GetDatabasesByWorkspaceID(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make([]*QuotaWarning, 0)
+
+	for _, database := range workspaceDatabases {
+		backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(database.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		plan, err := s.databasePlanService.GetDatabasePlan(database.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		limitMB := getStricterSizeLimitMB(
+			backupConfig.MaxBackupsTotalSizeMB,
+			plan.MaxBackupsTotalSizeMB,
+		)
+		if limitMB == 0 {
+			continue
+		}
+
+		currentSizeMB, err := s.backupRepository.GetTotalSizeByDatabase(database.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		usedPercent := currentSizeMB / float64(limitMB) * 100
+		if usedPercent <= thresholdPercent {
+			continue
+		}
+
+		dailyGrowthMB, err := s.getBackupSizeDailyGrowthMB(database.ID, currentSizeMB)
+		if err != nil {
+			return nil, err
+		}
+
+		warnings = append(warnings, &QuotaWarning{
+			DatabaseID:             database.ID,
+			CurrentSizeMB:          currentSizeMB,
+			LimitMB:                limitMB,
+			UsedPercent:            usedPercent,
+			EstimatedDaysUntilFull: estimateDaysUntilFull(currentSizeMB, limitMB, dailyGrowthMB),
+		})
+	}
+
+	return warnings, nil
+}
+
+// SimulatePlanChange validates the current backup config of the database
+// against newPlan and measures the data over its limits. Nothing is saved
+func (s *BackupService) SimulatePlanChange(
+	databaseID uuid.UUID,
+	newPlan *plans.DatabasePlan,
+) (*PlanChangeImpact, error) {
+	if newPlan == nil {
+		return nil, errors.New("new plan is required")
+	}
+
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &PlanChangeImpact{
+		DatabaseID:    databaseID,
+		IsConfigValid: true,
+	}
+
+	if err := backupConfig.Validate(newPlan); err != nil {
+		impact.IsConfigValid = false
+		impact.ValidationError = err.Error()
+	}
+
+	impact.CurrentSizeMB, err = s.backupRepository.GetTotalSizeByDatabase(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	impact.NewTotalSizeLimitMB = getStricterSizeLimitMB(
+		backupConfig.MaxBackupsTotalSizeMB,
+		newPlan.MaxBackupsTotalSizeMB,
+	)
+	if impact.NewTotalSizeLimitMB > 0 {
+		impact.OverSizeLimitMB = max(
+			impact.CurrentSizeMB-float64(impact.NewTotalSizeLimitMB),
+			0,
+		)
+	}
+
+	if newPlan.MaxStoragePeriod == "" || newPlan.MaxStoragePeriod == period.PeriodForever {
+		return impact, nil
+	}
+
+	// an unknown period has no duration and would mark every backup as over
+	// the limit, the validation error already reports it
+	if !newPlan.MaxStoragePeriod.IsValid() {
+		return impact, nil
+	}
+
+	periodDeadline := time.Now().UTC().Add(-newPlan.MaxStoragePeriod.ToDuration())
+
+	oldBackups, err := s.backupRepository.FindBackupsBeforeDate(databaseID, periodDeadline)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backup := range oldBackups {
+		if backup.Status == backups_core.BackupStatusInProgress {
+			continue
+		}
+
+		impact.OverPeriodLimitCount++
+		impact.OverPeriodLimitMB += backup.BackupSizeMb
+	}
+
+	return impact, nil
+}
+
+// RotateStorageCredentials replaces access keys of the storage. New keys are
+// saved only if the storage accepts them, after that the newest backup of every
+// database using the storage is opened to make sure existing backups stay
+// readable with the new keys. userID is nil when the rotation is not made on
+// behalf of a user
+func (s *BackupService) RotateStorageCredentials(
+	ctx context.Context,
+	userID *uuid.UUID,
+	storageID uuid.UUID,
+	newCredentials storages.StorageCredentials,
+) error {
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return err
+	}
+
+	if err := storage.SetCredentials(newCredentials); err != nil {
+		return err
+	}
+
+	if err := s.storageService.ValidateStorageAccess(storage); err != nil {
+		return fmt.Errorf("%w: %v", storages.ErrInvalidCredentials, err)
+	}
+
+	if err := storage.EncryptSensitiveData(s.fieldEncryptor); err != nil {
+		return err
+	}
+
+	if err := s.storageService.UpdateStorage(storage); err != nil {
+		return err
+	}
+
+	s.logger.Info("Storage credentials rotated", "storageId", storageID)
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Credentials rotated for storage: %s", storage.Name),
+		userID,
+		&storage.WorkspaceID,
+	)
+
+	return s.checkStorageBackupsReadable(ctx, storage)
+}
+
+func (s *BackupService) RotateStorageCredentialsWithAuth(
+	ctx context.Context,
+	user *users_models.User,
+	storageID uuid.UUID,
+	newCredentials storages.StorageCredentials,
+) error {
+	storage, err := s.storageService.GetStorageByID(storageID)
+	if err != nil {
+		return err
+	}
+
+	if storage == nil {
+		return errors.New("storage not found")
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(storage.WorkspaceID, user)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		return errors.New("insufficient permissions to rotate credentials of this storage")
+	}
+
+	if storage.IsSystem && user.Role != users_enums.UserRoleAdmin {
+		// keys of system storage are shared by every workspace
+		return errors.New("insufficient permissions to rotate credentials of this storage")
+	}
+
+	return s.RotateStorageCredentials(ctx, &user.ID, storageID, newCredentials)
+}
+
+func (s *BackupService) deleteDbBackups(databaseID uuid.UUID) error {
+	dbBackupsInProgress, err := s.backupRepository.FindByDatabaseIdAndStatus(
+		context.Background(),
+		databaseID,
+		backups_core.BackupStatusInProgress,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(dbBackupsInProgress) > 0 {
+		return errors.New("backup is in progress, storage cannot be removed")
+	}
+
+	dbBackups, err := s.backupRepository.FindByDatabaseID(
+		databaseID,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, dbBackup := range dbBackups {
+		err := s.backupCleaner.DeleteBackup(
+			context.Background(),
+			dbBackup,
+			backups_core.DeletionReasonStorageChange,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetBackupReader returns a reader for the backup file
+// If encrypted, wraps with DecryptionReader
+func (s *BackupService) getBackupReader(backupID uuid.UUID) (io.ReadCloser, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find backup: %w", err)
+	}
+
+	storage, err := s.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+
+	fileReader, err := storage.GetFile(s.fieldEncryptor, backup.FileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup file: %w", err)
+	}
+
+	return s.wrapWithDecryption(backup, fileReader)
+}
+
+func (s *BackupService) GenerateDownloadToken(
+	user *users_models.User,
+	backupID uuid.UUID,
+) (*backups_download.GenerateDownloadTokenResponse, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("cannot download backup for database without workspace")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to download backup for this database")
+	}
+
+	token, err := s.downloadTokenService.Generate(backupID, user.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	filename := s.generateBackupFilename(backup, database)
 
-	s.auditLogService.WriteAuditLog(
-		fmt.Sprintf("Download token generated for backup of database: %s", database.Name),
-		&user.ID,
-		database.WorkspaceID,
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Download token generated for backup of database: %s", database.Name),
+		&user.ID,
+		database.WorkspaceID,
+	)
+
+	return &backups_download.GenerateDownloadTokenResponse{
+		Token:    token,
+		Filename: filename,
+		BackupID: backupID,
+	}, nil
+}
+
+func (s *BackupService) ValidateDownloadToken(
+	token string,
+) (*backups_download.DownloadToken, *backups_download.RateLimiter, error) {
+	return s.downloadTokenService.ValidateAndConsume(token)
+}
+
+func (s *BackupService) GetBackupFileWithoutAuth(
+	backupID uuid.UUID,
+) (io.ReadCloser, *backups_core.Backup, *databases.Database, error) {
+	backup, err := s.backupRepository.FindByID(backupID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	reader, err := s.getBackupReader(backupID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return reader, backup, database, nil
+}
+
+func (s *BackupService) WriteAuditLogForDownload(
+	userID uuid.UUID,
+	backup *backups_core.Backup,
+	database *databases.Database,
+) {
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup file downloaded for database: %s", database.Name),
+		&userID,
+		database.WorkspaceID,
+	)
+}
+
+func (s *BackupService) RefreshDownloadLock(userID uuid.UUID) {
+	s.downloadTokenService.RefreshDownloadLock(userID)
+}
+
+func (s *BackupService) ReleaseDownloadLock(userID uuid.UUID) {
+	s.downloadTokenService.ReleaseDownloadLock(userID)
+}
+
+func (s *BackupService) IsDownloadInProgress(userID uuid.UUID) bool {
+	return s.downloadTokenService.IsDownloadInProgress(userID)
+}
+
+func (s *BackupService) UnregisterDownload(userID uuid.UUID) {
+	s.downloadTokenService.UnregisterDownload(userID)
+}
+
+func (s *BackupService) generateBackupFilename(
+	backup *backups_core.Backup,
+	database *databases.Database,
+) string {
+	timestamp := backup.CreatedAt.Format("2006-01-02_15-04-05")
+	safeName := files_utils.SanitizeFilename(database.Name)
+	extension := s.getBackupExtension(database.Type)
+	return fmt.Sprintf("%s_backup_%s%s", safeName, timestamp, extension)
+}
+
+func (s *BackupService) getBackupExtension(dbType databases.DatabaseType) string {
+	switch dbType {
+	case databases.DatabaseTypeMysql, databases.DatabaseTypeMariadb:
+		return ".sql.zst"
+	case databases.DatabaseTypePostgres:
+		return ".dump"
+	case databases.DatabaseTypeMongodb:
+		return ".archive"
+	default:
+		return ".backup"
+	}
+}
+
+// wrapWithDecryption returns fileReader as is for non-encrypted backups and wraps it
+// with DecryptionReader for encrypted ones. fileReader is closed on error
+func (s *BackupService) wrapWithDecryption(
+	backup *backups_core.Backup,
+	fileReader io.ReadCloser,
+) (io.ReadCloser, error) {
+	// If not encrypted, return raw reader
+	if backup.Encryption == backups_config.BackupEncryptionNone {
+		s.logger.Info("Returning non-encrypted backup", "backupId", backup.ID)
+		return fileReader, nil
+	}
+
+	// Decrypt on-the-fly for encrypted backups
+	if backup.Encryption != backups_config.BackupEncryptionEncrypted {
+		if err := fileReader.Close(); err != nil {
+			s.logger.Error("Failed to close file reader", "error", err)
+		}
+		return nil, fmt.Errorf("unsupported encryption type: %s", backup.Encryption)
+	}
+
+	if backup.EncryptionSalt == nil || backup.EncryptionIV == nil {
+		if err := fileReader.Close(); err != nil {
+			s.logger.Error("Failed to close file reader", "error", err)
+		}
+		return nil, fmt.Errorf("backup marked as encrypted but missing encryption metadata")
+	}
+
+	// Get encryption key
+	encryptionKey, err := s.encryptionKeyService.GetBackupKey(backup.EncryptionKeyID)
+	if err != nil {
+		if closeErr := fileReader.Close(); closeErr != nil {
+			s.logger.Error("Failed to close file reader", "error", closeErr)
+		}
+		return nil, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+
+	// Decode salt and IV
+	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
+	if err != nil {
+		if closeErr := fileReader.Close(); closeErr != nil {
+			s.logger.Error("Failed to close file reader", "error", closeErr)
+		}
+		return nil, fmt.Errorf("failed to decode salt: %w", err)
+	}
+
+	iv, err := base64.StdEncoding.DecodeString(*backup.EncryptionIV)
+	if err != nil {
+		if closeErr := fileReader.Close(); closeErr != nil {
+			s.logger.Error("Failed to close file reader", "error", closeErr)
+		}
+		return nil, fmt.Errorf("failed to decode IV: %w", err)
+	}
+
+	// Wrap with decrypting reader
+	decryptionReader, err := encryption.NewDecryptionReader(
+		fileReader,
+		encryptionKey,
+		backup.ID,
+		salt,
+		iv,
+	)
+	if err != nil {
+		if closeErr := fileReader.Close(); closeErr != nil {
+			s.logger.Error("Failed to close file reader", "error", closeErr)
+		}
+		return nil, fmt.Errorf("failed to create decrypting reader: %w", err)
+	}
+
+	s.logger.Info("Returning encrypted backup with decryption", "backupId", backup.ID)
+
+	return &DecryptionReaderCloser{
+		DecryptionReader: decryptionReader,
+		BaseReader:       fileReader,
+	}, nil
+}
+
+// getExpectedRunTimes returns scheduled run times from windowStart up to now plus
+// the first run time after now
+func (s *BackupService) getExpectedRunTimes(
+	backupConfig *backups_config.BackupConfig,
+	windowStart time.Time,
+	now time.Time,
+) []time.Time {
+	expectedTimes := []time.Time{}
+
+	cursor := windowStart
+	for len(expectedTimes) < missedBackupMaxRunTimesNum {
+		runTimes := backupConfig.BackupInterval.NextNRunTimes(cursor, missedBackupRunTimesBatch)
+		if len(runTimes) == 0 {
+			return expectedTimes
+		}
+
+		for _, runTime := range runTimes {
+			expectedTimes = append(expectedTimes, runTime)
+
+			if runTime.After(now) {
+				return expectedTimes
+			}
+		}
+
+		cursor = runTimes[len(runTimes)-1]
+	}
+
+	return expectedTimes
+}
+
+func (s *BackupService) sendMissedBackupsNotification(
+	backupConfig *backups_config.BackupConfig,
+	missedBackups []MissedBackup,
+) {
+	if !slices.Contains(
+		backupConfig.SendNotificationsOn,
+		backups_config.NotificationMissedBackup,
+	) {
+		return
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(backupConfig.DatabaseID)
+	if err != nil {
+		s.logger.Error("Failed to get database for missed backups notification", "error", err)
+		return
+	}
+
+	title := fmt.Sprintf("⚠️ Missed backups for database \"%s\"", database.Name)
+	message := fmt.Sprintf(
+		"%d scheduled backup(s) were not made in time. Last missed backup was expected at %s",
+		len(missedBackups),
+		missedBackups[len(missedBackups)-1].ExpectedAt.Format(time.RFC3339),
+	)
+
+	s.sendNotificationToRecipients(backupConfig, database, title, message)
+}
+
+func (s *BackupService) checkLowComplianceScore(
+	backupConfig *backups_config.BackupConfig,
+	database *databases.Database,
+	score *ComplianceScore,
+) {
+	cacheKey := database.ID.String()
+	previousScore := s.lastComplianceScoreCache.Get(cacheKey)
+	s.lastComplianceScoreCache.SetWithExpiration(cacheKey, &score.Score, lastComplianceScoreTTL)
+
+	threshold := backupConfig.MinComplianceScoreThreshold
+	if threshold == 0 || score.Score >= threshold {
+		return
+	}
+
+	if previousScore != nil && *previousScore < threshold {
+		return
+	}
+
+	if !slices.Contains(
+		backupConfig.SendNotificationsOn,
+		backups_config.NotificationLowComplianceScore,
+	) {
+		return
+	}
+
+	title := fmt.Sprintf("⚠️ Low backup compliance score for database \"%s\"", database.Name)
+	message := fmt.Sprintf(
+		"Backup compliance score is %d of %d, below the threshold of %d",
+		score.Score,
+		score.MaxScore,
+		threshold,
+	)
+
+	s.sendNotificationToRecipients(backupConfig, database, title, message)
+}
+
+func (s *BackupService) sendStorageQuotaWarningNotification(warning *QuotaWarning) {
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(warning.DatabaseID)
+	if err != nil {
+		s.logger.Error("Failed to get backup config for storage quota warning", "error", err)
+		return
+	}
+
+	if !slices.Contains(
+		backupConfig.SendNotificationsOn,
+		backups_config.NotificationStorageQuotaWarning,
+	) {
+		return
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(warning.DatabaseID)
+	if err != nil {
+		s.logger.Error("Failed to get database for storage quota warning", "error", err)
+		return
+	}
+
+	title := fmt.Sprintf(
+		"⚠️ Backups of database \"%s\" are close to the size limit",
+		database.Name,
+	)
+	message := fmt.Sprintf(
+		"Backups take %.2f MB of %d MB (%.1f%%)",
+		warning.CurrentSizeMB,
+		warning.LimitMB,
+		warning.UsedPercent,
+	)
+	if warning.EstimatedDaysUntilFull != nil {
+		message += fmt.Sprintf(
+			", the limit is expected to be reached in %d day(s)",
+			*warning.EstimatedDaysUntilFull,
+		)
+	}
+
+	s.sendNotificationToRecipients(backupConfig, database, title, message)
+}
+
+func (s *BackupService) sendIntegrityCheckFailedNotification(
+	database *databases.Database,
+	backup *backups_core.Backup,
+	result *backups_core.IntegrityCheckResult,
+) {
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(database.ID)
+	if err != nil {
+		s.logger.Error("Failed to get backup config for integrity check notification", "error", err)
+		return
+	}
+
+	if !slices.Contains(
+		backupConfig.SendNotificationsOn,
+		backups_config.NotificationIntegrityCheckFailed,
+	) {
+		return
+	}
+
+	title := fmt.Sprintf("❌ Backup of database \"%s\" failed integrity check", database.Name)
+	message := fmt.Sprintf(
+		"Integrity check of the backup made at %s failed: %s",
+		backup.CreatedAt.Format(time.RFC3339),
+		result.ErrorDetail,
+	)
+
+	s.sendNotificationToRecipients(backupConfig, database, title, message)
+}
+
+func (s *BackupService) sendNotificationToRecipients(
+	backupConfig *backups_config.BackupConfig,
+	database *databases.Database,
+	title string,
+	message string,
+) {
+	recipients, err := s.backupConfigService.GetNotificationRecipients(backupConfig, database)
+	if err != nil {
+		s.logger.Error(
+			"Failed to get some notification recipients",
+			"databaseId", database.ID,
+			"error", err,
+		)
+	}
+
+	for _, notifier := range recipients {
+		s.notificationSender.SendNotification(&notifier, title, message)
+	}
+}
+
+func (s *BackupService) checkBackupIntegrity(
+	ctx context.Context,
+	backup *backups_core.Backup,
+	database *databases.Database,
+) error {
+	storage, err := s.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		return fmt.Errorf("failed to get storage: %w", err)
+	}
+
+	fileReader, err := storage.GetFile(s.fieldEncryptor, backup.FileName)
+	if err != nil {
+		return fmt.Errorf("failed to get backup file: %w", err)
+	}
+
+	reader, err := s.wrapWithDecryption(backup, fileReader)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			s.logger.Error("Failed to close backup reader", "error", err)
+		}
+	}()
+
+	expectedMagicBytes := getDumpMagicBytes(database.Type)
+	head := make([]byte, len(expectedMagicBytes))
+	if _, err := io.ReadFull(reader, head); err != nil {
+		return fmt.Errorf("failed to read backup file: %w", err)
+	}
+
+	if !bytes.Equal(head, expectedMagicBytes) {
+		return fmt.Errorf("backup content does not look like a %s dump", database.Type)
+	}
+
+	// a truncated or corrupted tail only shows up when the file is read to the end
+	buf := make([]byte, integrityCheckBufferSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		_, err := reader.Read(buf)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read backup file to the end: %w", err)
+		}
+	}
+}
+
+// migrateBackupsStorageClass works on its own copy of the job, so callers
+// holding the returned job never race with progress updates
+func (s *BackupService) migrateBackupsStorageClass(
+	ctx context.Context,
+	job backups_core.MigrationJob,
+	backupsToMigrate []*backups_core.Backup,
+) {
+	// the job runs in its own goroutine, an unrecovered panic would crash the
+	// whole process and leave the job in progress forever
+	defer func() {
+		isPanicked := false
+		if recovered := recover(); recovered != nil {
+			isPanicked = true
+			s.logger.Error(
+				"Panic while migrating backups storage class",
+				"jobId", job.ID,
+				"panic", fmt.Sprint(recovered),
+				"stack", string(debug.Stack()),
+			)
+
+			errMessage := fmt.Sprintf("panic while migrating backups: %v", recovered)
+			job.LastError = &errMessage
+		}
+
+		completedAt := time.Now().UTC()
+		job.CompletedAt = &completedAt
+		job.Status = backups_core.MigrationJobStatusCompleted
+
+		if isPanicked || job.MigratedCount < job.TotalCount {
+			job.Status = backups_core.MigrationJobStatusFailed
+		}
+
+		if err := s.backupRepository.SaveMigrationJob(&job); err != nil {
+			s.logger.Error("Failed to save migration job result", "jobId", job.ID, "error", err)
+		}
+	}()
+
+	for _, backup := range backupsToMigrate {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if err := s.migrateBackupStorageClass(backup, job.TargetStorageClass); err != nil {
+			s.logger.Error(
+				"Failed to migrate backup storage class",
+				"backupId", backup.ID,
+				"jobId", job.ID,
+				"error", err,
+			)
+
+			errMessage := err.Error()
+			job.LastError = &errMessage
+			job.FailedCount++
+		} else {
+			job.MigratedCount++
+		}
+
+		if err := s.backupRepository.SaveMigrationJob(&job); err != nil {
+			s.logger.Error("Failed to save migration job progress", "jobId", job.ID, "error", err)
+		}
+	}
+}
+
+func (s *BackupService) migrateBackupStorageClass(
+	backup *backups_core.Backup,
+	storageClass backups_config.StorageClass,
+) error {
+	storage, err := s.storageService.GetStorageByID(backup.StorageID)
+	if err != nil {
+		return err
+	}
+
+	err = storage.CopyObjectWithNewClass(s.fieldEncryptor, backup.FileName, string(storageClass))
+	if err != nil {
+		return err
+	}
+
+	backup.StorageClass = storageClass
+
+	return s.backupRepository.Save(backup)
+}
+
+func (s *BackupService) checkStorageBackupsReadable(
+	ctx context.Context,
+	storage *storages.Storage,
+) error {
+	backupConfigs, err := s.backupConfigService.FindConfigsByStorageID(storage.ID)
+	if err != nil {
+		return err
+	}
+
+	completedBackups, err := s.backupRepository.FindByStorageIdAndStatus(
+		storage.ID,
+		backups_core.BackupStatusCompleted,
 	)
+	if err != nil {
+		return err
+	}
 
-	return &backups_download.GenerateDownloadTokenResponse{
-		Token:    token,
-		Filename: filename,
-		BackupID: backupID,
-	}, nil
+	// backups are sorted from the newest, so the first one met is the newest
+	newestBackupByDatabaseID := make(map[uuid.UUID]*backups_core.Backup)
+	for _, backup := range completedBackups {
+		if _, isFound := newestBackupByDatabaseID[backup.DatabaseID]; !isFound {
+			newestBackupByDatabaseID[backup.DatabaseID] = backup
+		}
+	}
+
+	var checkErrs []error
+
+	for _, backupConfig := range backupConfigs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		backup := newestBackupByDatabaseID[backupConfig.DatabaseID]
+		if backup == nil || backup.FileName == "" {
+			continue
+		}
+
+		reader, err := storage.GetFile(s.fieldEncryptor, backup.FileName)
+		if err != nil {
+			s.logger.Error(
+				"Backup is not readable after credentials rotation",
+				"storageId", storage.ID,
+				"databaseId", backupConfig.DatabaseID,
+				"backupId", backup.ID,
+				"error", err,
+			)
+			checkErrs = append(
+				checkErrs,
+				fmt.Errorf("database %s: %w", backupConfig.DatabaseID, err),
+			)
+
+			continue
+		}
+
+		if err := reader.Close(); err != nil {
+			s.logger.Error("Failed to close backup reader", "error", err)
+		}
+	}
+
+	if len(checkErrs) > 0 {
+		return fmt.Errorf(
+			"credentials rotated, but backups are not readable: %w",
+			errors.Join(checkErrs...),
+		)
+	}
+
+	return nil
 }
 
-func (s *BackupService) ValidateDownloadToken(
-	token string,
-) (*backups_download.DownloadToken, *backups_download.RateLimiter, error) {
-	return s.downloadTokenService.ValidateAndConsume(token)
+func (s *BackupService) getAverageBackupSizeMB(databaseID uuid.UUID) (float64, error) {
+	completedBackups, err := s.backupRepository.FindByDatabaseIdAndStatus(
+		context.Background(),
+		databaseID,
+		backups_core.BackupStatusCompleted,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(completedBackups) == 0 {
+		return 0, nil
+	}
+
+	var totalSizeMB float64
+	for _, backup := range completedBackups {
+		totalSizeMB += backup.BackupSizeMb
+	}
+
+	return totalSizeMB / float64(len(completedBackups)), nil
 }
 
-func (s *BackupService) GetBackupFileWithoutAuth(
-	backupID uuid.UUID,
-) (io.ReadCloser, *backups_core.Backup, *databases.Database, error) {
-	backup, err := s.backupRepository.FindByID(backupID)
+// getBackupSizeDailyGrowthMB estimates the net daily growth of the stored
+// backups. Retention removes old backups as new ones arrive, so the daily sum
+// of new backups is gross growth. The stored total rather follows the size of
+// a single backup, so its trend over the window is applied to the total
+func (s *BackupService) getBackupSizeDailyGrowthMB(
+	databaseID uuid.UUID,
+	currentSizeMB float64,
+) (float64, error) {
+	sizeByDate, err := s.backupRepository.SumSizeMBByDate(
+		databaseID,
+		storageQuotaGrowthWindowInDays,
+	)
 	if err != nil {
-		return nil, nil, nil, err
+		return 0, err
 	}
 
-	database, err := s.databaseService.GetDatabaseByID(backup.DatabaseID)
+	countByDate, err := s.backupRepository.CountByDate(
+		databaseID,
+		storageQuotaGrowthWindowInDays,
+	)
 	if err != nil {
-		return nil, nil, nil, err
+		return 0, err
 	}
 
-	reader, err := s.getBackupReader(backupID)
+	return getNetDailyGrowthMB(currentSizeMB, sizeByDate, countByDate), nil
+}
+
+// isRPOMet reports whether the newest completed backup is younger than the gap
+// after which GetMissedBackups considers a scheduled backup missed
+func (s *BackupService) isRPOMet(backupConfig *backups_config.BackupConfig) (bool, error) {
+	if !backupConfig.IsBackupsEnabled || backupConfig.BackupInterval == nil {
+		return false, nil
+	}
+
+	now := time.Now().UTC()
+
+	runTimes := backupConfig.BackupInterval.NextNRunTimes(now, 2)
+	if len(runTimes) < 2 {
+		return false, nil
+	}
+
+	lastCompletedBackup, err := s.backupRepository.FindNewestByDatabaseID(
+		backupConfig.DatabaseID,
+		backups_core.BackupStatusCompleted,
+	)
 	if err != nil {
-		return nil, nil, nil, err
+		return false, err
 	}
 
-	return reader, backup, database, nil
+	if lastCompletedBackup == nil {
+		return false, nil
+	}
+
+	maxBackupAge := time.Duration(float64(runTimes[1].Sub(runTimes[0])) * missedBackupGapRatio)
+
+	return now.Sub(lastCompletedBackup.CreatedAt) <= maxBackupAge, nil
 }
 
-func (s *BackupService) WriteAuditLogForDownload(
-	userID uuid.UUID,
-	backup *backups_core.Backup,
+func (s *BackupService) getDatabaseRPOStatus(
 	database *databases.Database,
-) {
-	s.auditLogService.WriteAuditLog(
-		fmt.Sprintf("Backup file downloaded for database: %s", database.Name),
-		&userID,
-		database.WorkspaceID,
+) (*DatabaseRPOStatus, error) {
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(database.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	isRPOMet, err := s.isRPOMet(backupConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &DatabaseRPOStatus{
+		DatabaseID:       database.ID,
+		DatabaseName:     database.Name,
+		IsBackupsEnabled: backupConfig.IsBackupsEnabled,
+		IsRPOMet:         isRPOMet,
+	}
+
+	lastCompletedBackup, err := s.backupRepository.FindNewestByDatabaseID(
+		database.ID,
+		backups_core.BackupStatusCompleted,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastCompletedBackup != nil {
+		status.LastBackupAt = &lastCompletedBackup.CreatedAt
+	}
+
+	return status, nil
 }
 
-func (s *BackupService) RefreshDownloadLock(userID uuid.UUID) {
-	s.downloadTokenService.RefreshDownloadLock(userID)
+func getRetentionComplianceFlag(backupConfig *backups_config.BackupConfig) string {
+	if backupConfig.IsReadOnlyMode {
+		return "read-only: backup creation disabled, existing backups preserved"
+	}
+
+	if !backupConfig.IsBackupsEnabled {
+		return "non-compliant: backups disabled"
+	}
+
+	if backupConfig.BackupInterval == nil {
+		return "non-compliant: missing backup schedule"
+	}
+
+	if backupConfig.BackupInterval.Interval == intervals.IntervalWeekly ||
+		backupConfig.BackupInterval.Interval == intervals.IntervalMonthly {
+		return "non-compliant: missing daily backup"
+	}
+
+	if backupConfig.Encryption != backups_config.BackupEncryptionEncrypted {
+		return "non-compliant: backups are not encrypted"
+	}
+
+	return "compliant"
 }
 
-func (s *BackupService) ReleaseDownloadLock(userID uuid.UUID) {
-	s.downloadTokenService.ReleaseDownloadLock(userID)
+// estimateRetainedBackupsCount returns how many backups the config keeps at
+// steady state. Only count, time period and GFS policies can be estimated
+func estimateRetainedBackupsCount(backupConfig *backups_config.BackupConfig) (int, bool) {
+	switch backupConfig.RetentionPolicyType {
+	case backups_config.RetentionPolicyTypeCount:
+		return backupConfig.RetentionCount, backupConfig.RetentionCount > 0
+
+	case backups_config.RetentionPolicyTypeGFS:
+		return backupConfig.RetentionGfsHours +
+			backupConfig.RetentionGfsDays +
+			backupConfig.RetentionGfsWeeks +
+			backupConfig.RetentionGfsMonths +
+			backupConfig.RetentionGfsYears, true
+
+	case backups_config.RetentionPolicyTypeTimePeriod, "":
+		retentionPeriod := backupConfig.RetentionTimePeriod
+		if !retentionPeriod.IsValid() || retentionPeriod == period.PeriodForever {
+			return 0, false
+		}
+
+		if backupConfig.BackupInterval == nil {
+			return 0, false
+		}
+
+		runTimes := backupConfig.BackupInterval.NextNRunTimes(time.Now().UTC(), 2)
+		if len(runTimes) < 2 {
+			return 0, false
+		}
+
+		intervalDuration := runTimes[1].Sub(runTimes[0])
+		if intervalDuration <= 0 {
+			return 0, false
+		}
+
+		return int(retentionPeriod.ToDuration() / intervalDuration), true
+
+	default:
+		return 0, false
+	}
 }
 
-func (s *BackupService) IsDownloadInProgress(userID uuid.UUID) bool {
-	return s.downloadTokenService.IsDownloadInProgress(userID)
+func buildWorkspaceCoverageReport(
+	statuses []*DatabaseRPOStatus,
+	now time.Time,
+) *WorkspaceCoverageReport {
+	report := &WorkspaceCoverageReport{TotalDatabases: len(statuses)}
+
+	var totalAgeHours float64
+	backedUpCount := 0
+
+	for _, status := range statuses {
+		if status.IsRPOMet {
+			report.DatabasesWithinRPO++
+		}
+
+		if !status.IsBackupsEnabled {
+			report.DatabasesWithBackupsDisabled++
+		}
+
+		if status.LastBackupAt == nil {
+			report.DatabasesNeverBacked++
+			continue
+		}
+
+		totalAgeHours += now.Sub(*status.LastBackupAt).Hours()
+		backedUpCount++
+
+		if report.WorstDatabase == nil ||
+			status.LastBackupAt.Before(*report.WorstDatabase.LastBackupAt) {
+			report.WorstDatabase = status
+		}
+	}
+
+	if backedUpCount > 0 {
+		report.AverageLastBackupAgeHours = totalAgeHours / float64(backedUpCount)
+	}
+
+	return report
 }
 
-func (s *BackupService) UnregisterDownload(userID uuid.UUID) {
-	s.downloadTokenService.UnregisterDownload(userID)
+func getStricterSizeLimitMB(configLimitMB, planLimitMB int64) int64 {
+	if configLimitMB == 0 {
+		return planLimitMB
+	}
+
+	if planLimitMB == 0 {
+		return configLimitMB
+	}
+
+	return min(configLimitMB, planLimitMB)
 }
 
-func (s *BackupService) generateBackupFilename(
-	backup *backups_core.Backup,
-	database *databases.Database,
-) string {
-	timestamp := backup.CreatedAt.Format("2006-01-02_15-04-05")
-	safeName := files_utils.SanitizeFilename(database.Name)
-	extension := s.getBackupExtension(database.Type)
-	return fmt.Sprintf("%s_backup_%s%s", safeName, timestamp, extension)
+// findMissedBackups compares expected run times with sorted backup times. The
+// last expected time is already in the future and only bounds the interval
+func findMissedBackups(expectedTimes, createdAts []time.Time, now time.Time) []MissedBackup {
+	missedBackups := []MissedBackup{}
+
+	for i := 0; i < len(expectedTimes)-1; i++ {
+		expectedAt := expectedTimes[i]
+		intervalDuration := expectedTimes[i+1].Sub(expectedAt)
+
+		nextIdx := sort.Search(len(createdAts), func(j int) bool {
+			return !createdAts[j].Before(expectedAt)
+		})
+
+		// the gap of a slot starts no earlier than the previous slot, otherwise
+		// an on time backup after a missed one would be counted as missed too
+		gapStart := expectedAt.Add(-intervalDuration)
+		if nextIdx > 0 && createdAts[nextIdx-1].After(gapStart) {
+			gapStart = createdAts[nextIdx-1]
+		}
+
+		gapEnd := now
+		if nextIdx < len(createdAts) {
+			gapEnd = createdAts[nextIdx]
+		}
+
+		gap := gapEnd.Sub(gapStart)
+		if float64(gap) > float64(intervalDuration)*missedBackupGapRatio {
+			missedBackups = append(missedBackups, MissedBackup{
+				ExpectedAt: expectedAt,
+				GapHours:   gap.Hours(),
+			})
+		}
+	}
+
+	return missedBackups
 }
 
-func (s *BackupService) getBackupExtension(dbType databases.DatabaseType) string {
+// getNetDailyGrowthMB fits a line through the average backup size per day and
+// scales its relative slope to the stored total. Zero means no net growth
+func getNetDailyGrowthMB(
+	currentSizeMB float64,
+	sizeByDate map[string]float64,
+	countByDate map[string]int,
+) float64 {
+	var days, avgSizesMB []float64
+	for day, sizeMB := range sizeByDate {
+		count := countByDate[day]
+		if count == 0 {
+			continue
+		}
+
+		date, err := time.Parse(time.DateOnly, day)
+		if err != nil {
+			continue
+		}
+
+		days = append(days, float64(date.Unix())/(24*60*60))
+		avgSizesMB = append(avgSizesMB, sizeMB/float64(count))
+	}
+
+	if len(days) < 2 {
+		return 0
+	}
+
+	var meanDay, meanSizeMB float64
+	for i := range days {
+		meanDay += days[i]
+		meanSizeMB += avgSizesMB[i]
+	}
+	meanDay /= float64(len(days))
+	meanSizeMB /= float64(len(days))
+
+	var covariance, variance float64
+	for i := range days {
+		covariance += (days[i] - meanDay) * (avgSizesMB[i] - meanSizeMB)
+		variance += (days[i] - meanDay) * (days[i] - meanDay)
+	}
+
+	if variance == 0 || meanSizeMB <= 0 {
+		return 0
+	}
+
+	slopeMB := covariance / variance
+	if slopeMB <= 0 {
+		return 0
+	}
+
+	return currentSizeMB * slopeMB / meanSizeMB
+}
+
+func estimateDaysUntilFull(currentSizeMB float64, limitMB int64, dailyGrowthMB float64) *int {
+	if dailyGrowthMB <= 0 {
+		return nil
+	}
+
+	days := max(int(math.Ceil((float64(limitMB)-currentSizeMB)/dailyGrowthMB)), 0)
+
+	return &days
+}
+
+func getBackupDeletionWarning(impact *BackupDeletionImpact) string {
+	var warnings []string
+
+	if impact.IsLocked {
+		warnings = append(warnings, "the backup is in progress or its database is read-only")
+	}
+
+	if impact.IsNewest {
+		warnings = append(warnings, "this is the most recent backup of the database")
+	}
+
+	if impact.HasDependentIncrementals {
+		warnings = append(warnings, "incremental backups depend on this backup")
+	}
+
+	if impact.IsWithinGracePeriod {
+		warnings = append(warnings, "the backup was created less than an hour ago")
+	}
+
+	if impact.IsRetentionOverride {
+		warnings = append(warnings, "the retention policy would keep this backup")
+	}
+
+	if len(warnings) == 0 {
+		return ""
+	}
+
+	return "Deleting this backup is irreversible: " + strings.Join(warnings, "; ")
+}
+
+// getSuccessRate returns the percentage of completed backups among completed and
+// failed ones, canceled backups are not counted. nil means there is no data yet
+func getSuccessRate(countByStatus map[backups_core.BackupStatus]int) *float64 {
+	completedCount := countByStatus[backups_core.BackupStatusCompleted]
+	finishedCount := completedCount + countByStatus[backups_core.BackupStatusFailed]
+	if finishedCount == 0 {
+		return nil
+	}
+
+	successRate := float64(completedCount) / float64(finishedCount) * 100
+
+	return &successRate
+}
+
+func getKeyFingerprint(masterKey string) string {
+	hash := sha256.Sum256([]byte(masterKey))
+	return hex.EncodeToString(hash[:8])
+}
+
+func getDumpMagicBytes(dbType databases.DatabaseType) []byte {
 	switch dbType {
-	case databases.DatabaseTypeMysql, databases.DatabaseTypeMariadb:
-		return ".sql.zst"
 	case databases.DatabaseTypePostgres:
-		return ".dump"
+		// pg_dump custom format (-Fc)
+		return []byte("PGDMP")
+	case databases.DatabaseTypeMysql, databases.DatabaseTypeMariadb:
+		// zstd frame, dumps are compressed before upload
+		return []byte{0x28, 0xB5, 0x2F, 0xFD}
 	case databases.DatabaseTypeMongodb:
-		return ".archive"
+		// gzip member, mongodump runs with --archive --gzip
+		return []byte{0x1F, 0x8B}
 	default:
-		return ".backup"
+		return []byte{}
+	}
+}
+
+func (s *BackupService) getManageableDatabase(
+	user *users_models.User,
+	databaseID uuid.UUID,
+) (*databases.Database, error) {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil {
+		return nil, errors.New("database has no workspace")
+	}
+
+	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to manage backups of this database")
+	}
+
+	return database, nil
+}
+
+func filterFilesOfBackups(
+	files []storages.StorageFileInfo,
+	backups []*backups_core.Backup,
+) []storages.StorageFileInfo {
+	isBackupFileByName := make(map[string]bool, len(backups)*2)
+	for _, backup := range backups {
+		if backup.FileName == "" {
+			continue
+		}
+
+		isBackupFileByName[backup.FileName] = true
+		isBackupFileByName[backup.FileName+".metadata"] = true
 	}
+
+	backupFiles := make([]storages.StorageFileInfo, 0, len(backups))
+	for _, file := range files {
+		if isBackupFileByName[file.Name] {
+			backupFiles = append(backupFiles, file)
+		}
+	}
+
+	return backupFiles
 }
@@ -0,0 +1,262 @@
+package backups
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/storage"
+	"databasus-backend/internal/util/encryption"
+)
+
+type dumpTestCase struct {
+	name        string
+	dbType      databases.DatabaseType
+	compression string
+	level       int
+}
+
+func Test_GetDumpMagicBytes_WithDumpOfEachTypeAndCompression_HeaderMatches(t *testing.T) {
+	for _, tt := range getDumpTestCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			dump := buildTestDump(t, tt)
+
+			assert.True(t, bytes.HasPrefix(dump, getDumpMagicBytes(tt.dbType)))
+		})
+	}
+}
+
+func Test_VerifyEncryption_WithDumpOfEachTypeAndCompression_BackupIsDecryptable(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabase("Test Database", workspace.ID, owner.Token, router)
+	testStorage := createTestStorage(workspace.ID)
+
+	backupRepo := &backups_core.BackupRepository{}
+
+	defer func() {
+		backups, _ := backupRepo.FindByDatabaseID(database.ID)
+		for _, backup := range backups {
+			backupRepo.DeleteByID(backup.ID)
+		}
+
+		setTestDatabaseType(t, database.ID, databases.DatabaseTypePostgres)
+		databases.RemoveTestDatabase(database)
+		time.Sleep(50 * time.Millisecond)
+		storages.RemoveTestStorage(testStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	for _, tt := range getDumpTestCases() {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestDatabaseType(t, database.ID, tt.dbType)
+
+			backupID := uuid.New()
+			backup := &backups_core.Backup{
+				ID:         backupID,
+				DatabaseID: database.ID,
+				StorageID:  testStorage.ID,
+				FileName:   backupID.String(),
+				Status:     backups_core.BackupStatusCompleted,
+				CreatedAt:  time.Now().UTC(),
+			}
+			assert.NoError(t, backupRepo.Save(backup))
+
+			err := testStorage.SaveFile(
+				context.Background(),
+				encryption.GetFieldEncryptor(),
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+				backup.FileName,
+				bytes.NewReader(buildTestDump(t, tt)),
+			)
+			assert.NoError(t, err)
+
+			result, err := GetBackupService().VerifyEncryption(context.Background(), backup.ID)
+			assert.NoError(t, err)
+			assert.True(t, result.IsDecryptable, result.ErrorDetail)
+		})
+	}
+}
+
+func Test_GetNetDailyGrowthMB_WithBackupSizesOverWindow_ReturnsNetGrowth(t *testing.T) {
+	tests := []struct {
+		name           string
+		sizeByDate     map[string]float64
+		countByDate    map[string]int
+		expectedGrowth float64
+	}{
+		{
+			name:           "growing backups scale the total",
+			sizeByDate:     map[string]float64{"2026-01-01": 100, "2026-01-11": 400},
+			countByDate:    map[string]int{"2026-01-01": 1, "2026-01-11": 2},
+			expectedGrowth: 1000 * 10.0 / 150,
+		},
+		{
+			name:           "same sized backups do not grow",
+			sizeByDate:     map[string]float64{"2026-01-01": 100, "2026-01-11": 300},
+			countByDate:    map[string]int{"2026-01-01": 1, "2026-01-11": 3},
+			expectedGrowth: 0,
+		},
+		{
+			name:           "shrinking backups do not grow",
+			sizeByDate:     map[string]float64{"2026-01-01": 200, "2026-01-11": 100},
+			countByDate:    map[string]int{"2026-01-01": 1, "2026-01-11": 1},
+			expectedGrowth: 0,
+		},
+		{
+			name:           "single day has no trend",
+			sizeByDate:     map[string]float64{"2026-01-11": 100},
+			countByDate:    map[string]int{"2026-01-11": 1},
+			expectedGrowth: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			growthMB := getNetDailyGrowthMB(1000, tt.sizeByDate, tt.countByDate)
+
+			assert.InDelta(t, tt.expectedGrowth, growthMB, 0.001)
+		})
+	}
+}
+
+func Test_FindMissedBackups_WithBackupTimes_ReturnsSlotsWithGapOverInterval(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return base.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	// hourly slots, the last one only bounds the interval
+	expectedTimes := []time.Time{at(0), at(60), at(120), at(180)}
+
+	tests := []struct {
+		name                string
+		createdAts          []time.Time
+		now                 time.Time
+		expectedMissedSlots []time.Time
+		expectedGapHours    []float64
+	}{
+		{
+			name:                "all backups on time",
+			createdAts:          []time.Time{at(-60), at(0), at(60), at(120)},
+			now:                 at(180),
+			expectedMissedSlots: []time.Time{},
+		},
+		{
+			name:                "backup late within 150% of interval",
+			createdAts:          []time.Time{at(-60), at(0), at(80), at(120)},
+			now:                 at(180),
+			expectedMissedSlots: []time.Time{},
+		},
+		{
+			name:                "skipped slot is missed, next on time backup is not",
+			createdAts:          []time.Time{at(-60), at(0), at(120)},
+			now:                 at(180),
+			expectedMissedSlots: []time.Time{at(60)},
+			expectedGapHours:    []float64{2},
+		},
+		{
+			name:                "backup late over 150% of interval",
+			createdAts:          []time.Time{at(-60), at(0), at(100), at(120)},
+			now:                 at(180),
+			expectedMissedSlots: []time.Time{at(60)},
+			expectedGapHours:    []float64{100.0 / 60},
+		},
+		{
+			name:                "no backups since window start",
+			createdAts:          []time.Time{at(-60)},
+			now:                 at(180),
+			expectedMissedSlots: []time.Time{at(0), at(60), at(120)},
+			expectedGapHours:    []float64{4, 3, 2},
+		},
+		{
+			name:                "latest slot not overdue yet",
+			createdAts:          []time.Time{at(-60), at(0), at(60)},
+			now:                 at(140),
+			expectedMissedSlots: []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missedBackups := findMissedBackups(expectedTimes, tt.createdAts, tt.now)
+
+			missedSlots := []time.Time{}
+			for i, missedBackup := range missedBackups {
+				missedSlots = append(missedSlots, missedBackup.ExpectedAt)
+				assert.InDelta(t, tt.expectedGapHours[i], missedBackup.GapHours, 0.001)
+			}
+			assert.Equal(t, tt.expectedMissedSlots, missedSlots)
+		})
+	}
+}
+
+func getDumpTestCases() []dumpTestCase {
+	return []dumpTestCase{
+		{"postgres with gzip", databases.DatabaseTypePostgres, "gzip", gzip.DefaultCompression},
+		{"postgres with zstd", databases.DatabaseTypePostgres, "zstd", 5},
+		{"mysql with fastest zstd", databases.DatabaseTypeMysql, "zstd", 1},
+		{"mysql with best zstd", databases.DatabaseTypeMysql, "zstd", 9},
+		{"mariadb with fastest zstd", databases.DatabaseTypeMariadb, "zstd", 1},
+		{"mariadb with best zstd", databases.DatabaseTypeMariadb, "zstd", 9},
+		{"mongodb with fastest gzip", databases.DatabaseTypeMongodb, "gzip", gzip.BestSpeed},
+		{"mongodb with best gzip", databases.DatabaseTypeMongodb, "gzip", gzip.BestCompression},
+	}
+}
+
+// buildTestDump compresses a payload the way the backup use case of the type
+// does: pg_dump compresses inside its custom format, the others compress the
+// whole stream
+func buildTestDump(t *testing.T, tt dumpTestCase) []byte {
+	payload := []byte("-- test dump payload")
+
+	var compressed bytes.Buffer
+	switch tt.compression {
+	case "gzip":
+		writer, err := gzip.NewWriterLevel(&compressed, tt.level)
+		assert.NoError(t, err)
+		_, err = writer.Write(payload)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+	case "zstd":
+		writer, err := zstd.NewWriter(
+			&compressed,
+			zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(tt.level)),
+		)
+		assert.NoError(t, err)
+		_, err = writer.Write(payload)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+	default:
+		t.Fatalf("unknown compression %s", tt.compression)
+	}
+
+	if tt.dbType == databases.DatabaseTypePostgres {
+		return append([]byte("PGDMP"), compressed.Bytes()...)
+	}
+
+	return compressed.Bytes()
+}
+
+func setTestDatabaseType(t *testing.T, databaseID uuid.UUID, dbType databases.DatabaseType) {
+	err := storage.GetDb().
+		Model(&databases.Database{}).
+		Where("id = ?", databaseID).
+		Update("type", dbType).Error
+	assert.NoError(t, err)
+}
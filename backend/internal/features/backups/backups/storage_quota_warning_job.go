@@ -0,0 +1,90 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+)
+
+const (
+	storageQuotaWarningCheckInterval    = 24 * time.Hour
+	storageQuotaWarningThresholdPercent = 80
+)
+
+// StorageQuotaWarningJob notifies once a day about databases whose backups are
+// close to the total size limit, before the cleaner starts removing old backups
+type StorageQuotaWarningJob struct {
+	backupService    *BackupService
+	workspaceService *workspaces_services.WorkspaceService
+	logger           *slog.Logger
+
+	runOnce sync.Once
+	hasRun  atomic.Bool
+}
+
+func (j *StorageQuotaWarningJob) Run(ctx context.Context) {
+	wasAlreadyRun := j.hasRun.Load()
+
+	j.runOnce.Do(func() {
+		j.hasRun.Store(true)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		ticker := time.NewTicker(storageQuotaWarningCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := j.warnAboutStorageQuotas(ctx); err != nil {
+					j.logger.Error("Failed to check storage quotas", "error", err)
+				}
+			}
+		}
+	})
+
+	if wasAlreadyRun {
+		panic(fmt.Sprintf("%T.Run() called multiple times", j))
+	}
+}
+
+func (j *StorageQuotaWarningJob) warnAboutStorageQuotas(ctx context.Context) error {
+	workspaces, err := j.workspaceService.GetAllWorkspaces()
+	if err != nil {
+		return err
+	}
+
+	for _, workspace := range workspaces {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		warnings, err := j.backupService.GetDatabasesApproachingStorageQuota(
+			workspace.ID,
+			storageQuotaWarningThresholdPercent,
+		)
+		if err != nil {
+			j.logger.Error(
+				"Failed to get databases approaching storage quota",
+				"workspaceId", workspace.ID,
+				"error", err,
+			)
+			continue
+		}
+
+		for _, warning := range warnings {
+			j.backupService.sendStorageQuotaWarningNotification(warning)
+		}
+	}
+
+	return nil
+}
@@ -21,7 +21,7 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	mongodbtypes "databasus-backend/internal/features/databases/databases/mongodb"
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/features/storages"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/tools"
@@ -35,9 +35,9 @@ const (
 )
 
 type CreateMongodbBackupUsecase struct {
-	logger           *slog.Logger
-	secretKeyService *encryption_secrets.SecretKeyService
-	fieldEncryptor   encryption.FieldEncryptor
+	logger               *slog.Logger
+	encryptionKeyService *encryption_keys.WorkspaceEncryptionKeyService
+	fieldEncryptor       encryption.FieldEncryptor
 }
 
 type writeResult struct {
@@ -165,6 +165,7 @@ func (uc *CreateMongodbBackupUsecase) streamToStorage(
 
 	finalWriter, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
 		backup.ID,
+		backup.EncryptionKeyID,
 		backupConfig,
 		storageWriter,
 	)
@@ -176,12 +177,14 @@ func (uc *CreateMongodbBackupUsecase) streamToStorage(
 
 	saveErrCh := make(chan error, 1)
 	go func() {
-		saveErr := storage.SaveFile(
+		saveErr := storage.SaveFileWithStorageClass(
 			ctx,
 			uc.fieldEncryptor,
 			uc.logger,
 			backup.FileName,
 			storageReader,
+			string(backup.StorageClass),
+			string(backupConfig.StorageACL),
 		)
 		saveErrCh <- saveErr
 	}()
@@ -265,6 +268,7 @@ func (uc *CreateMongodbBackupUsecase) createBackupContext(
 
 func (uc *CreateMongodbBackupUsecase) setupBackupEncryption(
 	backupID uuid.UUID,
+	encryptionKeyID *uuid.UUID,
 	backupConfig *backups_config.BackupConfig,
 	storageWriter io.WriteCloser,
 ) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
@@ -287,14 +291,14 @@ func (uc *CreateMongodbBackupUsecase) setupBackupEncryption(
 		return nil, nil, backupMetadata, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	masterKey, err := uc.secretKeyService.GetSecretKey()
+	encryptionKey, err := uc.encryptionKeyService.GetBackupKey(encryptionKeyID)
 	if err != nil {
-		return nil, nil, backupMetadata, fmt.Errorf("failed to get master key: %w", err)
+		return nil, nil, backupMetadata, fmt.Errorf("failed to get encryption key: %w", err)
 	}
 
 	encryptionWriter, err := backup_encryption.NewEncryptionWriter(
 		storageWriter,
-		masterKey,
+		encryptionKey,
 		backupID,
 		salt,
 		nonce,
@@ -1,14 +1,14 @@
 package usecases_mongodb
 
 import (
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/logger"
 )
 
 var createMongodbBackupUsecase = &CreateMongodbBackupUsecase{
 	logger.GetLogger(),
-	encryption_secrets.GetSecretKeyService(),
+	encryption_keys.GetWorkspaceEncryptionKeyService(),
 	encryption.GetFieldEncryptor(),
 }
 
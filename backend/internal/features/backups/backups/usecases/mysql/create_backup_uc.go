@@ -24,7 +24,7 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	mysqltypes "databasus-backend/internal/features/databases/databases/mysql"
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/features/storages"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/tools"
@@ -41,9 +41,9 @@ const (
 )
 
 type CreateMysqlBackupUsecase struct {
-	logger           *slog.Logger
-	secretKeyService *encryption_secrets.SecretKeyService
-	fieldEncryptor   encryption.FieldEncryptor
+	logger               *slog.Logger
+	encryptionKeyService *encryption_keys.WorkspaceEncryptionKeyService
+	fieldEncryptor       encryption.FieldEncryptor
 }
 
 type writeResult struct {
@@ -202,6 +202,7 @@ func (uc *CreateMysqlBackupUsecase) streamToStorage(
 
 	finalWriter, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
 		backup.ID,
+		backup.EncryptionKeyID,
 		backupConfig,
 		storageWriter,
 	)
@@ -209,21 +210,25 @@ func (uc *CreateMysqlBackupUsecase) streamToStorage(
 		return nil, err
 	}
 
+	compressionLevel := backupConfig.GetCompressionLevel(zstdStorageCompressionLevel)
 	zstdWriter, err := zstd.NewWriter(finalWriter,
-		zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(zstdStorageCompressionLevel)))
+		zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(compressionLevel)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create zstd writer: %w", err)
 	}
-	countingWriter := common.NewCountingWriter(zstdWriter)
+	timingWriter := common.NewTimingWriter(zstdWriter)
+	countingWriter := common.NewCountingWriter(timingWriter)
 
 	saveErrCh := make(chan error, 1)
 	go func() {
-		saveErr := storage.SaveFile(
+		saveErr := storage.SaveFileWithStorageClass(
 			ctx,
 			uc.fieldEncryptor,
 			uc.logger,
 			backup.FileName,
 			storageReader,
+			string(backup.StorageClass),
+			string(backupConfig.StorageACL),
 		)
 		saveErrCh <- saveErr
 	}()
@@ -259,6 +264,7 @@ func (uc *CreateMysqlBackupUsecase) streamToStorage(
 	if err := zstdWriter.Close(); err != nil {
 		uc.logger.Error("Failed to close zstd writer", "error", err)
 	}
+	backup.CompressionDurationSeconds = timingWriter.Duration.Seconds()
 	if err := uc.closeWriters(encryptionWriter, storageWriter); err != nil {
 		<-saveErrCh
 		return nil, err
@@ -435,6 +441,7 @@ func (uc *CreateMysqlBackupUsecase) createBackupContext(
 
 func (uc *CreateMysqlBackupUsecase) setupBackupEncryption(
 	backupID uuid.UUID,
+	encryptionKeyID *uuid.UUID,
 	backupConfig *backups_config.BackupConfig,
 	storageWriter io.WriteCloser,
 ) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
@@ -458,14 +465,14 @@ func (uc *CreateMysqlBackupUsecase) setupBackupEncryption(
 		return nil, nil, metadata, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	masterKey, err := uc.secretKeyService.GetSecretKey()
+	encryptionKey, err := uc.encryptionKeyService.GetBackupKey(encryptionKeyID)
 	if err != nil {
-		return nil, nil, metadata, fmt.Errorf("failed to get master key: %w", err)
+		return nil, nil, metadata, fmt.Errorf("failed to get encryption key: %w", err)
 	}
 
 	encWriter, err := backup_encryption.NewEncryptionWriter(
 		storageWriter,
-		masterKey,
+		encryptionKey,
 		backupID,
 		salt,
 		nonce,
@@ -1,14 +1,14 @@
 package usecases_mysql
 
 import (
-	"databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/logger"
 )
 
 var createMysqlBackupUsecase = &CreateMysqlBackupUsecase{
 	logger.GetLogger(),
-	secrets.GetSecretKeyService(),
+	encryption_keys.GetWorkspaceEncryptionKeyService(),
 	encryption.GetFieldEncryptor(),
 }
 
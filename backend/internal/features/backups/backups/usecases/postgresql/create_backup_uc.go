@@ -21,7 +21,7 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	pgtypes "databasus-backend/internal/features/databases/databases/postgresql"
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/features/storages"
 	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/tools"
@@ -42,9 +42,9 @@ const (
 )
 
 type CreatePostgresqlBackupUsecase struct {
-	logger           *slog.Logger
-	secretKeyService *encryption_secrets.SecretKeyService
-	fieldEncryptor   encryption.FieldEncryptor
+	logger               *slog.Logger
+	encryptionKeyService *encryption_keys.WorkspaceEncryptionKeyService
+	fieldEncryptor       encryption.FieldEncryptor
 }
 
 type writeResult struct {
@@ -80,7 +80,7 @@ func (uc *CreatePostgresqlBackupUsecase) Execute(
 		return nil, fmt.Errorf("database name is required for pg_dump backups")
 	}
 
-	args := uc.buildPgDumpArgs(pg)
+	args := uc.buildPgDumpArgs(pg, backupConfig.GetCompressionLevel(compressionLevel))
 
 	decryptedPassword, err := uc.fieldEncryptor.Decrypt(db.ID, pg.Password)
 	if err != nil {
@@ -168,6 +168,7 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 
 	finalWriter, encryptionWriter, backupMetadata, err := uc.setupBackupEncryption(
 		backup.ID,
+		backup.EncryptionKeyID,
 		backupConfig,
 		storageWriter,
 	)
@@ -182,12 +183,14 @@ func (uc *CreatePostgresqlBackupUsecase) streamToStorage(
 	// Start streaming into storage in its own goroutine
 	saveErrCh := make(chan error, 1)
 	go func() {
-		saveErr := storage.SaveFile(
+		saveErr := storage.SaveFileWithStorageClass(
 			ctx,
 			uc.fieldEncryptor,
 			uc.logger,
 			backup.FileName,
 			storageReader,
+			string(backup.StorageClass),
+			string(backupConfig.StorageACL),
 		)
 		saveErrCh <- saveErr
 	}()
@@ -334,7 +337,10 @@ func (uc *CreatePostgresqlBackupUsecase) copyWithShutdownCheck(
 	return totalBytesWritten, nil
 }
 
-func (uc *CreatePostgresqlBackupUsecase) buildPgDumpArgs(pg *pgtypes.PostgresqlDatabase) []string {
+func (uc *CreatePostgresqlBackupUsecase) buildPgDumpArgs(
+	pg *pgtypes.PostgresqlDatabase,
+	level int,
+) []string {
 	args := []string{
 		"-Fc",
 		"--no-password",
@@ -349,20 +355,25 @@ func (uc *CreatePostgresqlBackupUsecase) buildPgDumpArgs(pg *pgtypes.PostgresqlD
 		args = append(args, "-n", schema)
 	}
 
-	compressionArgs := uc.getCompressionArgs(pg.Version)
+	compressionArgs := uc.getCompressionArgs(pg.Version, level)
 	return append(args, compressionArgs...)
 }
 
 func (uc *CreatePostgresqlBackupUsecase) getCompressionArgs(
 	version tools.PostgresqlVersion,
+	level int,
 ) []string {
 	if uc.isOlderPostgresVersion(version) {
-		uc.logger.Info("Using gzip compression level 5 (zstd not available)", "version", version)
-		return []string{"-Z", strconv.Itoa(compressionLevel)}
+		uc.logger.Info(
+			"Using gzip compression (zstd not available)",
+			"version", version,
+			"level", level,
+		)
+		return []string{"-Z", strconv.Itoa(level)}
 	}
 
-	uc.logger.Info("Using zstd compression level 5", "version", version)
-	return []string{fmt.Sprintf("--compress=zstd:%d", compressionLevel)}
+	uc.logger.Info("Using zstd compression", "version", version, "level", level)
+	return []string{fmt.Sprintf("--compress=zstd:%d", level)}
 }
 
 func (uc *CreatePostgresqlBackupUsecase) isOlderPostgresVersion(
@@ -479,6 +490,7 @@ func (uc *CreatePostgresqlBackupUsecase) setupPgEnvironment(
 
 func (uc *CreatePostgresqlBackupUsecase) setupBackupEncryption(
 	backupID uuid.UUID,
+	encryptionKeyID *uuid.UUID,
 	backupConfig *backups_config.BackupConfig,
 	storageWriter io.WriteCloser,
 ) (io.Writer, *backup_encryption.EncryptionWriter, common.BackupMetadata, error) {
@@ -502,14 +514,14 @@ func (uc *CreatePostgresqlBackupUsecase) setupBackupEncryption(
 		return nil, nil, metadata, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	masterKey, err := uc.secretKeyService.GetSecretKey()
+	encryptionKey, err := uc.encryptionKeyService.GetBackupKey(encryptionKeyID)
 	if err != nil {
-		return nil, nil, metadata, fmt.Errorf("failed to get master key: %w", err)
+		return nil, nil, metadata, fmt.Errorf("failed to get encryption key: %w", err)
 	}
 
 	encWriter, err := backup_encryption.NewEncryptionWriter(
 		storageWriter,
-		masterKey,
+		encryptionKey,
 		backupID,
 		salt,
 		nonce,
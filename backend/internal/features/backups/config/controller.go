@@ -29,8 +29,9 @@ func (c *BackupConfigController) RegisterRoutes(router *gin.RouterGroup) {
 // @Tags backup-configs
 // @Accept json
 // @Produce json
-// @Param request body BackupConfig true "Backup configuration data (encryption field: NONE or ENCRYPTED)"
-// @Success 200 {object} BackupConfig "Returns the saved backup configuration including encryption settings"
+// @Param request body BackupConfigDTO true "Backup configuration data (encryption field: NONE or ENCRYPTED)"
+// @Param force query bool false "Skip the retention policy change cooldown (workspace owner only)"
+// @Success 200 {object} BackupConfigDTO "Returns the saved backup configuration including encryption settings"
 // @Failure 400 {object} map[string]string "Invalid encryption value or other validation errors"
 // @Failure 401 {object} map[string]string "User not authenticated"
 // @Failure 500 {object} map[string]string "Internal server error"
@@ -42,22 +43,28 @@ func (c *BackupConfigController) SaveBackupConfig(ctx *gin.Context) {
 		return
 	}
 
-	var requestDTO BackupConfig
+	var requestDTO BackupConfigDTO
 	if err := ctx.ShouldBindJSON(&requestDTO); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// make sure we rely on full .Storage object
-	requestDTO.StorageID = nil
+	isForce := ctx.Query("force") == "true"
 
-	savedConfig, err := c.backupConfigService.SaveBackupConfigWithAuth(user, &requestDTO)
+	savedConfig, warnings, err := c.backupConfigService.SaveBackupConfigWithAuth(
+		user,
+		&requestDTO,
+		isForce,
+	)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, savedConfig)
+	responseDTO := savedConfig.ToDTO()
+	responseDTO.Warnings = warnings
+
+	ctx.JSON(http.StatusOK, responseDTO)
 }
 
 // GetBackupConfigByDbID
@@ -66,7 +73,7 @@ func (c *BackupConfigController) SaveBackupConfig(ctx *gin.Context) {
 // @Tags backup-configs
 // @Produce json
 // @Param id path string true "Database ID"
-// @Success 200 {object} BackupConfig "Returns backup configuration with encryption field"
+// @Success 200 {object} BackupConfigDTO "Returns backup configuration with encryption field"
 // @Failure 400 {object} map[string]string "Invalid database ID"
 // @Failure 401 {object} map[string]string "User not authenticated"
 // @Failure 404 {object} map[string]string "Backup configuration not found"
@@ -90,7 +97,7 @@ func (c *BackupConfigController) GetBackupConfigByDbID(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, backupConfig)
+	ctx.JSON(http.StatusOK, backupConfig.ToDTO())
 }
 
 // GetDatabasePlan
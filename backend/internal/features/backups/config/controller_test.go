@@ -1,6 +1,7 @@
 package backups_config
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"databasus-backend/internal/config"
+	backups_settings "databasus-backend/internal/features/backups/settings"
 	"databasus-backend/internal/features/databases"
 	"databasus-backend/internal/features/databases/databases/postgresql"
 	"databasus-backend/internal/features/intervals"
@@ -25,6 +27,7 @@ import (
 	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
 	"databasus-backend/internal/storage"
+	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/period"
 	test_utils "databasus-backend/internal/util/testing"
 	"databasus-backend/internal/util/tools"
@@ -347,6 +350,374 @@ func Test_GetBackupConfigByDbID_ReturnsDefaultConfigForNewDatabase(t *testing.T)
 	assert.NotNil(t, response.BackupInterval)
 }
 
+func Test_ListDatabasesNeedingBackup_WhenEnabledDatabaseHasNoBackups_DatabaseListed(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	enabledDatabase := createTestDatabaseViaAPI(
+		"Enabled Database",
+		workspace.ID,
+		owner.Token,
+		router,
+	)
+	disabledDatabase := createTestDatabaseViaAPI(
+		"Disabled Database",
+		workspace.ID,
+		owner.Token,
+		router,
+	)
+
+	defer func() {
+		databases.RemoveTestDatabase(enabledDatabase)
+		databases.RemoveTestDatabase(disabledDatabase)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	backupConfig, err := GetBackupConfigService().GetBackupConfigByDbId(enabledDatabase.ID)
+	assert.NoError(t, err)
+
+	backupConfig.IsBackupsEnabled = true
+	_, err = GetBackupConfigService().SaveBackupConfig(backupConfig)
+	assert.NoError(t, err)
+
+	_, err = GetBackupConfigService().GetBackupConfigByDbId(disabledDatabase.ID)
+	assert.NoError(t, err)
+
+	triggers, err := GetBackupConfigService().ListDatabasesNeedingBackup(context.Background())
+	assert.NoError(t, err)
+
+	triggersByDatabaseID := make(map[uuid.UUID]*DatabaseBackupTrigger)
+	for _, trigger := range triggers {
+		triggersByDatabaseID[trigger.DatabaseID] = trigger
+	}
+
+	enabledTrigger, isListed := triggersByDatabaseID[enabledDatabase.ID]
+	assert.True(t, isListed)
+	assert.Nil(t, enabledTrigger.LastSuccessfulBackupAt)
+	assert.Equal(t, enabledDatabase.ID, enabledTrigger.Config.DatabaseID)
+
+	_, isListed = triggersByDatabaseID[disabledDatabase.ID]
+	assert.False(t, isListed)
+}
+
+func Test_CloneBackupConfig_WhenSourceHasCustomConfig_TargetGetsCopyWithOwnInterval(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	sourceDatabase := createTestDatabaseViaAPI("Source Database", workspace.ID, owner.Token, router)
+	targetDatabase := createTestDatabaseViaAPI("Target Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(sourceDatabase)
+		databases.RemoveTestDatabase(targetDatabase)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	sourceConfig, err := GetBackupConfigService().GetBackupConfigByDbId(sourceDatabase.ID)
+	assert.NoError(t, err)
+
+	sourceConfig.RetentionPolicyType = RetentionPolicyTypeCount
+	sourceConfig.RetentionCount = 5
+	sourceConfig, err = GetBackupConfigService().SaveBackupConfig(sourceConfig)
+	assert.NoError(t, err)
+
+	clonedConfig, err := GetBackupConfigService().CloneBackupConfig(
+		sourceDatabase.ID,
+		targetDatabase.ID,
+		owner.UserID,
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, targetDatabase.ID, clonedConfig.DatabaseID)
+	assert.Equal(t, RetentionPolicyTypeCount, clonedConfig.RetentionPolicyType)
+	assert.Equal(t, 5, clonedConfig.RetentionCount)
+	assert.Equal(t, owner.UserID, *clonedConfig.RetentionPolicyChangedBy)
+	assert.NotEqual(t, sourceConfig.BackupIntervalID, clonedConfig.BackupIntervalID)
+	assert.Equal(
+		t,
+		sourceConfig.BackupInterval.Interval,
+		clonedConfig.BackupInterval.Interval,
+	)
+}
+
+func Test_OnDatabaseCopied_WithCloneOption_BackupConfigClonedOnlyWhenRequested(t *testing.T) {
+	tests := []struct {
+		name                   string
+		isCloneBackupConfig    bool
+		expectedRetentionCount int
+	}{
+		{"clone requested", true, 5},
+		{"clone not requested", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := createTestRouter()
+			owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+			workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+			sourceDatabase := createTestDatabaseViaAPI(
+				"Source Database",
+				workspace.ID,
+				owner.Token,
+				router,
+			)
+			targetDatabase := createTestDatabaseViaAPI(
+				"Target Database",
+				workspace.ID,
+				owner.Token,
+				router,
+			)
+
+			defer func() {
+				databases.RemoveTestDatabase(sourceDatabase)
+				databases.RemoveTestDatabase(targetDatabase)
+				workspaces_testing.RemoveTestWorkspace(workspace, router)
+			}()
+
+			sourceConfig, err := GetBackupConfigService().GetBackupConfigByDbId(sourceDatabase.ID)
+			assert.NoError(t, err)
+
+			sourceConfig.RetentionPolicyType = RetentionPolicyTypeCount
+			sourceConfig.RetentionCount = 5
+			_, err = GetBackupConfigService().SaveBackupConfig(sourceConfig)
+			assert.NoError(t, err)
+
+			GetBackupConfigService().OnDatabaseCopied(
+				sourceDatabase.ID,
+				targetDatabase.ID,
+				databases.DatabaseCopyOptions{
+					CopiedByUserID:      owner.UserID,
+					IsCloneBackupConfig: tt.isCloneBackupConfig,
+				},
+			)
+
+			targetConfig, err := GetBackupConfigService().GetBackupConfigByDbId(targetDatabase.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRetentionCount, targetConfig.RetentionCount)
+		})
+	}
+}
+
+func Test_NewBackupConfigFromDefaults_WhenWorkspaceHasDefaults_InheritsRetentionAndInterval(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	timeOfDay := "02:30"
+	weekday := int(time.Sunday)
+	_, err := GetBackupConfigService().SaveWorkspaceBackupDefaults(&WorkspaceBackupDefaults{
+		WorkspaceID: workspace.ID,
+		Template: &BackupConfig{
+			IsBackupsEnabled:    false,
+			RetentionPolicyType: RetentionPolicyTypeCount,
+			RetentionCount:      14,
+			BackupInterval: &intervals.Interval{
+				Interval:  intervals.IntervalWeekly,
+				TimeOfDay: &timeOfDay,
+				Weekday:   &weekday,
+			},
+			SendNotificationsOn: []BackupNotificationType{NotificationBackupFailed},
+			Encryption:          BackupEncryptionNone,
+		},
+	})
+	assert.NoError(t, err)
+
+	backupConfig, err := GetBackupConfigService().NewBackupConfigFromDefaults(
+		database.ID,
+		workspace.ID,
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, database.ID, backupConfig.DatabaseID)
+	assert.Equal(t, RetentionPolicyTypeCount, backupConfig.RetentionPolicyType)
+	assert.Equal(t, 14, backupConfig.RetentionCount)
+	assert.Equal(t, uuid.Nil, backupConfig.BackupInterval.ID)
+	assert.Equal(t, intervals.IntervalWeekly, backupConfig.BackupInterval.Interval)
+	assert.Equal(t, timeOfDay, *backupConfig.BackupInterval.TimeOfDay)
+	assert.Equal(t, weekday, *backupConfig.BackupInterval.Weekday)
+	assert.Equal(
+		t,
+		[]BackupNotificationType{NotificationBackupFailed},
+		backupConfig.SendNotificationsOn,
+	)
+}
+
+func Test_SyncBackupConfigFromTemplate_WhenTemplateChanged_RetentionUpdatedAndIntervalKept(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	existingConfig, err := GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	timeOfDay := "02:30"
+	_, err = GetBackupConfigService().SaveWorkspaceBackupDefaults(&WorkspaceBackupDefaults{
+		WorkspaceID: workspace.ID,
+		Template: &BackupConfig{
+			RetentionPolicyType: RetentionPolicyTypeCount,
+			RetentionCount:      7,
+			BackupInterval: &intervals.Interval{
+				Interval:  intervals.IntervalDaily,
+				TimeOfDay: &timeOfDay,
+			},
+			SendNotificationsOn: []BackupNotificationType{NotificationBackupFailed},
+			Encryption:          BackupEncryptionNone,
+		},
+	})
+	assert.NoError(t, err)
+
+	syncedConfig, err := GetBackupConfigService().SyncBackupConfigFromTemplate(
+		context.Background(),
+		database.ID,
+		workspace.ID,
+		owner.UserID,
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, database.ID, syncedConfig.DatabaseID)
+	assert.Equal(t, RetentionPolicyTypeCount, syncedConfig.RetentionPolicyType)
+	assert.Equal(t, 7, syncedConfig.RetentionCount)
+	assert.Equal(
+		t,
+		[]BackupNotificationType{NotificationBackupFailed},
+		syncedConfig.SendNotificationsOn,
+	)
+	assert.Equal(t, existingConfig.BackupIntervalID, syncedConfig.BackupIntervalID)
+	assert.Equal(t, owner.UserID, *syncedConfig.RetentionPolicyChangedBy)
+}
+
+func Test_SyncBackupConfigFromTemplate_WhenRetentionChangedWithinCooldown_SyncRejected(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	request := createTimePeriodBackupConfig(database.ID, period.PeriodWeek)
+	test_utils.MakePostRequest(
+		t, router, "/api/v1/backup-configs/save", "Bearer "+owner.Token,
+		request, http.StatusOK,
+	)
+
+	timeOfDay := "02:30"
+	_, err := GetBackupConfigService().SaveWorkspaceBackupDefaults(&WorkspaceBackupDefaults{
+		WorkspaceID: workspace.ID,
+		Template: &BackupConfig{
+			RetentionPolicyType: RetentionPolicyTypeCount,
+			RetentionCount:      1,
+			BackupInterval: &intervals.Interval{
+				Interval:  intervals.IntervalDaily,
+				TimeOfDay: &timeOfDay,
+			},
+			Encryption: BackupEncryptionNone,
+		},
+	})
+	assert.NoError(t, err)
+
+	syncedConfig, err := GetBackupConfigService().SyncBackupConfigFromTemplate(
+		context.Background(),
+		database.ID,
+		workspace.ID,
+		owner.UserID,
+	)
+	assert.Nil(t, syncedConfig)
+	assert.ErrorIs(t, err, ErrRetentionPolicyChangedTooRecently)
+
+	savedConfig, err := GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, RetentionPolicyTypeTimePeriod, savedConfig.RetentionPolicyType)
+	assert.Equal(t, period.PeriodWeek, savedConfig.RetentionTimePeriod)
+}
+
+func Test_ImportConfig_WithExportedGfsConfig_PolicyFieldsEqual(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	sourceDatabase := createTestDatabaseViaAPI("Source Database", workspace.ID, owner.Token, router)
+	targetDatabase := createTestDatabaseViaAPI("Target Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(sourceDatabase)
+		databases.RemoveTestDatabase(targetDatabase)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	configService := GetBackupConfigService()
+
+	sourceConfig, err := configService.GetBackupConfigByDbId(sourceDatabase.ID)
+	assert.NoError(t, err)
+
+	timeOfDay := "03:15"
+	sourceConfig.RetentionPolicyType = RetentionPolicyTypeGFS
+	sourceConfig.RetentionGfsHours = 24
+	sourceConfig.RetentionGfsDays = 7
+	sourceConfig.RetentionGfsWeeks = 4
+	sourceConfig.RetentionGfsMonths = 12
+	sourceConfig.RetentionGfsYears = 3
+	sourceConfig.BackupInterval.Interval = intervals.IntervalDaily
+	sourceConfig.BackupInterval.TimeOfDay = &timeOfDay
+	sourceConfig.SendNotificationsOn = []BackupNotificationType{NotificationBackupFailed}
+	sourceConfig, err = configService.SaveBackupConfig(sourceConfig)
+	assert.NoError(t, err)
+
+	exportedConfig, err := configService.ExportConfig(sourceDatabase.ID)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(exportedConfig), sourceDatabase.ID.String())
+
+	targetConfigBefore, err := configService.GetBackupConfigByDbId(targetDatabase.ID)
+	assert.NoError(t, err)
+
+	targetPlan, err := plans.GetDatabasePlanService().GetDatabasePlan(targetDatabase.ID)
+	assert.NoError(t, err)
+
+	importedConfig, err := configService.ImportConfig(
+		targetDatabase.ID,
+		exportedConfig,
+		targetPlan,
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, targetDatabase.ID, importedConfig.DatabaseID)
+	assert.Equal(t, targetConfigBefore.BackupIntervalID, importedConfig.BackupIntervalID)
+
+	reloadedConfig, err := configService.GetBackupConfigByDbId(targetDatabase.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, sourceConfig.ToPortable(), reloadedConfig.ToPortable())
+}
+
 func Test_GetDatabasePlan_ForNewDatabase_PlanAlwaysReturned(t *testing.T) {
 	router := createTestRouter()
 	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
@@ -700,6 +1071,76 @@ func Test_SaveBackupConfig_WithEncryptionEncrypted_ConfigSaved(t *testing.T) {
 	assert.Equal(t, BackupEncryptionEncrypted, response.Encryption)
 }
 
+func Test_SaveBackupConfig_WithHookSignatureSecret_SecretEncryptedAndHidden(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	timeOfDay := "04:00"
+	hook := createValidBackupHook()
+	hook.SignatureSecret = "hook-signature-secret"
+	request := BackupConfig{
+		DatabaseID:          database.ID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod: period.PeriodWeek,
+		BackupInterval: &intervals.Interval{
+			Interval:  intervals.IntervalDaily,
+			TimeOfDay: &timeOfDay,
+		},
+		IsRetryIfFailed:     true,
+		MaxFailedTriesCount: 3,
+		Encryption:          BackupEncryptionNone,
+		PreBackupHook:       hook,
+	}
+
+	var response BackupConfig
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-configs/save",
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+	assert.NotNil(t, response.PreBackupHook)
+	assert.Empty(t, response.PreBackupHook.SignatureSecret)
+
+	// the client sends the hook back as it was read, without the secret
+	request.PreBackupHook.SignatureSecret = ""
+	request.PreBackupHook.TimeoutSeconds = 60
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-configs/save",
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+	assert.Empty(t, response.PreBackupHook.SignatureSecret)
+
+	savedConfig, err := GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 60, savedConfig.PreBackupHook.TimeoutSeconds)
+	assert.NotEqual(t, "hook-signature-secret", savedConfig.PreBackupHook.SignatureSecret)
+
+	decryptedSecret, err := encryption.GetFieldEncryptor().Decrypt(
+		database.ID,
+		savedConfig.PreBackupHook.SignatureSecret,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "hook-signature-secret", decryptedSecret)
+}
+
 func Test_TransferDatabase_PermissionsEnforced(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -1901,6 +2342,198 @@ func Test_SaveBackupConfig_WithSystemStorage_CanBeUsedByAnyDatabase(t *testing.T
 	workspaces_testing.RemoveTestWorkspace(workspaceB, router)
 }
 
+func Test_RevalidateAllConfigs_WhenPlanBecameStricter_ReportsOnlyViolatingConfigs(
+	t *testing.T,
+) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	validDatabase := createTestDatabaseViaAPI("Valid Database", workspace.ID, owner.Token, router)
+	violatingDatabase := createTestDatabaseViaAPI(
+		"Violating Database",
+		workspace.ID,
+		owner.Token,
+		router,
+	)
+
+	configService := GetBackupConfigService()
+	violatingConfig, err := configService.GetBackupConfigByDbId(violatingDatabase.ID)
+	assert.NoError(t, err)
+	_, err = configService.GetBackupConfigByDbId(validDatabase.ID)
+	assert.NoError(t, err)
+
+	err = storage.GetDb().Model(&plans.DatabasePlan{}).
+		Where("database_id = ?", violatingDatabase.ID).
+		Update("max_storage_period", period.PeriodDay).Error
+	assert.NoError(t, err)
+
+	issues, err := configService.RevalidateAllConfigs()
+	assert.NoError(t, err)
+
+	issueByDatabaseID := make(map[uuid.UUID]ConfigValidationIssue)
+	for _, issue := range issues {
+		issueByDatabaseID[issue.DatabaseID] = issue
+	}
+
+	assert.Contains(t, issueByDatabaseID, violatingDatabase.ID)
+	assert.NotEmpty(t, issueByDatabaseID[violatingDatabase.ID].Error)
+	assert.NotContains(t, issueByDatabaseID, validDatabase.ID)
+
+	configAfterRevalidation, err := configService.GetBackupConfigByDbId(violatingDatabase.ID)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		violatingConfig.RetentionTimePeriod,
+		configAfterRevalidation.RetentionTimePeriod,
+	)
+
+	databases.RemoveTestDatabase(validDatabase)
+	databases.RemoveTestDatabase(violatingDatabase)
+	workspaces_testing.RemoveTestWorkspace(workspace, router)
+}
+
+func Test_SaveBackupConfig_WhenRetentionChangedAroundCooldownBoundary_CooldownEnforced(
+	t *testing.T,
+) {
+	settings, err := backups_settings.GetBackupSystemSettingsService().GetSettings()
+	assert.NoError(t, err)
+	cooldown := time.Duration(settings.RetentionPolicyCooldownSeconds) * time.Second
+
+	tests := []struct {
+		name           string
+		lockedAgo      time.Duration
+		expectedStatus int
+	}{
+		{
+			name:           "inside cooldown",
+			lockedAgo:      cooldown - time.Minute,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "after cooldown",
+			lockedAgo:      cooldown + time.Minute,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := createTestRouter()
+			owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+			workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+			database := createTestDatabaseViaAPI(
+				"Test Database", workspace.ID, owner.Token, router,
+			)
+
+			defer func() {
+				databases.RemoveTestDatabase(database)
+				workspaces_testing.RemoveTestWorkspace(workspace, router)
+			}()
+
+			request := createTimePeriodBackupConfig(database.ID, period.PeriodWeek)
+			test_utils.MakePostRequest(
+				t, router, "/api/v1/backup-configs/save", "Bearer "+owner.Token,
+				request, http.StatusOK,
+			)
+
+			lockedAt := time.Now().UTC().Add(-tt.lockedAgo)
+			err := storage.GetDb().Model(&BackupConfig{}).
+				Where("database_id = ?", database.ID).
+				Update("retention_policy_locked_at", lockedAt).Error
+			assert.NoError(t, err)
+
+			request.RetentionTimePeriod = period.PeriodMonth
+			resp := test_utils.MakePostRequest(
+				t, router, "/api/v1/backup-configs/save", "Bearer "+owner.Token,
+				request, tt.expectedStatus,
+			)
+
+			if tt.expectedStatus == http.StatusBadRequest {
+				assert.Contains(
+					t,
+					string(resp.Body),
+					ErrRetentionPolicyChangedTooRecently.Error(),
+				)
+			}
+		})
+	}
+}
+
+func Test_SaveBackupConfig_WhenSavedTwiceUnchanged_SecondSaveChangesNothing(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	request := createTimePeriodBackupConfig(database.ID, period.PeriodWeek)
+	test_utils.MakePostRequest(
+		t, router, "/api/v1/backup-configs/save", "Bearer "+owner.Token,
+		request, http.StatusOK,
+	)
+
+	firstSavedConfig, err := GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, firstSavedConfig.RetentionPolicyLockedAt)
+
+	test_utils.MakePostRequest(
+		t, router, "/api/v1/backup-configs/save", "Bearer "+owner.Token,
+		request, http.StatusOK,
+	)
+
+	secondSavedConfig, err := GetBackupConfigService().GetBackupConfigByDbId(database.ID)
+	assert.NoError(t, err)
+
+	// the request has no interval ID, a write would have created a new interval
+	assert.Equal(t, firstSavedConfig.BackupIntervalID, secondSavedConfig.BackupIntervalID)
+	assert.True(
+		t,
+		firstSavedConfig.RetentionPolicyLockedAt.Equal(*secondSavedConfig.RetentionPolicyLockedAt),
+	)
+}
+
+func Test_SaveBackupConfig_WhenForcedWithinCooldown_OnlyOwnerCanOverride(t *testing.T) {
+	router := createTestRouter()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	admin := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspaces_testing.AddMemberToWorkspace(
+		workspace, admin, users_enums.WorkspaceRoleAdmin, owner.Token, router,
+	)
+
+	request := createTimePeriodBackupConfig(database.ID, period.PeriodWeek)
+	test_utils.MakePostRequest(
+		t, router, "/api/v1/backup-configs/save", "Bearer "+owner.Token,
+		request, http.StatusOK,
+	)
+
+	request.RetentionTimePeriod = period.PeriodMonth
+	adminResp := test_utils.MakePostRequest(
+		t, router, "/api/v1/backup-configs/save?force=true", "Bearer "+admin.Token,
+		request, http.StatusBadRequest,
+	)
+	assert.Contains(t, string(adminResp.Body), "only workspace owner")
+
+	var savedConfig BackupConfig
+	test_utils.MakePostRequestAndUnmarshal(
+		t, router, "/api/v1/backup-configs/save?force=true", "Bearer "+owner.Token,
+		request, http.StatusOK, &savedConfig,
+	)
+	assert.Equal(t, period.PeriodMonth, savedConfig.RetentionTimePeriod)
+}
+
 func createTestDatabaseViaAPI(
 	name string,
 	workspaceID uuid.UUID,
@@ -1987,3 +2620,27 @@ func createTestRouterWithNotifiersForTransfer() *gin.Engine {
 
 	return router
 }
+
+func createTimePeriodBackupConfig(
+	databaseID uuid.UUID,
+	retentionPeriod period.TimePeriod,
+) BackupConfig {
+	timeOfDay := "04:00"
+
+	return BackupConfig{
+		DatabaseID:          databaseID,
+		IsBackupsEnabled:    true,
+		RetentionPolicyType: RetentionPolicyTypeTimePeriod,
+		RetentionTimePeriod: retentionPeriod,
+		BackupInterval: &intervals.Interval{
+			Interval:  intervals.IntervalDaily,
+			TimeOfDay: &timeOfDay,
+		},
+		SendNotificationsOn: []BackupNotificationType{
+			NotificationBackupFailed,
+		},
+		IsRetryIfFailed:     true,
+		MaxFailedTriesCount: 3,
+		Encryption:          BackupEncryptionNone,
+	}
+}
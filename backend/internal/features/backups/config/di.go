@@ -4,11 +4,15 @@ import (
 	"sync"
 	"sync/atomic"
 
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_settings "databasus-backend/internal/features/backups/settings"
 	"databasus-backend/internal/features/databases"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/features/notifiers"
 	plans "databasus-backend/internal/features/plan"
 	"databasus-backend/internal/features/storages"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/logger"
 )
 
@@ -20,6 +24,11 @@ var backupConfigService = &BackupConfigService{
 	notifiers.GetNotifierService(),
 	workspaces_services.GetWorkspaceService(),
 	plans.GetDatabasePlanService(),
+	audit_logs.GetAuditLogService(),
+	encryption_keys.GetWorkspaceEncryptionKeyService(),
+	backups_settings.GetBackupSystemSettingsService(),
+	encryption.GetFieldEncryptor(),
+	logger.GetLogger(),
 	nil,
 }
 var backupConfigController = &BackupConfigController{
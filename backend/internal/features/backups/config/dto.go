@@ -1,6 +1,14 @@
 package backups_config
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"databasus-backend/internal/features/intervals"
+	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/period"
+
+	"github.com/google/uuid"
+)
 
 type TransferDatabaseRequest struct {
 	TargetWorkspaceID       uuid.UUID   `json:"targetWorkspaceId"                 binding:"required"`
@@ -9,3 +17,136 @@ type TransferDatabaseRequest struct {
 	IsTransferWithNotifiers bool        `json:"isTransferWithNotifiers,omitempty"`
 	TargetNotifierIDs       []uuid.UUID `json:"targetNotifierIds,omitempty"`
 }
+
+// ConfigValidationIssue is a stored config which does not pass validation
+// against the current plan of its database
+type ConfigValidationIssue struct {
+	DatabaseID uuid.UUID `json:"databaseId"`
+	Error      string    `json:"error"`
+}
+
+type DatabaseBackupTrigger struct {
+	DatabaseID             uuid.UUID
+	Config                 *BackupConfig
+	LastSuccessfulBackupAt *time.Time
+}
+
+// BackupConfigDTO is the API contract for backup configs. It is kept separate
+// from BackupConfig so DB-only columns and ids of associations do not leak
+type BackupConfigDTO struct {
+	DatabaseID       uuid.UUID `json:"databaseId"`
+	IsBackupsEnabled bool      `json:"isBackupsEnabled"`
+	IsReadOnlyMode   bool      `json:"isReadOnlyMode"`
+
+	RetentionPolicyType RetentionPolicyType `json:"retentionPolicyType"`
+	RetentionTimePeriod period.TimePeriod   `json:"retentionTimePeriod"`
+	RetentionCount      int                 `json:"retentionCount"`
+	RetentionGfsHours   int                 `json:"retentionGfsHours"`
+	RetentionGfsDays    int                 `json:"retentionGfsDays"`
+	RetentionGfsWeeks   int                 `json:"retentionGfsWeeks"`
+	RetentionGfsMonths  int                 `json:"retentionGfsMonths"`
+	RetentionGfsYears   int                 `json:"retentionGfsYears"`
+	HotRetention        period.TimePeriod   `json:"hotRetention"`
+	ColdRetention       period.TimePeriod   `json:"coldRetention"`
+	ColdStorageID       *uuid.UUID          `json:"coldStorageId"`
+	ThinningKeepEvery   int                 `json:"thinningKeepEvery"`
+	ThinningAfter       period.TimePeriod   `json:"thinningAfter"`
+
+	RetentionScheduleTime string `json:"retentionScheduleTime"`
+	RetentionScheduleDays int    `json:"retentionScheduleDays"`
+
+	GuaranteeOnePerRecentDay int      `json:"guaranteeOnePerRecentDay"`
+	ConsolidateAfterDays     int      `json:"consolidateAfterDays"`
+	RetentionExemptLabels    []string `json:"retentionExemptLabels"`
+
+	BackupInterval *intervals.Interval `json:"backupInterval,omitempty"`
+
+	// StorageID is response-only, requests select storage via Storage
+	Storage   *storages.Storage `json:"storage"`
+	StorageID *uuid.UUID        `json:"storageId"`
+
+	SendNotificationsOn []BackupNotificationType `json:"sendNotificationsOn"`
+	NotifierIDs         []uuid.UUID              `json:"notifierIds"`
+	IsRetryIfFailed     bool                     `json:"isRetryIfFailed"`
+	MaxFailedTriesCount int                      `json:"maxFailedTriesCount"`
+	IsRetryImmediately  bool                     `json:"isRetryImmediately"`
+	RetryDelaySeconds   int                      `json:"retryDelaySeconds"`
+
+	Encryption       BackupEncryption `json:"encryption"`
+	CompressionLevel int              `json:"compressionLevel"`
+	StorageACL       StorageACL       `json:"storageAcl"`
+	StorageClass     StorageClass     `json:"storageClass"`
+	EncryptionKeyID  *uuid.UUID       `json:"encryptionKeyId"`
+
+	MaxBackupSizeMB       int64 `json:"maxBackupSizeMb"`
+	MaxBackupsTotalSizeMB int64 `json:"maxBackupsTotalSizeMb"`
+
+	AllowRestoreToSameDatabase bool `json:"allowRestoreToSameDatabase"`
+	ShouldUseStorageObjectAge  bool `json:"shouldUseStorageObjectAge"`
+	CleanerPriority            int  `json:"cleanerPriority"`
+
+	ShouldDeleteFailedBackupFiles bool `json:"shouldDeleteFailedBackupFiles"`
+	IntegrityCheckIntervalDays    int  `json:"integrityCheckIntervalDays"`
+	MinComplianceScoreThreshold   int  `json:"minComplianceScoreThreshold"`
+
+	PreBackupHook  *BackupHook `json:"preBackupHook"`
+	PostBackupHook *BackupHook `json:"postBackupHook"`
+
+	RetentionPolicyLockedAt  *time.Time `json:"retentionPolicyLockedAt"`
+	RetentionPolicyChangedBy *uuid.UUID `json:"retentionPolicyChangedBy"`
+
+	LastCleanupError   string     `json:"lastCleanupError"`
+	LastCleanupErrorAt *time.Time `json:"lastCleanupErrorAt"`
+
+	// Warnings is response-only and lists non-fatal validation issues
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// PortableBackupConfig is the backup policy of a database without ids of the
+// database, storages, interval and encryption key, so it can be imported on
+// another instance
+type PortableBackupConfig struct {
+	IsBackupsEnabled bool `json:"isBackupsEnabled"`
+	IsReadOnlyMode   bool `json:"isReadOnlyMode"`
+
+	RetentionPolicyType       RetentionPolicyType `json:"retentionPolicyType"`
+	RetentionTimePeriod       period.TimePeriod   `json:"retentionTimePeriod"`
+	ShouldUseStorageObjectAge bool                `json:"shouldUseStorageObjectAge"`
+	RetentionCount            int                 `json:"retentionCount"`
+	RetentionGfsHours         int                 `json:"retentionGfsHours"`
+	RetentionGfsDays          int                 `json:"retentionGfsDays"`
+	RetentionGfsWeeks         int                 `json:"retentionGfsWeeks"`
+	RetentionGfsMonths        int                 `json:"retentionGfsMonths"`
+	RetentionGfsYears         int                 `json:"retentionGfsYears"`
+	HotRetention              period.TimePeriod   `json:"hotRetention"`
+	ColdRetention             period.TimePeriod   `json:"coldRetention"`
+	ThinningKeepEvery         int                 `json:"thinningKeepEvery"`
+	ThinningAfter             period.TimePeriod   `json:"thinningAfter"`
+	RetentionScheduleTime     string              `json:"retentionScheduleTime"`
+	RetentionScheduleDays     int                 `json:"retentionScheduleDays"`
+	GuaranteeOnePerRecentDay  int                 `json:"guaranteeOnePerRecentDay"`
+	ConsolidateAfterDays      int                 `json:"consolidateAfterDays"`
+	RetentionExemptLabels     []string            `json:"retentionExemptLabels"`
+
+	BackupInterval *intervals.Interval `json:"backupInterval"`
+
+	SendNotificationsOn []BackupNotificationType `json:"sendNotificationsOn"`
+	IsRetryIfFailed     bool                     `json:"isRetryIfFailed"`
+	MaxFailedTriesCount int                      `json:"maxFailedTriesCount"`
+	IsRetryImmediately  bool                     `json:"isRetryImmediately"`
+	RetryDelaySeconds   int                      `json:"retryDelaySeconds"`
+
+	Encryption       BackupEncryption `json:"encryption"`
+	CompressionLevel int              `json:"compressionLevel"`
+	StorageACL       StorageACL       `json:"storageAcl"`
+	StorageClass     StorageClass     `json:"storageClass"`
+
+	MaxBackupSizeMB       int64 `json:"maxBackupSizeMb"`
+	MaxBackupsTotalSizeMB int64 `json:"maxBackupsTotalSizeMb"`
+
+	AllowRestoreToSameDatabase    bool `json:"allowRestoreToSameDatabase"`
+	CleanerPriority               int  `json:"cleanerPriority"`
+	ShouldDeleteFailedBackupFiles bool `json:"shouldDeleteFailedBackupFiles"`
+	IntegrityCheckIntervalDays    int  `json:"integrityCheckIntervalDays"`
+	MinComplianceScoreThreshold   int  `json:"minComplianceScoreThreshold"`
+}
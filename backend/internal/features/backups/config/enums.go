@@ -1,10 +1,18 @@
 package backups_config
 
+import "slices"
+
 type BackupNotificationType string
 
 const (
-	NotificationBackupFailed  BackupNotificationType = "BACKUP_FAILED"
-	NotificationBackupSuccess BackupNotificationType = "BACKUP_SUCCESS"
+	NotificationBackupFailed       BackupNotificationType = "BACKUP_FAILED"
+	NotificationBackupSuccess      BackupNotificationType = "BACKUP_SUCCESS"
+	NotificationMissedBackup       BackupNotificationType = "MISSED_BACKUP"
+	NotificationLastBackupDeletion BackupNotificationType = "LAST_BACKUP_DELETION"
+
+	NotificationIntegrityCheckFailed BackupNotificationType = "INTEGRITY_CHECK_FAILED"
+	NotificationLowComplianceScore   BackupNotificationType = "LOW_COMPLIANCE_SCORE"
+	NotificationStorageQuotaWarning  BackupNotificationType = "STORAGE_QUOTA_WARNING"
 )
 
 type BackupEncryption string
@@ -14,10 +22,66 @@ const (
 	BackupEncryptionEncrypted BackupEncryption = "ENCRYPTED"
 )
 
+type StorageACL string
+
+const (
+	StorageACLPrivate           StorageACL = "PRIVATE"
+	StorageACLWorkspacePrivate  StorageACL = "WORKSPACE_PRIVATE"
+	StorageACLAuthenticatedRead StorageACL = "AUTHENTICATED_READ"
+)
+
+type StorageClass string
+
+const (
+	StorageClassStandard           StorageClass = "STANDARD"
+	StorageClassStandardIA         StorageClass = "STANDARD_IA"
+	StorageClassOneZoneIA          StorageClass = "ONEZONE_IA"
+	StorageClassIntelligentTiering StorageClass = "INTELLIGENT_TIERING"
+	StorageClassGlacierIR          StorageClass = "GLACIER_IR"
+	StorageClassGlacier            StorageClass = "GLACIER"
+	StorageClassDeepArchive        StorageClass = "DEEP_ARCHIVE"
+)
+
+func (c StorageClass) IsSupported() bool {
+	return slices.Contains(allowedStorageClasses, c)
+}
+
+var allowedStorageClasses = []StorageClass{
+	StorageClassStandard,
+	StorageClassStandardIA,
+	StorageClassOneZoneIA,
+	StorageClassIntelligentTiering,
+	StorageClassGlacierIR,
+	StorageClassGlacier,
+	StorageClassDeepArchive,
+}
+
+type BackupHookFailureAction string
+
+// ABORT fails the backup when the hook fails, LOG goes on with the backup and
+// logs the failure, CONTINUE goes on silently
+const (
+	BackupHookFailureActionAbort    BackupHookFailureAction = "ABORT"
+	BackupHookFailureActionLog      BackupHookFailureAction = "LOG"
+	BackupHookFailureActionContinue BackupHookFailureAction = "CONTINUE"
+)
+
+var allowedBackupHookFailureActions = []BackupHookFailureAction{
+	BackupHookFailureActionAbort,
+	BackupHookFailureActionLog,
+	BackupHookFailureActionContinue,
+}
+
 type RetentionPolicyType string
 
 const (
 	RetentionPolicyTypeTimePeriod RetentionPolicyType = "TIME_PERIOD"
 	RetentionPolicyTypeCount      RetentionPolicyType = "COUNT"
 	RetentionPolicyTypeGFS        RetentionPolicyType = "GFS"
+	RetentionPolicyTypeHotCold    RetentionPolicyType = "HOT_COLD"
+	RetentionPolicyTypeThinning   RetentionPolicyType = "THINNING"
+	RetentionPolicyTypeSchedule   RetentionPolicyType = "SCHEDULE"
+	// RetentionPolicyTypeUnion keeps a backup when any of the count, time period
+	// or GFS policies would keep it
+	RetentionPolicyTypeUnion RetentionPolicyType = "UNION"
 )
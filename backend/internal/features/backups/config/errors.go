@@ -27,4 +27,13 @@ var (
 	ErrTargetStorageNotSpecified = errors.New(
 		"target storage is not specified",
 	)
+	ErrWorkspaceBackupDefaultsNotFound = errors.New(
+		"workspace has no backup defaults",
+	)
+	ErrDatabaseNotInTemplateWorkspace = errors.New(
+		"database does not belong to the workspace of the template",
+	)
+	ErrRetentionPolicyChangedTooRecently = errors.New(
+		"retention policy was changed too recently, try again later",
+	)
 )
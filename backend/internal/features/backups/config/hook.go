@@ -0,0 +1,153 @@
+package backups_config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+const maxBackupHookTimeoutSeconds = 300
+
+// BackupHook is an HTTP call made before or after a backup. Body is a Go
+// template, SignatureSecret signs the body so the receiver can verify the sender.
+// SignatureSecret is stored encrypted and never returned to clients
+type BackupHook struct {
+	URL             string                  `json:"url"`
+	Method          string                  `json:"method"`
+	Headers         map[string]string       `json:"headers"`
+	Body            string                  `json:"body"`
+	TimeoutSeconds  int                     `json:"timeoutSeconds"`
+	OnFailure       BackupHookFailureAction `json:"onFailure"`
+	SignatureSecret string                  `json:"signatureSecret"`
+}
+
+func (h *BackupHook) Validate() error {
+	if h.URL == "" {
+		return errors.New("hook URL is required")
+	}
+
+	parsedURL, err := url.Parse(h.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") ||
+		parsedURL.Host == "" {
+		return errors.New("hook URL must be an absolute http or https URL")
+	}
+
+	if !slices.Contains(allowedBackupHookMethods, h.Method) {
+		return fmt.Errorf("hook method %q is not supported", h.Method)
+	}
+
+	for key := range h.Headers {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("hook header name must not be empty")
+		}
+	}
+
+	if _, err := template.New("hook").Parse(h.Body); err != nil {
+		return fmt.Errorf("hook body is not a valid template: %w", err)
+	}
+
+	if h.TimeoutSeconds <= 0 || h.TimeoutSeconds > maxBackupHookTimeoutSeconds {
+		return fmt.Errorf(
+			"hook timeout must be between 1 and %d seconds",
+			maxBackupHookTimeoutSeconds,
+		)
+	}
+
+	if !slices.Contains(allowedBackupHookFailureActions, h.OnFailure) {
+		return errors.New("hook on failure must be ABORT, LOG or CONTINUE")
+	}
+
+	return nil
+}
+
+func (h *BackupHook) Copy() *BackupHook {
+	if h == nil {
+		return nil
+	}
+
+	hook := *h
+	hook.Headers = maps.Clone(h.Headers)
+
+	return &hook
+}
+
+func (h *BackupHook) HideSensitiveData() {
+	if h == nil {
+		return
+	}
+
+	h.SignatureSecret = ""
+}
+
+func (h *BackupHook) EncryptSensitiveData(
+	encryptor encryption.FieldEncryptor,
+	databaseID uuid.UUID,
+) error {
+	if h == nil || h.SignatureSecret == "" {
+		return nil
+	}
+
+	signatureSecret, err := encryptor.Encrypt(databaseID, h.SignatureSecret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt hook signature secret: %w", err)
+	}
+
+	h.SignatureSecret = signatureSecret
+
+	return nil
+}
+
+// KeepHiddenSecret restores the stored secret when the client sends the hook
+// back without it, since the secret is hidden on every read
+func (h *BackupHook) KeepHiddenSecret(existing *BackupHook) {
+	if h == nil || existing == nil || h.SignatureSecret != "" {
+		return
+	}
+
+	h.SignatureSecret = existing.SignatureSecret
+}
+
+var allowedBackupHookMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+}
+
+func marshalBackupHook(hook *BackupHook) (*string, error) {
+	if hook == nil {
+		return nil, nil
+	}
+
+	hookBytes, err := json.Marshal(hook)
+	if err != nil {
+		return nil, err
+	}
+
+	hookString := string(hookBytes)
+
+	return &hookString, nil
+}
+
+func unmarshalBackupHook(hookString *string) (*BackupHook, error) {
+	if hookString == nil || *hookString == "" {
+		return nil, nil
+	}
+
+	var hook BackupHook
+	if err := json.Unmarshal([]byte(*hookString), &hook); err != nil {
+		return nil, err
+	}
+
+	return &hook, nil
+}
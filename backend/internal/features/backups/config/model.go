@@ -5,22 +5,58 @@ import (
 	"databasus-backend/internal/features/intervals"
 	plans "databasus-backend/internal/features/plan"
 	"databasus-backend/internal/features/storages"
+	"databasus-backend/internal/util/encryption"
 	"databasus-backend/internal/util/period"
 	"errors"
+	"fmt"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// maxBoundedRetentionWindowYears caps GFS yearly slots shown on a timeline,
+// larger values are effectively forever and would overflow time.Duration
+const maxBoundedRetentionWindowYears = 100
+
+// maxRetentionCount is a sanity bound for count based retention fields, larger
+// values come from typos or overflowed API input rather than real policies
+const maxRetentionCount = 100_000
+
+var unionRetentionPolicyTypes = []RetentionPolicyType{
+	RetentionPolicyTypeCount,
+	RetentionPolicyTypeTimePeriod,
+	RetentionPolicyTypeGFS,
+}
+
+// labels are stored comma separated, so a label itself cannot contain a comma
+const maxRetentionLabelLength = 64
+
+const (
+	defaultMinComplianceScoreThreshold = 70
+	maxComplianceScore                 = 100
+)
+
 type BackupConfig struct {
 	DatabaseID uuid.UUID `json:"databaseId" gorm:"column:database_id;type:uuid;primaryKey;not null"`
 
 	IsBackupsEnabled bool `json:"isBackupsEnabled" gorm:"column:is_backups_enabled;type:boolean;not null"`
 
+	// IsReadOnlyMode is for archived databases under legal hold: no new backups
+	// are made and retention never deletes the existing ones, restores still work
+	IsReadOnlyMode bool `json:"isReadOnlyMode" gorm:"column:is_read_only_mode;type:boolean;not null;default:false"`
+
 	RetentionPolicyType RetentionPolicyType `json:"retentionPolicyType" gorm:"column:retention_policy_type;type:text;not null;default:'TIME_PERIOD'"`
 	RetentionTimePeriod period.TimePeriod   `json:"retentionTimePeriod" gorm:"column:retention_time_period;type:text;not null;default:''"`
 
+	// ShouldUseStorageObjectAge makes time period retention rely on the last
+	// modification time reported by the storage instead of CreatedAt, which is
+	// not accurate for imported backups. Storages without such info use CreatedAt
+	ShouldUseStorageObjectAge bool `json:"shouldUseStorageObjectAge" gorm:"column:should_use_storage_object_age;type:boolean;not null;default:false"`
+
 	RetentionCount     int `json:"retentionCount"     gorm:"column:retention_count;type:int;not null;default:0"`
 	RetentionGfsHours  int `json:"retentionGfsHours"  gorm:"column:retention_gfs_hours;type:int;not null;default:0"`
 	RetentionGfsDays   int `json:"retentionGfsDays"   gorm:"column:retention_gfs_days;type:int;not null;default:0"`
@@ -28,6 +64,35 @@ type BackupConfig struct {
 	RetentionGfsMonths int `json:"retentionGfsMonths" gorm:"column:retention_gfs_months;type:int;not null;default:0"`
 	RetentionGfsYears  int `json:"retentionGfsYears"  gorm:"column:retention_gfs_years;type:int;not null;default:0"`
 
+	// HotRetention is how long backups stay in the primary storage before they are
+	// moved to ColdStorageID. ColdRetention is the total lifetime of the backup
+	HotRetention  period.TimePeriod `json:"hotRetention"  gorm:"column:hot_retention;type:text;not null;default:''"`
+	ColdRetention period.TimePeriod `json:"coldRetention" gorm:"column:cold_retention;type:text;not null;default:''"`
+	ColdStorageID *uuid.UUID        `json:"coldStorageId" gorm:"column:cold_storage_id;type:uuid;"`
+
+	// ThinningKeepEvery keeps every Nth backup (counting from the newest one) among
+	// backups older than ThinningAfter. Younger backups are always kept
+	ThinningKeepEvery int               `json:"thinningKeepEvery" gorm:"column:thinning_keep_every;type:int;not null;default:0"`
+	ThinningAfter     period.TimePeriod `json:"thinningAfter"     gorm:"column:thinning_after;type:text;not null;default:''"`
+
+	// RetentionScheduleTime is the UTC time of day in HH:MM format. For each of the
+	// last RetentionScheduleDays days the backup created closest to it is kept
+	RetentionScheduleTime string `json:"retentionScheduleTime" gorm:"column:retention_schedule_time;type:text;not null;default:''"`
+	RetentionScheduleDays int    `json:"retentionScheduleDays" gorm:"column:retention_schedule_days;type:int;not null;default:0"`
+
+	// GuaranteeOnePerRecentDay protects the newest completed backup of each of the
+	// last N UTC days from deletion by any retention policy. 0 disables it
+	GuaranteeOnePerRecentDay int `json:"guaranteeOnePerRecentDay" gorm:"column:guarantee_one_per_recent_day;type:int;not null;default:0"`
+
+	// ConsolidateAfterDays makes the GFS cleanup remove backups older than N days
+	// which fill no GFS slot in a single batch. 0 disables it
+	ConsolidateAfterDays int `json:"consolidateAfterDays" gorm:"column:consolidate_after_days;type:int;not null;default:0"`
+
+	// RetentionExemptLabels lists backup retention labels which retention
+	// policies never delete. The total size limit still applies to such backups
+	RetentionExemptLabels       []string `json:"retentionExemptLabels" gorm:"-"`
+	RetentionExemptLabelsString string   `json:"-"                     gorm:"column:retention_exempt_labels;type:text;not null;default:''"`
+
 	BackupIntervalID uuid.UUID           `json:"backupIntervalId"         gorm:"column:backup_interval_id;type:uuid;not null"`
 	BackupInterval   *intervals.Interval `json:"backupInterval,omitempty" gorm:"foreignKey:BackupIntervalID"`
 
@@ -37,15 +102,77 @@ type BackupConfig struct {
 	SendNotificationsOn       []BackupNotificationType `json:"sendNotificationsOn" gorm:"-"`
 	SendNotificationsOnString string                   `json:"-"                   gorm:"column:send_notifications_on;type:text;not null"`
 
+	// NotifierIDs selects the notifiers of backup events, empty means all
+	// notifiers attached to the database
+	NotifierIDs       []uuid.UUID `json:"notifierIds" gorm:"-"`
+	NotifierIDsString string      `json:"-"           gorm:"column:notifier_ids;type:text;not null;default:''"`
+
 	IsRetryIfFailed     bool `json:"isRetryIfFailed"     gorm:"column:is_retry_if_failed;type:boolean;not null"`
 	MaxFailedTriesCount int  `json:"maxFailedTriesCount" gorm:"column:max_failed_tries_count;type:int;not null"`
 
+	// IsRetryImmediately starts the first retry on the next scheduler tick,
+	// further retries wait RetryDelaySeconds after the failed backup
+	IsRetryImmediately bool `json:"isRetryImmediately" gorm:"column:is_retry_immediately;type:boolean;not null;default:false"`
+	RetryDelaySeconds  int  `json:"retryDelaySeconds"  gorm:"column:retry_delay_seconds;type:int;not null;default:0"`
+
 	Encryption BackupEncryption `json:"encryption" gorm:"column:encryption;type:text;not null;default:'NONE'"`
 
+	// EncryptionKeyID selects a workspace encryption key, nil means the instance master key
+	EncryptionKeyID *uuid.UUID `json:"encryptionKeyId" gorm:"column:encryption_key_id;type:uuid"`
+
+	// CompressionLevel is 1 (fastest) to 9 (best compression), 0 means the default
+	// level. zstd supports levels 1–22, values 10–22 would be silently clamped to 9
+	// for gzip, so only 0–9 is accepted to behave the same for both algorithms
+	CompressionLevel int `json:"compressionLevel" gorm:"column:compression_level;type:int;not null;default:0"`
+
+	// StorageACL is applied to uploaded files. Only S3 supports all values,
+	// other storages keep files private
+	StorageACL StorageACL `json:"storageAcl" gorm:"column:storage_acl;type:text;not null;default:'PRIVATE'"`
+
+	// StorageClass is the S3 storage class of uploaded backups, other storages ignore it.
+	// Archive classes are cheaper but backups must be restored from archive before download
+	StorageClass StorageClass `json:"storageClass" gorm:"column:storage_class;type:text;not null;default:'STANDARD'"`
+
 	// MaxBackupSizeMB limits individual backup size. 0 = unlimited.
 	MaxBackupSizeMB int64 `json:"maxBackupSizeMb"       gorm:"column:max_backup_size_mb;type:int;not null"`
 	// MaxBackupsTotalSizeMB limits total size of all backups. 0 = unlimited.
 	MaxBackupsTotalSizeMB int64 `json:"maxBackupsTotalSizeMb" gorm:"column:max_backups_total_size_mb;type:int;not null"`
+
+	// AllowRestoreToSameDatabase allows restoring backups over the database they
+	// were made from. Such restore additionally requires a confirmation token
+	AllowRestoreToSameDatabase bool `json:"allowRestoreToSameDatabase" gorm:"column:allow_restore_to_same_database;type:boolean;not null;default:false"`
+
+	// CleanerPriority orders databases within a cleaner pass, higher is cleaned
+	// sooner. 0 is normal priority
+	CleanerPriority int `json:"cleanerPriority" gorm:"column:cleaner_priority;type:int;not null;default:0"`
+
+	// ShouldDeleteFailedBackupFiles removes the partial file of a failed backup
+	// right away instead of leaving it in the storage until retention removes the row
+	ShouldDeleteFailedBackupFiles bool `json:"shouldDeleteFailedBackupFiles" gorm:"column:should_delete_failed_backup_files;type:boolean;not null;default:false"`
+
+	// IntegrityCheckIntervalDays is how often the newest completed backup is read
+	// back and its dump header checked. 0 disables the check
+	IntegrityCheckIntervalDays int `json:"integrityCheckIntervalDays" gorm:"column:integrity_check_interval_days;type:int;not null;default:0"`
+
+	// MinComplianceScoreThreshold is the compliance score below which subscribed
+	// notifiers are alerted. 0 disables the alert
+	MinComplianceScoreThreshold int `json:"minComplianceScoreThreshold" gorm:"column:min_compliance_score_threshold;type:int;not null;default:70"`
+
+	// PreBackupHook and PostBackupHook are nil when no hook is configured
+	PreBackupHook        *BackupHook `json:"preBackupHook"  gorm:"-"`
+	PreBackupHookString  *string     `json:"-"              gorm:"column:pre_backup_hook;type:jsonb"`
+	PostBackupHook       *BackupHook `json:"postBackupHook" gorm:"-"`
+	PostBackupHookString *string     `json:"-"              gorm:"column:post_backup_hook;type:jsonb"`
+
+	// RetentionPolicyLockedAt and RetentionPolicyChangedBy record the last change of
+	// any retention field. ChangedBy is nil when the change was made by the system
+	RetentionPolicyLockedAt  *time.Time `json:"retentionPolicyLockedAt"  gorm:"column:retention_policy_locked_at;type:timestamptz"`
+	RetentionPolicyChangedBy *uuid.UUID `json:"retentionPolicyChangedBy" gorm:"column:retention_policy_changed_by;type:uuid"`
+
+	// LastCleanupError is set by the retention cleaner when cleanup of the
+	// database fails and cleared by the next successful run
+	LastCleanupError   string     `json:"lastCleanupError"   gorm:"column:last_cleanup_error;type:text;not null;default:''"`
+	LastCleanupErrorAt *time.Time `json:"lastCleanupErrorAt" gorm:"column:last_cleanup_error_at;type:timestamptz"`
 }
 
 func (h *BackupConfig) TableName() string {
@@ -66,6 +193,26 @@ func (b *BackupConfig) BeforeSave(tx *gorm.DB) error {
 		b.SendNotificationsOnString = ""
 	}
 
+	b.RetentionExemptLabelsString = strings.Join(b.RetentionExemptLabels, ",")
+
+	notifierIDs := make([]string, len(b.NotifierIDs))
+	for i, notifierID := range b.NotifierIDs {
+		notifierIDs[i] = notifierID.String()
+	}
+	b.NotifierIDsString = strings.Join(notifierIDs, ",")
+
+	preBackupHookString, err := marshalBackupHook(b.PreBackupHook)
+	if err != nil {
+		return err
+	}
+	b.PreBackupHookString = preBackupHookString
+
+	postBackupHookString, err := marshalBackupHook(b.PostBackupHook)
+	if err != nil {
+		return err
+	}
+	b.PostBackupHookString = postBackupHookString
+
 	return nil
 }
 
@@ -82,6 +229,35 @@ func (b *BackupConfig) AfterFind(tx *gorm.DB) error {
 		b.SendNotificationsOn = []BackupNotificationType{}
 	}
 
+	if b.RetentionExemptLabelsString != "" {
+		b.RetentionExemptLabels = strings.Split(b.RetentionExemptLabelsString, ",")
+	} else {
+		b.RetentionExemptLabels = []string{}
+	}
+
+	b.NotifierIDs = []uuid.UUID{}
+	if b.NotifierIDsString != "" {
+		for notifierID := range strings.SplitSeq(b.NotifierIDsString, ",") {
+			parsedID, err := uuid.Parse(notifierID)
+			if err != nil {
+				return err
+			}
+			b.NotifierIDs = append(b.NotifierIDs, parsedID)
+		}
+	}
+
+	preBackupHook, err := unmarshalBackupHook(b.PreBackupHookString)
+	if err != nil {
+		return err
+	}
+	b.PreBackupHook = preBackupHook
+
+	postBackupHook, err := unmarshalBackupHook(b.PostBackupHookString)
+	if err != nil {
+		return err
+	}
+	b.PostBackupHook = postBackupHook
+
 	return nil
 }
 
@@ -94,15 +270,94 @@ func (b *BackupConfig) Validate(plan *plans.DatabasePlan) error {
 		return err
 	}
 
+	if b.IsBackupsEnabled && b.IsReadOnlyMode {
+		return errors.New("backups cannot be enabled in read-only mode")
+	}
+
 	if b.IsRetryIfFailed && b.MaxFailedTriesCount <= 0 {
 		return errors.New("max failed tries count must be greater than 0")
 	}
 
+	if b.IsRetryImmediately && !b.IsRetryIfFailed {
+		return errors.New("immediate retry requires retry if failed to be enabled")
+	}
+
+	if b.RetryDelaySeconds < 0 {
+		return errors.New("retry delay must not be negative")
+	}
+
+	if b.CleanerPriority < 0 {
+		return errors.New("cleaner priority must not be negative")
+	}
+
+	if b.GuaranteeOnePerRecentDay < 0 {
+		return errors.New("guaranteed recent days must not be negative")
+	}
+
+	if b.ConsolidateAfterDays < 0 {
+		return errors.New("consolidation days must not be negative")
+	}
+
+	if b.ConsolidateAfterDays > 0 && b.RetentionPolicyType != RetentionPolicyTypeGFS {
+		return errors.New("consolidation is supported only by GFS retention policy")
+	}
+
+	for _, label := range b.RetentionExemptLabels {
+		if label == "" {
+			return errors.New("retention exempt label must not be empty")
+		}
+
+		if err := ValidateRetentionLabel(label); err != nil {
+			return err
+		}
+	}
+
+	if b.IntegrityCheckIntervalDays < 0 {
+		return errors.New("integrity check interval must not be negative")
+	}
+
+	if b.MinComplianceScoreThreshold < 0 || b.MinComplianceScoreThreshold > maxComplianceScore {
+		return fmt.Errorf(
+			"min compliance score threshold must be between 0 and %d",
+			maxComplianceScore,
+		)
+	}
+
+	if b.PreBackupHook != nil {
+		if err := b.PreBackupHook.Validate(); err != nil {
+			return fmt.Errorf("pre-backup hook: %w", err)
+		}
+	}
+
+	if b.PostBackupHook != nil {
+		if err := b.PostBackupHook.Validate(); err != nil {
+			return fmt.Errorf("post-backup hook: %w", err)
+		}
+	}
+
 	if b.Encryption != "" && b.Encryption != BackupEncryptionNone &&
 		b.Encryption != BackupEncryptionEncrypted {
 		return errors.New("encryption must be NONE or ENCRYPTED")
 	}
 
+	if b.EncryptionKeyID != nil && b.Encryption != BackupEncryptionEncrypted {
+		return errors.New("encryption key can be set only when encryption is ENCRYPTED")
+	}
+
+	if b.CompressionLevel < 0 || b.CompressionLevel > 9 {
+		return errors.New("compression level must be between 0 and 9")
+	}
+
+	if b.StorageACL != "" && b.StorageACL != StorageACLPrivate &&
+		b.StorageACL != StorageACLWorkspacePrivate &&
+		b.StorageACL != StorageACLAuthenticatedRead {
+		return errors.New("storage ACL must be PRIVATE, WORKSPACE_PRIVATE or AUTHENTICATED_READ")
+	}
+
+	if b.StorageClass != "" && !b.StorageClass.IsSupported() {
+		return fmt.Errorf("storage class %q is not supported", b.StorageClass)
+	}
+
 	if config.GetEnv().IsCloud {
 		if b.Encryption != BackupEncryptionEncrypted {
 			return errors.New("encryption is mandatory for cloud storage")
@@ -133,10 +388,24 @@ func (b *BackupConfig) Validate(plan *plans.DatabasePlan) error {
 	return nil
 }
 
+func (b *BackupConfig) HideSensitiveData() {
+	b.PreBackupHook.HideSensitiveData()
+	b.PostBackupHook.HideSensitiveData()
+}
+
+func (b *BackupConfig) EncryptSensitiveData(encryptor encryption.FieldEncryptor) error {
+	if err := b.PreBackupHook.EncryptSensitiveData(encryptor, b.DatabaseID); err != nil {
+		return err
+	}
+
+	return b.PostBackupHook.EncryptSensitiveData(encryptor, b.DatabaseID)
+}
+
 func (b *BackupConfig) Copy(newDatabaseID uuid.UUID) *BackupConfig {
 	return &BackupConfig{
 		DatabaseID:            newDatabaseID,
 		IsBackupsEnabled:      b.IsBackupsEnabled,
+		IsReadOnlyMode:        b.IsReadOnlyMode,
 		RetentionPolicyType:   b.RetentionPolicyType,
 		RetentionTimePeriod:   b.RetentionTimePeriod,
 		RetentionCount:        b.RetentionCount,
@@ -145,40 +414,717 @@ func (b *BackupConfig) Copy(newDatabaseID uuid.UUID) *BackupConfig {
 		RetentionGfsWeeks:     b.RetentionGfsWeeks,
 		RetentionGfsMonths:    b.RetentionGfsMonths,
 		RetentionGfsYears:     b.RetentionGfsYears,
+		HotRetention:          b.HotRetention,
+		ColdRetention:         b.ColdRetention,
+		ColdStorageID:         b.ColdStorageID,
+		ThinningKeepEvery:     b.ThinningKeepEvery,
+		ThinningAfter:         b.ThinningAfter,
+		RetentionScheduleTime: b.RetentionScheduleTime,
+		RetentionScheduleDays: b.RetentionScheduleDays,
 		BackupIntervalID:      uuid.Nil,
 		BackupInterval:        b.BackupInterval.Copy(),
 		StorageID:             b.StorageID,
 		SendNotificationsOn:   b.SendNotificationsOn,
+		NotifierIDs:           slices.Clone(b.NotifierIDs),
 		IsRetryIfFailed:       b.IsRetryIfFailed,
 		MaxFailedTriesCount:   b.MaxFailedTriesCount,
+		IsRetryImmediately:    b.IsRetryImmediately,
+		RetryDelaySeconds:     b.RetryDelaySeconds,
 		Encryption:            b.Encryption,
+		EncryptionKeyID:       b.EncryptionKeyID,
+		CompressionLevel:      b.CompressionLevel,
+		StorageACL:            b.StorageACL,
+		StorageClass:          b.StorageClass,
 		MaxBackupSizeMB:       b.MaxBackupSizeMB,
 		MaxBackupsTotalSizeMB: b.MaxBackupsTotalSizeMB,
+
+		AllowRestoreToSameDatabase: b.AllowRestoreToSameDatabase,
+		ShouldUseStorageObjectAge:  b.ShouldUseStorageObjectAge,
+		CleanerPriority:            b.CleanerPriority,
+
+		ShouldDeleteFailedBackupFiles: b.ShouldDeleteFailedBackupFiles,
+		GuaranteeOnePerRecentDay:      b.GuaranteeOnePerRecentDay,
+		ConsolidateAfterDays:          b.ConsolidateAfterDays,
+		IntegrityCheckIntervalDays:    b.IntegrityCheckIntervalDays,
+		MinComplianceScoreThreshold:   b.MinComplianceScoreThreshold,
+		RetentionExemptLabels:         slices.Clone(b.RetentionExemptLabels),
+
+		PreBackupHook:  b.PreBackupHook.Copy(),
+		PostBackupHook: b.PostBackupHook.Copy(),
 	}
 }
 
-func (b *BackupConfig) validateRetentionPolicy(plan *plans.DatabasePlan) error {
+// ApplyTemplate overwrites retention and notification settings with the ones of
+// the template. Database, storages, schedule and the rest stay as they are
+func (b *BackupConfig) ApplyTemplate(template *BackupConfig) {
+	b.RetentionPolicyType = template.RetentionPolicyType
+	b.RetentionTimePeriod = template.RetentionTimePeriod
+	b.ShouldUseStorageObjectAge = template.ShouldUseStorageObjectAge
+	b.RetentionCount = template.RetentionCount
+	b.RetentionGfsHours = template.RetentionGfsHours
+	b.RetentionGfsDays = template.RetentionGfsDays
+	b.RetentionGfsWeeks = template.RetentionGfsWeeks
+	b.RetentionGfsMonths = template.RetentionGfsMonths
+	b.RetentionGfsYears = template.RetentionGfsYears
+	b.HotRetention = template.HotRetention
+	b.ColdRetention = template.ColdRetention
+	b.ThinningKeepEvery = template.ThinningKeepEvery
+	b.ThinningAfter = template.ThinningAfter
+	b.RetentionScheduleTime = template.RetentionScheduleTime
+	b.RetentionScheduleDays = template.RetentionScheduleDays
+	b.GuaranteeOnePerRecentDay = template.GuaranteeOnePerRecentDay
+	b.ConsolidateAfterDays = template.ConsolidateAfterDays
+	b.RetentionExemptLabels = slices.Clone(template.RetentionExemptLabels)
+	b.SendNotificationsOn = template.SendNotificationsOn
+}
+
+func (b *BackupConfig) ToPortable() *PortableBackupConfig {
+	portable := &PortableBackupConfig{
+		IsBackupsEnabled:              b.IsBackupsEnabled,
+		IsReadOnlyMode:                b.IsReadOnlyMode,
+		RetentionPolicyType:           b.RetentionPolicyType,
+		RetentionTimePeriod:           b.RetentionTimePeriod,
+		ShouldUseStorageObjectAge:     b.ShouldUseStorageObjectAge,
+		RetentionCount:                b.RetentionCount,
+		RetentionGfsHours:             b.RetentionGfsHours,
+		RetentionGfsDays:              b.RetentionGfsDays,
+		RetentionGfsWeeks:             b.RetentionGfsWeeks,
+		RetentionGfsMonths:            b.RetentionGfsMonths,
+		RetentionGfsYears:             b.RetentionGfsYears,
+		HotRetention:                  b.HotRetention,
+		ColdRetention:                 b.ColdRetention,
+		ThinningKeepEvery:             b.ThinningKeepEvery,
+		ThinningAfter:                 b.ThinningAfter,
+		RetentionScheduleTime:         b.RetentionScheduleTime,
+		RetentionScheduleDays:         b.RetentionScheduleDays,
+		GuaranteeOnePerRecentDay:      b.GuaranteeOnePerRecentDay,
+		ConsolidateAfterDays:          b.ConsolidateAfterDays,
+		RetentionExemptLabels:         b.RetentionExemptLabels,
+		SendNotificationsOn:           b.SendNotificationsOn,
+		IsRetryIfFailed:               b.IsRetryIfFailed,
+		MaxFailedTriesCount:           b.MaxFailedTriesCount,
+		IsRetryImmediately:            b.IsRetryImmediately,
+		RetryDelaySeconds:             b.RetryDelaySeconds,
+		Encryption:                    b.Encryption,
+		CompressionLevel:              b.CompressionLevel,
+		StorageACL:                    b.StorageACL,
+		StorageClass:                  b.StorageClass,
+		MaxBackupSizeMB:               b.MaxBackupSizeMB,
+		MaxBackupsTotalSizeMB:         b.MaxBackupsTotalSizeMB,
+		AllowRestoreToSameDatabase:    b.AllowRestoreToSameDatabase,
+		CleanerPriority:               b.CleanerPriority,
+		ShouldDeleteFailedBackupFiles: b.ShouldDeleteFailedBackupFiles,
+		IntegrityCheckIntervalDays:    b.IntegrityCheckIntervalDays,
+		MinComplianceScoreThreshold:   b.MinComplianceScoreThreshold,
+	}
+
+	if b.BackupInterval != nil {
+		portable.BackupInterval = b.BackupInterval.Copy()
+	}
+
+	return portable
+}
+
+// ApplyPortable overwrites the policy with the imported one. Storages and the
+// encryption key stay as they are, the interval keeps its ID so the existing
+// record is updated instead of a new one being created
+func (b *BackupConfig) ApplyPortable(portable *PortableBackupConfig) {
+	b.IsBackupsEnabled = portable.IsBackupsEnabled
+	b.IsReadOnlyMode = portable.IsReadOnlyMode
+	b.RetentionPolicyType = portable.RetentionPolicyType
+	b.RetentionTimePeriod = portable.RetentionTimePeriod
+	b.ShouldUseStorageObjectAge = portable.ShouldUseStorageObjectAge
+	b.RetentionCount = portable.RetentionCount
+	b.RetentionGfsHours = portable.RetentionGfsHours
+	b.RetentionGfsDays = portable.RetentionGfsDays
+	b.RetentionGfsWeeks = portable.RetentionGfsWeeks
+	b.RetentionGfsMonths = portable.RetentionGfsMonths
+	b.RetentionGfsYears = portable.RetentionGfsYears
+	b.HotRetention = portable.HotRetention
+	b.ColdRetention = portable.ColdRetention
+	b.ThinningKeepEvery = portable.ThinningKeepEvery
+	b.ThinningAfter = portable.ThinningAfter
+	b.RetentionScheduleTime = portable.RetentionScheduleTime
+	b.RetentionScheduleDays = portable.RetentionScheduleDays
+	b.GuaranteeOnePerRecentDay = portable.GuaranteeOnePerRecentDay
+	b.ConsolidateAfterDays = portable.ConsolidateAfterDays
+	b.RetentionExemptLabels = portable.RetentionExemptLabels
+	b.SendNotificationsOn = portable.SendNotificationsOn
+	b.IsRetryIfFailed = portable.IsRetryIfFailed
+	b.MaxFailedTriesCount = portable.MaxFailedTriesCount
+	b.IsRetryImmediately = portable.IsRetryImmediately
+	b.RetryDelaySeconds = portable.RetryDelaySeconds
+	b.Encryption = portable.Encryption
+	b.CompressionLevel = portable.CompressionLevel
+	b.StorageACL = portable.StorageACL
+	b.StorageClass = portable.StorageClass
+	b.MaxBackupSizeMB = portable.MaxBackupSizeMB
+	b.MaxBackupsTotalSizeMB = portable.MaxBackupsTotalSizeMB
+	b.AllowRestoreToSameDatabase = portable.AllowRestoreToSameDatabase
+	b.CleanerPriority = portable.CleanerPriority
+	b.ShouldDeleteFailedBackupFiles = portable.ShouldDeleteFailedBackupFiles
+	b.IntegrityCheckIntervalDays = portable.IntegrityCheckIntervalDays
+	b.MinComplianceScoreThreshold = portable.MinComplianceScoreThreshold
+
+	if portable.BackupInterval != nil {
+		interval := portable.BackupInterval.Copy()
+		interval.ID = b.BackupIntervalID
+		b.BackupInterval = interval
+	}
+}
+
+func (b *BackupConfig) ToDTO() *BackupConfigDTO {
+	return &BackupConfigDTO{
+		DatabaseID:          b.DatabaseID,
+		IsBackupsEnabled:    b.IsBackupsEnabled,
+		IsReadOnlyMode:      b.IsReadOnlyMode,
+		RetentionPolicyType: b.RetentionPolicyType,
+		RetentionTimePeriod: b.RetentionTimePeriod,
+		RetentionCount:      b.RetentionCount,
+		RetentionGfsHours:   b.RetentionGfsHours,
+		RetentionGfsDays:    b.RetentionGfsDays,
+		RetentionGfsWeeks:   b.RetentionGfsWeeks,
+		RetentionGfsMonths:  b.RetentionGfsMonths,
+		RetentionGfsYears:   b.RetentionGfsYears,
+		HotRetention:        b.HotRetention,
+		ColdRetention:       b.ColdRetention,
+		ColdStorageID:       b.ColdStorageID,
+		ThinningKeepEvery:   b.ThinningKeepEvery,
+		ThinningAfter:       b.ThinningAfter,
+		BackupInterval:      b.BackupInterval,
+		Storage:             b.Storage,
+		StorageID:           b.StorageID,
+		SendNotificationsOn: b.SendNotificationsOn,
+		NotifierIDs:         b.NotifierIDs,
+		IsRetryIfFailed:     b.IsRetryIfFailed,
+		MaxFailedTriesCount: b.MaxFailedTriesCount,
+		IsRetryImmediately:  b.IsRetryImmediately,
+		RetryDelaySeconds:   b.RetryDelaySeconds,
+		Encryption:          b.Encryption,
+		EncryptionKeyID:     b.EncryptionKeyID,
+		CompressionLevel:    b.CompressionLevel,
+		StorageACL:          b.StorageACL,
+		StorageClass:        b.StorageClass,
+
+		RetentionScheduleTime: b.RetentionScheduleTime,
+		RetentionScheduleDays: b.RetentionScheduleDays,
+
+		GuaranteeOnePerRecentDay: b.GuaranteeOnePerRecentDay,
+		ConsolidateAfterDays:     b.ConsolidateAfterDays,
+		RetentionExemptLabels:    b.RetentionExemptLabels,
+
+		MaxBackupSizeMB:       b.MaxBackupSizeMB,
+		MaxBackupsTotalSizeMB: b.MaxBackupsTotalSizeMB,
+
+		AllowRestoreToSameDatabase: b.AllowRestoreToSameDatabase,
+		ShouldUseStorageObjectAge:  b.ShouldUseStorageObjectAge,
+		CleanerPriority:            b.CleanerPriority,
+
+		ShouldDeleteFailedBackupFiles: b.ShouldDeleteFailedBackupFiles,
+		IntegrityCheckIntervalDays:    b.IntegrityCheckIntervalDays,
+		MinComplianceScoreThreshold:   b.MinComplianceScoreThreshold,
+
+		PreBackupHook:  b.PreBackupHook.Copy(),
+		PostBackupHook: b.PostBackupHook.Copy(),
+
+		RetentionPolicyLockedAt:  b.RetentionPolicyLockedAt,
+		RetentionPolicyChangedBy: b.RetentionPolicyChangedBy,
+
+		LastCleanupError:   b.LastCleanupError,
+		LastCleanupErrorAt: b.LastCleanupErrorAt,
+	}
+}
+
+// FromDTO converts API input to the model and validates it against the plan.
+// StorageID is ignored, the storage is taken from the full Storage object
+func FromDTO(dto *BackupConfigDTO, plan *plans.DatabasePlan) (*BackupConfig, error) {
+	backupConfig := &BackupConfig{
+		DatabaseID:          dto.DatabaseID,
+		IsBackupsEnabled:    dto.IsBackupsEnabled,
+		IsReadOnlyMode:      dto.IsReadOnlyMode,
+		RetentionPolicyType: dto.RetentionPolicyType,
+		RetentionTimePeriod: dto.RetentionTimePeriod,
+		RetentionCount:      dto.RetentionCount,
+		RetentionGfsHours:   dto.RetentionGfsHours,
+		RetentionGfsDays:    dto.RetentionGfsDays,
+		RetentionGfsWeeks:   dto.RetentionGfsWeeks,
+		RetentionGfsMonths:  dto.RetentionGfsMonths,
+		RetentionGfsYears:   dto.RetentionGfsYears,
+		HotRetention:        dto.HotRetention,
+		ColdRetention:       dto.ColdRetention,
+		ColdStorageID:       dto.ColdStorageID,
+		ThinningKeepEvery:   dto.ThinningKeepEvery,
+		ThinningAfter:       dto.ThinningAfter,
+		BackupInterval:      dto.BackupInterval,
+		Storage:             dto.Storage,
+		SendNotificationsOn: dto.SendNotificationsOn,
+		NotifierIDs:         dto.NotifierIDs,
+		IsRetryIfFailed:     dto.IsRetryIfFailed,
+		MaxFailedTriesCount: dto.MaxFailedTriesCount,
+		IsRetryImmediately:  dto.IsRetryImmediately,
+		RetryDelaySeconds:   dto.RetryDelaySeconds,
+		Encryption:          dto.Encryption,
+		EncryptionKeyID:     dto.EncryptionKeyID,
+		CompressionLevel:    dto.CompressionLevel,
+		StorageACL:          dto.StorageACL,
+		StorageClass:        dto.StorageClass,
+
+		RetentionScheduleTime: dto.RetentionScheduleTime,
+		RetentionScheduleDays: dto.RetentionScheduleDays,
+
+		GuaranteeOnePerRecentDay: dto.GuaranteeOnePerRecentDay,
+		ConsolidateAfterDays:     dto.ConsolidateAfterDays,
+		RetentionExemptLabels:    dto.RetentionExemptLabels,
+
+		MaxBackupSizeMB:       dto.MaxBackupSizeMB,
+		MaxBackupsTotalSizeMB: dto.MaxBackupsTotalSizeMB,
+
+		AllowRestoreToSameDatabase: dto.AllowRestoreToSameDatabase,
+		ShouldUseStorageObjectAge:  dto.ShouldUseStorageObjectAge,
+		CleanerPriority:            dto.CleanerPriority,
+
+		ShouldDeleteFailedBackupFiles: dto.ShouldDeleteFailedBackupFiles,
+		IntegrityCheckIntervalDays:    dto.IntegrityCheckIntervalDays,
+		MinComplianceScoreThreshold:   dto.MinComplianceScoreThreshold,
+
+		PreBackupHook:  dto.PreBackupHook.Copy(),
+		PostBackupHook: dto.PostBackupHook.Copy(),
+	}
+
+	if dto.BackupInterval != nil {
+		backupConfig.BackupIntervalID = dto.BackupInterval.ID
+	}
+
+	if err := backupConfig.Validate(plan); err != nil {
+		return nil, err
+	}
+
+	return backupConfig, nil
+}
+
+// GetValidationWarnings returns non-fatal issues of the config. Unlike Validate
+// errors they do not block saving
+func (b *BackupConfig) GetValidationWarnings(storage *storages.Storage) []string {
+	warnings := []string{}
+
+	isEncrypted := b.Encryption == BackupEncryptionEncrypted
+	if !isEncrypted && storage != nil && storage.IsRemote() {
+		warnings = append(
+			warnings,
+			"backups are stored unencrypted on a remote storage, consider enabling encryption",
+		)
+	}
+
+	return warnings
+}
+
+// IsRetentionLabelExempt reports whether backups with the label are kept by
+// every retention policy. Unlabeled backups are never exempt
+func (b *BackupConfig) IsRetentionLabelExempt(label string) bool {
+	return label != "" && slices.Contains(b.RetentionExemptLabels, label)
+}
+
+// GetCompressionLevel returns the configured level or defaultLevel when it is not set
+func (b *BackupConfig) GetCompressionLevel(defaultLevel int) int {
+	if b.CompressionLevel == 0 {
+		return defaultLevel
+	}
+
+	return b.CompressionLevel
+}
+
+// GetRetryDelay returns how long to wait after a failed backup before the retry
+// that follows failedTriesCount consecutive failures
+func (b *BackupConfig) GetRetryDelay(failedTriesCount int) time.Duration {
+	if b.IsRetryImmediately && failedTriesCount <= 1 {
+		return 0
+	}
+
+	return time.Duration(b.RetryDelaySeconds) * time.Second
+}
+
+// GetRetentionScheduleTime returns RetentionScheduleTime as an offset from midnight
+func (b *BackupConfig) GetRetentionScheduleTime() (time.Duration, error) {
+	scheduleTime, err := time.Parse("15:04", b.RetentionScheduleTime)
+	if err != nil {
+		return 0, errors.New("retention schedule time must be in HH:MM format")
+	}
+
+	return time.Duration(scheduleTime.Hour())*time.Hour +
+		time.Duration(scheduleTime.Minute())*time.Minute, nil
+}
+
+// EffectiveRetentionSummary describes the active retention policy in a human-readable way
+func (b *BackupConfig) EffectiveRetentionSummary() string {
 	switch b.RetentionPolicyType {
-	case RetentionPolicyTypeTimePeriod, "":
-		if b.RetentionTimePeriod == "" {
-			return errors.New("retention time period is required")
+	case RetentionPolicyTypeCount:
+		return fmt.Sprintf("keep last %d backups", b.RetentionCount)
+
+	case RetentionPolicyTypeGFS:
+		slots := []string{}
+		for _, slot := range []struct {
+			count int
+			name  string
+		}{
+			{b.RetentionGfsHours, "hourly"},
+			{b.RetentionGfsDays, "daily"},
+			{b.RetentionGfsWeeks, "weekly"},
+			{b.RetentionGfsMonths, "monthly"},
+			{b.RetentionGfsYears, "yearly"},
+		} {
+			if slot.count > 0 {
+				slots = append(slots, fmt.Sprintf("%d %s", slot.count, slot.name))
+			}
+		}
+
+		return "GFS: " + strings.Join(slots, ", ")
+
+	case RetentionPolicyTypeHotCold:
+		return fmt.Sprintf(
+			"hot storage for %s, cold storage up to %s",
+			b.HotRetention.Describe(),
+			b.ColdRetention.Describe(),
+		)
+
+	case RetentionPolicyTypeThinning:
+		return fmt.Sprintf(
+			"keep every %d backups older than %s",
+			b.ThinningKeepEvery,
+			b.ThinningAfter.Describe(),
+		)
+
+	case RetentionPolicyTypeSchedule:
+		return fmt.Sprintf(
+			"keep backup closest to %s UTC for %d days",
+			b.RetentionScheduleTime,
+			b.RetentionScheduleDays,
+		)
+
+	case RetentionPolicyTypeUnion:
+		summaries := []string{}
+		for _, policyType := range unionRetentionPolicyTypes {
+			summaries = append(
+				summaries,
+				b.withRetentionPolicyType(policyType).EffectiveRetentionSummary(),
+			)
+		}
+
+		return "keep if any keeps: " + strings.Join(summaries, "; ")
+
+	default:
+		if b.RetentionTimePeriod == period.PeriodForever {
+			return "keep backups forever"
+		}
+
+		return "keep backups for " + b.RetentionTimePeriod.Describe()
+	}
+}
+
+// EffectiveRetentionWindow returns how far back a backup can be retained under
+// the active policy. The second value is true when the window is unbounded
+func (b *BackupConfig) EffectiveRetentionWindow() (time.Duration, bool) {
+	day := 24 * time.Hour
+
+	switch b.RetentionPolicyType {
+	case RetentionPolicyTypeCount, RetentionPolicyTypeThinning, RetentionPolicyTypeUnion:
+		// these policies keep backups by position, not by age
+		return 0, true
+
+	case RetentionPolicyTypeGFS:
+		if b.RetentionGfsYears > maxBoundedRetentionWindowYears {
+			return 0, true
+		}
+
+		// slots are ordered from the longest one, so the first active slot wins
+		for _, slot := range []struct {
+			count int
+			unit  time.Duration
+		}{
+			{b.RetentionGfsYears, 365 * day},
+			{b.RetentionGfsMonths, 30 * day},
+			{b.RetentionGfsWeeks, 7 * day},
+			{b.RetentionGfsDays, day},
+			{b.RetentionGfsHours, time.Hour},
+		} {
+			if slot.count > 0 {
+				return time.Duration(slot.count) * slot.unit, false
+			}
+		}
+
+		return 0, true
+
+	case RetentionPolicyTypeHotCold:
+		if b.ColdRetention == period.PeriodForever || !b.ColdRetention.IsValid() {
+			return 0, true
+		}
+
+		return b.ColdRetention.ToDuration(), false
+
+	case RetentionPolicyTypeSchedule:
+		return time.Duration(b.RetentionScheduleDays) * day, false
+
+	default:
+		if b.RetentionTimePeriod == period.PeriodForever || !b.RetentionTimePeriod.IsValid() {
+			return 0, true
+		}
+
+		return b.RetentionTimePeriod.ToDuration(), false
+	}
+}
+
+// ValidateRetentionLabel checks a retention label of a backup, an empty label
+// means the backup has no label
+func ValidateRetentionLabel(label string) error {
+	if len(label) > maxRetentionLabelLength {
+		return fmt.Errorf("retention label must not exceed %d characters", maxRetentionLabelLength)
+	}
+
+	if strings.Contains(label, ",") {
+		return errors.New("retention label must not contain commas")
+	}
+
+	return nil
+}
+
+// DescribeRetention returns a one-line sentence about the retention policy, used
+// in notifications and audit logs so the wording stays the same everywhere
+func DescribeRetention(config *BackupConfig) string {
+	switch config.RetentionPolicyType {
+	case RetentionPolicyTypeCount:
+		if config.RetentionCount == 1 {
+			return "Keep the newest backup"
 		}
 
-		if plan.MaxStoragePeriod != period.PeriodForever {
-			if b.RetentionTimePeriod.CompareTo(plan.MaxStoragePeriod) > 0 {
-				return errors.New("storage period exceeds plan limit")
+		return fmt.Sprintf("Keep the %d newest backups", config.RetentionCount)
+
+	case RetentionPolicyTypeGFS:
+		slots := []string{}
+		for _, slot := range []struct {
+			count int
+			name  string
+			unit  string
+		}{
+			{config.RetentionGfsHours, "hourly", "hour"},
+			{config.RetentionGfsDays, "daily", "day"},
+			{config.RetentionGfsWeeks, "weekly", "week"},
+			{config.RetentionGfsMonths, "monthly", "month"},
+			{config.RetentionGfsYears, "yearly", "year"},
+		} {
+			if slot.count <= 0 {
+				continue
+			}
+
+			slotRetention := pluralize(slot.count, slot.unit)
+
+			if len(slots) == 0 {
+				slots = append(
+					slots,
+					fmt.Sprintf("Keep %s backups for %s", slot.name, slotRetention),
+				)
+				continue
 			}
+
+			slots = append(slots, fmt.Sprintf("%s for %s", slot.name, slotRetention))
+		}
+
+		if len(slots) == 0 {
+			return "Keep all backups"
+		}
+
+		return strings.Join(slots, ", ")
+
+	case RetentionPolicyTypeHotCold:
+		if config.ColdRetention == period.PeriodForever {
+			return fmt.Sprintf(
+				"Keep backups in hot storage for %s, then in cold storage forever",
+				config.HotRetention.Describe(),
+			)
+		}
+
+		return fmt.Sprintf(
+			"Keep backups in hot storage for %s, then in cold storage until %s old",
+			config.HotRetention.Describe(),
+			config.ColdRetention.Describe(),
+		)
+
+	case RetentionPolicyTypeThinning:
+		return fmt.Sprintf(
+			"Keep one of every %d backups older than %s",
+			config.ThinningKeepEvery,
+			config.ThinningAfter.Describe(),
+		)
+
+	case RetentionPolicyTypeSchedule:
+		return fmt.Sprintf(
+			"Keep the backup closest to %s UTC of each day for %s",
+			config.RetentionScheduleTime,
+			pluralize(config.RetentionScheduleDays, "day"),
+		)
+
+	case RetentionPolicyTypeUnion:
+		descriptions := []string{}
+		for _, policyType := range unionRetentionPolicyTypes {
+			descriptions = append(
+				descriptions,
+				DescribeRetention(config.withRetentionPolicyType(policyType)),
+			)
+		}
+
+		return "Keep a backup while any of these keeps it: " + strings.Join(descriptions, "; ")
+
+	default:
+		if config.RetentionTimePeriod == period.PeriodForever {
+			return "Keep backups forever"
+		}
+
+		return "Keep backups for " + config.RetentionTimePeriod.Describe()
+	}
+}
+
+// DiffRetentionPolicy returns names of retention fields that differ from other
+func (b *BackupConfig) DiffRetentionPolicy(other *BackupConfig) []string {
+	changedFields := []string{}
+
+	if b.RetentionPolicyType != other.RetentionPolicyType {
+		changedFields = append(changedFields, "retentionPolicyType")
+	}
+	if b.RetentionTimePeriod != other.RetentionTimePeriod {
+		changedFields = append(changedFields, "retentionTimePeriod")
+	}
+	if b.ShouldUseStorageObjectAge != other.ShouldUseStorageObjectAge {
+		changedFields = append(changedFields, "shouldUseStorageObjectAge")
+	}
+	if b.RetentionCount != other.RetentionCount {
+		changedFields = append(changedFields, "retentionCount")
+	}
+	if b.RetentionGfsHours != other.RetentionGfsHours {
+		changedFields = append(changedFields, "retentionGfsHours")
+	}
+	if b.RetentionGfsDays != other.RetentionGfsDays {
+		changedFields = append(changedFields, "retentionGfsDays")
+	}
+	if b.RetentionGfsWeeks != other.RetentionGfsWeeks {
+		changedFields = append(changedFields, "retentionGfsWeeks")
+	}
+	if b.RetentionGfsMonths != other.RetentionGfsMonths {
+		changedFields = append(changedFields, "retentionGfsMonths")
+	}
+	if b.RetentionGfsYears != other.RetentionGfsYears {
+		changedFields = append(changedFields, "retentionGfsYears")
+	}
+	if b.HotRetention != other.HotRetention {
+		changedFields = append(changedFields, "hotRetention")
+	}
+	if b.ColdRetention != other.ColdRetention {
+		changedFields = append(changedFields, "coldRetention")
+	}
+	if b.ThinningKeepEvery != other.ThinningKeepEvery {
+		changedFields = append(changedFields, "thinningKeepEvery")
+	}
+	if b.ThinningAfter != other.ThinningAfter {
+		changedFields = append(changedFields, "thinningAfter")
+	}
+	if b.RetentionScheduleTime != other.RetentionScheduleTime {
+		changedFields = append(changedFields, "retentionScheduleTime")
+	}
+	if b.RetentionScheduleDays != other.RetentionScheduleDays {
+		changedFields = append(changedFields, "retentionScheduleDays")
+	}
+	if b.GuaranteeOnePerRecentDay != other.GuaranteeOnePerRecentDay {
+		changedFields = append(changedFields, "guaranteeOnePerRecentDay")
+	}
+	if b.ConsolidateAfterDays != other.ConsolidateAfterDays {
+		changedFields = append(changedFields, "consolidateAfterDays")
+	}
+	if !slices.Equal(b.RetentionExemptLabels, other.RetentionExemptLabels) {
+		changedFields = append(changedFields, "retentionExemptLabels")
+	}
+
+	return changedFields
+}
+
+// IsSameSettings reports whether saving b over other changes nothing. Interval
+// IDs are ignored: the same schedule sent without its ID is not a change
+func (b *BackupConfig) IsSameSettings(other *BackupConfig) bool {
+	portable, otherPortable := b.ToPortable(), other.ToPortable()
+
+	// nil and empty slices mean the same, but reflect.DeepEqual tells them apart
+	if !slices.Equal(portable.SendNotificationsOn, otherPortable.SendNotificationsOn) ||
+		!slices.Equal(portable.RetentionExemptLabels, otherPortable.RetentionExemptLabels) {
+		return false
+	}
+	portable.SendNotificationsOn, otherPortable.SendNotificationsOn = nil, nil
+	portable.RetentionExemptLabels, otherPortable.RetentionExemptLabels = nil, nil
+
+	return reflect.DeepEqual(portable, otherPortable) &&
+		storageIDsEqual(b.getEffectiveStorageID(), other.getEffectiveStorageID()) &&
+		storageIDsEqual(b.ColdStorageID, other.ColdStorageID) &&
+		storageIDsEqual(b.EncryptionKeyID, other.EncryptionKeyID) &&
+		slices.Equal(b.NotifierIDs, other.NotifierIDs) &&
+		reflect.DeepEqual(b.PreBackupHook, other.PreBackupHook) &&
+		reflect.DeepEqual(b.PostBackupHook, other.PostBackupHook)
+}
+
+func (b *BackupConfig) validateRetentionPolicy(plan *plans.DatabasePlan) error {
+	switch b.RetentionPolicyType {
+	case RetentionPolicyTypeTimePeriod, "":
+		if err := b.validateTimePeriodRetention(plan); err != nil {
+			return err
 		}
 
 	case RetentionPolicyTypeCount:
-		if b.RetentionCount <= 0 {
-			return errors.New("retention count must be greater than 0")
+		if err := b.validateCountRetention(); err != nil {
+			return err
 		}
 
 	case RetentionPolicyTypeGFS:
-		if b.RetentionGfsHours <= 0 && b.RetentionGfsDays <= 0 && b.RetentionGfsWeeks <= 0 &&
-			b.RetentionGfsMonths <= 0 && b.RetentionGfsYears <= 0 {
-			return errors.New("at least one GFS retention field must be greater than 0")
+		if err := b.validateGFSRetention(); err != nil {
+			return err
+		}
+
+	case RetentionPolicyTypeUnion:
+		// an unset part would make the union silently weaker than the user expects
+		if err := b.validateTimePeriodRetention(plan); err != nil {
+			return err
+		}
+
+		if err := b.validateCountRetention(); err != nil {
+			return err
+		}
+
+		if err := b.validateGFSRetention(); err != nil {
+			return err
+		}
+
+	case RetentionPolicyTypeHotCold:
+		if err := b.validateHotColdRetention(plan); err != nil {
+			return err
+		}
+
+	case RetentionPolicyTypeThinning:
+		if b.ThinningKeepEvery < 2 {
+			return errors.New("thinning keep every must be at least 2")
+		}
+
+		if b.ThinningAfter == "" {
+			return errors.New("thinning after period is required")
+		}
+
+		if !b.ThinningAfter.IsValid() {
+			return fmt.Errorf("unknown storage period %q", b.ThinningAfter)
+		}
+
+		if b.ThinningAfter == period.PeriodForever {
+			return errors.New("thinning after period cannot be forever")
+		}
+
+	case RetentionPolicyTypeSchedule:
+		if _, err := b.GetRetentionScheduleTime(); err != nil {
+			return err
+		}
+
+		if b.RetentionScheduleDays <= 0 {
+			return errors.New("retention schedule days must be greater than 0")
 		}
 
 	default:
@@ -187,3 +1133,156 @@ func (b *BackupConfig) validateRetentionPolicy(plan *plans.DatabasePlan) error {
 
 	return nil
 }
+
+func (b *BackupConfig) validateTimePeriodRetention(plan *plans.DatabasePlan) error {
+	if b.RetentionTimePeriod == "" {
+		return errors.New("retention time period is required")
+	}
+
+	if err := validateStoragePeriods(plan, b.RetentionTimePeriod); err != nil {
+		return err
+	}
+
+	if plan.MaxStoragePeriod != period.PeriodForever {
+		if b.RetentionTimePeriod.CompareTo(plan.MaxStoragePeriod) > 0 {
+			return errors.New("storage period exceeds plan limit")
+		}
+	}
+
+	return nil
+}
+
+func (b *BackupConfig) validateCountRetention() error {
+	if b.RetentionCount <= 0 {
+		return errors.New("retention count must be greater than 0")
+	}
+
+	if b.RetentionCount > maxRetentionCount {
+		return fmt.Errorf("retention count must not exceed %d", maxRetentionCount)
+	}
+
+	return nil
+}
+
+func (b *BackupConfig) validateGFSRetention() error {
+	gfsFields := []struct {
+		value int
+		name  string
+	}{
+		{b.RetentionGfsHours, "hourly"},
+		{b.RetentionGfsDays, "daily"},
+		{b.RetentionGfsWeeks, "weekly"},
+		{b.RetentionGfsMonths, "monthly"},
+		{b.RetentionGfsYears, "yearly"},
+	}
+
+	hasActiveSlot := false
+	for _, field := range gfsFields {
+		if field.value < 0 {
+			return fmt.Errorf("GFS %s retention must not be negative", field.name)
+		}
+
+		if field.value > maxRetentionCount {
+			return fmt.Errorf(
+				"GFS %s retention must not exceed %d",
+				field.name,
+				maxRetentionCount,
+			)
+		}
+
+		if field.value > 0 {
+			hasActiveSlot = true
+		}
+	}
+
+	if !hasActiveSlot {
+		return errors.New("at least one GFS retention field must be greater than 0")
+	}
+
+	return nil
+}
+
+func (b *BackupConfig) validateHotColdRetention(plan *plans.DatabasePlan) error {
+	if b.HotRetention == "" {
+		return errors.New("hot retention period is required")
+	}
+
+	if b.ColdRetention == "" {
+		return errors.New("cold retention period is required")
+	}
+
+	if err := validateStoragePeriods(plan, b.HotRetention, b.ColdRetention); err != nil {
+		return err
+	}
+
+	if b.HotRetention == period.PeriodForever {
+		return errors.New("hot retention period cannot be forever")
+	}
+
+	if b.ColdRetention.CompareTo(b.HotRetention) < 0 {
+		return errors.New("cold retention period must be greater than or equal to hot retention period")
+	}
+
+	if b.ColdStorageID == nil || *b.ColdStorageID == uuid.Nil {
+		return errors.New("cold storage is required")
+	}
+
+	storageID := b.getEffectiveStorageID()
+	if storageID != nil && *storageID == *b.ColdStorageID {
+		return errors.New("cold storage must differ from the primary storage")
+	}
+
+	if plan.MaxStoragePeriod != period.PeriodForever {
+		if b.ColdRetention.CompareTo(plan.MaxStoragePeriod) > 0 {
+			return errors.New("storage period exceeds plan limit")
+		}
+	}
+
+	return nil
+}
+
+// withRetentionPolicyType returns a shallow copy of the config with another
+// policy type, so a single part of the union policy can be described alone
+func (b *BackupConfig) withRetentionPolicyType(
+	policyType RetentionPolicyType,
+) *BackupConfig {
+	copied := *b
+	copied.RetentionPolicyType = policyType
+
+	return &copied
+}
+
+// getEffectiveStorageID prefers the Storage object, the repository saves its ID
+// over StorageID
+func (b *BackupConfig) getEffectiveStorageID() *uuid.UUID {
+	if b.Storage != nil && b.Storage.ID != uuid.Nil {
+		return &b.Storage.ID
+	}
+
+	return b.StorageID
+}
+
+// validateStoragePeriods rejects unknown periods of the config and of the plan
+// before they are compared, so a corrupted value fails validation with a clear
+// message instead of being silently treated as the shortest period
+func validateStoragePeriods(plan *plans.DatabasePlan, storagePeriods ...period.TimePeriod) error {
+	for _, storagePeriod := range storagePeriods {
+		if !storagePeriod.IsValid() {
+			return fmt.Errorf("unknown storage period %q", storagePeriod)
+		}
+	}
+
+	if !plan.MaxStoragePeriod.IsValid() {
+		return fmt.Errorf("unknown storage period %q in plan", plan.MaxStoragePeriod)
+	}
+
+	return nil
+}
+
+func pluralize(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, unit)
+	}
+
+	return fmt.Sprintf("%d %ss", count, unit)
+}
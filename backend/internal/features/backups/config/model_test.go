@@ -1,10 +1,13 @@
 package backups_config
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"databasus-backend/internal/features/intervals"
 	plans "databasus-backend/internal/features/plan"
+	"databasus-backend/internal/features/storages"
 	"databasus-backend/internal/util/period"
 
 	"github.com/google/uuid"
@@ -112,6 +115,195 @@ func Test_Validate_WhenBackupSizeIsUnlimitedAndPlanHas500MBLimit_ValidationFails
 	assert.EqualError(t, err, "max backup size exceeds plan limit")
 }
 
+func Test_Validate_WhenBackupsEnabledInReadOnlyMode_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.IsReadOnlyMode = true
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "backups cannot be enabled in read-only mode")
+}
+
+func Test_Validate_WhenBackupsDisabledInReadOnlyMode_ValidationPasses(t *testing.T) {
+	config := createValidBackupConfig()
+	config.IsBackupsEnabled = false
+	config.IsReadOnlyMode = true
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.NoError(t, err)
+}
+
+func Test_Validate_WhenRetentionScheduleTimeIsInvalid_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeSchedule
+	config.RetentionScheduleTime = "3am"
+	config.RetentionScheduleDays = 30
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "retention schedule time must be in HH:MM format")
+}
+
+func Test_Validate_WhenRetentionScheduleIsConfigured_ValidationPasses(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeSchedule
+	config.RetentionScheduleTime = "03:00"
+	config.RetentionScheduleDays = 30
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.NoError(t, err)
+}
+
+func Test_Validate_WhenRetentionTimePeriodIsUnknown_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionTimePeriod = period.TimePeriod("FORTNIGHT")
+	plan := createUnlimitedPlan()
+	plan.MaxStoragePeriod = period.PeriodYear
+
+	err := config.Validate(plan)
+	assert.EqualError(t, err, `unknown storage period "FORTNIGHT"`)
+}
+
+func Test_Validate_WhenPlanStoragePeriodIsUnknown_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	plan := createUnlimitedPlan()
+	plan.MaxStoragePeriod = period.TimePeriod("CORRUPTED")
+
+	err := config.Validate(plan)
+	assert.EqualError(t, err, `unknown storage period "CORRUPTED" in plan`)
+}
+
+func Test_Validate_WhenHotColdPlanStoragePeriodIsUnknown_ValidationFails(t *testing.T) {
+	config := createValidHotColdBackupConfig()
+	plan := createUnlimitedPlan()
+	plan.MaxStoragePeriod = period.TimePeriod("CORRUPTED")
+
+	err := config.Validate(plan)
+	assert.EqualError(t, err, `unknown storage period "CORRUPTED" in plan`)
+}
+
+func Test_Validate_WhenThinningKeepEveryIsLessThanTwo_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeThinning
+	config.ThinningKeepEvery = 1
+	config.ThinningAfter = period.PeriodWeek
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "thinning keep every must be at least 2")
+}
+
+func Test_Validate_WhenThinningIsConfigured_ValidationPasses(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeThinning
+	config.ThinningKeepEvery = 2
+	config.ThinningAfter = period.PeriodWeek
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.NoError(t, err)
+}
+
+func Test_Validate_WhenCompressionLevelIsAboveNine_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.CompressionLevel = 10
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "compression level must be between 0 and 9")
+}
+
+func Test_GetCompressionLevel_WhenLevelIsNotSet_ReturnsDefaultLevel(t *testing.T) {
+	config := createValidBackupConfig()
+	assert.Equal(t, 5, config.GetCompressionLevel(5))
+
+	config.CompressionLevel = 1
+	assert.Equal(t, 1, config.GetCompressionLevel(5))
+}
+
+func Test_Validate_WhenRetryImmediatelyWithoutRetryIfFailed_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.IsRetryIfFailed = false
+	config.IsRetryImmediately = true
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "immediate retry requires retry if failed to be enabled")
+}
+
+func Test_GetRetryDelay_WhenRetryImmediately_OnlyFirstRetryHasNoDelay(t *testing.T) {
+	config := createValidBackupConfig()
+	config.IsRetryIfFailed = true
+	config.RetryDelaySeconds = 300
+
+	assert.Equal(t, 5*time.Minute, config.GetRetryDelay(1))
+	assert.Equal(t, 5*time.Minute, config.GetRetryDelay(2))
+
+	config.IsRetryImmediately = true
+	assert.Equal(t, time.Duration(0), config.GetRetryDelay(1))
+	assert.Equal(t, 5*time.Minute, config.GetRetryDelay(2))
+}
+
+func Test_GetValidationWarnings_WhenUnencryptedOnRemoteStorage_ReturnsWarningAndValidationPasses(
+	t *testing.T,
+) {
+	config := createValidBackupConfig()
+	config.Encryption = BackupEncryptionNone
+
+	remoteStorage := &storages.Storage{ID: uuid.New(), Type: storages.StorageTypeS3}
+
+	warnings := config.GetValidationWarnings(remoteStorage)
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "unencrypted")
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.NoError(t, err)
+}
+
+func Test_GetValidationWarnings_WhenUnencryptedOnLocalStorage_ReturnsNoWarnings(t *testing.T) {
+	config := createValidBackupConfig()
+	config.Encryption = BackupEncryptionNone
+
+	localStorage := &storages.Storage{ID: uuid.New(), Type: storages.StorageTypeLocal}
+
+	assert.Empty(t, config.GetValidationWarnings(localStorage))
+}
+
+func Test_Validate_WhenStorageACLIsAuthenticatedRead_ValidationPasses(t *testing.T) {
+	config := createValidBackupConfig()
+	config.StorageACL = StorageACLAuthenticatedRead
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.NoError(t, err)
+}
+
+func Test_Validate_WhenStorageACLIsUnknown_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.StorageACL = StorageACL("PUBLIC_READ")
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(
+		t,
+		err,
+		"storage ACL must be PRIVATE, WORKSPACE_PRIVATE or AUTHENTICATED_READ",
+	)
+}
+
+func Test_Validate_WhenStorageClassIsUnknown_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.StorageClass = StorageClass("REDUCED_REDUNDANCY")
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, `storage class "REDUCED_REDUNDANCY" is not supported`)
+
+	config.StorageClass = StorageClassGlacierIR
+	assert.NoError(t, config.Validate(createUnlimitedPlan()))
+}
+
+func Test_Validate_WhenEncryptionKeySetWithoutEncryption_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	keyID := uuid.New()
+	config.Encryption = BackupEncryptionNone
+	config.EncryptionKeyID = &keyID
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "encryption key can be set only when encryption is ENCRYPTED")
+}
+
 func Test_Validate_WhenBackupSizeEqualsExactPlanLimit_ValidationPasses(t *testing.T) {
 	config := createValidBackupConfig()
 	config.MaxBackupSizeMB = 500
@@ -449,6 +641,280 @@ func Test_Validate_WhenPolicyTypeIsInvalid_ValidationFails(t *testing.T) {
 	assert.EqualError(t, err, "invalid retention policy type")
 }
 
+func Test_Validate_WhenPolicyTypeIsHotCold_WithColdLongerThanHot_ValidationPasses(t *testing.T) {
+	config := createValidHotColdBackupConfig()
+
+	plan := createUnlimitedPlan()
+
+	err := config.Validate(plan)
+	assert.NoError(t, err)
+}
+
+func Test_Validate_WhenPolicyTypeIsHotCold_WithColdShorterThanHot_ValidationFails(t *testing.T) {
+	config := createValidHotColdBackupConfig()
+	config.HotRetention = period.PeriodYear
+	config.ColdRetention = period.PeriodMonth
+
+	plan := createUnlimitedPlan()
+
+	err := config.Validate(plan)
+	assert.EqualError(
+		t,
+		err,
+		"cold retention period must be greater than or equal to hot retention period",
+	)
+}
+
+func Test_Validate_WhenPolicyTypeIsHotCold_WithoutColdStorage_ValidationFails(t *testing.T) {
+	config := createValidHotColdBackupConfig()
+	config.ColdStorageID = nil
+
+	plan := createUnlimitedPlan()
+
+	err := config.Validate(plan)
+	assert.EqualError(t, err, "cold storage is required")
+}
+
+func Test_FromDTO_WhenHotColdStorageObjectIsColdStorage_ReturnsValidationError(t *testing.T) {
+	config := createValidHotColdBackupConfig()
+	config.Storage = &storages.Storage{ID: *config.ColdStorageID}
+
+	dto := config.ToDTO()
+	dto.StorageID = nil
+
+	restoredConfig, err := FromDTO(dto, createUnlimitedPlan())
+	assert.Nil(t, restoredConfig)
+	assert.EqualError(t, err, "cold storage must differ from the primary storage")
+}
+
+func Test_Validate_WhenPolicyTypeIsHotCold_WithColdExceedingPlan_ValidationFails(t *testing.T) {
+	config := createValidHotColdBackupConfig()
+
+	plan := createUnlimitedPlan()
+	plan.MaxStoragePeriod = period.PeriodMonth
+
+	err := config.Validate(plan)
+	assert.EqualError(t, err, "storage period exceeds plan limit")
+}
+
+func Test_DiffRetentionPolicy_WhenOnlyNonRetentionFieldsChanged_ReturnsNoChanges(t *testing.T) {
+	existingConfig := createValidBackupConfig()
+	newConfig := existingConfig.Copy(existingConfig.DatabaseID)
+	newConfig.IsBackupsEnabled = !existingConfig.IsBackupsEnabled
+	newConfig.MaxFailedTriesCount = existingConfig.MaxFailedTriesCount + 1
+
+	assert.Empty(t, newConfig.DiffRetentionPolicy(existingConfig))
+}
+
+func Test_DiffRetentionPolicy_WhenRetentionFieldsChanged_ReturnsChangedFields(t *testing.T) {
+	existingConfig := createValidBackupConfig()
+	newConfig := existingConfig.Copy(existingConfig.DatabaseID)
+	newConfig.RetentionPolicyType = RetentionPolicyTypeCount
+	newConfig.RetentionCount = 10
+
+	assert.Equal(
+		t,
+		[]string{"retentionPolicyType", "retentionCount"},
+		newConfig.DiffRetentionPolicy(existingConfig),
+	)
+}
+
+func Test_FromDTO_WhenRoundTrippedThroughToDTO_PreservesRetentionFields(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeGFS
+	config.RetentionTimePeriod = period.PeriodWeek
+	config.RetentionCount = 7
+	config.RetentionGfsHours = 24
+	config.RetentionGfsDays = 7
+	config.RetentionGfsWeeks = 4
+	config.RetentionGfsMonths = 12
+	config.RetentionGfsYears = 3
+	config.HotRetention = period.PeriodDay
+	config.ColdRetention = period.PeriodMonth
+	config.ThinningKeepEvery = 5
+	config.ThinningAfter = period.PeriodWeek
+	config.StorageACL = StorageACLWorkspacePrivate
+	config.StorageClass = StorageClassIntelligentTiering
+
+	restoredConfig, err := FromDTO(config.ToDTO(), createUnlimitedPlan())
+	assert.NoError(t, err)
+
+	assert.Equal(t, config.DatabaseID, restoredConfig.DatabaseID)
+	assert.Equal(t, config.BackupIntervalID, restoredConfig.BackupIntervalID)
+	assert.Equal(t, config.StorageACL, restoredConfig.StorageACL)
+	assert.Equal(t, config.StorageClass, restoredConfig.StorageClass)
+	assert.Empty(t, config.DiffRetentionPolicy(restoredConfig))
+}
+
+func Test_FromDTO_WhenDTOIsInvalid_ReturnsValidationError(t *testing.T) {
+	dto := createValidBackupConfig().ToDTO()
+	dto.RetentionPolicyType = RetentionPolicyTypeCount
+	dto.RetentionCount = 0
+
+	restoredConfig, err := FromDTO(dto, createUnlimitedPlan())
+	assert.Nil(t, restoredConfig)
+	assert.EqualError(t, err, "retention count must be greater than 0")
+}
+
+func Test_Validate_WhenGfsFieldIsNegative_ValidationFails(t *testing.T) {
+	tests := []struct {
+		name          string
+		configure     func(config *BackupConfig)
+		expectedError string
+	}{
+		{
+			name:          "negative hours",
+			configure:     func(config *BackupConfig) { config.RetentionGfsHours = -1 },
+			expectedError: "GFS hourly retention must not be negative",
+		},
+		{
+			name:          "negative days",
+			configure:     func(config *BackupConfig) { config.RetentionGfsDays = -7 },
+			expectedError: "GFS daily retention must not be negative",
+		},
+		{
+			name:          "negative years",
+			configure:     func(config *BackupConfig) { config.RetentionGfsYears = -1 },
+			expectedError: "GFS yearly retention must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createValidBackupConfig()
+			config.RetentionPolicyType = RetentionPolicyTypeGFS
+			config.RetentionGfsWeeks = 4
+			tt.configure(config)
+
+			err := config.Validate(createUnlimitedPlan())
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_Validate_WhenGfsFieldExceedsSanityBound_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeGFS
+	config.RetentionGfsHours = maxRetentionCount + 1
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "GFS hourly retention must not exceed 100000")
+}
+
+func Test_Validate_WhenRetentionCountExceedsSanityBound_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeCount
+	config.RetentionCount = maxRetentionCount + 1
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "retention count must not exceed 100000")
+}
+
+func Test_Validate_WhenBackupHookIsInvalid_ValidationFails(t *testing.T) {
+	tests := []struct {
+		name          string
+		configure     func(config *BackupConfig, hook *BackupHook)
+		expectedError string
+	}{
+		{
+			name: "pre-backup hook without URL",
+			configure: func(config *BackupConfig, hook *BackupHook) {
+				hook.URL = ""
+				config.PreBackupHook = hook
+			},
+			expectedError: "pre-backup hook: hook URL is required",
+		},
+		{
+			name: "post-backup hook with unknown failure action",
+			configure: func(config *BackupConfig, hook *BackupHook) {
+				hook.OnFailure = "RETRY"
+				config.PostBackupHook = hook
+			},
+			expectedError: "post-backup hook: hook on failure must be ABORT, LOG or CONTINUE",
+		},
+		{
+			name: "post-backup hook with broken body template",
+			configure: func(config *BackupConfig, hook *BackupHook) {
+				hook.Body = "{{ .DatabaseName"
+				config.PostBackupHook = hook
+			},
+			expectedError: "post-backup hook: hook body is not a valid template: " +
+				"template: hook:1: unclosed action",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createValidBackupConfig()
+			tt.configure(config, createValidBackupHook())
+
+			err := config.Validate(createUnlimitedPlan())
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_AfterFind_WhenHooksSavedAsJSON_RestoresHooks(t *testing.T) {
+	config := createValidBackupConfig()
+	config.PostBackupHook = createValidBackupHook()
+
+	assert.NoError(t, config.BeforeSave(nil))
+	assert.Nil(t, config.PreBackupHookString)
+	assert.NotNil(t, config.PostBackupHookString)
+
+	loadedConfig := &BackupConfig{PostBackupHookString: config.PostBackupHookString}
+	assert.NoError(t, loadedConfig.AfterFind(nil))
+	assert.Nil(t, loadedConfig.PreBackupHook)
+	assert.Equal(t, config.PostBackupHook, loadedConfig.PostBackupHook)
+}
+
+func Test_Validate_WhenUnionRetentionMissesAnyPolicy_ValidationFails(t *testing.T) {
+	tests := []struct {
+		name          string
+		configure     func(config *BackupConfig)
+		expectedError string
+	}{
+		{
+			name:          "no time period",
+			configure:     func(config *BackupConfig) { config.RetentionTimePeriod = "" },
+			expectedError: "retention time period is required",
+		},
+		{
+			name:          "no count",
+			configure:     func(config *BackupConfig) { config.RetentionCount = 0 },
+			expectedError: "retention count must be greater than 0",
+		},
+		{
+			name:          "no GFS slot",
+			configure:     func(config *BackupConfig) { config.RetentionGfsDays = 0 },
+			expectedError: "at least one GFS retention field must be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := createValidBackupConfig()
+			config.RetentionPolicyType = RetentionPolicyTypeUnion
+			config.RetentionCount = 5
+			config.RetentionGfsDays = 7
+			assert.NoError(t, config.Validate(createUnlimitedPlan()))
+
+			tt.configure(config)
+
+			err := config.Validate(createUnlimitedPlan())
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func Test_Validate_WhenRetentionExemptLabelContainsComma_ValidationFails(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionExemptLabels = []string{"release,migration"}
+
+	err := config.Validate(createUnlimitedPlan())
+	assert.EqualError(t, err, "retention label must not contain commas")
+}
+
 func createValidBackupConfig() *BackupConfig {
 	intervalID := uuid.New()
 	return &BackupConfig{
@@ -475,3 +941,130 @@ func createUnlimitedPlan() *plans.DatabasePlan {
 		MaxStoragePeriod:      period.PeriodForever,
 	}
 }
+
+func createValidHotColdBackupConfig() *BackupConfig {
+	storageID := uuid.New()
+	coldStorageID := uuid.New()
+
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeHotCold
+	config.HotRetention = period.PeriodMonth
+	config.ColdRetention = period.PeriodYear
+	config.StorageID = &storageID
+	config.ColdStorageID = &coldStorageID
+
+	return config
+}
+
+func createValidBackupHook() *BackupHook {
+	return &BackupHook{
+		URL:            "https://hooks.example.com/backup",
+		Method:         http.MethodPost,
+		Headers:        map[string]string{"X-Source": "databasus"},
+		Body:           `{"database": "{{ .DatabaseName }}"}`,
+		TimeoutSeconds: 30,
+		OnFailure:      BackupHookFailureActionLog,
+	}
+}
+
+func Test_DescribeRetention_ForEachPolicyType_ReturnsSentence(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *BackupConfig
+		expected string
+	}{
+		{
+			name: "time period",
+			config: &BackupConfig{
+				RetentionPolicyType: RetentionPolicyTypeTimePeriod,
+				RetentionTimePeriod: period.PeriodMonth,
+			},
+			expected: "Keep backups for 1 month",
+		},
+		{
+			name: "time period forever",
+			config: &BackupConfig{
+				RetentionPolicyType: RetentionPolicyTypeTimePeriod,
+				RetentionTimePeriod: period.PeriodForever,
+			},
+			expected: "Keep backups forever",
+		},
+		{
+			name: "count",
+			config: &BackupConfig{
+				RetentionPolicyType: RetentionPolicyTypeCount,
+				RetentionCount:      10,
+			},
+			expected: "Keep the 10 newest backups",
+		},
+		{
+			name: "GFS",
+			config: &BackupConfig{
+				RetentionPolicyType: RetentionPolicyTypeGFS,
+				RetentionGfsDays:    7,
+				RetentionGfsWeeks:   4,
+				RetentionGfsYears:   1,
+			},
+			expected: "Keep daily backups for 7 days, weekly for 4 weeks, yearly for 1 year",
+		},
+		{
+			name: "hot cold",
+			config: &BackupConfig{
+				RetentionPolicyType: RetentionPolicyTypeHotCold,
+				HotRetention:        period.PeriodWeek,
+				ColdRetention:       period.PeriodYear,
+			},
+			expected: "Keep backups in hot storage for 1 week, then in cold storage until 1 year old",
+		},
+		{
+			name: "thinning",
+			config: &BackupConfig{
+				RetentionPolicyType: RetentionPolicyTypeThinning,
+				ThinningKeepEvery:   4,
+				ThinningAfter:       period.Period3Month,
+			},
+			expected: "Keep one of every 4 backups older than 3 months",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DescribeRetention(tt.config))
+		})
+	}
+}
+
+func Test_EffectiveRetentionWindow_WhenTimePeriodIsMonth_ReturnsExactPeriod(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeTimePeriod
+	config.RetentionTimePeriod = period.PeriodMonth
+
+	window, isUnbounded := config.EffectiveRetentionWindow()
+
+	assert.False(t, isUnbounded)
+	assert.Equal(t, 30*24*time.Hour, window)
+}
+
+func Test_EffectiveRetentionWindow_WhenGfsHasYearlySlots_ReturnsLargestSlot(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeGFS
+	config.RetentionGfsDays = 7
+	config.RetentionGfsMonths = 12
+	config.RetentionGfsYears = 3
+
+	window, isUnbounded := config.EffectiveRetentionWindow()
+
+	assert.False(t, isUnbounded)
+	assert.Equal(t, 3*365*24*time.Hour, window)
+}
+
+func Test_EffectiveRetentionWindow_WhenPolicyIsCount_ReturnsUnbounded(t *testing.T) {
+	config := createValidBackupConfig()
+	config.RetentionPolicyType = RetentionPolicyTypeCount
+	config.RetentionCount = 10
+
+	window, isUnbounded := config.EffectiveRetentionWindow()
+
+	assert.True(t, isUnbounded)
+	assert.Equal(t, time.Duration(0), window)
+}
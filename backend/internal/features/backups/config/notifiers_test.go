@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	"databasus-backend/internal/features/databases"
@@ -15,6 +16,7 @@ import (
 	users_testing "databasus-backend/internal/features/users/testing"
 	workspaces_controllers "databasus-backend/internal/features/workspaces/controllers"
 	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	"databasus-backend/internal/util/period"
 	test_utils "databasus-backend/internal/util/testing"
 )
 
@@ -173,6 +175,103 @@ func Test_TransferNotifierWithAttachedDatabase_CannotTransfer(t *testing.T) {
 	)
 }
 
+func Test_SaveBackupConfig_WithNotificationsAndNoNotifier_ReturnsWarning(t *testing.T) {
+	router := createTestRouterWithNotifier()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	var response BackupConfigDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-configs/save",
+		"Bearer "+owner.Token,
+		createTimePeriodBackupConfig(database.ID, period.PeriodWeek),
+		http.StatusOK,
+		&response,
+	)
+
+	assert.Contains(t, response.Warnings, "notifications configured but no notifier is set")
+}
+
+func Test_SaveBackupConfig_WithNotificationsAndNotifier_ReturnsNoNotifierWarning(t *testing.T) {
+	router := createTestRouterWithNotifier()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		notifiers.RemoveTestNotifier(notifier)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	database.Notifiers = []notifiers.Notifier{*notifier}
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/databases/update",
+		"Bearer "+owner.Token,
+		database,
+		http.StatusOK,
+	)
+
+	var response BackupConfigDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-configs/save",
+		"Bearer "+owner.Token,
+		createTimePeriodBackupConfig(database.ID, period.PeriodWeek),
+		http.StatusOK,
+		&response,
+	)
+
+	assert.NotContains(t, response.Warnings, "notifications configured but no notifier is set")
+}
+
+func Test_SaveBackupConfig_WithNotificationsAndOnlyConfigNotifier_ReturnsNoNotifierWarning(
+	t *testing.T,
+) {
+	router := createTestRouterWithNotifier()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database := createTestDatabaseViaAPI("Test Database", workspace.ID, owner.Token, router)
+	notifier := notifiers.CreateTestNotifier(workspace.ID)
+
+	defer func() {
+		databases.RemoveTestDatabase(database)
+		notifiers.RemoveTestNotifier(notifier)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	request := createTimePeriodBackupConfig(database.ID, period.PeriodWeek)
+	request.NotifierIDs = []uuid.UUID{notifier.ID}
+
+	var response BackupConfigDTO
+	test_utils.MakePostRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-configs/save",
+		"Bearer "+owner.Token,
+		request,
+		http.StatusOK,
+		&response,
+	)
+
+	assert.NotContains(t, response.Warnings, "notifications configured but no notifier is set")
+}
+
 func createTestRouterWithNotifier() *gin.Engine {
 	router := workspaces_testing.CreateTestRouter(
 		workspaces_controllers.GetWorkspaceController(),
@@ -1,8 +1,10 @@
 package backups_config
 
 import (
+	"databasus-backend/internal/features/intervals"
 	"databasus-backend/internal/storage"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -74,6 +76,21 @@ func (r *BackupConfigRepository) FindByDatabaseID(databaseID uuid.UUID) (*Backup
 	return &backupConfig, nil
 }
 
+func (r *BackupConfigRepository) FindAll() ([]*BackupConfig, error) {
+	var backupConfigs []*BackupConfig
+
+	if err := storage.
+		GetDb().
+		Preload("BackupInterval").
+		Preload("Storage").
+		Order("database_id").
+		Find(&backupConfigs).Error; err != nil {
+		return nil, err
+	}
+
+	return backupConfigs, nil
+}
+
 func (r *BackupConfigRepository) GetWithEnabledBackups() ([]*BackupConfig, error) {
 	var backupConfigs []*BackupConfig
 
@@ -89,13 +106,51 @@ func (r *BackupConfigRepository) GetWithEnabledBackups() ([]*BackupConfig, error
 	return backupConfigs, nil
 }
 
+// GetLastSuccessfulBackupTimesForEnabled returns the time of the newest completed
+// backup for every config with enabled backups. Databases without completed
+// backups are present with nil time
+func (r *BackupConfigRepository) GetLastSuccessfulBackupTimesForEnabled() (
+	map[uuid.UUID]*time.Time,
+	error,
+) {
+	var rows []struct {
+		DatabaseID   uuid.UUID
+		LastBackupAt *time.Time
+	}
+
+	// status is hardcoded because the backups package depends on this one
+	if err := storage.
+		GetDb().
+		Raw(`
+			SELECT bc.database_id, lb.last_backup_at
+			FROM backup_configs bc
+			LEFT JOIN (
+				SELECT database_id, MAX(created_at) AS last_backup_at
+				FROM backups
+				WHERE status = 'COMPLETED'
+				GROUP BY database_id
+			) lb ON lb.database_id = bc.database_id
+			WHERE bc.is_backups_enabled = TRUE
+		`).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	lastBackupTimes := make(map[uuid.UUID]*time.Time, len(rows))
+	for _, row := range rows {
+		lastBackupTimes[row.DatabaseID] = row.LastBackupAt
+	}
+
+	return lastBackupTimes, nil
+}
+
 func (r *BackupConfigRepository) IsStorageUsing(storageID uuid.UUID) (bool, error) {
 	var count int64
 
 	if err := storage.
 		GetDb().
 		Table("backup_configs").
-		Where("storage_id = ?", storageID).
+		Where("storage_id = ? OR cold_storage_id = ?", storageID, storageID).
 		Count(&count).Error; err != nil {
 		return false, err
 	}
@@ -111,10 +166,112 @@ func (r *BackupConfigRepository) GetDatabasesIDsByStorageID(
 	if err := storage.
 		GetDb().
 		Table("backup_configs").
-		Where("storage_id = ?", storageID).
+		Where("storage_id = ? OR cold_storage_id = ?", storageID, storageID).
 		Pluck("database_id", &databasesIDs).Error; err != nil {
 		return nil, err
 	}
 
 	return databasesIDs, nil
 }
+
+// FindConfigsByStorageID returns configs which use the storage either
+// as primary storage or as cold storage
+func (r *BackupConfigRepository) FindConfigsByStorageID(
+	storageID uuid.UUID,
+) ([]*BackupConfig, error) {
+	var backupConfigs []*BackupConfig
+
+	if err := storage.
+		GetDb().
+		Preload("BackupInterval").
+		Preload("Storage").
+		Where("storage_id = ? OR cold_storage_id = ?", storageID, storageID).
+		Find(&backupConfigs).Error; err != nil {
+		return nil, err
+	}
+
+	return backupConfigs, nil
+}
+
+func (r *BackupConfigRepository) SaveWorkspaceDefaults(
+	defaults *WorkspaceBackupDefaults,
+) (*WorkspaceBackupDefaults, error) {
+	if err := storage.GetDb().Save(defaults).Error; err != nil {
+		return nil, err
+	}
+
+	return defaults, nil
+}
+
+func (r *BackupConfigRepository) FindWorkspaceDefaults(
+	workspaceID uuid.UUID,
+) (*WorkspaceBackupDefaults, error) {
+	var defaults WorkspaceBackupDefaults
+
+	if err := storage.
+		GetDb().
+		Where("workspace_id = ?", workspaceID).
+		First(&defaults).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &defaults, nil
+}
+
+// UpdateLastCleanupError updates only the cleanup error columns, so the
+// cleaner never overwrites a config saved concurrently by a user
+func (r *BackupConfigRepository) UpdateLastCleanupError(
+	databaseID uuid.UUID,
+	lastCleanupError string,
+	lastCleanupErrorAt *time.Time,
+) error {
+	return storage.
+		GetDb().
+		Model(&BackupConfig{}).
+		Where("database_id = ?", databaseID).
+		Updates(map[string]any{
+			"last_cleanup_error":    lastCleanupError,
+			"last_cleanup_error_at": lastCleanupErrorAt,
+		}).Error
+}
+
+// DeleteByDatabaseID deletes the backup config together with its interval
+func (r *BackupConfigRepository) DeleteByDatabaseID(databaseID uuid.UUID) error {
+	return storage.GetDb().Transaction(func(tx *gorm.DB) error {
+		return r.DeleteByDatabaseIDInTx(tx, databaseID)
+	})
+}
+
+// DeleteByDatabaseIDInTx is DeleteByDatabaseID within the transaction of the
+// caller, so the config is deleted together with rows of other tables
+func (r *BackupConfigRepository) DeleteByDatabaseIDInTx(tx *gorm.DB, databaseID uuid.UUID) error {
+	var backupConfig BackupConfig
+
+	if err := tx.
+		Where("database_id = ?", databaseID).
+		First(&backupConfig).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if err := tx.Delete(&BackupConfig{}, "database_id = ?", databaseID).Error; err != nil {
+		return err
+	}
+
+	if backupConfig.BackupIntervalID != uuid.Nil {
+		if err := tx.
+			Delete(&intervals.Interval{}, "id = ?", backupConfig.BackupIntervalID).
+			Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
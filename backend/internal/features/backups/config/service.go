@@ -1,26 +1,42 @@
 package backups_config
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log/slog"
+	"time"
 
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	backups_settings "databasus-backend/internal/features/backups/settings"
 	"databasus-backend/internal/features/databases"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/features/intervals"
 	"databasus-backend/internal/features/notifiers"
 	plans "databasus-backend/internal/features/plan"
 	"databasus-backend/internal/features/storages"
+	users_enums "databasus-backend/internal/features/users/enums"
 	users_models "databasus-backend/internal/features/users/models"
 	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/encryption"
 
 	"github.com/google/uuid"
 )
 
 type BackupConfigService struct {
-	backupConfigRepository *BackupConfigRepository
-	databaseService        *databases.DatabaseService
-	storageService         *storages.StorageService
-	notifierService        *notifiers.NotifierService
-	workspaceService       *workspaces_services.WorkspaceService
-	databasePlanService    *plans.DatabasePlanService
+	backupConfigRepository      *BackupConfigRepository
+	databaseService             *databases.DatabaseService
+	storageService              *storages.StorageService
+	notifierService             *notifiers.NotifierService
+	workspaceService            *workspaces_services.WorkspaceService
+	databasePlanService         *plans.DatabasePlanService
+	auditLogService             *audit_logs.AuditLogService
+	encryptionKeyService        *encryption_keys.WorkspaceEncryptionKeyService
+	backupSystemSettingsService *backups_settings.BackupSystemSettingsService
+	fieldEncryptor              encryption.FieldEncryptor
+	logger                      *slog.Logger
 
 	dbStorageChangeListener BackupConfigStorageChangeListener
 }
@@ -42,51 +58,132 @@ func (s *BackupConfigService) GetStorageAttachedDatabasesIDs(
 	return databasesIDs, nil
 }
 
+func (s *BackupConfigService) FindConfigsByStorageID(
+	storageID uuid.UUID,
+) ([]*BackupConfig, error) {
+	return s.backupConfigRepository.FindConfigsByStorageID(storageID)
+}
+
+// SaveBackupConfigWithAuth saves the config on behalf of the user. Retention
+// policy changes within the cooldown are rejected unless isForce is set by the
+// owner of the workspace
 func (s *BackupConfigService) SaveBackupConfigWithAuth(
 	user *users_models.User,
-	backupConfig *BackupConfig,
-) (*BackupConfig, error) {
-	plan, err := s.databasePlanService.GetDatabasePlan(backupConfig.DatabaseID)
+	backupConfigDTO *BackupConfigDTO,
+	isForce bool,
+) (*BackupConfig, []string, error) {
+	plan, err := s.databasePlanService.GetDatabasePlan(backupConfigDTO.DatabaseID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if err := backupConfig.Validate(plan); err != nil {
-		return nil, err
+	backupConfig, err := FromDTO(backupConfigDTO, plan)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	database, err := s.databaseService.GetDatabase(user, backupConfig.DatabaseID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if database.WorkspaceID == nil {
-		return nil, errors.New("cannot save backup config for database without workspace")
+		return nil, nil, errors.New("cannot save backup config for database without workspace")
 	}
 
 	canManage, err := s.workspaceService.CanUserManageDBs(*database.WorkspaceID, user)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !canManage {
-		return nil, errors.New("insufficient permissions to modify backup configuration")
+		return nil, nil, errors.New("insufficient permissions to modify backup configuration")
 	}
 
+	var storage *storages.Storage
 	if backupConfig.Storage != nil && backupConfig.Storage.ID != uuid.Nil {
-		storage, err := s.storageService.GetStorageByID(backupConfig.Storage.ID)
+		storage, err = s.storageService.GetStorageByID(backupConfig.Storage.ID)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if storage.WorkspaceID != *database.WorkspaceID && !storage.IsSystem {
-			return nil, errors.New("storage does not belong to the same workspace as the database")
+			return nil, nil, errors.New(
+				"storage does not belong to the same workspace as the database",
+			)
+		}
+	}
+
+	if backupConfig.EncryptionKeyID != nil {
+		if err := s.encryptionKeyService.ValidateKeyBelongsToWorkspace(
+			*backupConfig.EncryptionKeyID,
+			*database.WorkspaceID,
+		); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, notifierID := range backupConfig.NotifierIDs {
+		notifier, err := s.notifierService.GetNotifierByID(notifierID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if notifier.WorkspaceID != *database.WorkspaceID {
+			return nil, nil, errors.New(
+				"notifier does not belong to the same workspace as the database",
+			)
+		}
+	}
+
+	if backupConfig.ColdStorageID != nil {
+		coldStorage, err := s.storageService.GetStorageByID(*backupConfig.ColdStorageID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if coldStorage.WorkspaceID != *database.WorkspaceID && !coldStorage.IsSystem {
+			return nil, nil, errors.New(
+				"cold storage does not belong to the same workspace as the database",
+			)
+		}
+	}
+
+	if err := s.checkRetentionPolicyCooldown(
+		user,
+		*database.WorkspaceID,
+		backupConfig,
+		isForce,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	savedConfig, err := s.saveBackupConfig(backupConfig, &user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	warnings := savedConfig.GetValidationWarnings(storage)
+
+	// only a warning: default configs of new databases already request
+	// notifications before any notifier is attached
+	if len(savedConfig.SendNotificationsOn) > 0 {
+		recipients, err := s.GetNotificationRecipients(savedConfig, database)
+		if err == nil && len(recipients) == 0 {
+			warnings = append(warnings, "notifications configured but no notifier is set")
 		}
 	}
 
-	return s.SaveBackupConfig(backupConfig)
+	savedConfig.HideSensitiveData()
+
+	return savedConfig, warnings, nil
 }
 
 func (s *BackupConfigService) SaveBackupConfig(
 	backupConfig *BackupConfig,
+) (*BackupConfig, error) {
+	return s.saveBackupConfig(backupConfig, nil)
+}
+
+func (s *BackupConfigService) saveBackupConfig(
+	backupConfig *BackupConfig,
+	changedBy *uuid.UUID,
 ) (*BackupConfig, error) {
 	plan, err := s.databasePlanService.GetDatabasePlan(backupConfig.DatabaseID)
 	if err != nil {
@@ -104,6 +201,34 @@ func (s *BackupConfigService) SaveBackupConfig(
 	}
 
 	if existingConfig != nil {
+		backupConfig.PreBackupHook.KeepHiddenSecret(existingConfig.PreBackupHook)
+		backupConfig.PostBackupHook.KeepHiddenSecret(existingConfig.PostBackupHook)
+	}
+
+	if err := backupConfig.EncryptSensitiveData(s.fieldEncryptor); err != nil {
+		return nil, err
+	}
+
+	// autosave repeats unchanged configs, a write would still recreate the
+	// interval when it comes without its ID
+	if existingConfig != nil && backupConfig.IsSameSettings(existingConfig) {
+		return existingConfig, nil
+	}
+
+	if existingConfig == nil ||
+		len(backupConfig.DiffRetentionPolicy(existingConfig)) > 0 {
+		now := time.Now().UTC()
+		backupConfig.RetentionPolicyLockedAt = &now
+		backupConfig.RetentionPolicyChangedBy = changedBy
+	} else {
+		backupConfig.RetentionPolicyLockedAt = existingConfig.RetentionPolicyLockedAt
+		backupConfig.RetentionPolicyChangedBy = existingConfig.RetentionPolicyChangedBy
+	}
+
+	if existingConfig != nil {
+		backupConfig.LastCleanupError = existingConfig.LastCleanupError
+		backupConfig.LastCleanupErrorAt = existingConfig.LastCleanupErrorAt
+
 		// If storage is changing, notify the listener
 		if s.dbStorageChangeListener != nil &&
 			backupConfig.Storage != nil &&
@@ -128,7 +253,14 @@ func (s *BackupConfigService) GetBackupConfigByDbIdWithAuth(
 		return nil, err
 	}
 
-	return s.GetBackupConfigByDbId(databaseID)
+	config, err := s.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	config.HideSensitiveData()
+
+	return config, nil
 }
 
 func (s *BackupConfigService) GetDatabasePlan(
@@ -163,6 +295,42 @@ func (s *BackupConfigService) GetBackupConfigByDbId(
 	return config, nil
 }
 
+// GetNotificationRecipients returns the notifiers chosen in the config, or all
+// notifiers of the database when none are chosen. Notifiers which cannot be
+// loaded are skipped and reported in the error, the rest are still returned
+func (s *BackupConfigService) GetNotificationRecipients(
+	backupConfig *BackupConfig,
+	database *databases.Database,
+) ([]notifiers.Notifier, error) {
+	if len(backupConfig.NotifierIDs) == 0 {
+		return database.Notifiers, nil
+	}
+
+	recipients := make([]notifiers.Notifier, 0, len(backupConfig.NotifierIDs))
+	var lookupErrors []error
+
+	for _, notifierID := range backupConfig.NotifierIDs {
+		notifier, err := s.notifierService.GetNotifierByID(notifierID)
+		if err != nil {
+			lookupErrors = append(
+				lookupErrors,
+				fmt.Errorf("failed to get notifier %s: %w", notifierID, err),
+			)
+			continue
+		}
+
+		// the database could have been moved to another workspace after
+		// the notifiers were chosen
+		if database.WorkspaceID == nil || notifier.WorkspaceID != *database.WorkspaceID {
+			continue
+		}
+
+		recipients = append(recipients, *notifier)
+	}
+
+	return recipients, errors.Join(lookupErrors...)
+}
+
 func (s *BackupConfigService) IsStorageUsing(
 	user *users_models.User,
 	storageID uuid.UUID,
@@ -196,18 +364,303 @@ func (s *BackupConfigService) GetBackupConfigsWithEnabledBackups() ([]*BackupCon
 	return s.backupConfigRepository.GetWithEnabledBackups()
 }
 
-func (s *BackupConfigService) OnDatabaseCopied(originalDatabaseID, newDatabaseID uuid.UUID) {
-	originalConfig, err := s.GetBackupConfigByDbId(originalDatabaseID)
+// RevalidateAllConfigs validates every stored config against the current plan
+// of its database. Plans and validation rules change after configs are saved,
+// so this finds configs which would be rejected now. Nothing is modified
+func (s *BackupConfigService) RevalidateAllConfigs() ([]ConfigValidationIssue, error) {
+	backupConfigs, err := s.backupConfigRepository.FindAll()
 	if err != nil {
-		return
+		return nil, err
 	}
 
-	newConfig := originalConfig.Copy(newDatabaseID)
+	issues := []ConfigValidationIssue{}
+
+	for _, backupConfig := range backupConfigs {
+		plan, err := s.databasePlanService.FindDatabasePlan(backupConfig.DatabaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := backupConfig.Validate(plan); err != nil {
+			issues = append(issues, ConfigValidationIssue{
+				DatabaseID: backupConfig.DatabaseID,
+				Error:      err.Error(),
+			})
+		}
+	}
 
-	_, err = s.SaveBackupConfig(newConfig)
+	return issues, nil
+}
+
+// ListDatabasesNeedingBackup returns databases with enabled backups whose interval
+// is due since the last completed backup. Intervals are evaluated in Go, the
+// query only resolves the last completed backup of each database
+func (s *BackupConfigService) ListDatabasesNeedingBackup(
+	ctx context.Context,
+) ([]*DatabaseBackupTrigger, error) {
+	enabledBackupConfigs, err := s.backupConfigRepository.GetWithEnabledBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	lastBackupTimes, err := s.backupConfigRepository.GetLastSuccessfulBackupTimesForEnabled()
 	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	triggers := make([]*DatabaseBackupTrigger, 0)
+	for _, backupConfig := range enabledBackupConfigs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if backupConfig.BackupInterval == nil {
+			continue
+		}
+
+		lastBackupAt := lastBackupTimes[backupConfig.DatabaseID]
+		if !backupConfig.BackupInterval.ShouldTriggerBackup(now, lastBackupAt) {
+			continue
+		}
+
+		triggers = append(triggers, &DatabaseBackupTrigger{
+			DatabaseID:             backupConfig.DatabaseID,
+			Config:                 backupConfig,
+			LastSuccessfulBackupAt: lastBackupAt,
+		})
+	}
+
+	return triggers, nil
+}
+
+// SetLastCleanupError records the result of a retention cleanup run, nil
+// cleanupErr clears the previous error
+func (s *BackupConfigService) SetLastCleanupError(
+	databaseID uuid.UUID,
+	cleanupErr error,
+) error {
+	if cleanupErr == nil {
+		return s.backupConfigRepository.UpdateLastCleanupError(databaseID, "", nil)
+	}
+
+	now := time.Now().UTC()
+
+	return s.backupConfigRepository.UpdateLastCleanupError(databaseID, cleanupErr.Error(), &now)
+}
+
+func (s *BackupConfigService) OnDatabaseCopied(
+	originalDatabaseID, newDatabaseID uuid.UUID,
+	options databases.DatabaseCopyOptions,
+) {
+	if !options.IsCloneBackupConfig {
 		return
 	}
+
+	// the copy is already saved, so a failed clone leaves the copied database
+	// with the default config instead of failing the copy
+	_, err := s.CloneBackupConfig(originalDatabaseID, newDatabaseID, options.CopiedByUserID)
+	if err != nil {
+		s.logger.Error(
+			"Failed to clone backup config of copied database",
+			"originalDatabaseId", originalDatabaseID,
+			"newDatabaseId", newDatabaseID,
+			"error", err,
+		)
+	}
+}
+
+// CloneBackupConfig replicates the source database's backup config onto the
+// target database. The interval is copied as a new record so the two configs
+// can be edited independently afterwards
+func (s *BackupConfigService) CloneBackupConfig(
+	sourceDatabaseID, targetDatabaseID uuid.UUID,
+	clonedByUserID uuid.UUID,
+) (*BackupConfig, error) {
+	sourceConfig, err := s.GetBackupConfigByDbId(sourceDatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetDatabase, err := s.databaseService.GetDatabaseByID(targetDatabaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	clonedConfig, err := s.saveBackupConfig(sourceConfig.Copy(targetDatabaseID), &clonedByUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone backup config: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Backup config cloned for database %s from config of database %s",
+			targetDatabase.Name,
+			sourceConfig.DatabaseID,
+		),
+		&clonedByUserID,
+		targetDatabase.WorkspaceID,
+	)
+
+	return clonedConfig, nil
+}
+
+func (s *BackupConfigService) SaveWorkspaceBackupDefaults(
+	defaults *WorkspaceBackupDefaults,
+) (*WorkspaceBackupDefaults, error) {
+	return s.backupConfigRepository.SaveWorkspaceDefaults(defaults)
+}
+
+func (s *BackupConfigService) GetWorkspaceBackupDefaults(
+	workspaceID uuid.UUID,
+) (*WorkspaceBackupDefaults, error) {
+	return s.backupConfigRepository.FindWorkspaceDefaults(workspaceID)
+}
+
+// NewBackupConfigFromDefaults builds an unsaved backup config for the database from
+// the workspace template. Returns ErrWorkspaceBackupDefaultsNotFound when the
+// workspace has no template
+func (s *BackupConfigService) NewBackupConfigFromDefaults(
+	databaseID uuid.UUID,
+	workspaceID uuid.UUID,
+) (*BackupConfig, error) {
+	defaults, err := s.backupConfigRepository.FindWorkspaceDefaults(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaults == nil {
+		return nil, ErrWorkspaceBackupDefaultsNotFound
+	}
+
+	plan, err := s.databasePlanService.GetDatabasePlan(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	backupConfig := defaults.Template.Copy(databaseID)
+	if err := backupConfig.Validate(plan); err != nil {
+		return nil, fmt.Errorf("workspace backup defaults do not fit database plan: %w", err)
+	}
+
+	return backupConfig, nil
+}
+
+// SyncBackupConfigFromTemplate re-applies the workspace backup defaults to an
+// existing config of the database, so databases created from an older version
+// of the template pick up its current retention and notifications. The
+// template ID is the ID of the workspace owning the defaults
+func (s *BackupConfigService) SyncBackupConfigFromTemplate(
+	ctx context.Context,
+	databaseID, templateID uuid.UUID,
+	syncedByUserID uuid.UUID,
+) (*BackupConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	defaults, err := s.backupConfigRepository.FindWorkspaceDefaults(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaults == nil {
+		return nil, ErrWorkspaceBackupDefaultsNotFound
+	}
+
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if database.WorkspaceID == nil || *database.WorkspaceID != defaults.WorkspaceID {
+		return nil, ErrDatabaseNotInTemplateWorkspace
+	}
+
+	backupConfig, err := s.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	backupConfig.ApplyTemplate(defaults.Template)
+
+	isInCooldown, err := s.isRetentionPolicyInCooldown(backupConfig)
+	if err != nil {
+		return nil, err
+	}
+	if isInCooldown {
+		return nil, ErrRetentionPolicyChangedTooRecently
+	}
+
+	syncedConfig, err := s.saveBackupConfig(backupConfig, &syncedByUserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync backup config from template: %w", err)
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf(
+			"Backup config of database %s synced from workspace backup defaults",
+			database.Name,
+		),
+		&syncedByUserID,
+		database.WorkspaceID,
+	)
+
+	return syncedConfig, nil
+}
+
+// ExportConfig serializes the backup policy of the database to JSON which can be
+// imported into another database, possibly on another instance
+func (s *BackupConfigService) ExportConfig(databaseID uuid.UUID) ([]byte, error) {
+	backupConfig, err := s.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(backupConfig.ToPortable())
+}
+
+// ImportConfig applies an exported policy to the config of the database. Unknown
+// fields are rejected, so a file from a newer version fails instead of being
+// partially applied
+func (s *BackupConfigService) ImportConfig(
+	databaseID uuid.UUID,
+	data []byte,
+	plan *plans.DatabasePlan,
+) (*BackupConfig, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var portable PortableBackupConfig
+	if err := decoder.Decode(&portable); err != nil {
+		return nil, fmt.Errorf("invalid backup config: %w", err)
+	}
+
+	backupConfig, err := s.GetBackupConfigByDbId(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	backupConfig.ApplyPortable(&portable)
+
+	if err := backupConfig.Validate(plan); err != nil {
+		return nil, fmt.Errorf("imported backup config does not fit database plan: %w", err)
+	}
+
+	isInCooldown, err := s.isRetentionPolicyInCooldown(backupConfig)
+	if err != nil {
+		return nil, err
+	}
+	if isInCooldown {
+		return nil, ErrRetentionPolicyChangedTooRecently
+	}
+
+	return s.saveBackupConfig(backupConfig, nil)
+}
+
+func (s *BackupConfigService) DeleteBackupConfig(databaseID uuid.UUID) error {
+	return s.backupConfigRepository.DeleteByDatabaseID(databaseID)
 }
 
 func (s *BackupConfigService) CreateDisabledBackupConfig(databaseID uuid.UUID) error {
@@ -217,6 +670,23 @@ func (s *BackupConfigService) CreateDisabledBackupConfig(databaseID uuid.UUID) e
 func (s *BackupConfigService) initializeDefaultConfig(
 	databaseID uuid.UUID,
 ) error {
+	database, err := s.databaseService.GetDatabaseByID(databaseID)
+	if err != nil {
+		return err
+	}
+
+	if database.WorkspaceID != nil {
+		backupConfig, err := s.NewBackupConfigFromDefaults(databaseID, *database.WorkspaceID)
+		if err == nil {
+			_, err = s.backupConfigRepository.Save(backupConfig)
+			return err
+		}
+
+		if !errors.Is(err, ErrWorkspaceBackupDefaultsNotFound) {
+			return err
+		}
+	}
+
 	plan, err := s.databasePlanService.GetDatabasePlan(databaseID)
 	if err != nil {
 		return err
@@ -242,6 +712,8 @@ func (s *BackupConfigService) initializeDefaultConfig(
 		IsRetryIfFailed:     true,
 		MaxFailedTriesCount: 3,
 		Encryption:          BackupEncryptionNone,
+
+		MinComplianceScoreThreshold: defaultMinComplianceScoreThreshold,
 	})
 
 	return err
@@ -407,3 +879,62 @@ func storageIDsEqual(id1, id2 *uuid.UUID) bool {
 	}
 	return *id1 == *id2
 }
+
+func (s *BackupConfigService) checkRetentionPolicyCooldown(
+	user *users_models.User,
+	workspaceID uuid.UUID,
+	backupConfig *BackupConfig,
+	isForce bool,
+) error {
+	isInCooldown, err := s.isRetentionPolicyInCooldown(backupConfig)
+	if err != nil {
+		return err
+	}
+	if !isInCooldown {
+		return nil
+	}
+
+	if isForce {
+		_, role, err := s.workspaceService.CanUserAccessWorkspace(workspaceID, user)
+		if err != nil {
+			return err
+		}
+
+		if role != nil && *role == users_enums.WorkspaceRoleOwner {
+			return nil
+		}
+
+		return errors.New("only workspace owner can force retention policy change")
+	}
+
+	return ErrRetentionPolicyChangedTooRecently
+}
+
+// isRetentionPolicyInCooldown reports whether the config changes a retention
+// policy which a user set less than the cooldown ago
+func (s *BackupConfigService) isRetentionPolicyInCooldown(
+	backupConfig *BackupConfig,
+) (bool, error) {
+	existingConfig, err := s.GetBackupConfigByDbId(backupConfig.DatabaseID)
+	if err != nil {
+		return false, err
+	}
+
+	// Configs created by the system (e.g. with a new database) have no author,
+	// so the first user change is never blocked
+	if existingConfig == nil ||
+		existingConfig.RetentionPolicyLockedAt == nil ||
+		existingConfig.RetentionPolicyChangedBy == nil ||
+		len(backupConfig.DiffRetentionPolicy(existingConfig)) == 0 {
+		return false, nil
+	}
+
+	settings, err := s.backupSystemSettingsService.GetSettings()
+	if err != nil {
+		return false, err
+	}
+
+	cooldown := time.Duration(settings.RetentionPolicyCooldownSeconds) * time.Second
+
+	return time.Since(*existingConfig.RetentionPolicyLockedAt) < cooldown, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
 	"databasus-backend/internal/features/databases"
@@ -242,6 +243,40 @@ func Test_TransferStorageWithAttachedDatabase_CannotTransfer(t *testing.T) {
 	)
 }
 
+func Test_FindConfigsByStorageID_WhenTwoConfigsUseSameStorage_ReturnsBothConfigs(t *testing.T) {
+	router := createTestRouterWithStorage()
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace := workspaces_testing.CreateTestWorkspace("Test Workspace", owner, router)
+
+	database1 := createTestDatabaseViaAPI("Test Database 1", workspace.ID, owner.Token, router)
+	database2 := createTestDatabaseViaAPI("Test Database 2", workspace.ID, owner.Token, router)
+	sharedStorage := createTestStorage(workspace.ID)
+	otherStorage := createTestStorage(workspace.ID)
+
+	defer func() {
+		databases.RemoveTestDatabase(database1)
+		databases.RemoveTestDatabase(database2)
+		storages.RemoveTestStorage(sharedStorage.ID)
+		storages.RemoveTestStorage(otherStorage.ID)
+		workspaces_testing.RemoveTestWorkspace(workspace, router)
+	}()
+
+	EnableBackupsForTestDatabase(database1.ID, sharedStorage)
+	EnableBackupsForTestDatabase(database2.ID, sharedStorage)
+
+	configs, err := GetBackupConfigService().FindConfigsByStorageID(sharedStorage.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(configs))
+
+	databaseIDs := []uuid.UUID{configs[0].DatabaseID, configs[1].DatabaseID}
+	assert.Contains(t, databaseIDs, database1.ID)
+	assert.Contains(t, databaseIDs, database2.ID)
+
+	configs, err = GetBackupConfigService().FindConfigsByStorageID(otherStorage.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, configs)
+}
+
 func createTestRouterWithStorage() *gin.Engine {
 	router := workspaces_testing.CreateTestRouter(
 		workspaces_controllers.GetWorkspaceController(),
@@ -0,0 +1,67 @@
+package backups_config
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkspaceBackupDefaults is a backup config template applied to new databases
+// of the workspace, so all of them get the same retention and schedule
+type WorkspaceBackupDefaults struct {
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;primaryKey;not null"`
+
+	Template       *BackupConfig `json:"template" gorm:"-"`
+	TemplateString string        `json:"-"        gorm:"column:template;type:jsonb;not null"`
+}
+
+func (d *WorkspaceBackupDefaults) TableName() string {
+	return "workspace_backup_defaults"
+}
+
+func (d *WorkspaceBackupDefaults) BeforeSave(tx *gorm.DB) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+
+	// template is not bound to any database, interval or storage object
+	template := d.Template.Copy(uuid.Nil)
+
+	templateBytes, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	d.TemplateString = string(templateBytes)
+
+	return nil
+}
+
+func (d *WorkspaceBackupDefaults) AfterFind(tx *gorm.DB) error {
+	var template BackupConfig
+	if err := json.Unmarshal([]byte(d.TemplateString), &template); err != nil {
+		return err
+	}
+
+	d.Template = &template
+
+	return nil
+}
+
+func (d *WorkspaceBackupDefaults) Validate() error {
+	if d.WorkspaceID == uuid.Nil {
+		return errors.New("workspace ID is required")
+	}
+
+	if d.Template == nil {
+		return errors.New("template is required")
+	}
+
+	if d.Template.BackupInterval == nil {
+		return errors.New("template backup interval is required")
+	}
+
+	return d.Template.BackupInterval.Validate()
+}
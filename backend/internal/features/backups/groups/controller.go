@@ -0,0 +1,117 @@
+package backups_groups
+
+import (
+	"net/http"
+
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BackupGroupController struct {
+	backupGroupService *BackupGroupService
+}
+
+func (c *BackupGroupController) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/backup-groups", c.CreateBackupGroup)
+	router.GET("/backup-groups/:id", c.GetBackupGroup)
+	router.POST("/backup-groups/:id/backup", c.TriggerGroupBackup)
+}
+
+// CreateBackupGroup
+// @Summary Create a backup group
+// @Description Create a group of databases of one workspace that are backed up together
+// @Tags backup-groups
+// @Accept json
+// @Produce json
+// @Param request body CreateBackupGroupRequest true "Backup group data"
+// @Success 200 {object} BackupGroup
+// @Failure 400
+// @Failure 401
+// @Router /backup-groups [post]
+func (c *BackupGroupController) CreateBackupGroup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request CreateBackupGroupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := c.backupGroupService.CreateBackupGroupWithAuth(user, &request)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, group)
+}
+
+// GetBackupGroup
+// @Summary Get a backup group
+// @Description Get backup group metadata with status of the last group backup of each database
+// @Tags backup-groups
+// @Produce json
+// @Param id path string true "Backup group ID"
+// @Success 200 {object} GetBackupGroupResponse
+// @Failure 400
+// @Failure 401
+// @Router /backup-groups/{id} [get]
+func (c *BackupGroupController) GetBackupGroup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	groupID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup group ID"})
+		return
+	}
+
+	response, err := c.backupGroupService.GetBackupGroupWithAuth(user, groupID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// TriggerGroupBackup
+// @Summary Back up a backup group
+// @Description Start backups of all databases of the backup group
+// @Tags backup-groups
+// @Produce json
+// @Param id path string true "Backup group ID"
+// @Success 200 {object} TriggerGroupBackupResponse
+// @Failure 400
+// @Failure 401
+// @Router /backup-groups/{id}/backup [post]
+func (c *BackupGroupController) TriggerGroupBackup(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	groupID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup group ID"})
+		return
+	}
+
+	response, err := c.backupGroupService.TriggerGroupBackupWithAuth(user, groupID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
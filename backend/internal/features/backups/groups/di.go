@@ -0,0 +1,34 @@
+package backups_groups
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+	"databasus-backend/internal/util/logger"
+)
+
+var backupGroupRepository = &BackupGroupRepository{}
+
+var backupGroupService = &BackupGroupService{
+	backupGroupRepository,
+	&backups_core.BackupRepository{},
+	databases.GetDatabaseService(),
+	workspaces_services.GetWorkspaceService(),
+	audit_logs.GetAuditLogService(),
+	backuping.GetBackupsScheduler(),
+	logger.GetLogger(),
+}
+
+var backupGroupController = &BackupGroupController{
+	backupGroupService,
+}
+
+func GetBackupGroupService() *BackupGroupService {
+	return backupGroupService
+}
+
+func GetBackupGroupController() *BackupGroupController {
+	return backupGroupController
+}
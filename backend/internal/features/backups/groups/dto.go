@@ -0,0 +1,31 @@
+package backups_groups
+
+import (
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+
+	"github.com/google/uuid"
+)
+
+type CreateBackupGroupRequest struct {
+	Name        string      `json:"name"        binding:"required"`
+	WorkspaceID uuid.UUID   `json:"workspaceId" binding:"required"`
+	DatabaseIDs []uuid.UUID `json:"databaseIds" binding:"required"`
+}
+
+type BackupGroupDatabaseStatus struct {
+	DatabaseID uuid.UUID `json:"databaseId"`
+	// BackupID and Status are nil when the group backup was not started yet
+	BackupID *uuid.UUID                 `json:"backupId"`
+	Status   *backups_core.BackupStatus `json:"status"`
+}
+
+// TriggerGroupBackupResponse lists group databases whose backups were not
+// started, e.g. because the database was removed after the group was created
+type TriggerGroupBackupResponse struct {
+	SkippedDatabaseIDs []uuid.UUID `json:"skippedDatabaseIds"`
+}
+
+type GetBackupGroupResponse struct {
+	Group     *BackupGroup                `json:"group"`
+	Databases []BackupGroupDatabaseStatus `json:"databases"`
+}
@@ -0,0 +1,52 @@
+package backups_groups
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BackupGroup links databases that should be backed up together, for example
+// databases of microservices sharing one deployment
+type BackupGroup struct {
+	ID          uuid.UUID `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	Name        string    `json:"name"        gorm:"column:name;type:text;not null"`
+	WorkspaceID uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+
+	DatabaseIDs       []uuid.UUID `json:"databaseIds" gorm:"-"`
+	DatabaseIDsString string      `json:"-"           gorm:"column:database_ids;type:jsonb;not null"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"column:created_at"`
+}
+
+func (g *BackupGroup) TableName() string {
+	return "backup_groups"
+}
+
+func (g *BackupGroup) BeforeSave(tx *gorm.DB) error {
+	databaseIDs := g.DatabaseIDs
+	if databaseIDs == nil {
+		databaseIDs = []uuid.UUID{}
+	}
+
+	databaseIDsBytes, err := json.Marshal(databaseIDs)
+	if err != nil {
+		return err
+	}
+
+	g.DatabaseIDsString = string(databaseIDsBytes)
+
+	return nil
+}
+
+func (g *BackupGroup) AfterFind(tx *gorm.DB) error {
+	g.DatabaseIDs = []uuid.UUID{}
+
+	if g.DatabaseIDsString == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(g.DatabaseIDsString), &g.DatabaseIDs)
+}
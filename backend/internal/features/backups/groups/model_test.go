@@ -0,0 +1,31 @@
+package backups_groups
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BackupGroupDatabaseIDs_WhenSavedAndLoaded_KeepOrder(t *testing.T) {
+	databaseIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	group := &BackupGroup{DatabaseIDs: databaseIDs}
+
+	err := group.BeforeSave(nil)
+	assert.NoError(t, err)
+
+	loadedGroup := &BackupGroup{DatabaseIDsString: group.DatabaseIDsString}
+	err = loadedGroup.AfterFind(nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, databaseIDs, loadedGroup.DatabaseIDs)
+}
+
+func Test_BackupGroupDatabaseIDs_WhenNil_SavedAsEmptyArray(t *testing.T) {
+	group := &BackupGroup{}
+
+	err := group.BeforeSave(nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "[]", group.DatabaseIDsString)
+}
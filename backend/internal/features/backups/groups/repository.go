@@ -0,0 +1,42 @@
+package backups_groups
+
+import (
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BackupGroupRepository struct{}
+
+func (r *BackupGroupRepository) Save(group *BackupGroup) error {
+	db := storage.GetDb()
+
+	if group.ID == uuid.Nil {
+		group.ID = uuid.New()
+		return db.Create(group).Error
+	}
+
+	return db.Save(group).Error
+}
+
+func (r *BackupGroupRepository) FindByID(id uuid.UUID) (*BackupGroup, error) {
+	var group BackupGroup
+
+	if err := storage.
+		GetDb().
+		Where("id = ?", id).
+		First(&group).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+func (r *BackupGroupRepository) DeleteByID(id uuid.UUID) error {
+	return storage.GetDb().Delete(&BackupGroup{}, "id = ?", id).Error
+}
@@ -0,0 +1,235 @@
+package backups_groups
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/features/backups/backups/backuping"
+	backups_core "databasus-backend/internal/features/backups/backups/core"
+	"databasus-backend/internal/features/databases"
+	users_models "databasus-backend/internal/features/users/models"
+	workspaces_services "databasus-backend/internal/features/workspaces/services"
+
+	"github.com/google/uuid"
+)
+
+// groupBackupStartWindow is the time in which backups of all group databases
+// are expected to be started, so they capture close points in time
+const groupBackupStartWindow = 5 * time.Second
+
+type BackupGroupService struct {
+	backupGroupRepository *BackupGroupRepository
+	backupRepository      *backups_core.BackupRepository
+	databaseService       *databases.DatabaseService
+	workspaceService      *workspaces_services.WorkspaceService
+	auditLogService       *audit_logs.AuditLogService
+	backupsScheduler      *backuping.BackupsScheduler
+	logger                *slog.Logger
+}
+
+func (s *BackupGroupService) CreateBackupGroupWithAuth(
+	user *users_models.User,
+	request *CreateBackupGroupRequest,
+) (*BackupGroup, error) {
+	canManage, err := s.workspaceService.CanUserManageDBs(request.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return nil, errors.New("insufficient permissions to create backup group in this workspace")
+	}
+
+	group, err := s.CreateBackupGroup(request.Name, request.DatabaseIDs, request.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup group created: %s", group.Name),
+		&user.ID,
+		&group.WorkspaceID,
+	)
+
+	return group, nil
+}
+
+func (s *BackupGroupService) CreateBackupGroup(
+	name string,
+	databaseIDs []uuid.UUID,
+	workspaceID uuid.UUID,
+) (*BackupGroup, error) {
+	if name == "" {
+		return nil, errors.New("backup group name is required")
+	}
+
+	if len(databaseIDs) == 0 {
+		return nil, errors.New("backup group must contain at least one database")
+	}
+
+	seenDatabaseIDs := make(map[uuid.UUID]bool, len(databaseIDs))
+	for _, databaseID := range databaseIDs {
+		if seenDatabaseIDs[databaseID] {
+			return nil, fmt.Errorf("database %s is listed more than once", databaseID)
+		}
+		seenDatabaseIDs[databaseID] = true
+
+		database, err := s.databaseService.GetDatabaseByID(databaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		if database.WorkspaceID == nil || *database.WorkspaceID != workspaceID {
+			return nil, fmt.Errorf(
+				"database %s does not belong to the backup group workspace",
+				databaseID,
+			)
+		}
+	}
+
+	group := &BackupGroup{
+		Name:        name,
+		WorkspaceID: workspaceID,
+		DatabaseIDs: databaseIDs,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	if err := s.backupGroupRepository.Save(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *BackupGroupService) GetBackupGroupWithAuth(
+	user *users_models.User,
+	groupID uuid.UUID,
+) (*GetBackupGroupResponse, error) {
+	group, err := s.getGroupWithAccessCheck(user, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBackupGroupResponse{
+		Group:     group,
+		Databases: make([]BackupGroupDatabaseStatus, 0, len(group.DatabaseIDs)),
+	}
+
+	for _, databaseID := range group.DatabaseIDs {
+		databaseStatus := BackupGroupDatabaseStatus{DatabaseID: databaseID}
+
+		backup, err := s.backupRepository.FindLastByDatabaseIDAndGroupID(databaseID, group.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if backup != nil {
+			databaseStatus.BackupID = &backup.ID
+			databaseStatus.Status = &backup.Status
+		}
+
+		response.Databases = append(response.Databases, databaseStatus)
+	}
+
+	return response, nil
+}
+
+func (s *BackupGroupService) TriggerGroupBackupWithAuth(
+	user *users_models.User,
+	groupID uuid.UUID,
+) (*TriggerGroupBackupResponse, error) {
+	group, err := s.getGroupWithAccessCheck(user, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.TriggerGroupBackup(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup manually initiated for backup group: %s", group.Name),
+		&user.ID,
+		&group.WorkspaceID,
+	)
+
+	return response, nil
+}
+
+// TriggerGroupBackup starts backups of all group databases one after another.
+// Databases removed after the group was created are skipped and reported. While
+// backup creation is paused nothing is started, so the group is not backed up
+// partially
+func (s *BackupGroupService) TriggerGroupBackup(
+	groupID uuid.UUID,
+) (*TriggerGroupBackupResponse, error) {
+	group, err := s.backupGroupRepository.FindByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if group == nil {
+		return nil, errors.New("backup group not found")
+	}
+
+	if err := s.backupsScheduler.CheckBackupCreationAllowed(); err != nil {
+		return nil, err
+	}
+
+	response := &TriggerGroupBackupResponse{SkippedDatabaseIDs: []uuid.UUID{}}
+	startedAt := time.Now().UTC()
+
+	for _, databaseID := range group.DatabaseIDs {
+		database, err := s.databaseService.GetDatabaseByID(databaseID)
+		if err != nil {
+			s.logger.Error(
+				"Failed to get backup group database, skipping it",
+				"groupId", group.ID,
+				"databaseId", databaseID,
+				"error", err,
+			)
+			response.SkippedDatabaseIDs = append(response.SkippedDatabaseIDs, databaseID)
+			continue
+		}
+
+		s.backupsScheduler.StartGroupBackup(database, group.ID)
+	}
+
+	if startDuration := time.Since(startedAt); startDuration > groupBackupStartWindow {
+		s.logger.Warn(
+			"Backups of the group were not started within the expected window",
+			"groupId", group.ID,
+			"startDuration", startDuration,
+			"window", groupBackupStartWindow,
+		)
+	}
+
+	return response, nil
+}
+
+func (s *BackupGroupService) getGroupWithAccessCheck(
+	user *users_models.User,
+	groupID uuid.UUID,
+) (*BackupGroup, error) {
+	group, err := s.backupGroupRepository.FindByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if group == nil {
+		return nil, errors.New("backup group not found")
+	}
+
+	canAccess, _, err := s.workspaceService.CanUserAccessWorkspace(group.WorkspaceID, user)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, errors.New("insufficient permissions to access this backup group")
+	}
+
+	return group, nil
+}
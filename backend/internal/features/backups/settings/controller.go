@@ -0,0 +1,113 @@
+package backups_settings
+
+import (
+	"net/http"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_middleware "databasus-backend/internal/features/users/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BackupSystemSettingsController struct {
+	settingsService *BackupSystemSettingsService
+}
+
+func (c *BackupSystemSettingsController) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET(
+		"/backup-system-settings",
+		users_middleware.RequireRole(users_enums.UserRoleAdmin),
+		c.GetSettings,
+	)
+	router.POST(
+		"/backup-system-settings/pause",
+		users_middleware.RequireRole(users_enums.UserRoleAdmin),
+		c.PauseAll,
+	)
+	router.POST(
+		"/backup-system-settings/resume",
+		users_middleware.RequireRole(users_enums.UserRoleAdmin),
+		c.ResumeAll,
+	)
+}
+
+// GetSettings
+// @Summary Get backup system settings
+// @Description Get system-wide backup switches (admin only)
+// @Tags backup-system-settings
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} BackupSystemSettings
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /backup-system-settings [get]
+func (c *BackupSystemSettingsController) GetSettings(ctx *gin.Context) {
+	settings, err := c.settingsService.GetSettings()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// PauseAll
+// @Summary Pause backup creation system-wide
+// @Description Stop creation of new backups for all databases, for example during a storage incident. Backups in progress are not cancelled and old backups are still cleaned up (admin only)
+// @Tags backup-system-settings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body PauseAllRequest true "Pause reason"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /backup-system-settings/pause [post]
+func (c *BackupSystemSettingsController) PauseAll(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	var request PauseAllRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.settingsService.PauseAll(request.Reason, user.ID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "backup creation paused successfully"})
+}
+
+// ResumeAll
+// @Summary Resume backup creation system-wide
+// @Description Resume creation of backups paused by the pause endpoint (admin only)
+// @Tags backup-system-settings
+// @Produce json
+// @Security BearerAuth
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Router /backup-system-settings/resume [post]
+func (c *BackupSystemSettingsController) ResumeAll(ctx *gin.Context) {
+	user, ok := users_middleware.GetUserFromContext(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+
+	if err := c.settingsService.ResumeAll(user.ID); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "backup creation resumed successfully"})
+}
@@ -0,0 +1,74 @@
+package backups_settings
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+	test_utils "databasus-backend/internal/util/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PauseAll_WhenUserIsAdmin_PausesAndResumesBackupCreation(t *testing.T) {
+	router := workspaces_testing.CreateTestRouter(GetBackupSystemSettingsController())
+	admin := users_testing.CreateTestUser(users_enums.UserRoleAdmin)
+
+	defer func() {
+		_ = GetBackupSystemSettingsService().ResumeAll(admin.UserID)
+	}()
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-system-settings/pause",
+		"Bearer "+admin.Token,
+		PauseAllRequest{Reason: "storage incident"},
+		http.StatusOK,
+	)
+
+	var settings BackupSystemSettings
+	test_utils.MakeGetRequestAndUnmarshal(
+		t,
+		router,
+		"/api/v1/backup-system-settings",
+		"Bearer "+admin.Token,
+		http.StatusOK,
+		&settings,
+	)
+	assert.False(t, settings.IsGlobalBackupCreationEnabled)
+	assert.Equal(t, "storage incident", settings.GlobalPauseReason)
+
+	err := GetBackupSystemSettingsService().CheckBackupCreationAllowed()
+	assert.True(t, errors.Is(err, ErrGlobalBackupCreationPaused))
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-system-settings/resume",
+		"Bearer "+admin.Token,
+		nil,
+		http.StatusOK,
+	)
+
+	assert.NoError(t, GetBackupSystemSettingsService().CheckBackupCreationAllowed())
+}
+
+func Test_PauseAll_WhenUserIsMember_ReturnsForbidden(t *testing.T) {
+	router := workspaces_testing.CreateTestRouter(GetBackupSystemSettingsController())
+	member := users_testing.CreateTestUser(users_enums.UserRoleMember)
+
+	test_utils.MakePostRequest(
+		t,
+		router,
+		"/api/v1/backup-system-settings/pause",
+		"Bearer "+member.Token,
+		PauseAllRequest{Reason: "storage incident"},
+		http.StatusForbidden,
+	)
+
+	assert.NoError(t, GetBackupSystemSettingsService().CheckBackupCreationAllowed())
+}
@@ -0,0 +1,24 @@
+package backups_settings
+
+import (
+	audit_logs "databasus-backend/internal/features/audit_logs"
+	"databasus-backend/internal/util/logger"
+)
+
+var backupSystemSettingsService = &BackupSystemSettingsService{
+	&BackupSystemSettingsRepository{},
+	audit_logs.GetAuditLogService(),
+	logger.GetLogger(),
+}
+
+var backupSystemSettingsController = &BackupSystemSettingsController{
+	backupSystemSettingsService,
+}
+
+func GetBackupSystemSettingsService() *BackupSystemSettingsService {
+	return backupSystemSettingsService
+}
+
+func GetBackupSystemSettingsController() *BackupSystemSettingsController {
+	return backupSystemSettingsController
+}
@@ -0,0 +1,5 @@
+package backups_settings
+
+type PauseAllRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
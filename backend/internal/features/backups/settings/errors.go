@@ -0,0 +1,5 @@
+package backups_settings
+
+import "errors"
+
+var ErrGlobalBackupCreationPaused = errors.New("backup creation is paused system-wide")
@@ -0,0 +1,27 @@
+package backups_settings
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupSystemSettings is a single row of system wide backup switches. They are
+// meant for incidents, when backups of all databases have to be stopped without
+// changing configs of every database
+type BackupSystemSettings struct {
+	ID uuid.UUID `json:"id" gorm:"column:id;type:uuid;primaryKey"`
+
+	IsGlobalBackupCreationEnabled bool       `json:"isGlobalBackupCreationEnabled" gorm:"column:is_global_backup_creation_enabled;not null"`
+	GlobalPauseReason             string     `json:"globalPauseReason"             gorm:"column:global_pause_reason;type:text;not null"`
+	UpdatedBy                     *uuid.UUID `json:"updatedBy"                     gorm:"column:updated_by;type:uuid"`
+	UpdatedAt                     time.Time  `json:"updatedAt"                     gorm:"column:updated_at;not null"`
+
+	// RetentionPolicyCooldownSeconds is the minimal time between two user changes
+	// of the retention policy of a database. Zero disables the cooldown
+	RetentionPolicyCooldownSeconds int `json:"retentionPolicyCooldownSeconds" gorm:"column:retention_policy_cooldown_seconds;not null"`
+}
+
+func (s *BackupSystemSettings) TableName() string {
+	return "backup_system_settings"
+}
@@ -0,0 +1,51 @@
+package backups_settings
+
+import (
+	"errors"
+	"time"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const defaultRetentionPolicyCooldownSeconds = 3600
+
+type BackupSystemSettingsRepository struct{}
+
+func (r *BackupSystemSettingsRepository) GetSettings() (*BackupSystemSettings, error) {
+	var settings BackupSystemSettings
+
+	if err := storage.GetDb().First(&settings).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+
+		defaultSettings := &BackupSystemSettings{
+			ID:                             uuid.New(),
+			IsGlobalBackupCreationEnabled:  true,
+			RetentionPolicyCooldownSeconds: defaultRetentionPolicyCooldownSeconds,
+			UpdatedAt:                      time.Now().UTC(),
+		}
+
+		if err := storage.GetDb().Create(defaultSettings).Error; err != nil {
+			return nil, err
+		}
+
+		return defaultSettings, nil
+	}
+
+	return &settings, nil
+}
+
+func (r *BackupSystemSettingsRepository) UpdateSettings(settings *BackupSystemSettings) error {
+	existingSettings, err := r.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	settings.ID = existingSettings.ID
+
+	return storage.GetDb().Save(settings).Error
+}
@@ -0,0 +1,91 @@
+package backups_settings
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	audit_logs "databasus-backend/internal/features/audit_logs"
+
+	"github.com/google/uuid"
+)
+
+type BackupSystemSettingsService struct {
+	settingsRepository *BackupSystemSettingsRepository
+	auditLogService    *audit_logs.AuditLogService
+	logger             *slog.Logger
+}
+
+func (s *BackupSystemSettingsService) GetSettings() (*BackupSystemSettings, error) {
+	return s.settingsRepository.GetSettings()
+}
+
+// CheckBackupCreationAllowed returns ErrGlobalBackupCreationPaused with the
+// pause reason while backup creation is paused
+func (s *BackupSystemSettingsService) CheckBackupCreationAllowed() error {
+	settings, err := s.settingsRepository.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	if !settings.IsGlobalBackupCreationEnabled {
+		return fmt.Errorf("%w: %s", ErrGlobalBackupCreationPaused, settings.GlobalPauseReason)
+	}
+
+	return nil
+}
+
+// PauseAll stops creation of new backups for all databases. Backups in progress
+// are not cancelled and the cleaner keeps running
+func (s *BackupSystemSettingsService) PauseAll(reason string, adminID uuid.UUID) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("pause reason is required")
+	}
+
+	settings, err := s.settingsRepository.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	settings.IsGlobalBackupCreationEnabled = false
+	settings.GlobalPauseReason = reason
+	settings.UpdatedBy = &adminID
+	settings.UpdatedAt = time.Now().UTC()
+
+	if err := s.settingsRepository.UpdateSettings(settings); err != nil {
+		return err
+	}
+
+	s.logger.Warn("Backup creation paused system-wide", "reason", reason, "adminId", adminID)
+	s.auditLogService.WriteAuditLog(
+		fmt.Sprintf("Backup creation paused system-wide: %s", reason),
+		&adminID,
+		nil,
+	)
+
+	return nil
+}
+
+func (s *BackupSystemSettingsService) ResumeAll(adminID uuid.UUID) error {
+	settings, err := s.settingsRepository.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	settings.IsGlobalBackupCreationEnabled = true
+	settings.GlobalPauseReason = ""
+	settings.UpdatedBy = &adminID
+	settings.UpdatedAt = time.Now().UTC()
+
+	if err := s.settingsRepository.UpdateSettings(settings); err != nil {
+		return err
+	}
+
+	s.logger.Info("Backup creation resumed system-wide", "adminId", adminID)
+	s.auditLogService.WriteAuditLog("Backup creation resumed system-wide", &adminID, nil)
+
+	return nil
+}
@@ -339,6 +339,7 @@ func (c *DatabaseController) CountDatabasesByNotifier(ctx *gin.Context) {
 // @Tags databases
 // @Produce json
 // @Param id path string true "Database ID"
+// @Param clone_backup_config query bool false "Clone the backup config too (default true)"
 // @Success 201 {object} Database
 // @Failure 400
 // @Failure 401
@@ -357,7 +358,11 @@ func (c *DatabaseController) CopyDatabase(ctx *gin.Context) {
 		return
 	}
 
-	copiedDatabase, err := c.databaseService.CopyDatabase(user, id)
+	options := DatabaseCopyOptions{
+		IsCloneBackupConfig: ctx.DefaultQuery("clone_backup_config", "true") != "false",
+	}
+
+	copiedDatabase, err := c.databaseService.CopyDatabase(user, id, options)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
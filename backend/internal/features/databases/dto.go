@@ -1,5 +1,14 @@
 package databases
 
+import "github.com/google/uuid"
+
+// DatabaseCopyOptions tells copy listeners who copied the database and which
+// settings of other features should be copied along with it
+type DatabaseCopyOptions struct {
+	CopiedByUserID      uuid.UUID
+	IsCloneBackupConfig bool
+}
+
 type CreateReadOnlyUserResponse struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
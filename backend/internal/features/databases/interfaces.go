@@ -30,5 +30,5 @@ type DatabaseRemoveListener interface {
 }
 
 type DatabaseCopyListener interface {
-	OnDatabaseCopied(originalDatabaseID, newDatabaseID uuid.UUID)
+	OnDatabaseCopied(originalDatabaseID, newDatabaseID uuid.UUID, options DatabaseCopyOptions)
 }
@@ -412,6 +412,10 @@ func (s *DatabaseService) GetDatabaseByID(
 	return s.dbRepository.FindByID(id)
 }
 
+func (s *DatabaseService) GetDatabasesByWorkspaceID(workspaceID uuid.UUID) ([]*Database, error) {
+	return s.dbRepository.FindByWorkspaceID(workspaceID)
+}
+
 func (s *DatabaseService) GetAllDatabases() ([]*Database, error) {
 	return s.dbRepository.GetAllDatabases()
 }
@@ -450,6 +454,7 @@ func (s *DatabaseService) SetLastBackupTime(databaseID uuid.UUID, backupTime tim
 func (s *DatabaseService) CopyDatabase(
 	user *users_models.User,
 	databaseID uuid.UUID,
+	options DatabaseCopyOptions,
 ) (*Database, error) {
 	existingDatabase, err := s.dbRepository.FindByID(databaseID)
 	if err != nil {
@@ -555,8 +560,9 @@ func (s *DatabaseService) CopyDatabase(
 		listener.OnDatabaseCreated(copiedDatabase.ID)
 	}
 
+	options.CopiedByUserID = user.ID
 	for _, listener := range s.dbCopyListener {
-		listener.OnDatabaseCopied(databaseID, copiedDatabase.ID)
+		listener.OnDatabaseCopied(databaseID, copiedDatabase.ID, options)
 	}
 
 	s.auditLogService.WriteAuditLog(
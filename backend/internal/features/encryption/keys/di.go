@@ -0,0 +1,18 @@
+package encryption_keys
+
+import (
+	"databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/encryption"
+)
+
+var workspaceEncryptionKeyRepository = &WorkspaceEncryptionKeyRepository{}
+
+var workspaceEncryptionKeyService = &WorkspaceEncryptionKeyService{
+	workspaceEncryptionKeyRepository,
+	secrets.GetSecretKeyService(),
+	encryption.GetFieldEncryptor(),
+}
+
+func GetWorkspaceEncryptionKeyService() *WorkspaceEncryptionKeyService {
+	return workspaceEncryptionKeyService
+}
@@ -0,0 +1,21 @@
+package encryption_keys
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceEncryptionKey is a backup encryption key owned by a workspace. The
+// key material is stored encrypted with the field encryptor
+type WorkspaceEncryptionKey struct {
+	ID           uuid.UUID `json:"id"          gorm:"column:id;type:uuid;primaryKey"`
+	WorkspaceID  uuid.UUID `json:"workspaceId" gorm:"column:workspace_id;type:uuid;not null"`
+	Name         string    `json:"name"        gorm:"column:name;type:text;not null"`
+	EncryptedKey string    `json:"-"           gorm:"column:encrypted_key;type:text;not null"`
+	CreatedAt    time.Time `json:"createdAt"   gorm:"column:created_at"`
+}
+
+func (k *WorkspaceEncryptionKey) TableName() string {
+	return "workspace_encryption_keys"
+}
@@ -0,0 +1,33 @@
+package encryption_keys
+
+import (
+	"errors"
+
+	"databasus-backend/internal/storage"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WorkspaceEncryptionKeyRepository struct{}
+
+func (r *WorkspaceEncryptionKeyRepository) Save(key *WorkspaceEncryptionKey) error {
+	return storage.GetDb().Save(key).Error
+}
+
+func (r *WorkspaceEncryptionKeyRepository) FindByID(
+	id uuid.UUID,
+) (*WorkspaceEncryptionKey, error) {
+	var key WorkspaceEncryptionKey
+
+	err := storage.GetDb().Where("id = ?", id).First(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,91 @@
+package encryption_keys
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"databasus-backend/internal/features/encryption/secrets"
+	"databasus-backend/internal/util/encryption"
+
+	"github.com/google/uuid"
+)
+
+const keyMaterialBytes = 32
+
+type WorkspaceEncryptionKeyService struct {
+	keyRepository    *WorkspaceEncryptionKeyRepository
+	secretKeyService *secrets.SecretKeyService
+	fieldEncryptor   encryption.FieldEncryptor
+}
+
+func (s *WorkspaceEncryptionKeyService) CreateKey(
+	workspaceID uuid.UUID,
+	name string,
+) (*WorkspaceEncryptionKey, error) {
+	if name == "" {
+		return nil, errors.New("encryption key name is required")
+	}
+
+	keyMaterial := make([]byte, keyMaterialBytes)
+	if _, err := rand.Read(keyMaterial); err != nil {
+		return nil, fmt.Errorf("failed to generate encryption key: %w", err)
+	}
+
+	key := &WorkspaceEncryptionKey{
+		ID:          uuid.New(),
+		WorkspaceID: workspaceID,
+		Name:        name,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	encryptedKey, err := s.fieldEncryptor.Encrypt(key.ID, hex.EncodeToString(keyMaterial))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt encryption key: %w", err)
+	}
+	key.EncryptedKey = encryptedKey
+
+	if err := s.keyRepository.Save(key); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s *WorkspaceEncryptionKeyService) ValidateKeyBelongsToWorkspace(
+	keyID uuid.UUID,
+	workspaceID uuid.UUID,
+) error {
+	key, err := s.keyRepository.FindByID(keyID)
+	if err != nil {
+		return err
+	}
+
+	if key == nil || key.WorkspaceID != workspaceID {
+		return errors.New("encryption key does not belong to the database workspace")
+	}
+
+	return nil
+}
+
+// GetBackupKey returns the key material backups are encrypted with. Backups
+// without a workspace key were encrypted with the instance master key, so nil
+// keeps them readable
+func (s *WorkspaceEncryptionKeyService) GetBackupKey(keyID *uuid.UUID) (string, error) {
+	if keyID == nil {
+		return s.secretKeyService.GetSecretKey()
+	}
+
+	key, err := s.keyRepository.FindByID(*keyID)
+	if err != nil {
+		return "", err
+	}
+
+	if key == nil {
+		return "", fmt.Errorf("encryption key %s not found", *keyID)
+	}
+
+	return s.fieldEncryptor.Decrypt(key.ID, key.EncryptedKey)
+}
@@ -0,0 +1,65 @@
+package encryption_keys
+
+import (
+	"testing"
+
+	users_enums "databasus-backend/internal/features/users/enums"
+	users_testing "databasus-backend/internal/features/users/testing"
+	workspaces_testing "databasus-backend/internal/features/workspaces/testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetBackupKey_WhenKeyIDIsSet_ReturnsDecryptedWorkspaceKey(t *testing.T) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Test Workspace", owner.UserID)
+	assert.NoError(t, err)
+
+	defer func() {
+		_ = workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+	}()
+
+	service := GetWorkspaceEncryptionKeyService()
+
+	key, err := service.CreateKey(workspace.ID, "Production key")
+	assert.NoError(t, err)
+
+	workspaceKey, err := service.GetBackupKey(&key.ID)
+	assert.NoError(t, err)
+	assert.Len(t, workspaceKey, keyMaterialBytes*2)
+	assert.NotEqual(t, key.EncryptedKey, workspaceKey)
+
+	masterKey, err := service.GetBackupKey(nil)
+	assert.NoError(t, err)
+	assert.NotEqual(t, masterKey, workspaceKey)
+
+	expectedMasterKey, err := service.secretKeyService.GetSecretKey()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMasterKey, masterKey)
+}
+
+func Test_ValidateKeyBelongsToWorkspace_WhenKeyIsFromAnotherWorkspace_ValidationFails(
+	t *testing.T,
+) {
+	owner := users_testing.CreateTestUser(users_enums.UserRoleMember)
+	workspace, err := workspaces_testing.CreateTestWorkspaceDirect("Workspace", owner.UserID)
+	assert.NoError(t, err)
+	otherWorkspace, err := workspaces_testing.CreateTestWorkspaceDirect("Other", owner.UserID)
+	assert.NoError(t, err)
+
+	defer func() {
+		_ = workspaces_testing.RemoveTestWorkspaceDirect(workspace.ID)
+		_ = workspaces_testing.RemoveTestWorkspaceDirect(otherWorkspace.ID)
+	}()
+
+	service := GetWorkspaceEncryptionKeyService()
+
+	foreignKey, err := service.CreateKey(otherWorkspace.ID, "Foreign key")
+	assert.NoError(t, err)
+
+	err = service.ValidateKeyBelongsToWorkspace(foreignKey.ID, workspace.ID)
+	assert.EqualError(t, err, "encryption key does not belong to the database workspace")
+
+	err = service.ValidateKeyBelongsToWorkspace(foreignKey.ID, otherWorkspace.ID)
+	assert.NoError(t, err)
+}
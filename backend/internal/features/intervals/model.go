@@ -2,6 +2,7 @@ package intervals
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -79,14 +80,80 @@ func (i *Interval) ShouldTriggerBackup(now time.Time, lastBackupTime *time.Time)
 	}
 }
 
+// Copy returns an unsaved interval which shares no pointers with the original,
+// so editing the copy of a cloned config does not change the source config
 func (i *Interval) Copy() *Interval {
+	if i == nil {
+		return nil
+	}
+
 	return &Interval{
 		ID:             uuid.Nil,
 		Interval:       i.Interval,
-		TimeOfDay:      i.TimeOfDay,
-		Weekday:        i.Weekday,
-		DayOfMonth:     i.DayOfMonth,
-		CronExpression: i.CronExpression,
+		TimeOfDay:      copyPointer(i.TimeOfDay),
+		Weekday:        copyPointer(i.Weekday),
+		DayOfMonth:     copyPointer(i.DayOfMonth),
+		CronExpression: copyPointer(i.CronExpression),
+	}
+}
+
+// NextNRunTimes returns the next n scheduled run times strictly after from.
+// Hourly intervals are aligned to the start of the hour
+func (i *Interval) NextNRunTimes(from time.Time, n int) []time.Time {
+	runTimes := make([]time.Time, 0, n)
+
+	cursor := from
+	for len(runTimes) < n {
+		next, ok := i.getNextRunTime(cursor)
+		if !ok {
+			break
+		}
+
+		runTimes = append(runTimes, next)
+		cursor = next
+	}
+
+	return runTimes
+}
+
+// NextRunTime returns the first scheduled run strictly after from. The second
+// value is false when the interval cannot be scheduled (e.g. broken cron)
+func (i *Interval) NextRunTime(from time.Time) (time.Time, bool) {
+	return i.getNextRunTime(from)
+}
+
+// Describe returns a human-readable schedule, e.g. "weekly on Monday at 04:00"
+func (i *Interval) Describe() string {
+	timeOfDay := "00:00"
+	if i.TimeOfDay != nil {
+		timeOfDay = *i.TimeOfDay
+	}
+
+	switch i.Interval {
+	case IntervalHourly:
+		return "hourly"
+	case IntervalDaily:
+		return "daily at " + timeOfDay
+	case IntervalWeekly:
+		if i.Weekday == nil {
+			return "weekly at " + timeOfDay
+		}
+
+		return fmt.Sprintf("weekly on %s at %s", time.Weekday(*i.Weekday%7), timeOfDay)
+	case IntervalMonthly:
+		if i.DayOfMonth == nil {
+			return "monthly at " + timeOfDay
+		}
+
+		return fmt.Sprintf("monthly on day %d at %s", *i.DayOfMonth, timeOfDay)
+	case IntervalCron:
+		if i.CronExpression == nil {
+			return "cron"
+		}
+
+		return "cron: " + *i.CronExpression
+	default:
+		return string(i.Interval)
 	}
 }
 
@@ -248,3 +315,88 @@ func (i *Interval) validateCronExpression(expr string) error {
 	}
 	return nil
 }
+
+func (i *Interval) getNextRunTime(after time.Time) (time.Time, bool) {
+	hour, minute := i.getTimeOfDay()
+
+	switch i.Interval {
+	case IntervalHourly:
+		return after.Truncate(time.Hour).Add(time.Hour), true
+	case IntervalDaily:
+		next := time.Date(
+			after.Year(), after.Month(), after.Day(),
+			hour, minute, 0, 0, after.Location(),
+		)
+		if !next.After(after) {
+			next = next.AddDate(0, 0, 1)
+		}
+
+		return next, true
+	case IntervalWeekly:
+		if i.Weekday == nil {
+			return after.Add(7 * 24 * time.Hour), true
+		}
+
+		daysAhead := (*i.Weekday - int(after.Weekday()) + 7) % 7
+		next := time.Date(
+			after.Year(), after.Month(), after.Day()+daysAhead,
+			hour, minute, 0, 0, after.Location(),
+		)
+		if !next.After(after) {
+			next = next.AddDate(0, 0, 7)
+		}
+
+		return next, true
+	case IntervalMonthly:
+		day := 1
+		if i.DayOfMonth != nil {
+			day = *i.DayOfMonth
+		}
+
+		next := time.Date(after.Year(), after.Month(), day, hour, minute, 0, 0, after.Location())
+		if !next.After(after) {
+			next = time.Date(
+				after.Year(), after.Month()+1, day,
+				hour, minute, 0, 0, after.Location(),
+			)
+		}
+
+		return next, true
+	case IntervalCron:
+		if i.CronExpression == nil || *i.CronExpression == "" {
+			return time.Time{}, false
+		}
+
+		parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+		schedule, err := parser.Parse(*i.CronExpression)
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		return schedule.Next(after), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func (i *Interval) getTimeOfDay() (int, int) {
+	if i.TimeOfDay == nil {
+		return 0, 0
+	}
+
+	t, err := time.Parse("15:04", *i.TimeOfDay)
+	if err != nil {
+		return 0, 0
+	}
+
+	return t.Hour(), t.Minute()
+}
+
+func copyPointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+	return &copied
+}
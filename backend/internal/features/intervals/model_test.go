@@ -721,3 +721,144 @@ func TestInterval_Validate(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestInterval_NextNRunTimes(t *testing.T) {
+	from := time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC) // Monday
+
+	t.Run("Hourly interval: Return next full hours", func(t *testing.T) {
+		interval := &Interval{Interval: IntervalHourly}
+
+		runTimes := interval.NextNRunTimes(from, 3)
+
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC),
+		}, runTimes)
+	})
+
+	t.Run("Daily interval before time of day: Start from today", func(t *testing.T) {
+		timeOfDay := "15:00"
+		interval := &Interval{Interval: IntervalDaily, TimeOfDay: &timeOfDay}
+
+		runTimes := interval.NextNRunTimes(from, 2)
+
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 1, 15, 15, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 16, 15, 0, 0, 0, time.UTC),
+		}, runTimes)
+	})
+
+	t.Run("Weekly interval: Return configured weekday", func(t *testing.T) {
+		timeOfDay := "09:00"
+		weekday := int(time.Wednesday)
+		interval := &Interval{Interval: IntervalWeekly, TimeOfDay: &timeOfDay, Weekday: &weekday}
+
+		runTimes := interval.NextNRunTimes(from, 2)
+
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 1, 17, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 24, 9, 0, 0, 0, time.UTC),
+		}, runTimes)
+	})
+
+	t.Run("Monthly interval after day of month: Start from next month", func(t *testing.T) {
+		timeOfDay := "08:00"
+		dayOfMonth := 10
+		interval := &Interval{
+			Interval:   IntervalMonthly,
+			TimeOfDay:  &timeOfDay,
+			DayOfMonth: &dayOfMonth,
+		}
+
+		runTimes := interval.NextNRunTimes(from, 2)
+
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 2, 10, 8, 0, 0, 0, time.UTC),
+			time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC),
+		}, runTimes)
+	})
+
+	t.Run("Cron interval: Follow cron schedule", func(t *testing.T) {
+		cronExpression := "0 */6 * * *"
+		interval := &Interval{Interval: IntervalCron, CronExpression: &cronExpression}
+
+		runTimes := interval.NextNRunTimes(from, 2)
+
+		assert.Equal(t, []time.Time{
+			time.Date(2024, 1, 15, 18, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		}, runTimes)
+	})
+}
+
+func TestInterval_Describe(t *testing.T) {
+	timeOfDay := "04:00"
+	weekday := 1
+	dayOfMonth := 15
+	cronExpression := "0 */6 * * *"
+
+	tests := []struct {
+		name     string
+		interval *Interval
+		expected string
+	}{
+		{"Hourly", &Interval{Interval: IntervalHourly}, "hourly"},
+		{"Daily", &Interval{Interval: IntervalDaily, TimeOfDay: &timeOfDay}, "daily at 04:00"},
+		{
+			"Weekly",
+			&Interval{Interval: IntervalWeekly, TimeOfDay: &timeOfDay, Weekday: &weekday},
+			"weekly on Monday at 04:00",
+		},
+		{
+			"Monthly",
+			&Interval{Interval: IntervalMonthly, TimeOfDay: &timeOfDay, DayOfMonth: &dayOfMonth},
+			"monthly on day 15 at 04:00",
+		},
+		{
+			"Cron",
+			&Interval{Interval: IntervalCron, CronExpression: &cronExpression},
+			"cron: 0 */6 * * *",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.interval.Describe())
+		})
+	}
+}
+
+func TestInterval_Copy(t *testing.T) {
+	t.Run("Nil interval: Nil returned", func(t *testing.T) {
+		var interval *Interval
+		assert.Nil(t, interval.Copy())
+	})
+
+	t.Run("Copied interval: Pointers are independent", func(t *testing.T) {
+		timeOfDay := "04:00"
+		weekday := 3
+		interval := &Interval{
+			ID:        uuid.New(),
+			Interval:  IntervalWeekly,
+			TimeOfDay: &timeOfDay,
+			Weekday:   &weekday,
+		}
+
+		copied := interval.Copy()
+
+		assert.Equal(t, uuid.Nil, copied.ID)
+		assert.Equal(t, IntervalWeekly, copied.Interval)
+		assert.Equal(t, "04:00", *copied.TimeOfDay)
+		assert.Equal(t, 3, *copied.Weekday)
+		assert.Nil(t, copied.DayOfMonth)
+		assert.NotSame(t, interval.TimeOfDay, copied.TimeOfDay)
+		assert.NotSame(t, interval.Weekday, copied.Weekday)
+
+		*copied.TimeOfDay = "05:30"
+		*copied.Weekday = 5
+
+		assert.Equal(t, "04:00", *interval.TimeOfDay)
+		assert.Equal(t, 3, *interval.Weekday)
+	})
+}
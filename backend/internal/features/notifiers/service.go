@@ -274,6 +274,16 @@ func (s *NotifierService) SendNotification(
 	title string,
 	message string,
 ) {
+	// the failure is already kept in LastSendError of the notifier
+	_ = s.DeliverNotification(notifier, title, message)
+}
+
+// DeliverNotification sends the notification and returns the delivery error, if any
+func (s *NotifierService) DeliverNotification(
+	notifier *Notifier,
+	title string,
+	message string,
+) error {
 	// Truncate message to 2000 characters if it's too long
 	messageRunes := []rune(message)
 	if len(messageRunes) > 2000 {
@@ -282,25 +292,22 @@ func (s *NotifierService) SendNotification(
 
 	notifiedFromDb, err := s.notifierRepository.FindByID(notifier.ID)
 	if err != nil {
-		return
+		return err
 	}
 
-	err = notifiedFromDb.Send(s.fieldEncryptor, s.logger, title, message)
-	if err != nil {
-		errMsg := err.Error()
+	sendErr := notifiedFromDb.Send(s.fieldEncryptor, s.logger, title, message)
+	if sendErr != nil {
+		errMsg := sendErr.Error()
 		notifiedFromDb.LastSendError = &errMsg
-
-		_, err = s.notifierRepository.Save(notifiedFromDb)
-		if err != nil {
-			s.logger.Error("Failed to save notifier", "error", err)
-		}
+	} else {
+		notifiedFromDb.LastSendError = nil
 	}
 
-	notifiedFromDb.LastSendError = nil
-	_, err = s.notifierRepository.Save(notifiedFromDb)
-	if err != nil {
+	if _, err := s.notifierRepository.Save(notifiedFromDb); err != nil {
 		s.logger.Error("Failed to save notifier", "error", err)
 	}
+
+	return sendErr
 }
 
 func (s *NotifierService) TransferNotifierToWorkspace(
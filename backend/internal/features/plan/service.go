@@ -36,6 +36,21 @@ func (s *DatabasePlanService) GetDatabasePlan(databaseID uuid.UUID) (*DatabasePl
 	return plan, nil
 }
 
+// FindDatabasePlan returns the plan the database is limited by without creating
+// it, databases without a stored plan are limited by the default one
+func (s *DatabasePlanService) FindDatabasePlan(databaseID uuid.UUID) (*DatabasePlan, error) {
+	plan, err := s.databasePlanRepository.GetDatabasePlan(databaseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan == nil {
+		return s.createDefaultDatabasePlan(databaseID), nil
+	}
+
+	return plan, nil
+}
+
 func (s *DatabasePlanService) createDefaultDatabasePlan(databaseID uuid.UUID) *DatabasePlan {
 	var plan DatabasePlan
 
@@ -12,4 +12,8 @@ type RestoreBackupRequest struct {
 	MysqlDatabase      *mysql.MysqlDatabase           `json:"mysqlDatabase"`
 	MariadbDatabase    *mariadb.MariadbDatabase       `json:"mariadbDatabase"`
 	MongodbDatabase    *mongodb.MongodbDatabase       `json:"mongodbDatabase"`
+
+	// ConfirmationToken is required only to restore to the same database
+	// the backup was made from and must match the database name
+	ConfirmationToken string `json:"confirmationToken"`
 }
@@ -0,0 +1,12 @@
+package restores_core
+
+import "errors"
+
+var (
+	ErrRestoreToSameDatabaseForbidden = errors.New(
+		"restore to the same database the backup was made from is forbidden by backup config",
+	)
+	ErrInvalidRestoreConfirmationToken = errors.New(
+		"confirmation token must match the database name to restore to the same database",
+	)
+)
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -135,10 +136,18 @@ func (s *RestoreService) RestoreBackupWithAuth(
 		requestDTO.PostgresqlDatabase.CpuCount = 1
 	}
 
+	if err := s.validateRestoreToSameDatabase(backupDatabase, requestDTO); err != nil {
+		return err
+	}
+
 	if err := s.validateVersionCompatibility(backupDatabase, requestDTO); err != nil {
 		return err
 	}
 
+	if err := s.validateBackupChain(backup.ID); err != nil {
+		return err
+	}
+
 	// Validate disk space before starting restore
 	if err := s.validateDiskSpace(backup, requestDTO); err != nil {
 		return err
@@ -149,6 +158,14 @@ func (s *RestoreService) RestoreBackupWithAuth(
 		return err
 	}
 
+	// the restore is allowed at this point, a backup running meanwhile would
+	// capture a partially overwritten database
+	if isSameDatabaseTarget(backupDatabase, requestDTO) {
+		if err := s.backupService.CancelInProgressBackup(backupDatabase.ID); err != nil {
+			return err
+		}
+	}
+
 	// Create restore record with the request configuration
 	restore := restores_core.Restore{
 		ID:                 uuid.New(),
@@ -320,8 +337,7 @@ func (s *RestoreService) validateDiskSpace(
 		return fmt.Errorf("failed to check disk space: %w", err)
 	}
 
-	// Convert backup size from MB to bytes
-	backupSizeBytes := int64(backup.BackupSizeMb * 1024 * 1024)
+	backupSizeBytes := backup.GetSizeBytes()
 
 	// Calculate required space: backup size + 10% buffer
 	bufferBytes := int64(float64(backupSizeBytes) * 0.1)
@@ -352,6 +368,30 @@ func (s *RestoreService) validateDiskSpace(
 	return nil
 }
 
+func (s *RestoreService) validateRestoreToSameDatabase(
+	backupDatabase *databases.Database,
+	requestDTO restores_core.RestoreBackupRequest,
+) error {
+	if !isSameDatabaseTarget(backupDatabase, requestDTO) {
+		return nil
+	}
+
+	backupConfig, err := s.backupConfigService.GetBackupConfigByDbId(backupDatabase.ID)
+	if err != nil {
+		return err
+	}
+
+	if !backupConfig.AllowRestoreToSameDatabase {
+		return restores_core.ErrRestoreToSameDatabaseForbidden
+	}
+
+	if requestDTO.ConfirmationToken != backupDatabase.Name {
+		return restores_core.ErrInvalidRestoreConfirmationToken
+	}
+
+	return nil
+}
+
 func (s *RestoreService) validateNoParallelRestores(databaseID uuid.UUID) error {
 	inProgressRestores, err := s.restoreRepository.FindInProgressRestoresByDatabaseID(databaseID)
 	if err != nil {
@@ -368,6 +408,22 @@ func (s *RestoreService) validateNoParallelRestores(databaseID uuid.UUID) error
 	return nil
 }
 
+func (s *RestoreService) validateBackupChain(backupID uuid.UUID) error {
+	report, err := s.backupService.GetIncrementalChainIntegrity(backupID)
+	if err != nil {
+		return err
+	}
+
+	if !report.IsRestorable {
+		return fmt.Errorf(
+			"backup chain is broken at backup %s, restore is not possible",
+			*report.BrokenAt,
+		)
+	}
+
+	return nil
+}
+
 func (s *RestoreService) CancelRestore(
 	user *users_models.User,
 	restoreID uuid.UUID,
@@ -415,3 +471,58 @@ func (s *RestoreService) CancelRestore(
 
 	return nil
 }
+
+func isSameDatabaseTarget(
+	backupDatabase *databases.Database,
+	requestDTO restores_core.RestoreBackupRequest,
+) bool {
+	switch {
+	case backupDatabase.Postgresql != nil && requestDTO.PostgresqlDatabase != nil:
+		source := backupDatabase.Postgresql
+		target := requestDTO.PostgresqlDatabase
+
+		return isSameEndpoint(source.Host, source.Port, target.Host, target.Port) &&
+			isSameDatabaseName(source.Database, target.Database)
+	case backupDatabase.Mysql != nil && requestDTO.MysqlDatabase != nil:
+		source := backupDatabase.Mysql
+		target := requestDTO.MysqlDatabase
+
+		return isSameEndpoint(source.Host, source.Port, target.Host, target.Port) &&
+			isSameDatabaseName(source.Database, target.Database)
+	case backupDatabase.Mariadb != nil && requestDTO.MariadbDatabase != nil:
+		source := backupDatabase.Mariadb
+		target := requestDTO.MariadbDatabase
+
+		return isSameEndpoint(source.Host, source.Port, target.Host, target.Port) &&
+			isSameDatabaseName(source.Database, target.Database)
+	case backupDatabase.Mongodb != nil && requestDTO.MongodbDatabase != nil:
+		source := backupDatabase.Mongodb
+		target := requestDTO.MongodbDatabase
+
+		sourcePort, targetPort := 0, 0
+		if source.Port != nil {
+			sourcePort = *source.Port
+		}
+		if target.Port != nil {
+			targetPort = *target.Port
+		}
+
+		return isSameEndpoint(source.Host, sourcePort, target.Host, targetPort) &&
+			source.Database == target.Database
+	default:
+		return false
+	}
+}
+
+func isSameEndpoint(sourceHost string, sourcePort int, targetHost string, targetPort int) bool {
+	return strings.EqualFold(strings.TrimSpace(sourceHost), strings.TrimSpace(targetHost)) &&
+		sourcePort == targetPort
+}
+
+func isSameDatabaseName(source, target *string) bool {
+	if source == nil || target == nil {
+		return source == nil && target == nil
+	}
+
+	return *source == *target
+}
@@ -23,7 +23,7 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	mariadbtypes "databasus-backend/internal/features/databases/databases/mariadb"
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	restores_core "databasus-backend/internal/features/restores/core"
 	"databasus-backend/internal/features/storages"
 	util_encryption "databasus-backend/internal/util/encryption"
@@ -31,8 +31,8 @@ import (
 )
 
 type RestoreMariadbBackupUsecase struct {
-	logger           *slog.Logger
-	secretKeyService *encryption_secrets.SecretKeyService
+	logger               *slog.Logger
+	encryptionKeyService *encryption_keys.WorkspaceEncryptionKeyService
 }
 
 func (uc *RestoreMariadbBackupUsecase) Execute(
@@ -247,9 +247,9 @@ func (uc *RestoreMariadbBackupUsecase) setupDecryption(
 		return nil, fmt.Errorf("backup is encrypted but missing encryption metadata")
 	}
 
-	masterKey, err := uc.secretKeyService.GetSecretKey()
+	encryptionKey, err := uc.encryptionKeyService.GetBackupKey(backup.EncryptionKeyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get master key for decryption: %w", err)
+		return nil, fmt.Errorf("failed to get encryption key for decryption: %w", err)
 	}
 
 	salt, err := base64.StdEncoding.DecodeString(*backup.EncryptionSalt)
@@ -264,7 +264,7 @@ func (uc *RestoreMariadbBackupUsecase) setupDecryption(
 
 	decryptReader, err := encryption.NewDecryptionReader(
 		reader,
-		masterKey,
+		encryptionKey,
 		backup.ID,
 		salt,
 		iv,
@@ -1,13 +1,13 @@
 package usecases_mongodb
 
 import (
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/util/logger"
 )
 
 var restoreMongodbBackupUsecase = &RestoreMongodbBackupUsecase{
 	logger.GetLogger(),
-	encryption_secrets.GetSecretKeyService(),
+	encryption_keys.GetWorkspaceEncryptionKeyService(),
 }
 
 func GetRestoreMongodbBackupUsecase() *RestoreMongodbBackupUsecase {
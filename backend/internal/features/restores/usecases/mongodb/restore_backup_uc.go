@@ -19,7 +19,7 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	mongodbtypes "databasus-backend/internal/features/databases/databases/mongodb"
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	restores_core "databasus-backend/internal/features/restores/core"
 	"databasus-backend/internal/features/storages"
 	util_encryption "databasus-backend/internal/util/encryption"
@@ -31,8 +31,8 @@ const (
 )
 
 type RestoreMongodbBackupUsecase struct {
-	logger           *slog.Logger
-	secretKeyService *encryption_secrets.SecretKeyService
+	logger               *slog.Logger
+	encryptionKeyService *encryption_keys.WorkspaceEncryptionKeyService
 }
 
 func (uc *RestoreMongodbBackupUsecase) Execute(
@@ -262,14 +262,14 @@ func (uc *RestoreMongodbBackupUsecase) setupDecryption(
 		return nil, fmt.Errorf("failed to decode encryption IV: %w", err)
 	}
 
-	masterKey, err := uc.secretKeyService.GetSecretKey()
+	encryptionKey, err := uc.encryptionKeyService.GetBackupKey(backup.EncryptionKeyID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get secret key: %w", err)
 	}
 
 	decryptReader, err := encryption.NewDecryptionReader(
 		reader,
-		masterKey,
+		encryptionKey,
 		backup.ID,
 		salt,
 		nonce,
@@ -1,13 +1,13 @@
 package usecases_mysql
 
 import (
-	"databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	"databasus-backend/internal/util/logger"
 )
 
 var restoreMysqlBackupUsecase = &RestoreMysqlBackupUsecase{
 	logger.GetLogger(),
-	secrets.GetSecretKeyService(),
+	encryption_keys.GetWorkspaceEncryptionKeyService(),
 }
 
 func GetRestoreMysqlBackupUsecase() *RestoreMysqlBackupUsecase {
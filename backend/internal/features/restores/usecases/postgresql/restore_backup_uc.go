@@ -20,7 +20,7 @@ import (
 	backups_config "databasus-backend/internal/features/backups/config"
 	"databasus-backend/internal/features/databases"
 	pgtypes "databasus-backend/internal/features/databases/databases/postgresql"
-	encryption_secrets "databasus-backend/internal/features/encryption/secrets"
+	encryption_keys "databasus-backend/internal/features/encryption/keys"
 	restores_core "databasus-backend/internal/features/restores/core"
 	"databasus-backend/internal/features/storages"
 	util_encryption "databasus-backend/internal/util/encryption"
@@ -30,8 +30,8 @@ import (
 )
 
 type RestorePostgresqlBackupUsecase struct {
-	logger           *slog.Logger
-	secretKeyService *encryption_secrets.SecretKeyService
+	logger               *slog.Logger
+	encryptionKeyService *encryption_keys.WorkspaceEncryptionKeyService
 }
 
 func (uc *RestorePostgresqlBackupUsecase) Execute(
@@ -226,10 +226,10 @@ func (uc *RestorePostgresqlBackupUsecase) restoreViaStdin(
 			return fmt.Errorf("backup is encrypted but missing encryption metadata")
 		}
 
-		// Get master key
-		masterKey, err := uc.secretKeyService.GetSecretKey()
+		// Get encryption key
+		encryptionKey, err := uc.encryptionKeyService.GetBackupKey(backup.EncryptionKeyID)
 		if err != nil {
-			return fmt.Errorf("failed to get master key for decryption: %w", err)
+			return fmt.Errorf("failed to get encryption key for decryption: %w", err)
 		}
 
 		// Decode salt and IV from base64
@@ -246,7 +246,7 @@ func (uc *RestorePostgresqlBackupUsecase) restoreViaStdin(
 		// Create decryption reader
 		decryptReader, err := encryption.NewDecryptionReader(
 			rawReader,
-			masterKey,
+			encryptionKey,
 			backup.ID,
 			salt,
 			iv,
@@ -563,11 +563,11 @@ func (uc *RestorePostgresqlBackupUsecase) downloadBackupToTempFile(
 			return "", nil, fmt.Errorf("backup is encrypted but missing encryption metadata")
 		}
 
-		// Get master key
-		masterKey, err := uc.secretKeyService.GetSecretKey()
+		// Get encryption key
+		encryptionKey, err := uc.encryptionKeyService.GetBackupKey(backup.EncryptionKeyID)
 		if err != nil {
 			cleanupFunc()
-			return "", nil, fmt.Errorf("failed to get master key for decryption: %w", err)
+			return "", nil, fmt.Errorf("failed to get encryption key for decryption: %w", err)
 		}
 
 		// Decode salt and IV from base64
@@ -586,7 +586,7 @@ func (uc *RestorePostgresqlBackupUsecase) downloadBackupToTempFile(
 		// Create decryption reader
 		decryptReader, err := encryption.NewDecryptionReader(
 			rawReader,
-			masterKey,
+			encryptionKey,
 			backup.ID,
 			salt,
 			iv,
@@ -1,7 +1,23 @@
 package storages
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type TransferStorageRequest struct {
 	TargetWorkspaceID uuid.UUID `json:"targetWorkspaceId" binding:"required"`
 }
+
+// StorageCredentials are plain (not encrypted) access keys of a storage
+type StorageCredentials struct {
+	AccessKey string `json:"accessKey" binding:"required"`
+	SecretKey string `json:"secretKey" binding:"required"`
+}
+
+type StorageFileInfo struct {
+	Name      string    `json:"name"`
+	SizeBytes int64     `json:"sizeBytes"`
+	ModTime   time.Time `json:"modTime"`
+}
@@ -42,4 +42,10 @@ var (
 	ErrLocalStorageNotAllowedInCloudMode = errors.New(
 		"local storage can only be managed by administrators in cloud mode",
 	)
+	ErrInvalidCredentials = errors.New(
+		"storage cannot be accessed with the new credentials",
+	)
+	ErrCredentialsRotationNotSupported = errors.New(
+		"credentials rotation is supported only for S3 storages",
+	)
 )
@@ -3,8 +3,10 @@ package storages
 import (
 	"context"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
 	"io"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,6 +24,8 @@ type StorageFileSaver interface {
 
 	DeleteFile(encryptor encryption.FieldEncryptor, fileName string) error
 
+	SetFileACL(encryptor encryption.FieldEncryptor, fileName string, acl string) error
+
 	Validate(encryptor encryption.FieldEncryptor) error
 
 	TestConnection(encryptor encryption.FieldEncryptor) error
@@ -31,6 +35,42 @@ type StorageFileSaver interface {
 	EncryptSensitiveData(encryptor encryption.FieldEncryptor) error
 }
 
+// StorageFileModTimeGetter is implemented by storages that can report the
+// server-side last modification time of a file
+type StorageFileModTimeGetter interface {
+	GetFileModTime(encryptor encryption.FieldEncryptor, fileName string) (time.Time, error)
+}
+
+// StorageFileLister is implemented by storages that can list the backup files
+// they hold
+type StorageFileLister interface {
+	ListFiles(encryptor encryption.FieldEncryptor) ([]files_utils.FileInfo, error)
+}
+
+// StorageClassFileSaver is implemented by storages that can place a file into
+// a specific storage class (tier) and grant it an ACL at upload time
+type StorageClassFileSaver interface {
+	SaveFileWithStorageClass(
+		ctx context.Context,
+		encryptor encryption.FieldEncryptor,
+		logger *slog.Logger,
+		fileName string,
+		file io.Reader,
+		storageClass string,
+		acl string,
+	) error
+}
+
+// StorageClassChanger is implemented by storages that can move an existing
+// file into another storage class without downloading it
+type StorageClassChanger interface {
+	CopyObjectWithNewClass(
+		encryptor encryption.FieldEncryptor,
+		fileName string,
+		storageClass string,
+	) error
+}
+
 type StorageDatabaseCounter interface {
 	GetStorageAttachedDatabasesIDs(storageID uuid.UUID) ([]uuid.UUID, error)
 }
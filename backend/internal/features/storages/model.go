@@ -12,8 +12,10 @@ import (
 	sftp_storage "databasus-backend/internal/features/storages/models/sftp"
 	"databasus-backend/internal/util/encryption"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -26,6 +28,13 @@ type Storage struct {
 	LastSaveError *string     `json:"lastSaveError" gorm:"column:last_save_error;type:text"`
 	IsSystem      bool        `json:"isSystem"      gorm:"column:is_system;not null;default:false"`
 
+	// CostPerGBPerMonth is the provider price in USD used for cost previews, nil if unknown
+	CostPerGBPerMonth *float64 `json:"costPerGbPerMonth" gorm:"column:cost_per_gb_per_month;type:double precision"`
+
+	// UnavailableSince is set by health checks when the storage stops responding,
+	// nil while the storage is available
+	UnavailableSince *time.Time `json:"unavailableSince" gorm:"column:unavailable_since"`
+
 	// specific storage
 	LocalStorage       *local_storage.LocalStorage              `json:"localStorage"       gorm:"foreignKey:StorageID"`
 	S3Storage          *s3_storage.S3Storage                    `json:"s3Storage"          gorm:"foreignKey:StorageID"`
@@ -44,7 +53,38 @@ func (s *Storage) SaveFile(
 	fileName string,
 	file io.Reader,
 ) error {
-	err := s.getSpecificStorage().SaveFile(ctx, encryptor, logger, fileName, file)
+	return s.SaveFileWithStorageClass(ctx, encryptor, logger, fileName, file, "", "")
+}
+
+// SaveFileWithStorageClass uploads the file into the given storage class with
+// the given ACL. Storages without storage classes and ACLs, as well as an empty
+// class and ACL, use the default upload and keep the file private
+func (s *Storage) SaveFileWithStorageClass(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	fileName string,
+	file io.Reader,
+	storageClass string,
+	acl string,
+) error {
+	var err error
+
+	classSaver, ok := s.getSpecificStorage().(StorageClassFileSaver)
+	if ok && (storageClass != "" || acl != "") {
+		err = classSaver.SaveFileWithStorageClass(
+			ctx,
+			encryptor,
+			logger,
+			fileName,
+			file,
+			storageClass,
+			acl,
+		)
+	} else {
+		err = s.getSpecificStorage().SaveFile(ctx, encryptor, logger, fileName, file)
+	}
+
 	if err != nil {
 		lastSaveError := err.Error()
 		s.LastSaveError = &lastSaveError
@@ -67,6 +107,76 @@ func (s *Storage) DeleteFile(encryptor encryption.FieldEncryptor, fileName strin
 	return s.getSpecificStorage().DeleteFile(encryptor, fileName)
 }
 
+// SetFileACL changes the ACL of an already uploaded file. Storages without
+// per-file ACLs keep their files private and ignore it
+func (s *Storage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	return s.getSpecificStorage().SetFileACL(encryptor, fileName, acl)
+}
+
+// GetFileModTime returns the last modification time reported by the storage.
+// isSupported is false for storages that cannot report it
+func (s *Storage) GetFileModTime(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+) (modTime time.Time, isSupported bool, err error) {
+	modTimeGetter, ok := s.getSpecificStorage().(StorageFileModTimeGetter)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	modTime, err = modTimeGetter.GetFileModTime(encryptor, fileName)
+	if err != nil {
+		return time.Time{}, true, err
+	}
+
+	return modTime, true, nil
+}
+
+// ListBackupFiles returns files held by the storage. isSupported is false for
+// storages that cannot list their content
+func (s *Storage) ListBackupFiles(
+	encryptor encryption.FieldEncryptor,
+) (files []StorageFileInfo, isSupported bool, err error) {
+	fileLister, ok := s.getSpecificStorage().(StorageFileLister)
+	if !ok {
+		return nil, false, nil
+	}
+
+	listedFiles, err := fileLister.ListFiles(encryptor)
+	if err != nil {
+		return nil, true, err
+	}
+
+	files = make([]StorageFileInfo, 0, len(listedFiles))
+	for _, file := range listedFiles {
+		files = append(files, StorageFileInfo(file))
+	}
+
+	return files, true, nil
+}
+
+func (s *Storage) CopyObjectWithNewClass(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	storageClass string,
+) error {
+	classChanger, ok := s.getSpecificStorage().(StorageClassChanger)
+	if !ok {
+		return fmt.Errorf("storage type %s does not support storage classes", s.Type)
+	}
+
+	return classChanger.CopyObjectWithNewClass(encryptor, fileName, storageClass)
+}
+
+// IsRemote reports whether files leave the node, i.e. every storage except local
+func (s *Storage) IsRemote() bool {
+	return s.Type != StorageTypeLocal
+}
+
 func (s *Storage) Validate(encryptor encryption.FieldEncryptor) error {
 	if s.Type == "" {
 		return errors.New("storage type is required")
@@ -76,6 +186,10 @@ func (s *Storage) Validate(encryptor encryption.FieldEncryptor) error {
 		return errors.New("storage name is required")
 	}
 
+	if s.CostPerGBPerMonth != nil && *s.CostPerGBPerMonth < 0 {
+		return errors.New("storage cost per GB must not be negative")
+	}
+
 	return s.getSpecificStorage().Validate(encryptor)
 }
 
@@ -83,6 +197,19 @@ func (s *Storage) TestConnection(encryptor encryption.FieldEncryptor) error {
 	return s.getSpecificStorage().TestConnection(encryptor)
 }
 
+// SetCredentials replaces access keys of the storage with plain ones, they have
+// to be encrypted with EncryptSensitiveData before saving
+func (s *Storage) SetCredentials(credentials StorageCredentials) error {
+	if s.Type != StorageTypeS3 || s.S3Storage == nil {
+		return ErrCredentialsRotationNotSupported
+	}
+
+	s.S3Storage.S3AccessKey = credentials.AccessKey
+	s.S3Storage.S3SecretKey = credentials.SecretKey
+
+	return nil
+}
+
 func (s *Storage) HideSensitiveData() {
 	s.getSpecificStorage().HideSensitiveData()
 }
@@ -106,6 +233,7 @@ func (s *Storage) Update(incoming *Storage) {
 	s.Name = incoming.Name
 	s.Type = incoming.Type
 	s.IsSystem = incoming.IsSystem
+	s.CostPerGBPerMonth = incoming.CostPerGBPerMonth
 
 	switch s.Type {
 	case StorageTypeLocal:
@@ -207,6 +207,42 @@ func (s *AzureBlobStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileN
 	return nil
 }
 
+func (s *AzureBlobStorage) GetFileModTime(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+) (time.Time, error) {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), azureDeleteTimeout)
+	defer cancel()
+
+	properties, err := client.ServiceClient().
+		NewContainerClient(s.ContainerName).
+		NewBlobClient(s.buildBlobName(fileName)).
+		GetProperties(ctx, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get blob properties from Azure: %w", err)
+	}
+
+	if properties.LastModified == nil {
+		return time.Time{}, errors.New("azure did not return blob last modified time")
+	}
+
+	return properties.LastModified.UTC(), nil
+}
+
+// Azure controls access on container level, blobs are treated as private
+func (s *AzureBlobStorage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	return nil
+}
+
 func (s *AzureBlobStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if s.ContainerName == "" {
 		return errors.New("container name is required")
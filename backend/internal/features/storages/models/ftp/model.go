@@ -161,6 +161,15 @@ func (f *FTPStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileName st
 	return nil
 }
 
+// FTP has no per-file permissions that map to ACLs, files stay private
+func (f *FTPStorage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	return nil
+}
+
 func (f *FTPStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if f.Host == "" {
 		return errors.New("FTP host is required")
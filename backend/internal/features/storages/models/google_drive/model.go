@@ -197,6 +197,15 @@ func (s *GoogleDriveStorage) DeleteFile(
 	})
 }
 
+// files are shared only with the drive owner, so any ACL is treated as private
+func (s *GoogleDriveStorage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	return nil
+}
+
 func (s *GoogleDriveStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	switch {
 	case s.ClientID == "":
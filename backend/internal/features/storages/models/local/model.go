@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -160,6 +161,62 @@ func (l *LocalStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileName
 	return nil
 }
 
+func (l *LocalStorage) GetFileModTime(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+) (time.Time, error) {
+	fileInfo, err := os.Stat(filepath.Join(config.GetEnv().DataFolder, fileName))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return fileInfo.ModTime().UTC(), nil
+}
+
+// ListFiles lists the data folder, which is shared by all local storages of the
+// instance, so files of other local storages are listed too
+func (l *LocalStorage) ListFiles(
+	encryptor encryption.FieldEncryptor,
+) ([]files_utils.FileInfo, error) {
+	entries, err := os.ReadDir(config.GetEnv().DataFolder)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []files_utils.FileInfo{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read data folder: %w", err)
+	}
+
+	files := make([]files_utils.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		fileInfo, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat file %s: %w", entry.Name(), err)
+		}
+
+		files = append(files, files_utils.FileInfo{
+			Name:      entry.Name(),
+			SizeBytes: fileInfo.Size(),
+			ModTime:   fileInfo.ModTime().UTC(),
+		})
+	}
+
+	return files, nil
+}
+
+// local files are accessible only by the node, ACL is not applicable
+func (l *LocalStorage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	return nil
+}
+
 func (l *LocalStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	return nil
 }
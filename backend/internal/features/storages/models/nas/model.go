@@ -229,6 +229,15 @@ func (n *NASStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileName st
 	return nil
 }
 
+// NAS shares are accessed with storage credentials only
+func (n *NASStorage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	return nil
+}
+
 func (n *NASStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if n.Host == "" {
 		return errors.New("NAS host is required")
@@ -139,6 +139,15 @@ func (r *RcloneStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileName
 	return nil
 }
 
+// rclone remotes do not expose object ACLs in a portable way
+func (r *RcloneStorage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	return nil
+}
+
 func (r *RcloneStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if r.ConfigContent == "" {
 		return errors.New("rclone config content is required")
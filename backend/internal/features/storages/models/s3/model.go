@@ -6,6 +6,7 @@ import (
 	"crypto/md5"
 	"crypto/tls"
 	"databasus-backend/internal/util/encryption"
+	files_utils "databasus-backend/internal/util/files"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -27,6 +28,7 @@ const (
 	s3IdleConnTimeout     = 90 * time.Second
 	s3TLSHandshakeTimeout = 30 * time.Second
 	s3DeleteTimeout       = 30 * time.Second
+	s3ListTimeout         = 5 * time.Minute
 
 	// Chunk size for multipart uploads - 16MB provides good balance between
 	// memory usage and upload efficiency. This creates backpressure to pg_dump
@@ -57,6 +59,20 @@ func (s *S3Storage) SaveFile(
 	logger *slog.Logger,
 	fileName string,
 	file io.Reader,
+) error {
+	return s.SaveFileWithStorageClass(ctx, encryptor, logger, fileName, file, "", "")
+}
+
+// SaveFileWithStorageClass uploads the file with the given S3 storage class and
+// canned ACL, an empty class leaves the choice to the bucket default
+func (s *S3Storage) SaveFileWithStorageClass(
+	ctx context.Context,
+	encryptor encryption.FieldEncryptor,
+	logger *slog.Logger,
+	fileName string,
+	file io.Reader,
+	storageClass string,
+	acl string,
 ) error {
 	select {
 	case <-ctx.Done():
@@ -64,6 +80,16 @@ func (s *S3Storage) SaveFile(
 	default:
 	}
 
+	cannedACL, err := s.getCannedACL(acl)
+	if err != nil {
+		return err
+	}
+
+	putOptions := minio.PutObjectOptions{StorageClass: storageClass}
+	if cannedACL != "" {
+		putOptions.UserMetadata = map[string]string{"x-amz-acl": cannedACL}
+	}
+
 	coreClient, err := s.getCoreClient(encryptor)
 	if err != nil {
 		return err
@@ -75,7 +101,7 @@ func (s *S3Storage) SaveFile(
 		ctx,
 		s.S3Bucket,
 		objectKey,
-		minio.PutObjectOptions{},
+		putOptions,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to initiate multipart upload: %w", err)
@@ -150,15 +176,14 @@ func (s *S3Storage) SaveFile(
 		if err != nil {
 			return err
 		}
+		putOptions.SendContentMd5 = true
 		_, err = client.PutObject(
 			ctx,
 			s.S3Bucket,
 			objectKey,
 			bytes.NewReader([]byte{}),
 			0,
-			minio.PutObjectOptions{
-				SendContentMd5: true,
-			},
+			putOptions,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to upload empty file: %w", err)
@@ -245,6 +270,160 @@ func (s *S3Storage) DeleteFile(encryptor encryption.FieldEncryptor, fileName str
 	return nil
 }
 
+func (s *S3Storage) GetFileModTime(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+) (time.Time, error) {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s3DeleteTimeout)
+	defer cancel()
+
+	objectInfo, err := client.StatObject(
+		ctx,
+		s.S3Bucket,
+		s.buildObjectKey(fileName),
+		minio.StatObjectOptions{},
+	)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to stat file in S3: %w", err)
+	}
+
+	return objectInfo.LastModified.UTC(), nil
+}
+
+func (s *S3Storage) ListFiles(
+	encryptor encryption.FieldEncryptor,
+) ([]files_utils.FileInfo, error) {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s3ListTimeout)
+	defer cancel()
+
+	prefix := s.buildObjectKey("")
+	files := []files_utils.FileInfo{}
+
+	for object := range client.ListObjects(ctx, s.S3Bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+	}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list files in S3: %w", object.Err)
+		}
+
+		files = append(files, files_utils.FileInfo{
+			Name:      strings.TrimPrefix(object.Key, prefix),
+			SizeBytes: object.Size,
+			ModTime:   object.LastModified.UTC(),
+		})
+	}
+
+	return files, nil
+}
+
+// CopyObjectWithNewClass copies the object onto itself with another storage
+// class. Objects already in GLACIER or DEEP_ARCHIVE must be restored first,
+// S3 rejects copying them
+func (s *S3Storage) CopyObjectWithNewClass(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	storageClass string,
+) error {
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return err
+	}
+
+	objectKey := s.buildObjectKey(fileName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s3DeleteTimeout)
+	defer cancel()
+
+	_, err = client.CopyObject(
+		ctx,
+		minio.CopyDestOptions{
+			Bucket:          s.S3Bucket,
+			Object:          objectKey,
+			ReplaceMetadata: true,
+			UserMetadata:    map[string]string{"x-amz-storage-class": storageClass},
+		},
+		minio.CopySrcOptions{
+			Bucket: s.S3Bucket,
+			Object: objectKey,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to change storage class of file in S3: %w", err)
+	}
+
+	return nil
+}
+
+// SetFileACL copies the object onto itself with a canned ACL header, S3 API of
+// minio-go cannot change the ACL of an existing object. The storage class is
+// passed again because the copy would reset it to the bucket default
+func (s *S3Storage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	cannedACL, err := s.getCannedACL(acl)
+	if err != nil {
+		return err
+	}
+
+	// the upload omits the header for private files, but an existing object may
+	// have been shared before, so the ACL has to be reset explicitly
+	if cannedACL == "" {
+		cannedACL = "private"
+	}
+
+	client, err := s.getClient(encryptor)
+	if err != nil {
+		return err
+	}
+
+	objectKey := s.buildObjectKey(fileName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s3DeleteTimeout)
+	defer cancel()
+
+	objectInfo, err := client.StatObject(ctx, s.S3Bucket, objectKey, minio.StatObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to stat file in S3: %w", err)
+	}
+
+	headers := map[string]string{"x-amz-acl": cannedACL}
+	if objectInfo.StorageClass != "" {
+		headers["x-amz-storage-class"] = objectInfo.StorageClass
+	}
+
+	_, err = client.CopyObject(
+		ctx,
+		minio.CopyDestOptions{
+			Bucket:          s.S3Bucket,
+			Object:          objectKey,
+			ReplaceMetadata: true,
+			UserMetadata:    headers,
+		},
+		minio.CopySrcOptions{
+			Bucket: s.S3Bucket,
+			Object: objectKey,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set ACL of file in S3: %w", err)
+	}
+
+	return nil
+}
+
 func (s *S3Storage) Validate(encryptor encryption.FieldEncryptor) error {
 	if s.S3Bucket == "" {
 		return errors.New("S3 bucket is required")
@@ -467,3 +646,18 @@ func (s *S3Storage) getClientParams(
 
 	return endpoint, useSSL, accessKey, secretKey, bucketLookup, transport, nil
 }
+
+// getCannedACL returns an empty ACL for private files. Private is the S3 default
+// and buckets with ACLs disabled reject any ACL header
+func (s *S3Storage) getCannedACL(acl string) (string, error) {
+	switch acl {
+	case "", "PRIVATE":
+		return "", nil
+	case "WORKSPACE_PRIVATE":
+		return "bucket-owner-full-control", nil
+	case "AUTHENTICATED_READ":
+		return "authenticated-read", nil
+	default:
+		return "", fmt.Errorf("unsupported ACL: %s", acl)
+	}
+}
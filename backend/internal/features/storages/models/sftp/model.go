@@ -182,6 +182,39 @@ func (s *SFTPStorage) DeleteFile(encryptor encryption.FieldEncryptor, fileName s
 	return nil
 }
 
+func (s *SFTPStorage) GetFileModTime(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sftpDeleteTimeout)
+	defer cancel()
+
+	client, sshConn, err := s.connectWithContext(ctx, encryptor, sftpDeleteTimeout)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to connect to SFTP: %w", err)
+	}
+	defer func() {
+		_ = client.Close()
+		_ = sshConn.Close()
+	}()
+
+	fileInfo, err := client.Stat(s.getFilePath(fileName))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to stat file on SFTP: %w", err)
+	}
+
+	return fileInfo.ModTime().UTC(), nil
+}
+
+// files are owned by the SFTP user and readable only with its credentials
+func (s *SFTPStorage) SetFileACL(
+	encryptor encryption.FieldEncryptor,
+	fileName string,
+	acl string,
+) error {
+	return nil
+}
+
 func (s *SFTPStorage) Validate(encryptor encryption.FieldEncryptor) error {
 	if s.Host == "" {
 		return errors.New("SFTP host is required")
@@ -1,6 +1,8 @@
 package storages
 
 import (
+	"time"
+
 	db "databasus-backend/internal/storage"
 
 	"github.com/google/uuid"
@@ -152,6 +154,17 @@ func (r *StorageRepository) FindByID(id uuid.UUID) (*Storage, error) {
 	return &s, nil
 }
 
+func (r *StorageRepository) UpdateUnavailableSince(
+	id uuid.UUID,
+	unavailableSince *time.Time,
+) error {
+	return db.
+		GetDb().
+		Model(&Storage{}).
+		Where("id = ?", id).
+		Update("unavailable_since", unavailableSince).Error
+}
+
 func (r *StorageRepository) FindByWorkspaceID(workspaceID uuid.UUID) ([]*Storage, error) {
 	var storages []*Storage
 
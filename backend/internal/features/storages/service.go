@@ -2,6 +2,7 @@ package storages
 
 import (
 	"fmt"
+	"time"
 
 	"databasus-backend/internal/config"
 	audit_logs "databasus-backend/internal/features/audit_logs"
@@ -320,6 +321,55 @@ func (s *StorageService) GetStorageByID(
 	return s.storageRepository.FindByID(id)
 }
 
+// IsAvailable reports whether the storage was not marked as unavailable by
+// health checks. Files of an unavailable storage may still exist, they just
+// cannot be reached right now
+func (s *StorageService) IsAvailable(storageID uuid.UUID) (bool, error) {
+	storage, err := s.storageRepository.FindByID(storageID)
+	if err != nil {
+		return false, err
+	}
+
+	return storage.UnavailableSince == nil, nil
+}
+
+// SetAvailability records the result of a storage health check. The time of
+// the first failed check is kept while the storage stays unavailable
+func (s *StorageService) SetAvailability(storageID uuid.UUID, isAvailable bool) error {
+	if isAvailable {
+		return s.storageRepository.UpdateUnavailableSince(storageID, nil)
+	}
+
+	storage, err := s.storageRepository.FindByID(storageID)
+	if err != nil {
+		return err
+	}
+
+	if storage.UnavailableSince != nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	return s.storageRepository.UpdateUnavailableSince(storageID, &now)
+}
+
+// ValidateStorageAccess checks that files can be written to and removed from the
+// storage with its current (possibly not saved yet) settings
+func (s *StorageService) ValidateStorageAccess(storage *Storage) error {
+	if err := storage.Validate(s.fieldEncryptor); err != nil {
+		return err
+	}
+
+	return storage.TestConnection(s.fieldEncryptor)
+}
+
+// UpdateStorage saves the storage without permission checks, it is meant for
+// system flows. Sensitive data must already be encrypted
+func (s *StorageService) UpdateStorage(storage *Storage) error {
+	_, err := s.storageRepository.Save(storage)
+	return err
+}
+
 func (s *StorageService) TransferStorageToWorkspace(
 	user *users_models.User,
 	storageID uuid.UUID,
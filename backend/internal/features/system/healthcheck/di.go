@@ -9,6 +9,7 @@ var healthcheckService = &HealthcheckService{
 	disk.GetDiskService(),
 	backuping.GetBackupsScheduler(),
 	backuping.GetBackuperNode(),
+	backuping.GetBackupCleaner(),
 }
 var healthcheckController = &HealthcheckController{
 	healthcheckService,
@@ -11,10 +11,15 @@ import (
 	"time"
 )
 
+// a cleaner tick goes over every database, so it may legitimately take much
+// longer than the ticker interval
+const cleanerTickHealthcheckThreshold = 30 * time.Minute
+
 type HealthcheckService struct {
 	diskService             *disk.DiskService
 	backupBackgroundService *backuping.BackupsScheduler
 	backuperNode            *backuping.BackuperNode
+	backupCleaner           *backuping.BackupCleaner
 }
 
 func (s *HealthcheckService) IsHealthy() error {
@@ -56,6 +61,16 @@ func (s *HealthcheckService) performHealthCheck() error {
 		if !s.backupBackgroundService.IsBackupNodesAvailable() {
 			return errors.New("no backup nodes available")
 		}
+
+		cleanerHealth := s.backupCleaner.CleanerHealth()
+		if !cleanerHealth.IsRunning {
+			return errors.New("backup cleaner is not running")
+		}
+
+		if cleanerHealth.LastTickAt != nil &&
+			cleanerHealth.LastTickAt.Before(time.Now().UTC().Add(-cleanerTickHealthcheckThreshold)) {
+			return errors.New("backup cleaner has not completed a tick for more than 30 minutes")
+		}
 	}
 
 	if config.GetEnv().IsProcessingNode {
@@ -5,14 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
 type CloudflareTurnstileService struct {
-	secretKey string
-	siteKey   string
+	secretKey        string
+	siteKey          string
+	allowedHostnames []string
+	logger           *slog.Logger
+
+	verifiedTokensMu sync.Mutex
+	verifiedTokens   map[string]verifiedToken
+}
+
+type verifiedToken struct {
+	remoteIP   string
+	verifiedAt time.Time
 }
 
 type cloudflareTurnstileResponse struct {
@@ -24,6 +37,10 @@ type cloudflareTurnstileResponse struct {
 
 const cloudflareTurnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
 
+// Turnstile tokens expire after 300 seconds, so the IP a token was verified
+// from is never useful for longer than that
+const verifiedTokenIPWindow = 5 * time.Minute
+
 func (s *CloudflareTurnstileService) IsEnabled() bool {
 	return s.secretKey != ""
 }
@@ -37,6 +54,10 @@ func (s *CloudflareTurnstileService) VerifyToken(token, remoteIP string) (bool,
 		return false, errors.New("cloudflare Turnstile token is required")
 	}
 
+	if err := s.checkTokenRemoteIP(token, remoteIP, time.Now().UTC()); err != nil {
+		return false, err
+	}
+
 	formData := url.Values{}
 	formData.Set("secret", s.secretKey)
 	formData.Set("response", token)
@@ -67,5 +88,102 @@ func (s *CloudflareTurnstileService) VerifyToken(token, remoteIP string) (bool,
 		)
 	}
 
+	if err := verifyHostname(turnstileResp.Hostname, s.allowedHostnames); err != nil {
+		return false, err
+	}
+
+	s.rememberVerifiedToken(token, remoteIP, time.Now().UTC())
+
 	return true, nil
 }
+
+// checkTokenRemoteIP rejects a token already verified from another IP within
+// the window, it has most likely been stolen. Tokens from the same IP are still
+// sent to siteverify, which is the only place single use is enforced
+func (s *CloudflareTurnstileService) checkTokenRemoteIP(
+	token, remoteIP string,
+	now time.Time,
+) error {
+	s.verifiedTokensMu.Lock()
+	defer s.verifiedTokensMu.Unlock()
+
+	cached, isFound := s.verifiedTokens[token]
+	if !isFound || now.Sub(cached.verifiedAt) > verifiedTokenIPWindow {
+		return nil
+	}
+
+	if cached.remoteIP != remoteIP {
+		s.logger.Warn(
+			"Cloudflare Turnstile token reused from a different IP",
+			"originalRemoteIP", cached.remoteIP,
+			"remoteIP", remoteIP,
+			"verifiedAt", cached.verifiedAt,
+		)
+
+		return errors.New(
+			"cloudflare Turnstile token was already verified from another IP",
+		)
+	}
+
+	return nil
+}
+
+func (s *CloudflareTurnstileService) rememberVerifiedToken(
+	token, remoteIP string,
+	now time.Time,
+) {
+	s.verifiedTokensMu.Lock()
+	defer s.verifiedTokensMu.Unlock()
+
+	if s.verifiedTokens == nil {
+		s.verifiedTokens = make(map[string]verifiedToken)
+	}
+
+	for cachedToken, cached := range s.verifiedTokens {
+		if now.Sub(cached.verifiedAt) > verifiedTokenIPWindow {
+			delete(s.verifiedTokens, cachedToken)
+		}
+	}
+
+	s.verifiedTokens[token] = verifiedToken{remoteIP, now}
+}
+
+// verifyHostname checks the hostname the challenge was solved on against the
+// allow-list. Entries like "*.example.com" match any subdomain of example.com,
+// but not example.com itself. Empty allow-list allows any hostname
+func verifyHostname(hostname string, allowedHostnames []string) error {
+	if len(allowedHostnames) == 0 {
+		return nil
+	}
+
+	normalizedHostname := normalizeHostname(hostname)
+
+	for _, allowedHostname := range allowedHostnames {
+		normalizedAllowed := normalizeHostname(allowedHostname)
+		if normalizedAllowed == "" {
+			continue
+		}
+
+		if domain, isWildcard := strings.CutPrefix(normalizedAllowed, "*."); isWildcard {
+			if strings.HasSuffix(normalizedHostname, "."+domain) {
+				return nil
+			}
+
+			continue
+		}
+
+		if normalizedHostname == normalizedAllowed {
+			return nil
+		}
+	}
+
+	return fmt.Errorf(
+		"cloudflare Turnstile hostname %q is not allowed, allowed hostnames: %s",
+		hostname,
+		strings.Join(allowedHostnames, ", "),
+	)
+}
+
+func normalizeHostname(hostname string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(hostname)), ".")
+}
@@ -0,0 +1,71 @@
+package cloudflare_turnstile
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_VerifyHostname_WhenHostnameMatchesExactEntry_HostnameAllowed(t *testing.T) {
+	allowedHostnames := []string{"app.example.com", "staging.example.com"}
+
+	assert.NoError(t, verifyHostname("staging.example.com", allowedHostnames))
+	assert.NoError(t, verifyHostname("APP.example.com.", allowedHostnames))
+}
+
+func Test_VerifyHostname_WhenHostnameMatchesWildcardEntry_HostnameAllowed(t *testing.T) {
+	allowedHostnames := []string{"*.example.com"}
+
+	assert.NoError(t, verifyHostname("app.example.com", allowedHostnames))
+	assert.NoError(t, verifyHostname("eu.app.example.com", allowedHostnames))
+}
+
+func Test_VerifyHostname_WhenHostnameIsNotInAllowList_ErrorListsAllowedHostnames(t *testing.T) {
+	allowedHostnames := []string{"app.example.com", "*.staging.example.com"}
+
+	testCases := []string{
+		"evil.com",
+		"app.example.com.evil.com",
+		"staging.example.com",
+		"notstaging.example.com",
+	}
+
+	for _, hostname := range testCases {
+		err := verifyHostname(hostname, allowedHostnames)
+
+		assert.Error(t, err, hostname)
+		assert.Contains(t, err.Error(), "app.example.com, *.staging.example.com")
+	}
+}
+
+func Test_VerifyHostname_WhenAllowListIsEmpty_AnyHostnameAllowed(t *testing.T) {
+	assert.NoError(t, verifyHostname("anything.example.org", nil))
+}
+
+func Test_CheckTokenRemoteIP_WhenSameTokenComesFromAnotherIP_TokenRejected(t *testing.T) {
+	service := &CloudflareTurnstileService{logger: slog.Default()}
+	verifiedAt := time.Now().UTC()
+
+	service.rememberVerifiedToken("token", "203.0.113.10", verifiedAt)
+
+	checkedAt := verifiedAt.Add(time.Minute)
+
+	assert.NoError(t, service.checkTokenRemoteIP("token", "203.0.113.10", checkedAt))
+	assert.Error(t, service.checkTokenRemoteIP("token", "198.51.100.7", checkedAt))
+}
+
+func Test_CheckTokenRemoteIP_WhenWindowPassed_AnotherIPNotRejected(t *testing.T) {
+	service := &CloudflareTurnstileService{logger: slog.Default()}
+	verifiedAt := time.Now().UTC()
+
+	service.rememberVerifiedToken("token", "203.0.113.10", verifiedAt)
+
+	err := service.checkTokenRemoteIP(
+		"token",
+		"198.51.100.7",
+		verifiedAt.Add(verifiedTokenIPWindow+time.Second),
+	)
+	assert.NoError(t, err)
+}
@@ -1,12 +1,19 @@
 package cloudflare_turnstile
 
 import (
+	"sync"
+
 	"databasus-backend/internal/config"
+	"databasus-backend/internal/util/logger"
 )
 
 var cloudflareTurnstileService = &CloudflareTurnstileService{
 	config.GetEnv().CloudflareTurnstileSecretKey,
 	config.GetEnv().CloudflareTurnstileSiteKey,
+	config.GetEnv().CloudflareTurnstileAllowedHostnames,
+	logger.GetLogger(),
+	sync.Mutex{},
+	make(map[string]verifiedToken),
 }
 
 func GetCloudflareTurnstileService() *CloudflareTurnstileService {
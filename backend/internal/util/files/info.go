@@ -0,0 +1,11 @@
+package files_utils
+
+import "time"
+
+// FileInfo describes a file found in a storage. ModTime is zero when the
+// storage does not report it
+type FileInfo struct {
+	Name      string
+	SizeBytes int64
+	ModTime   time.Time
+}
@@ -18,6 +18,17 @@ const (
 	PeriodForever TimePeriod = "FOREVER"
 )
 
+// IsValid reports whether the period is one of the known periods
+func (p TimePeriod) IsValid() bool {
+	switch p {
+	case PeriodDay, PeriodWeek, PeriodMonth, Period3Month, Period6Month, PeriodYear,
+		Period2Years, Period3Years, Period4Years, Period5Years, PeriodForever:
+		return true
+	default:
+		return false
+	}
+}
+
 // ToDuration converts Period to time.Duration
 func (p TimePeriod) ToDuration() time.Duration {
 	switch p {
@@ -48,18 +59,63 @@ func (p TimePeriod) ToDuration() time.Duration {
 	}
 }
 
+// Describe returns the period in a human-readable form, e.g. "3 months"
+func (p TimePeriod) Describe() string {
+	switch p {
+	case PeriodDay:
+		return "1 day"
+	case PeriodWeek:
+		return "1 week"
+	case PeriodMonth:
+		return "1 month"
+	case Period3Month:
+		return "3 months"
+	case Period6Month:
+		return "6 months"
+	case PeriodYear:
+		return "1 year"
+	case Period2Years:
+		return "2 years"
+	case Period3Years:
+		return "3 years"
+	case Period4Years:
+		return "4 years"
+	case Period5Years:
+		return "5 years"
+	case PeriodForever:
+		return "forever"
+	default:
+		return string(p)
+	}
+}
+
 // CompareTo compares this period with another and returns:
 // -1 if p < other
 //
 //	0 if p == other
 //	1 if p > other
 //
-// FOREVER is treated as the longest period
+// FOREVER is treated as the longest period. Unknown periods (e.g. corrupted
+// values loaded from the DB) are treated as the shortest ones instead of
+// panicking in ToDuration, callers that must reject them check IsValid
 func (p TimePeriod) CompareTo(other TimePeriod) int {
 	if p == other {
 		return 0
 	}
 
+	isValid := p.IsValid()
+	isOtherValid := other.IsValid()
+	if !isValid || !isOtherValid {
+		switch {
+		case isValid:
+			return 1
+		case isOtherValid:
+			return -1
+		default:
+			return 0
+		}
+	}
+
 	d1 := p.ToDuration()
 	d2 := other.ToDuration()
 
@@ -80,3 +136,29 @@ func (p TimePeriod) CompareTo(other TimePeriod) int {
 
 	return 0
 }
+
+// NearestTimePeriod returns the defined period closest to d, preferring the
+// longer period on a tie so snapping never shortens retention. Durations
+// longer than 5 years map to FOREVER
+func NearestTimePeriod(d time.Duration) TimePeriod {
+	if d > Period5Years.ToDuration() {
+		return PeriodForever
+	}
+
+	periods := []TimePeriod{
+		PeriodDay, PeriodWeek, PeriodMonth, Period3Month, Period6Month, PeriodYear,
+		Period2Years, Period3Years, Period4Years, Period5Years,
+	}
+
+	nearest := PeriodDay
+	nearestDiff := (d - nearest.ToDuration()).Abs()
+	for _, p := range periods[1:] {
+		diff := (d - p.ToDuration()).Abs()
+		if diff <= nearestDiff {
+			nearest = p
+			nearestDiff = diff
+		}
+	}
+
+	return nearest
+}
@@ -0,0 +1,42 @@
+package period
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CompareTo_WhenPeriodIsUnknown_TreatedAsShortestWithoutPanic(t *testing.T) {
+	unknownPeriod := TimePeriod("CORRUPTED")
+
+	assert.NotPanics(t, func() {
+		assert.Equal(t, -1, unknownPeriod.CompareTo(PeriodDay))
+		assert.Equal(t, 1, PeriodForever.CompareTo(unknownPeriod))
+		assert.Equal(t, 0, unknownPeriod.CompareTo(TimePeriod("OTHER")))
+	})
+}
+
+func Test_NearestTimePeriod_WithDuration_SnapsToClosestPeriod(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		expected TimePeriod
+	}{
+		{name: "40 days", duration: 40 * day, expected: PeriodMonth},
+		{name: "400 days", duration: 400 * day, expected: PeriodYear},
+		{name: "exact week", duration: 7 * day, expected: PeriodWeek},
+		{name: "tie prefers longer period", duration: 60 * day, expected: Period3Month},
+		{name: "zero", duration: 0, expected: PeriodDay},
+		{name: "5 years", duration: 5 * 365 * day, expected: Period5Years},
+		{name: "beyond 5 years", duration: 6 * 365 * day, expected: PeriodForever},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NearestTimePeriod(tt.duration))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package size
+
+import "math"
+
+const (
+	BytesInMB int64 = 1024 * 1024
+	BytesInGB int64 = 1024 * BytesInMB
+)
+
+// MBToBytes converts MB to bytes. MB values produced by BytesToMB are
+// converted back without loss, because dividing by 2^20 is exact in float64
+func MBToBytes(sizeMB float64) int64 {
+	return int64(math.Round(sizeMB * float64(BytesInMB)))
+}
+
+// BytesToMB converts bytes to MB for display. Totals should be summed in bytes
+// and converted once, otherwise float rounding accumulates
+func BytesToMB(sizeBytes int64) float64 {
+	return float64(sizeBytes) / float64(BytesInMB)
+}
+
+func BytesToGB(sizeBytes int64) float64 {
+	return float64(sizeBytes) / float64(BytesInGB)
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN hot_retention   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN cold_retention  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN cold_storage_id UUID;
+
+ALTER TABLE backup_configs
+    ADD CONSTRAINT fk_backup_config_cold_storage_id
+    FOREIGN KEY (cold_storage_id)
+    REFERENCES storages (id)
+    ON DELETE SET NULL;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP CONSTRAINT IF EXISTS fk_backup_config_cold_storage_id;
+
+ALTER TABLE backup_configs
+    DROP COLUMN hot_retention,
+    DROP COLUMN cold_retention,
+    DROP COLUMN cold_storage_id;
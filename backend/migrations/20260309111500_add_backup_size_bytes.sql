@@ -0,0 +1,13 @@
+-- +goose Up
+
+ALTER TABLE backups
+    ADD COLUMN backup_size_bytes BIGINT NOT NULL DEFAULT 0;
+
+UPDATE backups
+SET backup_size_bytes = ROUND(backup_size_mb * 1048576)
+WHERE backup_size_mb > 0;
+
+-- +goose Down
+
+ALTER TABLE backups
+    DROP COLUMN backup_size_bytes;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN allow_restore_to_same_database BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN allow_restore_to_same_database;
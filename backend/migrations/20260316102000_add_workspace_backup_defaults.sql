@@ -0,0 +1,16 @@
+-- +goose Up
+
+CREATE TABLE workspace_backup_defaults (
+    workspace_id UUID PRIMARY KEY,
+    template     JSONB NOT NULL
+);
+
+ALTER TABLE workspace_backup_defaults
+    ADD CONSTRAINT fk_workspace_backup_defaults_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+-- +goose Down
+
+DROP TABLE IF EXISTS workspace_backup_defaults;
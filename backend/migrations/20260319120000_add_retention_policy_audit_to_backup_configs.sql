@@ -0,0 +1,11 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN retention_policy_locked_at TIMESTAMPTZ,
+    ADD COLUMN retention_policy_changed_by UUID;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN retention_policy_changed_by,
+    DROP COLUMN retention_policy_locked_at;
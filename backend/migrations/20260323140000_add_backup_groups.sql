@@ -0,0 +1,36 @@
+-- +goose Up
+
+CREATE TABLE backup_groups (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name         TEXT NOT NULL,
+    workspace_id UUID NOT NULL,
+    database_ids JSONB NOT NULL DEFAULT '[]',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE backup_groups
+    ADD CONSTRAINT fk_backup_groups_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_backup_groups_workspace_id ON backup_groups (workspace_id);
+
+ALTER TABLE backups
+    ADD COLUMN group_id UUID;
+
+ALTER TABLE backups
+    ADD CONSTRAINT fk_backups_group_id
+    FOREIGN KEY (group_id)
+    REFERENCES backup_groups (id)
+    ON DELETE SET NULL;
+
+-- +goose Down
+
+ALTER TABLE backups
+    DROP CONSTRAINT IF EXISTS fk_backups_group_id;
+
+ALTER TABLE backups
+    DROP COLUMN IF EXISTS group_id;
+
+DROP TABLE IF EXISTS backup_groups;
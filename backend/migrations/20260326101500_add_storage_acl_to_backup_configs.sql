@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN storage_acl TEXT NOT NULL DEFAULT 'PRIVATE';
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN storage_acl;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN thinning_keep_every INT  NOT NULL DEFAULT 0,
+    ADD COLUMN thinning_after      TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN thinning_after,
+    DROP COLUMN thinning_keep_every;
@@ -0,0 +1,21 @@
+-- +goose Up
+
+CREATE TABLE deletion_jobs (
+    id                       UUID PRIMARY KEY,
+    database_id              UUID        NOT NULL,
+    created_before           TIMESTAMPTZ NOT NULL,
+    status                   TEXT        NOT NULL,
+    total_count              INT         NOT NULL DEFAULT 0,
+    completed_count          INT         NOT NULL DEFAULT 0,
+    failed_count             INT         NOT NULL DEFAULT 0,
+    last_processed_backup_id UUID,
+    created_at               TIMESTAMPTZ NOT NULL,
+    completed_at             TIMESTAMPTZ
+);
+
+CREATE INDEX idx_deletion_jobs_status ON deletion_jobs (status);
+
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_deletion_jobs_status;
+DROP TABLE IF EXISTS deletion_jobs;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN compression_level INT NOT NULL DEFAULT 0;
+
+ALTER TABLE backups
+    ADD COLUMN compression_duration_seconds DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE backups
+    DROP COLUMN compression_duration_seconds;
+
+ALTER TABLE backup_configs
+    DROP COLUMN compression_level;
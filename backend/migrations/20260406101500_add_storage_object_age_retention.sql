@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN should_use_storage_object_age BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN should_use_storage_object_age;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN is_retry_immediately BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN retry_delay_seconds INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN retry_delay_seconds,
+    DROP COLUMN is_retry_immediately;
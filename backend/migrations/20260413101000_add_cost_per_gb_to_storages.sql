@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE storages
+    ADD COLUMN cost_per_gb_per_month DOUBLE PRECISION;
+
+-- +goose Down
+
+ALTER TABLE storages
+    DROP COLUMN cost_per_gb_per_month;
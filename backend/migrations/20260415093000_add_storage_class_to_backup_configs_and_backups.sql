@@ -0,0 +1,15 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN storage_class TEXT NOT NULL DEFAULT 'STANDARD';
+
+ALTER TABLE backups
+    ADD COLUMN storage_class TEXT NOT NULL DEFAULT 'STANDARD';
+
+-- +goose Down
+
+ALTER TABLE backups
+    DROP COLUMN storage_class;
+
+ALTER TABLE backup_configs
+    DROP COLUMN storage_class;
@@ -0,0 +1,50 @@
+-- +goose Up
+
+CREATE TABLE workspace_encryption_keys (
+    id            UUID PRIMARY KEY,
+    workspace_id  UUID NOT NULL,
+    name          TEXT NOT NULL,
+    encrypted_key TEXT NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE workspace_encryption_keys
+    ADD CONSTRAINT fk_workspace_encryption_keys_workspace_id
+    FOREIGN KEY (workspace_id)
+    REFERENCES workspaces (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_workspace_encryption_keys_workspace_id
+    ON workspace_encryption_keys (workspace_id);
+
+ALTER TABLE backup_configs
+    ADD COLUMN encryption_key_id UUID;
+
+ALTER TABLE backup_configs
+    ADD CONSTRAINT fk_backup_configs_encryption_key_id
+    FOREIGN KEY (encryption_key_id)
+    REFERENCES workspace_encryption_keys (id);
+
+ALTER TABLE backups
+    ADD COLUMN encryption_key_id UUID;
+
+ALTER TABLE backups
+    ADD CONSTRAINT fk_backups_encryption_key_id
+    FOREIGN KEY (encryption_key_id)
+    REFERENCES workspace_encryption_keys (id);
+
+-- +goose Down
+
+ALTER TABLE backups
+    DROP CONSTRAINT IF EXISTS fk_backups_encryption_key_id;
+
+ALTER TABLE backups
+    DROP COLUMN IF EXISTS encryption_key_id;
+
+ALTER TABLE backup_configs
+    DROP CONSTRAINT IF EXISTS fk_backup_configs_encryption_key_id;
+
+ALTER TABLE backup_configs
+    DROP COLUMN IF EXISTS encryption_key_id;
+
+DROP TABLE IF EXISTS workspace_encryption_keys;
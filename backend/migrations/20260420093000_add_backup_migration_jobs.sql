@@ -0,0 +1,26 @@
+-- +goose Up
+
+CREATE TABLE backup_migration_jobs (
+    id                   UUID PRIMARY KEY,
+    database_id          UUID NOT NULL,
+    target_storage_class TEXT NOT NULL,
+    status               TEXT NOT NULL,
+    total_count          INT NOT NULL DEFAULT 0,
+    migrated_count       INT NOT NULL DEFAULT 0,
+    failed_count         INT NOT NULL DEFAULT 0,
+    last_error           TEXT,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT now(),
+    completed_at         TIMESTAMPTZ
+);
+
+ALTER TABLE backup_migration_jobs
+    ADD CONSTRAINT fk_backup_migration_jobs_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_backup_migration_jobs_database_id ON backup_migration_jobs (database_id);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS backup_migration_jobs;
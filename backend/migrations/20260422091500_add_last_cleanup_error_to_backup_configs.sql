@@ -0,0 +1,11 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN last_cleanup_error TEXT NOT NULL DEFAULT '',
+    ADD COLUMN last_cleanup_error_at TIMESTAMPTZ;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN last_cleanup_error_at,
+    DROP COLUMN last_cleanup_error;
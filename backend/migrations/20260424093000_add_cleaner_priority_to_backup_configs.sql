@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN cleaner_priority INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN cleaner_priority;
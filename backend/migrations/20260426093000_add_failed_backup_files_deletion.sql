@@ -0,0 +1,15 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN should_delete_failed_backup_files BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE backups
+    ADD COLUMN is_failed_file_deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+
+ALTER TABLE backups
+    DROP COLUMN is_failed_file_deleted;
+
+ALTER TABLE backup_configs
+    DROP COLUMN should_delete_failed_backup_files;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN is_read_only_mode BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN is_read_only_mode;
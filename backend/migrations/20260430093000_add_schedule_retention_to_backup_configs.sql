@@ -0,0 +1,11 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN retention_schedule_time TEXT NOT NULL DEFAULT '',
+    ADD COLUMN retention_schedule_days INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN retention_schedule_days,
+    DROP COLUMN retention_schedule_time;
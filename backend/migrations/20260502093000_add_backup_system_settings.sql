@@ -0,0 +1,13 @@
+-- +goose Up
+
+CREATE TABLE backup_system_settings (
+    id                                UUID PRIMARY KEY,
+    is_global_backup_creation_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    global_pause_reason               TEXT NOT NULL DEFAULT '',
+    updated_by                        UUID,
+    updated_at                        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS backup_system_settings;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE storages
+    ADD COLUMN unavailable_since TIMESTAMPTZ;
+
+-- +goose Down
+
+ALTER TABLE storages
+    DROP COLUMN unavailable_since;
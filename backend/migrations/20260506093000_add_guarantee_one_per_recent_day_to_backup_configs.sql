@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN guarantee_one_per_recent_day INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN guarantee_one_per_recent_day;
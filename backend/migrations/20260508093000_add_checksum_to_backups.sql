@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backups
+    ADD COLUMN checksum TEXT;
+
+-- +goose Down
+
+ALTER TABLE backups
+    DROP COLUMN checksum;
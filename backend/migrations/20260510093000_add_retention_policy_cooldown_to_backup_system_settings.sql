@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_system_settings
+    ADD COLUMN retention_policy_cooldown_seconds INT NOT NULL DEFAULT 3600;
+
+-- +goose Down
+
+ALTER TABLE backup_system_settings
+    DROP COLUMN IF EXISTS retention_policy_cooldown_seconds;
@@ -0,0 +1,23 @@
+-- +goose Up
+
+CREATE TABLE scheduled_cleanups (
+    id           UUID PRIMARY KEY,
+    database_id  UUID NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    created_by   UUID NOT NULL,
+    executed_at  TIMESTAMPTZ,
+    status       TEXT NOT NULL
+);
+
+ALTER TABLE scheduled_cleanups
+    ADD CONSTRAINT fk_scheduled_cleanups_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_scheduled_cleanups_status_scheduled_at
+    ON scheduled_cleanups (status, scheduled_at);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS scheduled_cleanups;
@@ -0,0 +1,30 @@
+-- +goose Up
+
+CREATE TABLE backup_restorability_tests (
+    id           UUID PRIMARY KEY,
+    backup_id    UUID NOT NULL,
+    database_id  UUID NOT NULL,
+    is_passed    BOOLEAN NOT NULL,
+    error_detail TEXT NOT NULL DEFAULT '',
+    duration_ms  BIGINT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE backup_restorability_tests
+    ADD CONSTRAINT fk_backup_restorability_tests_database_id
+    FOREIGN KEY (database_id)
+    REFERENCES databases (id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_backup_restorability_tests_database_id_created_at
+    ON backup_restorability_tests (database_id, created_at DESC);
+
+ALTER TABLE backup_configs
+    ADD COLUMN test_restorability_interval_days INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN IF EXISTS test_restorability_interval_days;
+
+DROP TABLE IF EXISTS backup_restorability_tests;
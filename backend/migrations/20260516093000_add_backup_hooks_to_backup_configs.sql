@@ -0,0 +1,11 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN pre_backup_hook  JSONB,
+    ADD COLUMN post_backup_hook JSONB;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN IF EXISTS pre_backup_hook,
+    DROP COLUMN IF EXISTS post_backup_hook;
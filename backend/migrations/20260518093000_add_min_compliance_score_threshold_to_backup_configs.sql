@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN min_compliance_score_threshold INT NOT NULL DEFAULT 70;
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN IF EXISTS min_compliance_score_threshold;
@@ -0,0 +1,15 @@
+-- +goose Up
+
+ALTER TABLE backups
+    ADD COLUMN retention_label TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE backup_configs
+    ADD COLUMN retention_exempt_labels TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN retention_exempt_labels;
+
+ALTER TABLE backups
+    DROP COLUMN retention_label;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+ALTER TABLE backup_configs
+    ADD COLUMN notifier_ids TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+
+ALTER TABLE backup_configs
+    DROP COLUMN notifier_ids;
@@ -0,0 +1,43 @@
+-- +goose Up
+
+ALTER TABLE backup_restorability_tests
+    RENAME TO backup_integrity_checks;
+
+ALTER TABLE backup_integrity_checks
+    RENAME CONSTRAINT fk_backup_restorability_tests_database_id
+    TO fk_backup_integrity_checks_database_id;
+
+ALTER INDEX idx_backup_restorability_tests_database_id_created_at
+    RENAME TO idx_backup_integrity_checks_database_id_created_at;
+
+ALTER TABLE backup_configs
+    RENAME COLUMN test_restorability_interval_days TO integrity_check_interval_days;
+
+UPDATE backup_configs
+SET send_notifications_on = REPLACE(
+    send_notifications_on,
+    'RESTORABILITY_TEST_FAILED',
+    'INTEGRITY_CHECK_FAILED'
+);
+
+-- +goose Down
+
+UPDATE backup_configs
+SET send_notifications_on = REPLACE(
+    send_notifications_on,
+    'INTEGRITY_CHECK_FAILED',
+    'RESTORABILITY_TEST_FAILED'
+);
+
+ALTER TABLE backup_configs
+    RENAME COLUMN integrity_check_interval_days TO test_restorability_interval_days;
+
+ALTER INDEX idx_backup_integrity_checks_database_id_created_at
+    RENAME TO idx_backup_restorability_tests_database_id_created_at;
+
+ALTER TABLE backup_integrity_checks
+    RENAME CONSTRAINT fk_backup_integrity_checks_database_id
+    TO fk_backup_restorability_tests_database_id;
+
+ALTER TABLE backup_integrity_checks
+    RENAME TO backup_restorability_tests;
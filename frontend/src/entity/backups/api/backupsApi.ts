@@ -1,6 +1,7 @@
 import { getApplicationServer } from '../../../constants';
 import RequestOptions from '../../../shared/api/RequestOptions';
 import { apiHelper } from '../../../shared/api/apiHelper';
+import type { BackupDeletionImpact } from '../model/BackupDeletionImpact';
 import type { GetBackupsResponse } from '../model/GetBackupsResponse';
 
 export const backupsApi = {
@@ -25,6 +26,14 @@ export const backupsApi = {
     );
   },
 
+  async getBackupDeletionImpact(id: string) {
+    return apiHelper.fetchGetJson<BackupDeletionImpact>(
+      `${getApplicationServer()}/api/v1/backups/${id}/deletion-impact`,
+      undefined,
+      true,
+    );
+  },
+
   async deleteBackup(id: string) {
     return apiHelper.fetchDeleteRaw(`${getApplicationServer()}/api/v1/backups/${id}`);
   },
@@ -2,6 +2,7 @@ export { backupsApi } from './api/backupsApi';
 export { backupConfigApi } from './api/backupConfigApi';
 export { BackupStatus } from './model/BackupStatus';
 export type { Backup } from './model/Backup';
+export type { BackupDeletionImpact } from './model/BackupDeletionImpact';
 export type { BackupConfig } from './model/BackupConfig';
 export { BackupNotificationType } from './model/BackupNotificationType';
 export { BackupEncryption } from './model/BackupEncryption';
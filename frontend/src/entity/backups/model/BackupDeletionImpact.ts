@@ -0,0 +1,11 @@
+export interface BackupDeletionImpact {
+  backupId: string;
+
+  isNewest: boolean;
+  hasDependentIncrementals: boolean;
+  isWithinGracePeriod: boolean;
+  isLocked: boolean;
+  isRetentionOverride: boolean;
+
+  warning: string;
+}
@@ -1,4 +1,6 @@
 export enum BackupNotificationType {
   BackupFailed = 'BACKUP_FAILED',
   BackupSuccess = 'BACKUP_SUCCESS',
+  MissedBackup = 'MISSED_BACKUP',
+  LastBackupDeletion = 'LAST_BACKUP_DELETION',
 }
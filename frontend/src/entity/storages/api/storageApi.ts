@@ -2,6 +2,7 @@ import { getApplicationServer } from '../../../constants';
 import RequestOptions from '../../../shared/api/RequestOptions';
 import { apiHelper } from '../../../shared/api/apiHelper';
 import type { Storage } from '../models/Storage';
+import type { StorageCostEstimate } from '../models/StorageCostEstimate';
 
 export const storageApi = {
   async saveStorage(storage: Storage) {
@@ -31,6 +32,15 @@ export const storageApi = {
     );
   },
 
+  async getStorageCostEstimate(id: string, sizeGb: number, months: number) {
+    const requestOptions: RequestOptions = new RequestOptions();
+    return apiHelper.fetchGetJson<StorageCostEstimate>(
+      `${getApplicationServer()}/api/v1/storages/${id}/cost-estimate?sizeGB=${sizeGb}&months=${months}`,
+      requestOptions,
+      true,
+    );
+  },
+
   async deleteStorage(id: string) {
     const requestOptions: RequestOptions = new RequestOptions();
     return apiHelper.fetchDeleteJson(
@@ -1,5 +1,6 @@
 export { storageApi } from './api/storageApi';
 export { type Storage } from './models/Storage';
+export { type StorageCostEstimate } from './models/StorageCostEstimate';
 export { StorageType } from './models/StorageType';
 export { type LocalStorage } from './models/LocalStorage';
 export { type S3Storage } from './models/S3Storage';
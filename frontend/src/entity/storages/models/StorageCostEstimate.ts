@@ -0,0 +1,17 @@
+export interface MonthlyCostDataPoint {
+  month: number;
+  costUsd: number;
+  cumulativeCostUsd: number;
+}
+
+export interface StorageCostEstimate {
+  storageId: string;
+  projectedSizeGb: number;
+  months: number;
+
+  // true when the storage has no price configured, all costs are 0 then
+  isCostUnknown: boolean;
+  monthlyCostUsd: number;
+  totalCostUsd: number;
+  costBreakdown: MonthlyCostDataPoint[];
+}
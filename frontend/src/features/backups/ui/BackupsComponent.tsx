@@ -52,6 +52,7 @@ export const BackupsComponent = ({ database, isCanManageDBs, scrollContainerRef
   const [showingBackupError, setShowingBackupError] = useState<Backup | undefined>();
 
   const [deleteConfimationId, setDeleteConfimationId] = useState<string | undefined>();
+  const [deleteWarning, setDeleteWarning] = useState<string | undefined>();
   const [deletingBackupId, setDeletingBackupId] = useState<string | undefined>();
 
   const [showingRestoresBackupId, setShowingRestoresBackupId] = useState<string | undefined>();
@@ -144,6 +145,20 @@ export const BackupsComponent = ({ database, isCanManageDBs, scrollContainerRef
     setIsMakeBackupRequestLoading(false);
   };
 
+  const showDeleteConfirmation = async (backupId: string) => {
+    setDeleteWarning(undefined);
+
+    try {
+      const impact = await backupsApi.getBackupDeletionImpact(backupId);
+      setDeleteWarning(impact.warning || undefined);
+    } catch (e) {
+      // the confirmation is still shown, just without the impact warning
+      console.error(e);
+    }
+
+    setDeleteConfimationId(backupId);
+  };
+
   const deleteBackup = async () => {
     if (!deleteConfimationId) {
       return;
@@ -321,7 +336,7 @@ export const BackupsComponent = ({ database, isCanManageDBs, scrollContainerRef
                       className="cursor-pointer"
                       onClick={() => {
                         if (deletingBackupId) return;
-                        setDeleteConfimationId(record.id);
+                        showDeleteConfirmation(record.id);
                       }}
                       style={{ color: '#ff0000', opacity: deletingBackupId ? 0.2 : 1 }}
                     />
@@ -600,7 +615,11 @@ export const BackupsComponent = ({ database, isCanManageDBs, scrollContainerRef
         <ConfirmationComponent
           onConfirm={deleteBackup}
           onDecline={() => setDeleteConfimationId(undefined)}
-          description="Are you sure you want to delete this backup?"
+          description={
+            deleteWarning
+              ? `Are you sure you want to delete this backup?<br/><br/><b>Warning:</b> ${deleteWarning}`
+              : 'Are you sure you want to delete this backup?'
+          }
           actionButtonColor="red"
           actionText="Delete"
         />
@@ -27,7 +27,12 @@ import { BackupNotificationType } from '../../../entity/backups/model/BackupNoti
 import type { Database } from '../../../entity/databases';
 import { Period } from '../../../entity/databases/model/Period';
 import { type Interval, IntervalType } from '../../../entity/intervals';
-import { type Storage, getStorageLogoFromType, storageApi } from '../../../entity/storages';
+import {
+  type Storage,
+  type StorageCostEstimate,
+  getStorageLogoFromType,
+  storageApi,
+} from '../../../entity/storages';
 import type { UserProfile } from '../../../entity/users';
 import { getUserTimeFormat } from '../../../shared/time';
 import {
@@ -65,6 +70,10 @@ const weekdayOptions = [
   { value: 7, label: 'Sun' },
 ];
 
+// used for the cost estimate when the config has no total size limit
+const DEFAULT_COST_ESTIMATE_SIZE_GB = 50;
+const COST_ESTIMATE_MONTHS = 12;
+
 const retentionPolicyOptions = [
   {
     label: 'GFS (keep last N hourly, daily, weekly, monthly and yearly backups)',
@@ -94,6 +103,7 @@ export const EditBackupConfigComponent = ({
   const [storages, setStorages] = useState<Storage[]>([]);
   const [isShowCreateStorage, setShowCreateStorage] = useState(false);
   const [storageSelectKey, setStorageSelectKey] = useState(0);
+  const [storageCostEstimate, setStorageCostEstimate] = useState<StorageCostEstimate>();
 
   const [isShowWarn, setIsShowWarn] = useState(false);
 
@@ -287,6 +297,33 @@ export const EditBackupConfigComponent = ({
     run();
   }, [database]);
 
+  const selectedStorageId = backupConfig?.storage?.id;
+  const maxBackupsTotalSizeMb = backupConfig?.maxBackupsTotalSizeMb ?? 0;
+
+  useEffect(() => {
+    setStorageCostEstimate(undefined);
+
+    if (!selectedStorageId) return;
+
+    const projectedSizeGb =
+      maxBackupsTotalSizeMb > 0 ? maxBackupsTotalSizeMb / 1024 : DEFAULT_COST_ESTIMATE_SIZE_GB;
+
+    let isCancelled = false;
+
+    storageApi
+      .getStorageCostEstimate(selectedStorageId, projectedSizeGb, COST_ESTIMATE_MONTHS)
+      .then((estimate) => {
+        if (!isCancelled) setStorageCostEstimate(estimate);
+      })
+      .catch(() => {
+        // the estimate is only a hint, the storage can be chosen without it
+      });
+
+    return () => {
+      isCancelled = true;
+    };
+  }, [selectedStorageId, maxBackupsTotalSizeMb]);
+
   if (isLoading) {
     return (
       <div className="mb-5 flex items-center">
@@ -545,6 +582,20 @@ export const EditBackupConfigComponent = ({
         </div>
       </div>
 
+      {storageCostEstimate && (
+        <div className="mb-1 flex w-full flex-col items-start sm:flex-row sm:items-center">
+          <div className="mb-1 min-w-[150px] sm:mb-0" />
+          <div className="text-xs text-gray-500">
+            {storageCostEstimate.isCostUnknown
+              ? 'Storage price is not set, cost cannot be estimated'
+              : `~$${storageCostEstimate.monthlyCostUsd.toFixed(2)} per month, ` +
+                `~$${storageCostEstimate.totalCostUsd.toFixed(2)} per ` +
+                `${storageCostEstimate.months} months for ` +
+                `${Number(storageCostEstimate.projectedSizeGb.toFixed(1))} GB`}
+          </div>
+        </div>
+      )}
+
       {!IS_CLOUD && (
         <div className="mb-1 flex w-full flex-col items-start sm:flex-row sm:items-center">
           <div className="mb-1 min-w-[150px] sm:mb-0">Encryption</div>
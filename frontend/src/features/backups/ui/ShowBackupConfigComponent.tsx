@@ -63,6 +63,8 @@ const periodLabels = {
 const notificationLabels = {
   [BackupNotificationType.BackupFailed]: 'Backup failed',
   [BackupNotificationType.BackupSuccess]: 'Backup success',
+  [BackupNotificationType.MissedBackup]: 'Missed backup',
+  [BackupNotificationType.LastBackupDeletion]: 'Last backup deletion',
 };
 
 const formatGfsRetention = (config: BackupConfig): string => {